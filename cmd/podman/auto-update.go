@@ -56,6 +56,10 @@ func init() {
 	flags.BoolVar(&autoUpdateOptions.DryRun, "dry-run", false, "Check for pending updates")
 	flags.BoolVar(&autoUpdateOptions.Rollback, "rollback", true, "Rollback to previous image if update fails")
 
+	maxUnitsFlagName := "max-units"
+	flags.IntVar(&autoUpdateOptions.MaxUnits, maxUnitsFlagName, 0, "Restart at most `N` systemd units at once, 0 for no limit")
+	_ = autoUpdateCommand.RegisterFlagCompletionFunc(maxUnitsFlagName, completion.AutocompleteNone)
+
 	flags.StringVar(&autoUpdateOptions.format, "format", "", "Change the output format to JSON or a Go template")
 	_ = autoUpdateCommand.RegisterFlagCompletionFunc("format", common.AutocompleteFormat(&autoUpdateOutput{}))
 
@@ -73,6 +77,9 @@ func autoUpdate(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	}
+	if autoUpdateOptions.MaxUnits < 0 {
+		return fmt.Errorf("--max-units must not be negative: %d", autoUpdateOptions.MaxUnits)
+	}
 	if cmd.Flags().Changed("tls-verify") {
 		autoUpdateOptions.InsecureSkipTLSVerify = types.NewOptionalBool(!autoUpdateOptions.tlsVerify)
 	}
@@ -97,6 +104,7 @@ type autoUpdateOutput struct {
 	Image         string
 	Policy        string
 	Updated       string
+	Reason        string
 }
 
 func reportsToOutput(allReports []*entities.AutoUpdateReport) []autoUpdateOutput {
@@ -110,6 +118,7 @@ func reportsToOutput(allReports []*entities.AutoUpdateReport) []autoUpdateOutput
 			Image:         r.ImageName,
 			Policy:        r.Policy,
 			Updated:       r.Updated,
+			Reason:        r.Reason,
 		}
 	}
 	return output
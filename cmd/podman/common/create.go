@@ -513,7 +513,6 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 
 		if registry.IsRemote() {
 			_ = createFlags.MarkHidden("env-host")
-			_ = createFlags.MarkHidden(decryptionKeysFlagName)
 		} else {
 			createFlags.StringVar(
 				&cf.SignaturePolicy,
@@ -583,6 +582,32 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(healthCmdFlagName, completion.AutocompleteNone)
 
+		if mode == entities.CreateMode {
+			healthGRPCFlagName := "health-grpc"
+			createFlags.StringVar(
+				&cf.HealthGRPC,
+				healthGRPCFlagName, "",
+				"set a gRPC healthcheck run by Podman in the network namespace of the container ([HOST:]PORT[/SERVICE])",
+			)
+			_ = cmd.RegisterFlagCompletionFunc(healthGRPCFlagName, completion.AutocompleteNone)
+
+			healthHTTPGetFlagName := "health-http-get"
+			createFlags.StringVar(
+				&cf.HealthHTTPGet,
+				healthHTTPGetFlagName, "",
+				"set a HTTP GET healthcheck run by Podman in the network namespace of the container ([SCHEME://][HOST]:PORT[/PATH])",
+			)
+			_ = cmd.RegisterFlagCompletionFunc(healthHTTPGetFlagName, completion.AutocompleteNone)
+
+			healthTCPFlagName := "health-tcp"
+			createFlags.StringVar(
+				&cf.HealthTCP,
+				healthTCPFlagName, "",
+				"set a TCP healthcheck run by Podman in the network namespace of the container ([HOST:]PORT)",
+			)
+			_ = cmd.RegisterFlagCompletionFunc(healthTCPFlagName, completion.AutocompleteNone)
+		}
+
 		info := ""
 		if mode == entities.UpdateMode {
 			info = "Changing this setting resets timer."
@@ -700,6 +725,28 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"Set the maximum amount of time that the startup healthcheck may take before it is considered failed",
 		)
 		_ = cmd.RegisterFlagCompletionFunc(startupHCTimeoutFlagName, completion.AutocompleteNone)
+
+		// Pressure monitoring
+
+		info = ""
+		if mode == entities.UpdateMode {
+			info = "Changing this setting resets the timer."
+		}
+		pressureIntervalFlagName := "pressure-interval"
+		createFlags.StringVar(
+			&cf.PressureInterval,
+			pressureIntervalFlagName, "",
+			"Set an interval for the checks of the memory events and resource pressure of the container ('disable' turns off the monitoring) "+info,
+		)
+		_ = cmd.RegisterFlagCompletionFunc(pressureIntervalFlagName, completion.AutocompleteNone)
+
+		pressureThresholdFlagName := "pressure-threshold"
+		createFlags.StringArrayVar(
+			&cf.PressureThreshold,
+			pressureThresholdFlagName, []string{},
+			"Set a threshold for the pressure of a resource as RESOURCE=PERCENT (resource: cpu, memory, io)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(pressureThresholdFlagName, completion.AutocompleteNone)
 	}
 
 	// Restart is allowed for created, updated, and infra ctr
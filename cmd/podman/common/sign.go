@@ -47,11 +47,9 @@ func DefineSigningFlags(cmd *cobra.Command, cliOpts *SigningCLIOnlyOptions, push
 	_ = cmd.RegisterFlagCompletionFunc(signPassphraseFileFlagName, completion.AutocompleteDefault)
 
 	if registry.IsRemote() {
+		// Keys in the local GPG and Sequoia keyrings cannot be forwarded.
 		_ = flags.MarkHidden(signByFlagName)
 		_ = flags.MarkHidden(signBySequoiaFingerprintFlagName)
-		_ = flags.MarkHidden(signBySigstoreFlagName)
-		_ = flags.MarkHidden(signBySigstorePrivateKeyFlagName)
-		_ = flags.MarkHidden(signPassphraseFileFlagName)
 	}
 }
 
@@ -101,7 +99,11 @@ func PrepareSigning(pushOpts *entities.ImagePushOptions, cliOpts *SigningCLIOnly
 			cleanup.cleanup()
 		}
 	}()
-	if cliOpts.signBySigstoreParamFile != "" {
+	if cliOpts.signBySigstoreParamFile != "" && registry.IsRemote() {
+		// Remote clients forward the key material referred to by the
+		// file to the server.
+		pushOpts.SignBySigstoreParamFile = cliOpts.signBySigstoreParamFile
+	} else if cliOpts.signBySigstoreParamFile != "" {
 		signer, err := sigstore.NewSignerFromParameterFile(cliOpts.signBySigstoreParamFile, &sigstore.Options{
 			PrivateKeyPassphrasePrompt: cli.ReadPassphraseFile,
 			Stdin:                      os.Stdin,
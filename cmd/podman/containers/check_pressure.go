@@ -0,0 +1,26 @@
+package containers
+
+import (
+	"github.com/spf13/cobra"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+)
+
+// checkPressureCmd is run by the timer of the pressure monitoring of a
+// container at every interval.
+var checkPressureCmd = &cobra.Command{
+	Use:    "check-pressure CONTAINER",
+	Short:  "Check the memory events and resource pressure of a container",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		return registry.ContainerEngine().ContainerCheckPressure(registry.Context(), args[0])
+	},
+	Example: "podman container check-pressure ctrID",
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkPressureCmd,
+		Parent:  containerCmd,
+	})
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.podman.io/common/pkg/completion"
 	"go.podman.io/podman/v6/cmd/podman/common"
 	"go.podman.io/podman/v6/cmd/podman/registry"
@@ -52,6 +54,7 @@ var (
 	execDetach        bool
 	execCidFile       string
 	execNoSession     bool
+	execOutputBuffer  string
 )
 
 func execFlags(cmd *cobra.Command) {
@@ -78,7 +81,12 @@ func execFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(envFileFlagName, completion.AutocompleteDefault)
 
 	flags.BoolVarP(&execOpts.Interactive, "interactive", "i", false, "Make STDIN available to the contained process")
+	outputBufferFlagName := "output-buffer"
+	flags.StringVar(&execOutputBuffer, outputBufferFlagName, "", "Keep the last given size of output to replay when attaching to the exec session again, and keep STDIN open when detaching")
+	_ = cmd.RegisterFlagCompletionFunc(outputBufferFlagName, completion.AutocompleteNone)
+
 	flags.BoolVar(&execOpts.Privileged, "privileged", podmanConfig.ContainersConfDefaultsRO.Containers.Privileged, "Give the process extended Linux capabilities inside the container.  The default is false")
+	flags.BoolVar(&execOpts.Record, "record", false, "Record the exec session")
 	flags.BoolVarP(&execOpts.Tty, "tty", "t", false, "Allocate a pseudo-TTY. The default is false")
 
 	userFlagName := "user"
@@ -127,11 +135,19 @@ func init() {
 
 func exec(cmd *cobra.Command, args []string) error {
 	if execNoSession {
-		if execDetach || cmd.Flags().Changed("detach-keys") {
-			return errors.New("--no-session cannot be used with --detach or --detach-keys")
+		if execDetach || cmd.Flags().Changed("detach-keys") || execOutputBuffer != "" || execOpts.Record {
+			return errors.New("--no-session cannot be used with --detach, --detach-keys, --output-buffer or --record")
 		}
 	}
 
+	if execOutputBuffer != "" {
+		size, err := units.FromHumanSize(execOutputBuffer)
+		if err != nil {
+			return fmt.Errorf("invalid output buffer size %q: %w", execOutputBuffer, err)
+		}
+		execOpts.OutputBuffer = size
+	}
+
 	nameOrID, command, err := determineTargetCtrAndCmd(args, execOpts.Latest, execCidFile != "")
 	if err != nil {
 		return err
@@ -207,6 +223,31 @@ func exec(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// execSubcommandMisrouted reports whether a subcommand of exec was selected
+// although options of exec itself were given before its name, e.g.
+// `podman exec --latest ls /`. The name is then the command to execute in the
+// container.
+func execSubcommandMisrouted(cmd *cobra.Command) bool {
+	misrouted := false
+	cmd.Parent().LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			misrouted = true
+		}
+	})
+	return misrouted
+}
+
+// execSubcommandArgs wraps the argument validation of a subcommand of exec,
+// skipping it if the subcommand was misrouted.
+func execSubcommandArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if execSubcommandMisrouted(cmd) {
+			return nil
+		}
+		return validate(cmd, args)
+	}
+}
+
 // determineTargetCtrAndCmd determines which command exec should run in which container
 func determineTargetCtrAndCmd(args []string, latestSpecified bool, execCidFileProvided bool) (string, []string, error) {
 	var nameOrID string
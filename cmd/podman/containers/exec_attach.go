@@ -0,0 +1,87 @@
+package containers
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/podman/v6/cmd/podman/common"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+var (
+	execAttachDescription = `Attach to a running exec session.
+
+  The output kept by the session, if it was created with --output-buffer, is replayed first.`
+	execAttachCommand = &cobra.Command{
+		Use:               "attach [options] SESSION",
+		Short:             "Attach to a running exec session",
+		Long:              execAttachDescription,
+		RunE:              execAttach,
+		Args:              execSubcommandArgs(cobra.ExactArgs(1)),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman exec attach 0e4c6a2d4f7b
+podman exec attach --no-stdin 0e4c6a2d4f7b`,
+	}
+
+	containerExecAttachCommand = &cobra.Command{
+		Use:               execAttachCommand.Use,
+		Short:             execAttachCommand.Short,
+		Long:              execAttachCommand.Long,
+		RunE:              execAttachCommand.RunE,
+		Args:              execAttachCommand.Args,
+		ValidArgsFunction: execAttachCommand.ValidArgsFunction,
+		Example: `podman container exec attach 0e4c6a2d4f7b
+podman container exec attach --no-stdin 0e4c6a2d4f7b`,
+	}
+)
+
+var execAttachOpts entities.ExecAttachOptions
+
+func execAttachFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	detachKeysFlagName := "detach-keys"
+	flags.StringVar(&execAttachOpts.DetachKeys, detachKeysFlagName, containerConfig.DetachKeys(), "Select the key sequence for detaching from the exec session. Format is a single character [a-Z] or ctrl-<value> where <value> is one of: a-z, @, ^, [, , or _")
+	_ = cmd.RegisterFlagCompletionFunc(detachKeysFlagName, common.AutocompleteDetachKeys)
+
+	flags.BoolVar(&execAttachOpts.NoStdin, "no-stdin", false, "Do not attach STDIN. The default is false")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: execAttachCommand,
+		Parent:  execCommand,
+	})
+	execAttachFlags(execAttachCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerExecAttachCommand,
+		Parent:  containerExecCommand,
+	})
+	execAttachFlags(containerExecAttachCommand)
+}
+
+func execAttach(cmd *cobra.Command, args []string) error {
+	if execSubcommandMisrouted(cmd) {
+		return exec(cmd.Parent(), append([]string{cmd.CalledAs()}, args...))
+	}
+
+	streams := define.AttachStreams{
+		OutputStream: os.Stdout,
+		ErrorStream:  os.Stderr,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	if !execAttachOpts.NoStdin {
+		streams.InputStream = bufio.NewReader(os.Stdin)
+		streams.AttachInput = true
+	}
+
+	exitCode, err := registry.ContainerEngine().ContainerExecAttach(registry.Context(), args[0], execAttachOpts, streams)
+	registry.SetExitCode(exitCode)
+	return err
+}
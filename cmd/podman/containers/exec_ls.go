@@ -0,0 +1,136 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+	"go.podman.io/podman/v6/cmd/podman/common"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+	"go.podman.io/podman/v6/cmd/podman/validate"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+var (
+	execLsDescription = `List the exec sessions of a container.
+
+  The IDs of running sessions can be passed to podman exec attach.`
+	execLsCommand = &cobra.Command{
+		Use:               "ls [options] CONTAINER",
+		Short:             "List the exec sessions of a container",
+		Long:              execLsDescription,
+		RunE:              execLs,
+		Args:              execSubcommandArgs(validate.IDOrLatestArgs),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman exec ls ctrID
+podman exec ls --format "{{.ID}}" ctrID`,
+	}
+
+	containerExecLsCommand = &cobra.Command{
+		Use:               execLsCommand.Use,
+		Short:             execLsCommand.Short,
+		Long:              execLsCommand.Long,
+		RunE:              execLsCommand.RunE,
+		Args:              execLsCommand.Args,
+		ValidArgsFunction: execLsCommand.ValidArgsFunction,
+		Example: `podman container exec ls ctrID
+podman container exec ls --format "{{.ID}}" ctrID`,
+	}
+)
+
+var (
+	execLsOpts      entities.ExecListOptions
+	execLsFormat    string
+	execLsNoHeading bool
+)
+
+func execLsFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&execLsFormat, formatFlagName, "{{range .}}{{.ID}}\t{{.Command}}\t{{.Status}}\t{{.Pid}}\n{{end -}}", "Format exec session output using Go template")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&execSessionReporter{}))
+
+	flags.BoolVarP(&execLsNoHeading, "noheading", "n", false, "Do not print headers")
+
+	validate.AddLatestFlag(cmd, &execLsOpts.Latest)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: execLsCommand,
+		Parent:  execCommand,
+	})
+	execLsFlags(execLsCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerExecLsCommand,
+		Parent:  containerExecCommand,
+	})
+	execLsFlags(containerExecLsCommand)
+}
+
+func execLs(cmd *cobra.Command, args []string) error {
+	if execSubcommandMisrouted(cmd) {
+		return exec(cmd.Parent(), append([]string{cmd.CalledAs()}, args...))
+	}
+
+	var nameOrID string
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	responses, err := registry.ContainerEngine().ContainerExecList(registry.Context(), nameOrID, execLsOpts)
+	if err != nil {
+		return err
+	}
+
+	sessions := make([]execSessionReporter, 0, len(responses))
+	for _, response := range responses {
+		sessions = append(sessions, execSessionReporter{response})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, execLsFormat)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, execLsFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !execLsNoHeading {
+		headers := report.Headers(execSessionReporter{}, map[string]string{
+			"Pid": "PID",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(sessions)
+}
+
+type execSessionReporter struct {
+	*entities.ExecListReport
+}
+
+// Command returns the command executed by the exec session
+func (e execSessionReporter) Command() string {
+	if e.ProcessConfig == nil {
+		return ""
+	}
+	return strings.Join(append([]string{e.ProcessConfig.Entrypoint}, e.ProcessConfig.Arguments...), " ")
+}
+
+// Status returns whether the exec session is running, or its exit code
+func (e execSessionReporter) Status() string {
+	if e.Running {
+		return "running"
+	}
+	return "exited (" + strconv.Itoa(e.ExitCode) + ")"
+}
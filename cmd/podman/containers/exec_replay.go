@@ -0,0 +1,148 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/podman/v6/cmd/podman/common"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+	"go.podman.io/podman/v6/cmd/podman/validate"
+	"go.podman.io/podman/v6/libpod/recording"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+var (
+	execReplayDescription = `Play back a recording of an exec or attach session of a container.
+
+  Sessions are recorded with the --record option of podman exec and podman attach. The ID of the
+  recording of an exec session is the ID of the session.`
+	execReplayCommand = &cobra.Command{
+		Use:               "replay [options] CONTAINER RECORDING",
+		Short:             "Play back a recorded exec or attach session",
+		Long:              execReplayDescription,
+		RunE:              execReplay,
+		Args:              execSubcommandArgs(execReplayArgs),
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman exec replay ctrID 0e4c6a2d4f7b
+podman exec replay --speed 2 --idle-time-limit 1s ctrID 0e4c6a2d4f7b
+podman exec replay --raw ctrID 0e4c6a2d4f7b > session.cast`,
+	}
+
+	containerExecReplayCommand = &cobra.Command{
+		Use:               execReplayCommand.Use,
+		Short:             execReplayCommand.Short,
+		Long:              execReplayCommand.Long,
+		RunE:              execReplayCommand.RunE,
+		Args:              execReplayCommand.Args,
+		ValidArgsFunction: execReplayCommand.ValidArgsFunction,
+		Example: `podman container exec replay ctrID 0e4c6a2d4f7b
+podman container exec replay --speed 2 --idle-time-limit 1s ctrID 0e4c6a2d4f7b
+podman container exec replay --raw ctrID 0e4c6a2d4f7b > session.cast`,
+	}
+)
+
+var (
+	execReplayOpts entities.ExecRecordingOptions
+	execReplayPlay recording.PlayOptions
+	execReplayRaw  bool
+)
+
+func execReplayFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	speedFlagName := "speed"
+	flags.Float64Var(&execReplayPlay.Speed, speedFlagName, 1, "Playback speed, 0 plays back the output without pauses")
+	_ = cmd.RegisterFlagCompletionFunc(speedFlagName, completion.AutocompleteNone)
+
+	idleTimeLimitFlagName := "idle-time-limit"
+	flags.DurationVar(&execReplayPlay.IdleTimeLimit, idleTimeLimitFlagName, 0, "Limit pauses between the output to the given duration")
+	_ = cmd.RegisterFlagCompletionFunc(idleTimeLimitFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&execReplayRaw, "raw", false, "Write the recording in the asciicast v2 format instead of playing it back")
+
+	validate.AddLatestFlag(cmd, &execReplayOpts.Latest)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: execReplayCommand,
+		Parent:  execCommand,
+	})
+	execReplayFlags(execReplayCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerExecReplayCommand,
+		Parent:  containerExecCommand,
+	})
+	execReplayFlags(containerExecReplayCommand)
+}
+
+// execReplayArgs requires a container and a recording, or only a recording
+// with --latest.
+func execReplayArgs(cmd *cobra.Command, args []string) error {
+	latest := false
+	if flag := cmd.Flags().Lookup("latest"); flag != nil {
+		latest = flag.Changed
+	}
+	switch {
+	case latest && len(args) != 1:
+		return errors.New("--latest requires the ID of a recording")
+	case !latest && len(args) != 2:
+		return errors.New("replay requires the name or ID of a container and the ID of a recording")
+	}
+	return nil
+}
+
+func execReplay(cmd *cobra.Command, args []string) error {
+	if execSubcommandMisrouted(cmd) {
+		return exec(cmd.Parent(), append([]string{cmd.CalledAs()}, args...))
+	}
+
+	if execReplayPlay.Speed < 0 {
+		return fmt.Errorf("invalid speed %v, it must not be negative", execReplayPlay.Speed)
+	}
+	if execReplayPlay.IdleTimeLimit < 0 {
+		return fmt.Errorf("invalid idle time limit %s, it must not be negative", execReplayPlay.IdleTimeLimit)
+	}
+
+	var nameOrID string
+	if len(args) > 1 {
+		nameOrID = args[0]
+	}
+	execReplayOpts.Recording = args[len(args)-1]
+
+	if execReplayRaw {
+		execReplayOpts.Output = os.Stdout
+		return registry.ContainerEngine().ContainerExecRecording(registry.Context(), nameOrID, execReplayOpts)
+	}
+
+	pr, pw := io.Pipe()
+	execReplayOpts.Output = pw
+	errChan := make(chan error, 1)
+	go func() {
+		err := registry.ContainerEngine().ContainerExecRecording(registry.Context(), nameOrID, execReplayOpts)
+		pw.CloseWithError(err)
+		errChan <- err
+	}()
+
+	err := playRecording(pr)
+	// Unblock the retrieval of the recording if the playback stopped early.
+	pr.CloseWithError(err)
+	if retrieveErr := <-errChan; retrieveErr != nil {
+		return retrieveErr
+	}
+	return err
+}
+
+// playRecording plays back the recording read from r on stdout.
+func playRecording(r io.Reader) error {
+	reader, err := recording.NewReader(r)
+	if err != nil {
+		return err
+	}
+	return recording.Play(registry.Context(), reader, os.Stdout, execReplayPlay)
+}
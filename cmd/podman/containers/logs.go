@@ -118,6 +118,22 @@ func logsFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&logsOptions.Colors, "color", "", false, "Output the containers with different colors in the log.")
 	flags.BoolVarP(&logsOptions.Names, "names", "n", false, "Output the container name in the log")
 
+	grepFlagName := "grep"
+	flags.StringVar(&logsOptions.Grep, grepFlagName, "", "Only show the lines matching REGEX")
+	_ = cmd.RegisterFlagCompletionFunc(grepFlagName, completion.AutocompleteNone)
+
+	streamFlagName := "stream"
+	flags.StringVar(&logsOptions.Stream, streamFlagName, "", "Only show the lines of STREAM, stdout or stderr")
+	_ = cmd.RegisterFlagCompletionFunc(streamFlagName, common.AutocompleteLogStream)
+
+	jsonFieldFlagName := "json-field"
+	flags.StringArrayVar(&logsOptions.JSONFields, jsonFieldFlagName, []string{}, "Only show the lines logged as JSON objects with the field FIELD=VALUE")
+	_ = cmd.RegisterFlagCompletionFunc(jsonFieldFlagName, completion.AutocompleteNone)
+
+	outputFlagName := "output"
+	flags.StringVar(&logsOptions.Output, outputFlagName, entities.LogsOutputText, "Output format of the log lines, text or json")
+	_ = cmd.RegisterFlagCompletionFunc(outputFlagName, common.AutocompleteLogsOutput)
+
 	_ = flags.MarkHidden("details")
 }
 
@@ -139,6 +155,11 @@ func logs(_ *cobra.Command, args []string) error {
 		}
 		logsOptions.Until = until
 	}
+	switch logsOptions.Output {
+	case entities.LogsOutputText, entities.LogsOutputJSON:
+	default:
+		return fmt.Errorf("invalid output format %q, must be %s or %s", logsOptions.Output, entities.LogsOutputText, entities.LogsOutputJSON)
+	}
 	logsOptions.StdoutWriter = os.Stdout
 	logsOptions.StderrWriter = os.Stderr
 	return registry.ContainerEngine().ContainerLogs(registry.Context(), args, logsOptions.ContainerLogsOptions)
@@ -26,7 +26,6 @@ var (
 		Annotations: map[string]string{
 			registry.UnshareNSRequired: "",
 			registry.ParentNSRequired:  "",
-			registry.EngineMode:        registry.ABIMode,
 		},
 		Use:   "mount [options] [CONTAINER...]",
 		Short: "Mount a working container's root filesystem",
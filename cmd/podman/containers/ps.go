@@ -393,6 +393,9 @@ func (l psReporter) Status() string {
 	if hc != "" {
 		state += " (" + hc + ")"
 	}
+	if l.ListContainer.Readiness != "" && l.ListContainer.State == "running" {
+		state += " (" + l.ListContainer.Readiness + ")"
+	}
 	return state
 }
 
@@ -20,12 +20,11 @@ var (
   An unmount can be forced with the --force flag.
 `
 	unmountCommand = &cobra.Command{
-		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
-		Use:         "unmount [options] CONTAINER [CONTAINER...]",
-		Aliases:     []string{"umount"},
-		Short:       "Unmount working container's root filesystem",
-		Long:        description,
-		RunE:        unmount,
+		Use:     "unmount [options] CONTAINER [CONTAINER...]",
+		Aliases: []string{"umount"},
+		Short:   "Unmount working container's root filesystem",
+		Long:    description,
+		RunE:    unmount,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
 		},
@@ -36,12 +35,11 @@ podman unmount --all`,
 	}
 
 	containerUnmountCommand = &cobra.Command{
-		Annotations: unmountCommand.Annotations,
-		Use:         unmountCommand.Use,
-		Short:       unmountCommand.Short,
-		Aliases:     unmountCommand.Aliases,
-		Long:        unmountCommand.Long,
-		RunE:        unmountCommand.RunE,
+		Use:     unmountCommand.Use,
+		Short:   unmountCommand.Short,
+		Aliases: unmountCommand.Aliases,
+		Long:    unmountCommand.Long,
+		RunE:    unmountCommand.RunE,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
 		},
@@ -202,6 +202,15 @@ func update(cmd *cobra.Command, args []string) error {
 		opts.Rlimits = rlimits
 	}
 
+	if cmd.Flags().Changed("pressure-interval") || cmd.Flags().Changed("pressure-threshold") {
+		opts.PressureMonitor = &define.UpdatePressureMonitor{
+			PressureThresholds: updateOptions.PressureThreshold,
+		}
+		if cmd.Flags().Changed("pressure-interval") {
+			opts.PressureMonitor.PressureInterval = &updateOptions.PressureInterval
+		}
+	}
+
 	rep, err := registry.ContainerEngine().ContainerUpdate(context.Background(), opts)
 	if err != nil {
 		return err
@@ -24,7 +24,6 @@ var (
 		Annotations: map[string]string{
 			registry.UnshareNSRequired: "",
 			registry.ParentNSRequired:  "",
-			registry.EngineMode:        registry.ABIMode,
 		},
 		Use:               "mount [options] [IMAGE...]",
 		Short:             "Mount an image's root filesystem",
@@ -141,8 +141,6 @@ func pushFlags(cmd *cobra.Command) {
 		_ = flags.MarkHidden("cert-dir")
 		_ = flags.MarkHidden("compress")
 		_ = flags.MarkHidden("quiet")
-		_ = flags.MarkHidden(encryptionKeysFlagName)
-		_ = flags.MarkHidden(encryptLayersFlagName)
 	} else {
 		signaturePolicyFlagName := "signature-policy"
 		flags.StringVar(&pushOptions.SignaturePolicy, signaturePolicyFlagName, "", "Path to a signature-policy file")
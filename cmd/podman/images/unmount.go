@@ -20,7 +20,6 @@ var (
   An unmount can be forced with the --force flag.
 `
 	unmountCommand = &cobra.Command{
-		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
 		Use:               "unmount [options] IMAGE [IMAGE...]",
 		Aliases:           []string{"umount"},
 		Short:             "Unmount an image's root filesystem",
@@ -0,0 +1,39 @@
+package kube
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+)
+
+// cronJobRunCmd is run by the scheduler of a CronJob created by kube play
+// every time its schedule fires.  The scheduler passes the scheduled time of
+// the activation as Unix time, the current time is used if it is omitted.
+var cronJobRunCmd = &cobra.Command{
+	Use:    "cronjob-run NAME [TIME]",
+	Short:  "Run a job of a CronJob scheduled by kube play",
+	Args:   cobra.RangeArgs(1, 2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		scheduled := time.Now()
+		if len(args) > 1 {
+			seconds, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid scheduled time %q: %w", args[1], err)
+			}
+			scheduled = time.Unix(seconds, 0)
+		}
+		return registry.ContainerEngine().KubeCronJobRun(registry.Context(), args[0], scheduled)
+	},
+	Example: "podman kube cronjob-run backup 1760659200",
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: cronJobRunCmd,
+		Parent:  kubeCmd,
+	})
+}
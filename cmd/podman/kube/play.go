@@ -50,7 +50,7 @@ var (
 	playOptions        = playKubeOptionsWrapper{}
 	playDescription    = `Reads in a structured file of Kubernetes YAML.
 
-  Creates pods or volumes based on the Kubernetes kind described in the YAML. Supported kinds are Pods, Deployments, DaemonSets, Jobs, and PersistentVolumeClaims.`
+  Creates pods or volumes based on the Kubernetes kind described in the YAML. Supported kinds are Pods, Deployments, DaemonSets, Jobs, CronJobs, PersistentVolumeClaims, and Services.`
 
 	playCmd = &cobra.Command{
 		Use:               "play [options] [KUBEFILE [KUBEFILE...]]|-",
@@ -160,6 +160,9 @@ func playFlags(cmd *cobra.Command) {
 	replaceFlagName := "replace"
 	flags.BoolVar(&playOptions.Replace, replaceFlagName, false, "Delete and recreate pods defined in the YAML file")
 
+	updateFlagName := "update"
+	flags.BoolVar(&playOptions.Update, updateFlagName, false, "Perform a rolling update of the pods of deployments defined in the YAML file")
+
 	publishPortsFlagName := "publish"
 	flags.StringSliceVar(&playOptions.PublishPorts, publishPortsFlagName, []string{}, "Publish a container's port, or a range of ports, to the host")
 	_ = cmd.RegisterFlagCompletionFunc(publishPortsFlagName, completion.AutocompleteNone)
@@ -280,6 +283,9 @@ func play(cmd *cobra.Command, args []string) error {
 	if playOptions.Force && !playOptions.Down {
 		return errors.New("--force may be specified only with --down")
 	}
+	if playOptions.Update && (playOptions.Replace || playOptions.Down || playOptions.Wait) {
+		return errors.New("--update cannot be combined with --replace, --down or --wait")
+	}
 
 	reader, err := readerFromArgs(args)
 	if err != nil {
@@ -425,12 +431,48 @@ func teardown(body io.Reader, options entities.PlayKubeDownOptions) error {
 		podRmErrors   utils.OutputErrors
 		volRmErrors   utils.OutputErrors
 		secRmErrors   utils.OutputErrors
+		svcRmErrors   utils.OutputErrors
+		cronRmErrors  utils.OutputErrors
 	)
 	reports, err := registry.ContainerEngine().PlayKubeDown(registry.Context(), body, options)
 	if err != nil {
 		return err
 	}
 
+	// Output stopped services
+	if len(reports.ServiceRmReport) > 0 {
+		fmt.Println("Services stopped:")
+		for _, stopped := range reports.ServiceRmReport {
+			switch {
+			case stopped.Err != nil:
+				svcRmErrors = append(svcRmErrors, stopped.Err)
+			default:
+				fmt.Println(stopped.Name)
+			}
+		}
+		lastSvcRmError := svcRmErrors.PrintErrors()
+		if lastSvcRmError != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", lastSvcRmError)
+		}
+	}
+
+	// Output removed cron job schedules
+	if len(reports.CronJobRmReport) > 0 {
+		fmt.Println("CronJobs removed:")
+		for _, removed := range reports.CronJobRmReport {
+			switch {
+			case removed.Err != nil:
+				cronRmErrors = append(cronRmErrors, removed.Err)
+			default:
+				fmt.Println(removed.Name)
+			}
+		}
+		lastCronRmError := cronRmErrors.PrintErrors()
+		if lastCronRmError != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", lastCronRmError)
+		}
+	}
+
 	// Output stopped pods
 	fmt.Println("Pods stopped:")
 	for _, stopped := range reports.StopReport {
@@ -533,6 +575,26 @@ func printPlayReport(report *entities.PlayKubeReport) error {
 		fmt.Println(secret.CreateReport.ID)
 	}
 
+	// Print services report
+	for i, service := range report.Services {
+		if i == 0 {
+			fmt.Println("Services:")
+		}
+		fmt.Printf("%s %s\n", service.Name, strings.Join(service.Ports, ","))
+	}
+
+	// Print cron jobs report
+	for i, cronJob := range report.CronJobs {
+		if i == 0 {
+			fmt.Println("CronJobs:")
+		}
+		if cronJob.Suspended {
+			fmt.Printf("%s %s (suspended)\n", cronJob.Name, cronJob.Schedule)
+			continue
+		}
+		fmt.Printf("%s %s\n", cronJob.Name, cronJob.Schedule)
+	}
+
 	// Print pods report
 	for _, pod := range report.Pods {
 		for _, l := range pod.Logs {
@@ -0,0 +1,27 @@
+package kube
+
+import (
+	"github.com/spf13/cobra"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+	"go.podman.io/podman/v6/cmd/podman/validate"
+)
+
+// resumeCmd is run at boot by podman-kube-resume.service to restart the
+// schedulers of CronJobs and the forwarders of Services played by kube play.
+var resumeCmd = &cobra.Command{
+	Use:    "resume",
+	Short:  "Resume the CronJobs and Services played by kube play",
+	Args:   validate.NoArgs,
+	Hidden: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return registry.ContainerEngine().KubeResume(registry.Context())
+	},
+	Example: "podman kube resume",
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: resumeCmd,
+		Parent:  kubeCmd,
+	})
+}
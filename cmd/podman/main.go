@@ -107,9 +107,9 @@ func parseCommands() *cobra.Command {
 			}
 		}
 
-		// Command cannot be run rootless
+		// Command cannot be run rootless, unless the server runs it
 		_, found := c.Command.Annotations[registry.UnshareNSRequired]
-		if found {
+		if found && cfg.EngineMode == entities.ABIMode {
 			if rootless.IsRootless() && os.Getuid() != 0 {
 				c.Command.RunE = func(cmd *cobra.Command, _ []string) error {
 					return fmt.Errorf("cannot run command %q in rootless mode, must execute `podman unshare` first", cmd.CommandPath())
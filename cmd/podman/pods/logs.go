@@ -92,6 +92,22 @@ func logsFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&logsPodOptions.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
 	flags.BoolVarP(&logsPodOptions.Colors, "color", "", false, "Output the containers within a pod with different colors in the log")
 
+	grepFlagName := "grep"
+	flags.StringVar(&logsPodOptions.Grep, grepFlagName, "", "Only show the lines matching REGEX")
+	_ = cmd.RegisterFlagCompletionFunc(grepFlagName, completion.AutocompleteNone)
+
+	streamFlagName := "stream"
+	flags.StringVar(&logsPodOptions.Stream, streamFlagName, "", "Only show the lines of STREAM, stdout or stderr")
+	_ = cmd.RegisterFlagCompletionFunc(streamFlagName, common.AutocompleteLogStream)
+
+	jsonFieldFlagName := "json-field"
+	flags.StringArrayVar(&logsPodOptions.JSONFields, jsonFieldFlagName, []string{}, "Only show the lines logged as JSON objects with the field FIELD=VALUE")
+	_ = cmd.RegisterFlagCompletionFunc(jsonFieldFlagName, completion.AutocompleteNone)
+
+	outputFlagName := "output"
+	flags.StringVar(&logsPodOptions.Output, outputFlagName, entities.LogsOutputText, "Output format of the log lines, text or json")
+	_ = cmd.RegisterFlagCompletionFunc(outputFlagName, common.AutocompleteLogsOutput)
+
 	_ = flags.MarkHidden("details")
 }
 
@@ -113,6 +129,12 @@ func logs(_ *cobra.Command, args []string) error {
 		logsPodOptions.Until = until
 	}
 
+	switch logsPodOptions.Output {
+	case entities.LogsOutputText, entities.LogsOutputJSON:
+	default:
+		return fmt.Errorf("invalid output format %q, must be %s or %s", logsPodOptions.Output, entities.LogsOutputText, entities.LogsOutputJSON)
+	}
+
 	// Remote can only process one container at a time
 	if registry.IsRemote() && logsPodOptions.ContainerName == "" {
 		return fmt.Errorf("-c or --container cannot be empty: %w", define.ErrInvalidArg)
@@ -27,7 +27,9 @@ var (
 		},
 		ValidArgsFunction: completion.AutocompleteDefault,
 		Example: `podman quadlet install /path/to/myquadlet.container
-podman quadlet install https://github.com/containers/podman/blob/main/test/e2e/quadlet/basic.container`,
+podman quadlet install https://github.com/containers/podman/blob/main/test/e2e/quadlet/basic.container
+podman quadlet install --replace --restart-changed /path/to/myquadlet.container
+podman quadlet install oci://quay.io/example/myapp:1.0`,
 	}
 
 	installOptions entities.QuadletInstallOptions
@@ -37,6 +39,7 @@ func installFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 	flags.BoolVar(&installOptions.ReloadSystemd, "reload-systemd", true, "Reload systemd after installing Quadlets")
 	flags.BoolVarP(&installOptions.Replace, "replace", "r", false, "Replace the installation even if the quadlet already exists")
+	flags.BoolVar(&installOptions.RestartChanged, "restart-changed", false, "Restart the running units changed by the installation")
 }
 
 func init() {
@@ -59,6 +62,7 @@ func install(_ *cobra.Command, args []string) error {
 	for _, s := range installReport.InstalledQuadlets {
 		fmt.Println(s)
 	}
+	errs = append(errs, printUnitChanges(installReport.UnitChanges)...)
 
 	if len(installReport.QuadletErrors) > 0 {
 		errs = append(errs, errors.New("errors occurred installing some Quadlets"))
@@ -66,3 +70,16 @@ func install(_ *cobra.Command, args []string) error {
 
 	return errs.PrintErrors()
 }
+
+// printUnitChanges prints how the changes of the generated systemd units
+// were applied and returns the errors which occurred
+func printUnitChanges(changes []entities.QuadletUnitChange) []error {
+	var errs []error
+	for _, change := range changes {
+		fmt.Printf("%s: %s\n", change.Unit, change.Action)
+		if change.Error != "" {
+			errs = append(errs, fmt.Errorf("unable to apply the change of unit %s: %s", change.Unit, change.Error))
+		}
+	}
+	return errs
+}
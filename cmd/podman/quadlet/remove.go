@@ -35,6 +35,7 @@ func rmFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&removeOptions.All, "all", "a", false, "Remove all Quadlets for the current user")
 	flags.BoolVarP(&removeOptions.Ignore, "ignore", "i", false, "Do not error for Quadlets that do not exist")
 	flags.BoolVar(&removeOptions.ReloadSystemd, "reload-systemd", true, "Reload systemd after removal")
+	flags.BoolVar(&removeOptions.RestartChanged, "restart-changed", false, "Restart the running units changed by the removal and stop the removed ones")
 }
 
 func init() {
@@ -59,6 +60,7 @@ func rm(_ *cobra.Command, args []string) error {
 		for _, rq := range removeReport.Removed {
 			fmt.Println(rq)
 		}
+		errs = append(errs, printUnitChanges(removeReport.UnitChanges)...)
 		for quadlet, quadletErr := range removeReport.Errors {
 			errs = append(errs, fmt.Errorf("unable to remove Quadlet %s: %v", quadlet, quadletErr))
 		}
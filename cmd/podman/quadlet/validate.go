@@ -0,0 +1,94 @@
+package quadlet
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+	"go.podman.io/podman/v6/cmd/podman/common"
+	"go.podman.io/podman/v6/cmd/podman/registry"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+var (
+	quadletValidateDescription = `Convert Quadlet files like the Quadlet generator does and report the problems found in them.
+
+  If no files are given, all Quadlets of the current user are validated. References to other Quadlets are resolved against the given files and the installed Quadlets.`
+
+	quadletValidateCmd = &cobra.Command{
+		Use:               "validate [options] [QUADLET-PATH...]",
+		Short:             "Validate Quadlet files",
+		Long:              quadletValidateDescription,
+		RunE:              validateQuadlets,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman quadlet validate
+podman quadlet validate myquadlet.container mynetwork.network
+podman quadlet validate --format json *.container`,
+	}
+
+	validateFormat string
+)
+
+func validateFlags(cmd *cobra.Command) {
+	formatFlagName := "format"
+	flags := cmd.Flags()
+
+	flags.StringVar(&validateFormat, formatFlagName, "", "Pretty-print diagnostics to JSON or using a Go template")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.QuadletDiagnostic{}))
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: quadletValidateCmd,
+		Parent:  quadletCmd,
+	})
+	validateFlags(quadletValidateCmd)
+}
+
+func validateQuadlets(cmd *cobra.Command, args []string) error {
+	validateReport, err := registry.ContainerEngine().QuadletValidate(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(validateFormat):
+		b, err := json.MarshalIndent(validateReport.Diagnostics, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	case cmd.Flag("format").Changed:
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUser, validateFormat)
+		if err != nil {
+			return err
+		}
+		if err := rpt.Execute(validateReport.Diagnostics); err != nil {
+			return err
+		}
+		if err := rpt.Flush(); err != nil {
+			return err
+		}
+	default:
+		for _, d := range validateReport.Diagnostics {
+			location := d.File
+			if d.Line > 0 {
+				location = fmt.Sprintf("%s:%d", d.File, d.Line)
+			}
+			fmt.Printf("%s: %s: %s\n", location, d.Severity, d.Message)
+		}
+	}
+
+	numErrors := 0
+	for _, d := range validateReport.Diagnostics {
+		if d.Severity == "error" {
+			numErrors++
+		}
+	}
+	if numErrors > 0 {
+		return fmt.Errorf("%d error(s) found in Quadlet files", numErrors)
+	}
+	return nil
+}
@@ -30,7 +30,8 @@ var (
 		Example: `podman events
 podman events --filter event=create
 podman events --format {{.Image}}
-podman events --since 1h30s`,
+podman events --since 1h30s
+podman events --after-cursor 42`,
 	}
 
 	systemEventsCommand = &cobra.Command{
@@ -76,6 +77,8 @@ type Event struct {
 	HealthStatus string `json:"health_status,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:",omitempty"`
+	// Cursor is the position of the event in the event log
+	Cursor string `json:"cursor,omitempty"`
 
 	events.Details
 }
@@ -95,6 +98,7 @@ func newEventFromLibpodEvent(e *events.Event) Event {
 		Details:           e.Details,
 		TimeNano:          e.Time.UnixNano(),
 		Error:             e.Error,
+		Cursor:            e.Cursor,
 	}
 }
 
@@ -137,10 +141,14 @@ func eventsFlags(cmd *cobra.Command) {
 	untilFlagName := "until"
 	flags.StringVar(&eventOptions.Until, untilFlagName, "", "show all events until timestamp")
 	_ = cmd.RegisterFlagCompletionFunc(untilFlagName, completion.AutocompleteNone)
+
+	afterCursorFlagName := "after-cursor"
+	flags.StringVar(&eventOptions.AfterCursor, afterCursorFlagName, "", "show all events after the event with the given cursor")
+	_ = cmd.RegisterFlagCompletionFunc(afterCursorFlagName, completion.AutocompleteNone)
 }
 
 func eventsCmd(cmd *cobra.Command, _ []string) error {
-	if len(eventOptions.Since) > 0 || len(eventOptions.Until) > 0 {
+	if len(eventOptions.Since) > 0 || len(eventOptions.Until) > 0 || len(eventOptions.AfterCursor) > 0 {
 		eventOptions.FromStart = true
 	}
 	eventChannel := make(chan events.ReadResult, 1)
@@ -4,10 +4,11 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
-	"sort"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -126,73 +127,6 @@ func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
 	return units, prevError
 }
 
-func loadUnitDropins(unit *parser.UnitFile, sourcePaths []string) error {
-	var prevError error
-	reportError := func(err error) {
-		if prevError != nil {
-			err = fmt.Errorf("%s\n%s", prevError, err)
-		}
-		prevError = err
-	}
-
-	unitDropinPaths := unit.GetUnitDropinPaths()
-	dropinDirs := make([]string, 0, len(unitDropinPaths))
-	for _, dropinPath := range unitDropinPaths {
-		for _, sourcePath := range sourcePaths {
-			dropinDirs = append(dropinDirs, path.Join(sourcePath, dropinPath))
-		}
-	}
-
-	dropinPaths := make(map[string]string)
-	for _, dropinDir := range dropinDirs {
-		dropinFiles, err := os.ReadDir(dropinDir)
-		if err != nil {
-			if !errors.Is(err, os.ErrNotExist) {
-				reportError(fmt.Errorf("error reading directory %q, %w", dropinDir, err))
-			}
-
-			continue
-		}
-
-		for _, dropinFile := range dropinFiles {
-			dropinName := dropinFile.Name()
-			if filepath.Ext(dropinName) != ".conf" {
-				continue // Only *.conf supported
-			}
-
-			if _, ok := dropinPaths[dropinName]; ok {
-				continue // We already saw this name
-			}
-
-			dropinPaths[dropinName] = path.Join(dropinDir, dropinName)
-		}
-	}
-
-	dropinFiles := make([]string, len(dropinPaths))
-	i := 0
-	for k := range dropinPaths {
-		dropinFiles[i] = k
-		i++
-	}
-
-	// Merge in alpha-numerical order
-	sort.Strings(dropinFiles)
-
-	for _, dropinFile := range dropinFiles {
-		dropinPath := dropinPaths[dropinFile]
-
-		Debugf("Loading source drop-in file %s", dropinPath)
-
-		if f, err := parser.ParseUnitFile(dropinPath); err != nil {
-			reportError(fmt.Errorf("error loading %q, %w", dropinPath, err))
-		} else {
-			unit.Merge(f)
-		}
-	}
-
-	return prevError
-}
-
 func generateServiceFile(service *parser.UnitFile) error {
 	Debugf("writing %q", service.Path)
 
@@ -370,53 +304,6 @@ func warnIfUnsupportedServiceKeys(unit *parser.UnitFile) {
 	}
 }
 
-func generateUnitsInfoMap(units []*parser.UnitFile) map[string]*quadlet.UnitInfo {
-	unitsInfoMap := make(map[string]*quadlet.UnitInfo)
-	for _, unit := range units {
-		var serviceName string
-		var containers []string
-		var resourceName string
-		var err error
-
-		serviceName, err = quadlet.GetUnitServiceName(unit)
-		if err != nil {
-			Logf("Error obtaining service name: %v", err)
-		}
-
-		switch {
-		case strings.HasSuffix(unit.Filename, ".container"):
-			// Prefill resourceNames for .container files. This solves network reusing.
-			resourceName = quadlet.GetContainerResourceName(unit)
-		case strings.HasSuffix(unit.Filename, ".build"):
-			// Prefill resourceNames for .build files. This is significantly less complex than
-			// pre-computing all resourceNames for all Quadlet types (which is rather complex for a few
-			// types), but still breaks the dependency cycle between .volume and .build ([Volume] can
-			// have Image=some.build, and [Build] can have Volume=some.volume:/some-volume)
-			resourceName = quadlet.GetBuiltImageName(unit)
-		case strings.HasSuffix(unit.Filename, ".artifact"):
-			serviceName = quadlet.GetArtifactServiceName(unit)
-		case strings.HasSuffix(unit.Filename, ".pod"):
-			containers = make([]string, 0)
-			// Prefill resourceNames for .pod files.
-			// This is requires for referencing the pod from .container files
-			resourceName = quadlet.GetPodResourceName(unit)
-		case strings.HasSuffix(unit.Filename, ".volume"), strings.HasSuffix(unit.Filename, ".kube"), strings.HasSuffix(unit.Filename, ".network"), strings.HasSuffix(unit.Filename, ".image"):
-			// Do nothing for these case.
-		default:
-			Logf("Unsupported file type %q", unit.Filename)
-			continue
-		}
-
-		unitsInfoMap[unit.Filename] = &quadlet.UnitInfo{
-			ServiceName:       serviceName,
-			ContainersToStart: containers,
-			ResourceName:      resourceName,
-		}
-	}
-
-	return unitsInfoMap
-}
-
 // quadletLogger implements the logiface.Logger interface using quadlet's custom logging
 type quadletLogger struct{}
 
@@ -497,11 +384,16 @@ func process() bool {
 	}
 
 	for _, unit := range units {
-		if err := loadUnitDropins(unit, sourcePathsMap); err != nil {
+		if err := quadlet.LoadUnitDropins(unit, sourcePathsMap); err != nil {
 			reportError(err)
 		}
 	}
 
+	units, composeAliases, composeErrors := quadlet.ExpandComposeUnits(units)
+	for _, name := range slices.Sorted(maps.Keys(composeErrors)) {
+		reportError(fmt.Errorf("converting %q: %w", name, composeErrors[name]))
+	}
+
 	if !dryRunFlag {
 		err := os.MkdirAll(outputPath, os.ModePerm)
 		if err != nil {
@@ -510,55 +402,35 @@ func process() bool {
 		}
 	}
 
-	// Sort unit files according to potential inter-dependencies, with Volume and Network units
-	// taking precedence over all others.
-	sort.Slice(units, func(i, j int) bool {
-		getOrder := func(i int) int {
-			ext := filepath.Ext(units[i].Filename)
-			order, ok := quadlet.SupportedExtensions[ext]
-			if !ok {
-				return 0
-			}
-			return order
-		}
-		return getOrder(i) < getOrder(j)
-	})
+	quadlet.SortUnits(units)
 
 	// Generate the PodsInfoMap to allow containers to link to their pods and add themselves to the pod's containers list
-	unitsInfoMap := generateUnitsInfoMap(units)
+	unitsInfoMap, err := quadlet.GenerateUnitsInfoMap(units)
+	if err != nil {
+		Logf("%v", err)
+	}
+	// Dependencies on .compose units are dependencies on their pods
+	for composeFile, podFile := range composeAliases {
+		if info, ok := unitsInfoMap[podFile]; ok {
+			unitsInfoMap[composeFile] = info
+		}
+	}
 
 	for _, unit := range units {
-		var service *parser.UnitFile
-		var warnings, err error
-
 		warnIfUnsupportedServiceKeys(unit)
 
 		switch {
 		case strings.HasSuffix(unit.Filename, ".container"):
 			warnIfAmbiguousName(unit, quadlet.ContainerGroup)
-			service, warnings, err = quadlet.ConvertContainer(unit, unitsInfoMap, isUserFlag)
 		case strings.HasSuffix(unit.Filename, ".volume"):
 			warnIfAmbiguousName(unit, quadlet.VolumeGroup)
-			service, warnings, err = quadlet.ConvertVolume(unit, unitsInfoMap, isUserFlag)
-		case strings.HasSuffix(unit.Filename, ".kube"):
-			service, err = quadlet.ConvertKube(unit, unitsInfoMap, isUserFlag)
-		case strings.HasSuffix(unit.Filename, ".network"):
-			service, warnings, err = quadlet.ConvertNetwork(unit, unitsInfoMap, isUserFlag)
 		case strings.HasSuffix(unit.Filename, ".image"):
 			warnIfAmbiguousName(unit, quadlet.ImageGroup)
-			service, err = quadlet.ConvertImage(unit, unitsInfoMap, isUserFlag)
-		case strings.HasSuffix(unit.Filename, ".build"):
-			service, warnings, err = quadlet.ConvertBuild(unit, unitsInfoMap, isUserFlag)
 		case strings.HasSuffix(unit.Filename, ".artifact"):
 			warnIfAmbiguousName(unit, quadlet.ArtifactGroup)
-			service, err = quadlet.ConvertArtifact(unit, unitsInfoMap, isUserFlag)
-		case strings.HasSuffix(unit.Filename, ".pod"):
-			service, warnings, err = quadlet.ConvertPod(unit, unitsInfoMap, isUserFlag)
-		default:
-			Logf("Unsupported file type %q", unit.Filename)
-			continue
 		}
 
+		service, warnings, err := quadlet.ConvertUnit(unit, unitsInfoMap, isUserFlag)
 		if warnings != nil {
 			Logf("%s", warnings.Error())
 		}
@@ -568,21 +440,33 @@ func process() bool {
 			continue
 		}
 
-		service.Path = path.Join(outputPath, service.Filename)
+		generated := []*parser.UnitFile{service}
+		timer, err := quadlet.ConvertSchedule(unit)
+		if err != nil {
+			reportError(fmt.Errorf("converting %q: %w", unit.Filename, err))
+			continue
+		}
+		if timer != nil {
+			generated = append(generated, timer)
+		}
+
+		for _, file := range generated {
+			file.Path = path.Join(outputPath, file.Filename)
 
-		if dryRunFlag {
-			data, err := service.ToString()
-			if err != nil {
-				reportError(fmt.Errorf("parsing %s: %w", service.Path, err))
+			if dryRunFlag {
+				data, err := file.ToString()
+				if err != nil {
+					reportError(fmt.Errorf("parsing %s: %w", file.Path, err))
+					continue
+				}
+				fmt.Printf("---%s---\n%s\n", file.Path, data)
 				continue
 			}
-			fmt.Printf("---%s---\n%s\n", service.Path, data)
-			continue
-		}
-		if err := generateServiceFile(service); err != nil {
-			reportError(fmt.Errorf("generating service file %s: %w", service.Path, err))
+			if err := generateServiceFile(file); err != nil {
+				reportError(fmt.Errorf("generating service file %s: %w", file.Path, err))
+			}
+			enableServiceFile(outputPath, file)
 		}
-		enableServiceFile(outputPath, service)
 	}
 	return processErred
 }
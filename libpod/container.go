@@ -209,6 +209,28 @@ type ContainerState struct {
 	// HCUnitName records the name of the healthcheck unit.
 	// Automatically generated when the healthcheck is started.
 	HCUnitName string `json:"hcUnitName,omitempty"`
+	// Ready indicates that the readiness check of the container passed.
+	Ready bool `json:"ready,omitempty"`
+	// ReadinessSuccessCount is the number of consecutive successes of the
+	// readiness check.
+	ReadinessSuccessCount int `json:"readinessSuccessCount,omitempty"`
+	// ReadinessFailureCount is the number of consecutive failures of the
+	// readiness check.
+	ReadinessFailureCount int `json:"readinessFailureCount,omitempty"`
+	// ReadinessUnitName records the name of the readiness check unit.
+	// Automatically generated when the readiness check is started.
+	ReadinessUnitName string `json:"readinessUnitName,omitempty"`
+	// PressureUnitName records the name of the pressure monitoring unit.
+	// Automatically generated when the monitoring is started.
+	PressureUnitName string `json:"pressureUnitName,omitempty"`
+	// MemoryHighEvents and MemoryMaxEvents are the counters of the
+	// high and max entries of the memory.events file of the cgroup of the
+	// container at the last pressure check.
+	MemoryHighEvents uint64 `json:"memoryHighEvents,omitempty"`
+	MemoryMaxEvents  uint64 `json:"memoryMaxEvents,omitempty"`
+	// PressureExceeded are the resources whose pressure was above their
+	// threshold at the last pressure check.
+	PressureExceeded []string `json:"pressureExceeded,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -659,6 +681,18 @@ func (c *Container) LogPath() string {
 	return c.config.LogPath
 }
 
+// LogMaxFiles returns the maximum number of log files kept by the json-file
+// log driver
+func (c *Container) LogMaxFiles() int {
+	return c.config.LogMaxFiles
+}
+
+// LogCompress returns whether the json-file log driver compresses the
+// rotated log files
+func (c *Container) LogCompress() bool {
+	return c.config.LogCompress
+}
+
 // LogTag returns the tag to the container's log file
 func (c *Container) LogTag() string {
 	return c.config.LogTag
@@ -1074,6 +1108,35 @@ func (c *Container) StartupHCPassed() (bool, error) {
 	return c.state.StartupHCPassed, nil
 }
 
+// ReadinessStatus returns the readiness of the container.  It is empty if the
+// container does not have a readiness check.
+func (c *Container) ReadinessStatus() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	return c.readinessStatus(), nil
+}
+
+// readinessStatus returns the readiness of the container.
+// NOTE: The caller must lock and sync the container.
+func (c *Container) readinessStatus() string {
+	if c.config.ReadinessCheckConfig == nil {
+		return ""
+	}
+	// A container that is not running cannot be ready, regardless of what
+	// the last readiness check reported.
+	if c.state.Ready && c.state.State == define.ContainerStateRunning {
+		return define.ReadinessCheckReady
+	}
+	return define.ReadinessCheckNotReady
+}
+
 // Misc Accessors
 // Most will require locking
 
@@ -1354,6 +1417,18 @@ func (c *Container) HealthCheckConfig() *manifest.Schema2HealthConfig {
 	return c.config.HealthCheckConfig
 }
 
+// ReadinessCheckConfig returns the command and timing attributes of the
+// readiness check
+func (c *Container) ReadinessCheckConfig() *define.ReadinessCheck {
+	return c.config.ReadinessCheckConfig
+}
+
+// PressureMonitorConfig returns the configuration of the pressure monitoring
+// of the container
+func (c *Container) PressureMonitorConfig() *define.PressureMonitor {
+	return c.config.PressureMonitorConfig
+}
+
 func (c *Container) HealthCheckLogDestination() string {
 	if c.config.HealthLogDestination == nil {
 		return define.DefaultHealthCheckLocalDestination
@@ -157,16 +157,22 @@ func (c *Container) Update(updateOptions *entities.ContainerUpdateOptions) error
 		return err
 	}
 
+	if err := c.updatePressureMonitor(updateOptions.PressureMonitor); err != nil {
+		return err
+	}
+
 	defer c.newContainerEvent(events.Update)
 	return c.update(updateOptions)
 }
 
 // Attach to a container.
-// The last parameter "start" can be used to also start the container.
+// The parameter "start" can be used to also start the container.
 // This will then Start and Attach APIs, ensuring proper
 // ordering of the two such that no output from the container is lost (e.g. the
 // Attach call occurs before Start).
-func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, keys string, resize <-chan resize.TerminalSize, start bool) (retChan <-chan error, finalErr error) {
+// The last parameter "record" records the attach session. Sessions of
+// containers with the define.RecordAnnotation annotation are always recorded.
+func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, keys string, resize <-chan resize.TerminalSize, start, record bool) (retChan <-chan error, finalErr error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -207,6 +213,17 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 		}
 	}
 
+	var rec *sessionRecording
+	if record || c.recordSessions() {
+		var err error
+		rec, err = c.startAttachRecording()
+		if err != nil {
+			return nil, err
+		}
+		streams = rec.streams(streams)
+		resize = rec.resizes(resize)
+	}
+
 	attachChan := make(chan error)
 
 	// We need to ensure that we don't return until start() fired in attach.
@@ -215,6 +232,10 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 
 	// Attach to the container before starting it
 	go func() {
+		if rec != nil {
+			defer c.stopRecording(rec)
+		}
+
 		// Start resizing
 		if c.LogDriver() != define.PassthroughLogging && c.LogDriver() != define.PassthroughTTYLogging {
 			registerResizeFunc(resize, c.bundlePath())
@@ -395,7 +416,9 @@ func (c *Container) Kill(signal uint) error {
 // over the socket; if this is not set, but streamLogs is, only the logs will be
 // sent.
 // At least one of streamAttach and streamLogs must be set.
-func (c *Container) HTTPAttach(r *http.Request, w http.ResponseWriter, streams *HTTPAttachStreams, detachKeys *string, cancel <-chan bool, streamAttach, streamLogs bool, hijackDone chan<- bool) error {
+// The record parameter records the attach session, sessions of containers
+// with the define.RecordAnnotation annotation are always recorded.
+func (c *Container) HTTPAttach(r *http.Request, w http.ResponseWriter, streams *HTTPAttachStreams, detachKeys *string, cancel <-chan bool, streamAttach, streamLogs, record bool, hijackDone chan<- bool) error {
 	// Ensure we don't leak a goroutine if we exit before hijack completes.
 	defer func() {
 		close(hijackDone)
@@ -433,6 +456,15 @@ func (c *Container) HTTPAttach(r *http.Request, w http.ResponseWriter, streams *
 
 	logrus.Infof("Performing HTTP Hijack attach to container %s", c.ID())
 
+	if streamAttach && (record || c.recordSessions()) {
+		rec, err := c.startAttachRecording()
+		if err != nil {
+			return err
+		}
+		defer c.stopRecording(rec)
+		streams = rec.httpStreams(streams)
+	}
+
 	c.newContainerEvent(events.Attach)
 	return c.ociRuntime.HTTPAttach(c, r, w, streams, detachKeys, cancel, hijackDone, streamAttach, streamLogs)
 }
@@ -455,7 +487,11 @@ func (c *Container) AttachResize(newSize resize.TerminalSize) error {
 
 	logrus.Infof("Resizing TTY of container %s", c.ID())
 
-	return c.ociRuntime.AttachResize(c, newSize)
+	if err := c.ociRuntime.AttachResize(c, newSize); err != nil {
+		return err
+	}
+	c.recordResize(c.ID(), newSize)
+	return nil
 }
 
 // Mount mounts a container's filesystem on the host
@@ -1192,6 +1228,37 @@ func (c *Container) Stat(_ context.Context, containerPath string) (*define.FileI
 	return info, err
 }
 
+// Walk lists the contents of the directory at the specified path *inside* the
+// container, descending into subdirectories down to the specified depth.
+func (c *Container) Walk(_ context.Context, containerPath string, depth int) ([]*define.FileInfo, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	var mountPoint string
+	var err error
+	if c.state.Mounted {
+		mountPoint = c.state.Mountpoint
+	} else {
+		mountPoint, err = c.mount()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := c.unmount(false); err != nil {
+				logrus.Errorf("Unmounting container %s: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	return c.walk(mountPoint, containerPath, depth)
+}
+
 func saveContainerError(c *Container, err error) error {
 	c.state.Error = err.Error()
 	return c.save()
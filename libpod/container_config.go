@@ -379,6 +379,12 @@ type ContainerMiscConfig struct {
 	LogLabels map[string]string `json:"logLabels,omitempty"`
 	// LogSize is the maximum size of the container's log file
 	LogSize int64 `json:"logSize"`
+	// LogMaxFiles is the maximum number of log files kept by the json-file
+	// log driver when rotating the log, including the current log file
+	LogMaxFiles int `json:"logMaxFiles,omitempty"`
+	// LogCompress indicates whether the json-file log driver compresses
+	// the rotated log files
+	LogCompress bool `json:"logCompress,omitempty"`
 	// LogDriver driver for logs
 	LogDriver string `json:"logDriver"`
 	// File containing the conmon PID
@@ -419,6 +425,9 @@ type ContainerMiscConfig struct {
 	HealthCheckConfig *manifest.Schema2HealthConfig `json:"healthcheck"`
 	// HealthCheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthCheckOnFailureAction define.HealthCheckOnFailureAction `json:"healthcheck_on_failure_action"`
+	// HealthCheckOnFailureHook is the hook run on the host by the exec
+	// on-failure action.
+	HealthCheckOnFailureHook string `json:"healthcheck_on_failure_hook,omitempty"`
 	// HealthLogDestination defines the destination where the log is stored
 	// Nil value means the default value (local).
 	HealthLogDestination *string `json:"healthLogDestination,omitempty"`
@@ -434,6 +443,13 @@ type ContainerMiscConfig struct {
 	// healthcheck for the container. This will run before the regular HC
 	// runs, and when it passes the regular HC will be activated.
 	StartupHealthCheckConfig *define.StartupHealthCheck `json:"startupHealthCheck,omitempty"`
+	// ReadinessCheckConfig is the configuration of the readiness check
+	// for the container. It runs independently of the healthcheck and
+	// only determines whether the container is ready.
+	ReadinessCheckConfig *define.ReadinessCheck `json:"readinessCheck,omitempty"`
+	// PressureMonitorConfig is the configuration of the monitoring of the
+	// cgroup v2 memory events and resource pressure of the container.
+	PressureMonitorConfig *define.PressureMonitor `json:"pressureMonitor,omitempty"`
 	// PreserveFDs is a number of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process. The total FDs
 	// passed will be 3 + PreserveFDs.
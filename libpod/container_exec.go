@@ -80,6 +80,16 @@ type ExecConfig struct {
 	// exiting, and the exit command being executed. If set to 0, there is
 	// no delay. If set, ExitCommand must also be set.
 	ExitCommandDelay uint `json:"exitCommandDelay,omitempty"`
+	// OutputBuffer is the size, in bytes, of the output of the exec
+	// session that is kept to be replayed when attaching to the session
+	// again. Only the last OutputBuffer bytes of the output are kept. If
+	// set, STDIN is kept open when a client detaches from the session. If
+	// 0, no output is kept.
+	OutputBuffer int64 `json:"outputBuffer,omitempty"`
+	// Record records the exec session: its output, and its input and
+	// terminal resizes while it is attached to. The recording is kept with
+	// the container and can be played back.
+	Record bool `json:"record,omitempty"`
 }
 
 // ExecSession contains information on a single exec session attached to a given
@@ -149,6 +159,8 @@ func (e *ExecSession) Inspect() (*define.InspectExecSession, error) {
 	output.ProcessConfig.Privileged = e.Config.Privileged
 	output.ProcessConfig.Tty = e.Config.Terminal
 	output.ProcessConfig.User = e.Config.User
+	output.OutputBuffer = e.Config.OutputBuffer
+	output.Record = e.Config.Record
 
 	return output, nil
 }
@@ -171,6 +183,9 @@ func (c *Container) verifyExecConfig(config *ExecConfig) error {
 	if config.ExitCommandDelay > 0 && len(config.ExitCommand) == 0 {
 		return fmt.Errorf("must provide a non-empty exit command if giving an exit command delay: %w", define.ErrInvalidArg)
 	}
+	if config.OutputBuffer < 0 {
+		return fmt.Errorf("output buffer size must not be negative: %w", define.ErrInvalidArg)
+	}
 	return nil
 }
 
@@ -304,6 +319,13 @@ func (c *Container) ExecStart(sessionID string) error {
 		return err
 	}
 
+	if c.recordExecSession(session) {
+		opts.Recording, err = c.createExecRecording(session)
+		if err != nil {
+			return err
+		}
+	}
+
 	pid, err := c.ociRuntime.ExecContainerDetached(c, session.ID(), opts, session.Config.AttachStdin)
 	if err != nil {
 		return err
@@ -358,6 +380,16 @@ func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachS
 		return err
 	}
 
+	if !isHealthcheck && c.recordExecSession(session) {
+		rec, err := c.startExecRecording(session, newSize)
+		if err != nil {
+			return err
+		}
+		defer c.stopRecording(rec)
+		streams = rec.streams(streams)
+		opts.Recording = rec.Path()
+	}
+
 	pid, attachChan, err := c.ociRuntime.ExecContainer(c, session.ID(), opts, streams, newSize)
 	if err != nil {
 		return err
@@ -529,6 +561,16 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 		streams.Stderr = session.Config.AttachStderr
 	}
 
+	if c.recordExecSession(session) {
+		rec, err := c.startExecRecording(session, newSize)
+		if err != nil {
+			return err
+		}
+		defer c.stopRecording(rec)
+		streams = rec.httpStreams(streams)
+		execOpts.Recording = rec.Path()
+	}
+
 	holdConnOpen := make(chan bool)
 
 	defer func() {
@@ -613,6 +655,209 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 	return lastErr
 }
 
+// checkExecAttach verifies that the given exec session can be attached to,
+// and returns it.
+// MUST BE CALLED with container `c` locked.
+func (c *Container) checkExecAttach(sessionID string) (*ExecSession, error) {
+	if !c.ensureState(define.ContainerStateRunning) {
+		return nil, fmt.Errorf("can only attach to exec sessions when their container is running: %w", define.ErrCtrStateInvalid)
+	}
+
+	session, ok := c.state.ExecSessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("container %s has no exec session with ID %s: %w", c.ID(), sessionID, define.ErrNoSuchExecSession)
+	}
+
+	if session.State != define.ExecStateRunning {
+		return nil, fmt.Errorf("can only attach to running exec sessions, while container %s session %s state is %q: %w", c.ID(), session.ID(), session.State.String(), define.ErrExecSessionStateInvalid)
+	}
+
+	// The exec session may have exited since we last updated.
+	running, err := c.ociRuntime.ExecUpdateStatus(c, session.ID())
+	if err != nil {
+		return nil, err
+	}
+	if !running {
+		if err := retrieveAndWriteExecExitCode(c, session.ID()); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cannot attach to container %s exec session %s as it has stopped: %w", c.ID(), session.ID(), define.ErrExecSessionStateInvalid)
+	}
+
+	return session, nil
+}
+
+// ExecAttach attaches to an exec session that is already running, e.g. one
+// that was started detached or that a client detached from. If the session
+// keeps an output buffer, its contents are replayed first.
+// Returns the exit code of the session once it exits. If the user detaches,
+// define.ErrDetach is returned and the session keeps running.
+func (c *Container) ExecAttach(sessionID string, streams *define.AttachStreams, detachKeys *string, resizeChan <-chan resize.TerminalSize) (int, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return -1, err
+		}
+	}
+
+	session, err := c.checkExecAttach(sessionID)
+	if err != nil {
+		return -1, err
+	}
+
+	if detachKeys == nil {
+		detachKeys = session.Config.DetachKeys
+	}
+
+	logrus.Infof("Going to attach to container %s exec session %s", c.ID(), session.ID())
+
+	// Unlock so other processes can use the container, and relock before
+	// returning, as the deferred unlock expects.
+	if !c.batched {
+		c.lock.Unlock()
+		defer c.lock.Lock()
+	}
+
+	if c.recordExecSession(session) {
+		rec, err := c.startExecRecording(session, nil)
+		if err != nil {
+			return -1, err
+		}
+		defer c.stopRecording(rec)
+		streams = rec.streams(streams)
+	}
+
+	if resizeChan != nil {
+		go func() {
+			logrus.Debugf("Sending resize events to exec session %s", sessionID)
+			for resizeRequest := range resizeChan {
+				if err := c.ExecResize(sessionID, resizeRequest); err != nil {
+					if errors.Is(err, define.ErrExecSessionStateInvalid) {
+						logrus.Infof("Missed resize on exec session %s, already stopped", sessionID)
+					} else {
+						logrus.Warnf("Error resizing exec session %s: %v", sessionID, err)
+					}
+					return
+				}
+			}
+		}()
+	}
+
+	if err := c.ociRuntime.ExecAttach(c, sessionID, session.Config.OutputBuffer, streams, detachKeys); err != nil {
+		if errors.Is(err, define.ErrDetach) {
+			return -1, err
+		}
+		logrus.Errorf("Container %s exec session %s attach error: %v", c.ID(), sessionID, err)
+	}
+
+	return c.execAttachExitCode(sessionID)
+}
+
+// ExecHTTPAttach performs an HTTP attach to an exec session that is already
+// running. If the session keeps an output buffer, its contents are replayed
+// first.
+// The streams variable is only supported if the session was not created with
+// a terminal. If it is nil, the streams the session was created with are used.
+func (c *Container) ExecHTTPAttach(sessionID string, r *http.Request, w http.ResponseWriter,
+	streams *HTTPAttachStreams, detachKeys *string, cancel <-chan bool, hijackDone chan<- bool,
+) error {
+	// Ensure that we don't leak a goroutine here
+	defer func() {
+		close(hijackDone)
+	}()
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	session, err := c.checkExecAttach(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if streams == nil {
+		streams = new(HTTPAttachStreams)
+		streams.Stdin = session.Config.AttachStdin
+		streams.Stdout = session.Config.AttachStdout
+		streams.Stderr = session.Config.AttachStderr
+	}
+	if detachKeys == nil {
+		detachKeys = session.Config.DetachKeys
+	}
+	isTerminal := session.Config.Terminal
+
+	logrus.Infof("Going to HTTP attach to container %s exec session %s", c.ID(), session.ID())
+
+	// Unlock so other processes can use the container, and relock before
+	// returning, as the deferred unlock expects.
+	if !c.batched {
+		c.lock.Unlock()
+		defer c.lock.Lock()
+	}
+
+	if c.recordExecSession(session) {
+		rec, err := c.startExecRecording(session, nil)
+		if err != nil {
+			return err
+		}
+		defer c.stopRecording(rec)
+		streams = rec.httpStreams(streams)
+	}
+
+	return c.ociRuntime.ExecHTTPAttach(c, sessionID, session.Config.OutputBuffer, r, w, streams, detachKeys, isTerminal, cancel, hijackDone)
+}
+
+// execAttachExitCode retrieves the exit code of an exec session after an
+// attach to it finished, and cleans up after it if its exit command has not
+// done so yet.
+// MUST BE CALLED with container `c` unlocked.
+func (c *Container) execAttachExitCode(sessionID string) (int, error) {
+	exitCode, exitCodeErr := c.readExecExitCode(sessionID)
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+	}
+	if err := c.syncContainer(); err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+			return exitCode, exitCodeErr
+		}
+		return -1, fmt.Errorf("syncing container %s state to update exec session %s: %w", c.ID(), sessionID, err)
+	}
+
+	session, ok := c.state.ExecSessions[sessionID]
+	if !ok {
+		// The exec session was removed entirely, probably by the
+		// cleanup process, which recorded the exit code in an event.
+		diedEvent, err := c.runtime.GetExecDiedEvent(context.Background(), c.ID(), sessionID)
+		if err != nil {
+			return -1, fmt.Errorf("retrieving exec session %s exit code: %w", sessionID, err)
+		}
+		return *diedEvent.ContainerExitCode, nil
+	}
+	if session.State == define.ExecStateStopped {
+		return session.ExitCode, nil
+	}
+	if exitCodeErr != nil {
+		return -1, exitCodeErr
+	}
+
+	logrus.Debugf("Container %s exec session %s completed with exit code %d", c.ID(), sessionID, exitCode)
+
+	if err := justWriteExecExitCode(c, sessionID, exitCode, true); err != nil {
+		return -1, err
+	}
+
+	return exitCode, c.cleanupExecBundle(sessionID)
+}
+
 // ExecStop stops an exec session in the container.
 // If a timeout is provided, it will be used; otherwise, the timeout will
 // default to the stop timeout of the container.
@@ -811,7 +1056,11 @@ func (c *Container) ExecResize(sessionID string, newSize resize.TerminalSize) er
 
 	// Make sure the exec session is still running.
 
-	return c.ociRuntime.ExecAttachResize(c, sessionID, newSize)
+	if err := c.ociRuntime.ExecAttachResize(c, sessionID, newSize); err != nil {
+		return err
+	}
+	c.recordResize(sessionID, newSize)
+	return nil
 }
 
 func (c *Container) healthCheckExec(config *ExecConfig, timeout time.Duration, streams *define.AttachStreams) (int, error) {
@@ -950,6 +1199,11 @@ func (c *Container) execLogPath(sessionID string) string {
 	return filepath.Join(c.execBundlePath(sessionID), "exec_log")
 }
 
+// the FIFO conmon writes the output of an exec session to
+func (c *Container) execLogFIFO(sessionID string) string {
+	return filepath.Join(c.execBundlePath(sessionID), "exec_log.fifo")
+}
+
 // the socket conmon creates for an exec session
 func (c *Container) execAttachSocketPath(sessionID string) (string, error) {
 	return c.ociRuntime.ExecAttachSocketPath(c, sessionID)
@@ -1098,6 +1352,7 @@ func (c *Container) getActiveExecSessions() ([]string, error) {
 				session.State = define.ExecStateStopped
 
 				c.newExecDiedEvent(session.ID(), exitCode)
+				c.newExecRecordEvent(session.ID())
 
 				needSave = true
 			}
@@ -1180,6 +1435,7 @@ func prepareForExec(c *Container, session *ExecSession) (*ExecOptions, error) {
 	opts.ExitCommand = session.Config.ExitCommand
 	opts.ExitCommandDelay = session.Config.ExitCommandDelay
 	opts.Privileged = session.Config.Privileged
+	opts.OutputBuffer = session.Config.OutputBuffer
 
 	return opts, nil
 }
@@ -1217,6 +1473,7 @@ func justWriteExecExitCode(c *Container, sessionID string, exitCode int, emitEve
 	// Write an event first
 	if emitEvent {
 		c.newExecDiedEvent(sessionID, exitCode)
+		c.newExecRecordEvent(sessionID)
 	}
 
 	session, ok := c.state.ExecSessions[sessionID]
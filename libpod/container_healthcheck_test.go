@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	manifest "go.podman.io/image/v5/manifest"
+	"go.podman.io/podman/v6/libpod/define"
 )
 
 func TestHasHealthCheckCases(t *testing.T) {
@@ -34,3 +35,34 @@ func TestHasHealthCheckCases(t *testing.T) {
 	ctr.config.HealthCheckConfig = &manifest.Schema2HealthConfig{Test: []string{"CMD-SHELL", "echo hi"}}
 	assert.True(t, ctr.HasHealthCheck(), "non-empty Test with command should be considered a healthcheck")
 }
+
+func TestHealthCheckCommand(t *testing.T) {
+	assert.Nil(t, healthCheckCommand(nil))
+	assert.Nil(t, healthCheckCommand([]string{"NONE"}))
+	assert.Nil(t, healthCheckCommand([]string{"CMD"}))
+	assert.Nil(t, healthCheckCommand([]string{"CMD", ""}))
+	assert.Equal(t, []string{"cat", "/ready"}, healthCheckCommand([]string{"CMD", "cat", "/ready"}))
+	assert.Equal(t, []string{"/bin/sh", "-c", "cat /ready || exit 1"}, healthCheckCommand([]string{"CMD-SHELL", "cat", "/ready", "||", "exit", "1"}))
+	assert.Equal(t, []string{"cat", "/ready"}, healthCheckCommand([]string{"cat", "/ready"}))
+	// probes are run by Podman, not in the container
+	assert.Nil(t, healthCheckCommand([]string{"HTTP-GET", "http://localhost:8080/"}))
+	assert.Nil(t, healthCheckCommand([]string{"TCP-SOCKET", "localhost:5432"}))
+}
+
+func TestReadinessStatus(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{}, state: &ContainerState{}}
+
+	// no readiness check -> no readiness
+	assert.Empty(t, ctr.readinessStatus())
+
+	ctr.config.ReadinessCheckConfig = &define.ReadinessCheck{}
+	assert.Equal(t, define.ReadinessCheckNotReady, ctr.readinessStatus())
+
+	// a stopped container is never ready
+	ctr.state.Ready = true
+	ctr.state.State = define.ContainerStateExited
+	assert.Equal(t, define.ReadinessCheckNotReady, ctr.readinessStatus())
+
+	ctr.state.State = define.ContainerStateRunning
+	assert.Equal(t, define.ReadinessCheckReady, ctr.readinessStatus())
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"strconv"
 	"strings"
 
 	"github.com/docker/go-units"
@@ -205,6 +206,8 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		data.State.Health = nil
 	}
 
+	data.State.Readiness = c.readinessStatus()
+
 	networkConfig, err := c.getContainerNetworkInfo()
 	if err != nil {
 		return nil, err
@@ -426,7 +429,11 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 
 	ctrConfig.Healthcheck = c.config.HealthCheckConfig
 
-	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction.String()
+	ctrConfig.ReadinessCheck = c.config.ReadinessCheckConfig
+
+	ctrConfig.PressureMonitor = c.config.PressureMonitorConfig
+
+	ctrConfig.HealthcheckOnFailureAction = define.FormatHealthCheckOnFailure(c.config.HealthCheckOnFailureAction, c.config.HealthCheckOnFailureHook)
 
 	ctrConfig.HealthLogDestination = c.HealthCheckLogDestination()
 
@@ -492,6 +499,15 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	logConfig.Path = c.config.LogPath
 	logConfig.Size = units.HumanSize(float64(c.LogSizeMax()))
 	logConfig.Tag = c.config.LogTag
+	if c.config.LogDriver == define.JSONLogging && (c.config.LogMaxFiles > 0 || c.config.LogCompress) {
+		logConfig.Config = make(map[string]string)
+		if c.config.LogMaxFiles > 0 {
+			logConfig.Config["max-file"] = strconv.Itoa(c.config.LogMaxFiles)
+		}
+		if c.config.LogCompress {
+			logConfig.Config["compress"] = "true"
+		}
+	}
 
 	hostConfig.LogConfig = logConfig
 
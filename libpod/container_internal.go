@@ -38,6 +38,7 @@ import (
 	cutil "go.podman.io/common/pkg/util"
 	"go.podman.io/podman/v6/libpod/define"
 	"go.podman.io/podman/v6/libpod/events"
+	"go.podman.io/podman/v6/libpod/logs"
 	"go.podman.io/podman/v6/libpod/shutdown"
 	"go.podman.io/podman/v6/pkg/ctime"
 	"go.podman.io/podman/v6/pkg/domain/entities"
@@ -287,6 +288,12 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 			return false, err
 		}
 	}
+	if err := c.removeReadinessTimer(ctx); err != nil {
+		return false, err
+	}
+	if err := c.removePressureTimer(ctx); err != nil {
+		return false, err
+	}
 
 	// Is the container running again?
 	// If so, we don't have to do anything
@@ -397,6 +404,23 @@ func (c *Container) syncContainer() error {
 	return nil
 }
 
+// jsonFileLogFIFO returns the path of the FIFO conmon writes the log of a
+// container using the json-file log driver to.
+func (c *Container) jsonFileLogFIFO() string {
+	return filepath.Join(c.state.RunDir, "ctr.log.fifo")
+}
+
+// jsonFileLoggerConfig returns the configuration of the json-file logger of
+// the container.
+func (c *Container) jsonFileLoggerConfig() *logs.JSONFileConfig {
+	return &logs.JSONFileConfig{
+		Path:     c.LogPath(),
+		MaxSize:  c.LogSizeMax(),
+		MaxFiles: c.LogMaxFiles(),
+		Compress: c.LogCompress(),
+	}
+}
+
 func (c *Container) setupStorageMapping(dest, from *storage.IDMappingOptions) {
 	*dest = *from
 	// If we are creating a container inside a pod, we always want to inherit the
@@ -651,6 +675,14 @@ func resetContainerState(state *ContainerState) {
 	state.StartupHCSuccessCount = 0
 	state.StartupHCFailureCount = 0
 	state.HCUnitName = ""
+	state.Ready = false
+	state.ReadinessSuccessCount = 0
+	state.ReadinessFailureCount = 0
+	state.ReadinessUnitName = ""
+	state.PressureUnitName = ""
+	state.MemoryHighEvents = 0
+	state.MemoryMaxEvents = 0
+	state.PressureExceeded = nil
 	state.NetNS = ""
 	state.NetworkStatus = nil
 }
@@ -1117,6 +1149,20 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		return err
 	}
 
+	// A (re)started container is not ready until its readiness check
+	// passes again.
+	if c.state.Ready {
+		c.readinessChanged(false)
+	}
+	c.state.Ready = false
+	c.state.ReadinessSuccessCount = 0
+	c.state.ReadinessFailureCount = 0
+
+	// The counters of the memory events start over in the new cgroup.
+	c.state.MemoryHighEvents = 0
+	c.state.MemoryMaxEvents = 0
+	c.state.PressureExceeded = nil
+
 	if err := c.save(); err != nil {
 		return err
 	}
@@ -1131,6 +1177,18 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		}
 	}
 
+	if c.config.ReadinessCheckConfig != nil {
+		if err := c.createReadinessTimer(); err != nil {
+			return fmt.Errorf("create readiness check: %w", err)
+		}
+	}
+
+	if c.config.PressureMonitorConfig != nil {
+		if err := c.createPressureTimer(); err != nil {
+			return fmt.Errorf("create pressure monitoring: %w", err)
+		}
+	}
+
 	defer c.newContainerEvent(events.Init)
 	return c.completeNetworkSetup()
 }
@@ -1322,6 +1380,14 @@ func (c *Container) start() error {
 		}
 	}
 
+	if err := c.startReadinessTimer(); err != nil {
+		return fmt.Errorf("start readiness check: %w", err)
+	}
+
+	if err := c.startPressureTimer(); err != nil {
+		return fmt.Errorf("start pressure monitoring: %w", err)
+	}
+
 	c.newContainerEvent(events.Start)
 
 	return c.save()
@@ -1566,6 +1632,12 @@ func (c *Container) pause() error {
 			return fmt.Errorf("failed to remove HealthCheck timer: %v", err)
 		}
 	}
+	if err := c.removeReadinessTimer(context.Background()); err != nil {
+		return fmt.Errorf("failed to remove readiness check timer: %v", err)
+	}
+	if err := c.removePressureTimer(context.Background()); err != nil {
+		return fmt.Errorf("failed to remove pressure monitoring timer: %v", err)
+	}
 
 	if err := c.ociRuntime.PauseContainer(c); err != nil {
 		// TODO when using docker-py there is some sort of race/incompatibility here
@@ -1613,6 +1685,24 @@ func (c *Container) unpause() error {
 		}
 	}
 
+	if c.config.ReadinessCheckConfig != nil {
+		if err := c.createReadinessTimer(); err != nil {
+			return fmt.Errorf("create readiness check: %w", err)
+		}
+		if err := c.startReadinessTimer(); err != nil {
+			return err
+		}
+	}
+
+	if c.config.PressureMonitorConfig != nil {
+		if err := c.createPressureTimer(); err != nil {
+			return fmt.Errorf("create pressure monitoring: %w", err)
+		}
+		if err := c.startPressureTimer(); err != nil {
+			return err
+		}
+	}
+
 	logrus.Debugf("Unpaused container %s", c.ID())
 
 	c.state.State = define.ContainerStateRunning
@@ -1649,6 +1739,12 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 				logrus.Error(err.Error())
 			}
 		}
+		if err := c.removeReadinessTimer(context.Background()); err != nil {
+			logrus.Error(err.Error())
+		}
+		if err := c.removePressureTimer(context.Background()); err != nil {
+			logrus.Error(err.Error())
+		}
 		// Ensure we tear down the container network so it will be
 		// recreated - otherwise, behavior of restart differs from stop
 		// and start
@@ -2185,6 +2281,12 @@ func (c *Container) cleanup(ctx context.Context) error {
 			logrus.Errorf("Removing timer for container %s healthcheck: %v", c.ID(), err)
 		}
 	}
+	if err := c.removeReadinessTimer(ctx); err != nil {
+		logrus.Errorf("Removing timer for container %s readiness check: %v", c.ID(), err)
+	}
+	if err := c.removePressureTimer(ctx); err != nil {
+		logrus.Errorf("Removing timer for container %s pressure monitoring: %v", c.ID(), err)
+	}
 
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
@@ -2976,6 +3078,7 @@ func (c *Container) updateHealthCheck(newHealthCheckConfig IHealthCheckConfig, c
 
 func (c *Container) updateGlobalHealthCheckConfiguration(globalOptions define.GlobalHealthCheckOptions) error {
 	oldHealthCheckOnFailureAction := c.config.HealthCheckOnFailureAction
+	oldHealthCheckOnFailureHook := c.config.HealthCheckOnFailureHook
 	oldHealthLogDestination := c.config.HealthLogDestination
 	oldHealthMaxLogCount := c.config.HealthMaxLogCount
 	oldHealthMaxLogSize := c.config.HealthMaxLogSize
@@ -2984,6 +3087,10 @@ func (c *Container) updateGlobalHealthCheckConfiguration(globalOptions define.Gl
 		c.config.HealthCheckOnFailureAction = *globalOptions.HealthCheckOnFailureAction
 	}
 
+	if globalOptions.HealthCheckOnFailureHook != nil {
+		c.config.HealthCheckOnFailureHook = *globalOptions.HealthCheckOnFailureHook
+	}
+
 	if globalOptions.HealthMaxLogCount != nil {
 		c.config.HealthMaxLogCount = globalOptions.HealthMaxLogCount
 	}
@@ -3003,6 +3110,7 @@ func (c *Container) updateGlobalHealthCheckConfiguration(globalOptions define.Gl
 	if err := c.runtime.state.RewriteContainerConfig(c, c.config); err != nil {
 		// Assume DB write failed, revert to old resources block
 		c.config.HealthCheckOnFailureAction = oldHealthCheckOnFailureAction
+		c.config.HealthCheckOnFailureHook = oldHealthCheckOnFailureHook
 		c.config.HealthLogDestination = oldHealthLogDestination
 		c.config.HealthMaxLogCount = oldHealthMaxLogCount
 		c.config.HealthMaxLogSize = oldHealthMaxLogSize
@@ -3012,3 +3120,42 @@ func (c *Container) updateGlobalHealthCheckConfiguration(globalOptions define.Gl
 	logrus.Debugf("Global HealthCheck configuration updated for container %s", c.ID())
 	return nil
 }
+
+func (c *Container) updatePressureMonitor(update *define.UpdatePressureMonitor) error {
+	if update.IsEmpty() {
+		return nil
+	}
+	newConfig, err := update.Apply(c.config.PressureMonitorConfig)
+	if err != nil {
+		return err
+	}
+
+	oldConfig := c.config.PressureMonitorConfig
+	c.config.PressureMonitorConfig = newConfig
+	if err := c.runtime.state.RewriteContainerConfig(c, c.config); err != nil {
+		// Assume DB write failed, revert to old pressure monitoring
+		c.config.PressureMonitorConfig = oldConfig
+		return err
+	}
+	c.warnPressureTimer()
+
+	if c.ensureState(define.ContainerStateRunning) {
+		if err := c.removePressureTimer(context.Background()); err != nil {
+			return err
+		}
+		if newConfig != nil {
+			if err := c.createPressureTimer(); err != nil {
+				return err
+			}
+			if err := c.startPressureTimer(); err != nil {
+				return err
+			}
+		}
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
+	logrus.Debugf("Pressure monitoring configuration updated for container %s", c.ID())
+	return nil
+}
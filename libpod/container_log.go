@@ -21,7 +21,7 @@ import (
 var logDrivers []string
 
 func init() {
-	logDrivers = append(logDrivers, define.KubernetesLogging, define.NoLogging, define.PassthroughLogging)
+	logDrivers = append(logDrivers, define.KubernetesLogging, define.JSONLogging, define.NoLogging, define.PassthroughLogging)
 }
 
 // Log is a runtime function that can read one or more container logs.
@@ -48,18 +48,19 @@ func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logCh
 	case define.JournaldLogging:
 		return c.readFromJournal(ctx, options, logChannel, colorID, "")
 	case define.JSONLogging:
-		// TODO provide a separate implementation of this when Conmon
-		// has support.
-		fallthrough
+		return c.readFromLogFile(ctx, options, logChannel, colorID, logs.GetJSONLogFile, logs.NewJSONLogLine)
 	case define.KubernetesLogging, "":
-		return c.readFromLogFile(ctx, options, logChannel, colorID)
+		return c.readFromLogFile(ctx, options, logChannel, colorID, logs.GetLogFile, logs.NewLogLine)
 	default:
 		return fmt.Errorf("unrecognized log driver %q, cannot read logs: %w", c.LogDriver(), define.ErrInternal)
 	}
 }
 
-func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
-	t, tailLog, err := logs.GetLogFile(c.LogPath(), options)
+// readFromLogFile reads the log file of the container, getLogFile opens the
+// log file and newLogLine parses its lines.
+func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64,
+	getLogFile func(string, *logs.LogOptions) (*tail.Tail, []*logs.LogLine, error), newLogLine func(string) (*logs.LogLine, error)) error {
+	t, tailLog, err := getLogFile(c.LogPath(), options)
 	if err != nil {
 		// If the log file does not exist, this is not fatal.
 		if errors.Is(err, os.ErrNotExist) {
@@ -78,14 +79,20 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 	}()
 
 	go func() {
-		for _, nll := range tailLog {
-			nll.CID = c.ID()
-			nll.CName = c.Name()
-			nll.ColorID = colorID
-			if nll.Since(options.Since) && nll.Until(options.Until) {
-				logChannel <- nll
+		filter := options.Filter.Lines()
+		send := func(nll *logs.LogLine) {
+			for _, nll := range filter(nll) {
+				nll.CID = c.ID()
+				nll.CName = c.Name()
+				nll.ColorID = colorID
+				if nll.Since(options.Since) && nll.Until(options.Until) {
+					logChannel <- nll
+				}
 			}
 		}
+		for _, nll := range tailLog {
+			send(nll)
+		}
 		defer options.WaitGroup.Done()
 		var line *tail.Line
 		var ok bool
@@ -97,21 +104,18 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 				return
 			case line, ok = <-t.Lines:
 				if !ok {
-					// channel was closed
+					// channel was closed, return the partial lines
+					// held back by the filter
+					send(nil)
 					return
 				}
 			}
-			nll, err := logs.NewLogLine(line.Text)
+			nll, err := newLogLine(line.Text)
 			if err != nil {
 				logrus.Errorf("Getting new log line: %v", err)
 				continue
 			}
-			nll.CID = c.ID()
-			nll.CName = c.Name()
-			nll.ColorID = colorID
-			if nll.Since(options.Since) && nll.Until(options.Until) {
-				logChannel <- nll
-			}
+			send(nll)
 		}
 	}()
 	// Check if container is still running or paused
@@ -35,8 +35,8 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 ) error {
 	// We need the container's events in the same journal to guarantee
 	// consistency, see #10323.
-	if options.Follow && c.runtime.config.Engine.EventsLogger != "journald" {
-		return fmt.Errorf("using --follow with the journald --log-driver but without the journald --events-backend (%s) is not supported", c.runtime.config.Engine.EventsLogger)
+	if options.Follow && c.runtime.eventer.String() != events.Journald.String() {
+		return fmt.Errorf("using --follow with the journald --log-driver but without the journald --events-backend (%s) is not supported", c.runtime.eventer.String())
 	}
 
 	journal, err := sdjournal.NewJournal()
@@ -132,6 +132,7 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 			}
 		}()
 
+		filter := options.Filter.Lines()
 		tailQueue := []*logs.LogLine{} // needed for options.Tail
 		doTail := options.Tail >= 0
 		doTailFunc := func() {
@@ -163,6 +164,9 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 					doTailFunc()
 					continue
 				}
+				for _, logLine := range filter(nil) {
+					logChannel <- logLine
+				}
 				return
 			}
 
@@ -202,11 +206,14 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 			if options.UseName {
 				logLine.CName = c.Name()
 			}
+			lines := filter(logLine)
 			if doTail {
-				tailQueue = append(tailQueue, logLine)
+				tailQueue = append(tailQueue, lines...)
 				continue
 			}
-			logChannel <- logLine
+			for _, logLine := range lines {
+				logChannel <- logLine
+			}
 		}
 	}()
 
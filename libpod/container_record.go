@@ -0,0 +1,263 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/resize"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/libpod/recording"
+	"go.podman.io/storage/pkg/fileutils"
+	"go.podman.io/storage/pkg/stringid"
+)
+
+// recordingExtension is the file extension of recordings.
+const recordingExtension = ".cast"
+
+// sessionRecording is the recording of an exec or attach session of a
+// container.
+type sessionRecording struct {
+	*recording.Recorder
+	// id is the ID of the recording.
+	id string
+	// key is the exec session or container ID the session is resized
+	// with.
+	key string
+	// execSessionID is the ID of the recorded exec session, it is empty
+	// for attach sessions.
+	execSessionID string
+	// output is set if the output of the session is recorded by the
+	// attached client.  The output of exec sessions is recorded by their
+	// output keeper instead, even while no client is attached.
+	output bool
+}
+
+// recordingsPath returns the directory of the recordings of the exec and
+// attach sessions of the container.
+func (c *Container) recordingsPath() string {
+	return filepath.Join(c.bundlePath(), "recordings")
+}
+
+// recordSessions returns whether all exec and attach sessions of the
+// container are recorded, as set by define.RecordAnnotation.
+func (c *Container) recordSessions() bool {
+	if c.config.Spec == nil {
+		return false
+	}
+	value, ok := c.config.Spec.Annotations[define.RecordAnnotation]
+	if !ok {
+		return false
+	}
+	record, err := strconv.ParseBool(value)
+	if err != nil {
+		// Rather record too much than miss a session.
+		logrus.Warnf("Invalid value %q of annotation %s of container %s, recording the session", value, define.RecordAnnotation, c.ID())
+		return true
+	}
+	return record
+}
+
+// recordExecSession returns whether the given exec session is recorded.
+func (c *Container) recordExecSession(session *ExecSession) bool {
+	return session.Config.Record || c.recordSessions()
+}
+
+// startExecRecording starts recording the input and the resizes of an exec
+// session.  The recording of a session is continued each time it is attached
+// to.  newSize is the initial size of the terminal, if known.
+func (c *Container) startExecRecording(session *ExecSession, newSize *resize.TerminalSize) (*sessionRecording, error) {
+	return c.startRecording(session.ID(), session.ID(), session.ID(), false, c.execRecordingHeader(session, newSize))
+}
+
+// createExecRecording creates the recording of an exec session started
+// without attaching to it and returns its path.  Its output is recorded by
+// the output keeper of the session.
+func (c *Container) createExecRecording(session *ExecSession) (string, error) {
+	recorder, err := c.createRecording(session.ID(), c.execRecordingHeader(session, nil))
+	if err != nil {
+		return "", err
+	}
+	if err := recorder.Close(); err != nil {
+		return "", fmt.Errorf("recording session of container %s: %w", c.ID(), err)
+	}
+	return recorder.Path(), nil
+}
+
+// execRecordingHeader returns the header of the recording of an exec session.
+func (c *Container) execRecordingHeader(session *ExecSession, newSize *resize.TerminalSize) recording.Header {
+	header := recording.Header{
+		Command: strings.Join(session.Config.Command, " "),
+		Title:   fmt.Sprintf("%s exec %s", c.Name(), session.ID()),
+	}
+	if newSize != nil {
+		header.Width, header.Height = newSize.Width, newSize.Height
+	}
+	return header
+}
+
+// startAttachRecording starts recording an attach session.
+func (c *Container) startAttachRecording() (*sessionRecording, error) {
+	header := recording.Header{
+		Title: fmt.Sprintf("%s attach", c.Name()),
+	}
+	if c.config.Spec != nil && c.config.Spec.Process != nil {
+		header.Command = strings.Join(c.config.Spec.Process.Args, " ")
+	}
+	return c.startRecording(stringid.GenerateRandomID(), c.ID(), "", true, header)
+}
+
+// startRecording creates or continues the recording with the given ID.
+// Resizes made with the given key are added to the recording until it is
+// stopped.  The output is only recorded by the streams of the recording if
+// output is set.
+func (c *Container) startRecording(id, key, execSessionID string, output bool, header recording.Header) (*sessionRecording, error) {
+	recorder, err := c.createRecording(id, header)
+	if err != nil {
+		return nil, err
+	}
+	c.runtime.sessionRecorders.Put(key, recorder)
+
+	logrus.Debugf("Recording session of container %s in %s", c.ID(), recorder.Path())
+
+	return &sessionRecording{
+		Recorder:      recorder,
+		id:            id,
+		key:           key,
+		execSessionID: execSessionID,
+		output:        output,
+	}, nil
+}
+
+// createRecording creates or opens the recording with the given ID.
+func (c *Container) createRecording(id string, header recording.Header) (*recording.Recorder, error) {
+	if err := os.MkdirAll(c.recordingsPath(), 0o700); err != nil {
+		return nil, fmt.Errorf("creating recordings directory of container %s: %w", c.ID(), err)
+	}
+	recorder, err := recording.Create(filepath.Join(c.recordingsPath(), id+recordingExtension), header)
+	if err != nil {
+		return nil, fmt.Errorf("recording session of container %s: %w", c.ID(), err)
+	}
+	return recorder, nil
+}
+
+// stopRecording stops recording a session.  An event referencing the
+// recording is written for attach sessions, and once the session exited for
+// exec sessions (see newExecRecordEvent).
+func (c *Container) stopRecording(rec *sessionRecording) {
+	if current, ok := c.runtime.sessionRecorders.Get(rec.key); ok && current == rec.Recorder {
+		c.runtime.sessionRecorders.Delete(rec.key)
+	}
+	if err := rec.Close(); err != nil {
+		logrus.Errorf("Recording session of container %s: %v", c.ID(), err)
+	}
+	if rec.execSessionID == "" {
+		c.newRecordEvent(rec.id, rec.Path(), "")
+	}
+}
+
+// newExecRecordEvent writes an event referencing the recording of an exec
+// session which exited, if the session was recorded.
+func (c *Container) newExecRecordEvent(sessionID string) {
+	path := filepath.Join(c.recordingsPath(), sessionID+recordingExtension)
+	if err := fileutils.Exists(path); err != nil {
+		return
+	}
+	c.newRecordEvent(sessionID, path, sessionID)
+}
+
+// recordResize adds a resize made with the given exec session or container ID
+// to the recording of the session, if it is recorded by this process.
+func (c *Container) recordResize(key string, newSize resize.TerminalSize) {
+	if recorder, ok := c.runtime.sessionRecorders.Get(key); ok {
+		recorder.Resize(newSize.Width, newSize.Height)
+	}
+}
+
+// streams returns streams which record the data copied over the given
+// streams.
+func (r *sessionRecording) streams(streams *define.AttachStreams) *define.AttachStreams {
+	if streams == nil {
+		return nil
+	}
+	recorded := *streams
+	if r.output && streams.OutputStream != nil {
+		recorded.OutputStream = r.Writer(recording.Output, streams.OutputStream)
+	}
+	if r.output && streams.ErrorStream != nil {
+		recorded.ErrorStream = r.Writer(recording.Output, streams.ErrorStream)
+	}
+	if streams.InputStream != nil {
+		recorded.InputStream = bufio.NewReader(r.Reader(recording.Input, streams.InputStream))
+	}
+	return &recorded
+}
+
+// httpStreams returns HTTP attach streams which record the session.
+// Nil streams attach to all streams.
+func (r *sessionRecording) httpStreams(streams *HTTPAttachStreams) *HTTPAttachStreams {
+	recorded := HTTPAttachStreams{Stdin: true, Stdout: true, Stderr: true}
+	if streams != nil {
+		recorded = *streams
+	}
+	recorded.recorder = r.Recorder
+	recorded.recordOutput = r.output
+	return &recorded
+}
+
+// resizes returns a channel which receives the resizes sent to the given
+// channel, after adding them to the recording.
+func (r *sessionRecording) resizes(resizeChan <-chan resize.TerminalSize) <-chan resize.TerminalSize {
+	if resizeChan == nil {
+		return nil
+	}
+	recorded := make(chan resize.TerminalSize)
+	go func() {
+		defer close(recorded)
+		for newSize := range resizeChan {
+			r.Resize(newSize.Width, newSize.Height)
+			recorded <- newSize
+		}
+	}()
+	return recorded
+}
+
+// RecordingPath returns the path of a recording of an exec or attach session
+// of the container. The ID of the recording may be truncated, as long as it
+// is unique.
+func (c *Container) RecordingPath(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid recording ID %q: %w", id, define.ErrInvalidArg)
+	}
+
+	entries, err := os.ReadDir(c.recordingsPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("reading recordings of container %s: %w", c.ID(), err)
+	}
+	var matches []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), recordingExtension)
+		if !ok || !strings.HasPrefix(name, id) {
+			continue
+		}
+		if name == id {
+			return filepath.Join(c.recordingsPath(), entry.Name()), nil
+		}
+		matches = append(matches, entry.Name())
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("container %s has no recording with ID %s: %w", c.ID(), id, define.ErrNoSuchRecording)
+	case 1:
+		return filepath.Join(c.recordingsPath(), matches[0]), nil
+	default:
+		return "", fmt.Errorf("recording ID %s of container %s is ambiguous: %w", id, c.ID(), define.ErrInvalidArg)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"go.podman.io/buildah/copier"
@@ -152,3 +153,111 @@ func secureStat(root string, path string) (*copier.StatForItem, error) {
 	// Nothing found!
 	return nil, copy.ErrENOENT
 }
+
+// walk lists the contents of the directory at the specified path inside the
+// container, descending into subdirectories down to the specified depth.
+func (c *Container) walk(containerMountPoint string, containerPath string, depth int) ([]*define.FileInfo, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("depth must be at least 1: %w", define.ErrInvalidArg)
+	}
+
+	info, resolvedRoot, resolvedPath, err := c.stat(containerMountPoint, containerPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir {
+		return nil, fmt.Errorf("%q is not a directory: %w", containerPath, define.ErrInvalidArg)
+	}
+
+	var items []*copier.StatForItem
+	err = c.joinMountAndExec(
+		func() error {
+			var walkErr error
+			items, walkErr = secureWalk(resolvedRoot, resolvedPath, depth)
+			return walkErr
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return walkInfos(c.pathAbs(containerPath), resolvedRoot, resolvedPath, items)
+}
+
+// secureWalk lists the contents of the directory at path in a chroot'ed
+// environment in root, descending into subdirectories down to the specified
+// depth.  The names of the returned items are relative to root.
+func secureWalk(root string, path string, depth int) ([]*copier.StatForItem, error) {
+	dir, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each level of the directory tree is matched by one more glob.
+	glob := escapeGlob(dir)
+	globs := make([]string, 0, depth)
+	for range depth {
+		glob = filepath.Join(glob, "*")
+		globs = append(globs, glob)
+	}
+
+	globStats, err := copier.Stat(root, "", copier.StatOptions{}, globs)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*copier.StatForItem
+	for _, globStat := range globStats {
+		if globStat.Error != "" {
+			return nil, errors.New(globStat.Error)
+		}
+		for _, name := range globStat.Globbed {
+			items = append(items, globStat.Results[name])
+		}
+	}
+	return items, nil
+}
+
+// escapeGlob escapes the meta characters of a glob in path.
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// walkInfos turns the items found by secureWalk in the directory dir below
+// root into file infos.  The items are named by their path below base and
+// sorted by name.
+func walkInfos(base string, root string, dir string, items []*copier.StatForItem) ([]*define.FileInfo, error) {
+	relDir, err := filepath.Rel(root, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*define.FileInfo, 0, len(items))
+	for _, item := range items {
+		rel, err := filepath.Rel(relDir, item.Name)
+		if err != nil {
+			return nil, err
+		}
+		info := &define.FileInfo{
+			Name:    filepath.Join(base, rel),
+			Size:    item.Size,
+			Mode:    item.Mode,
+			ModTime: item.ModTime,
+			IsDir:   item.IsDir,
+		}
+		if item.IsSymlink {
+			info.LinkTarget = item.ImmediateTarget
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b *define.FileInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos, nil
+}
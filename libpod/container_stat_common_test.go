@@ -0,0 +1,41 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/buildah/copier"
+)
+
+func TestEscapeGlob(t *testing.T) {
+	assert.Equal(t, "etc", escapeGlob("etc"))
+	assert.Equal(t, `a\*b/\?/\[x]/c\\d`, escapeGlob(`a*b/?/[x]/c\d`))
+}
+
+func TestWalkInfos(t *testing.T) {
+	items := []*copier.StatForItem{
+		{Name: "etc/ssl/certs", IsDir: true},
+		{Name: "etc/hosts", Size: 42},
+		{Name: "etc/ssl", IsDir: true},
+		{Name: "etc/localtime", IsSymlink: true, ImmediateTarget: "/usr/share/zoneinfo/UTC"},
+	}
+
+	infos, err := walkInfos("/etc", "/mnt/root", "/mnt/root/etc", items)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"/etc/hosts", "/etc/localtime", "/etc/ssl", "/etc/ssl/certs"}, names)
+	assert.Equal(t, int64(42), infos[0].Size)
+	assert.Empty(t, infos[0].LinkTarget)
+	assert.Equal(t, "/usr/share/zoneinfo/UTC", infos[1].LinkTarget)
+	assert.True(t, infos[3].IsDir)
+
+	// Walking the root of a running container.
+	infos, err = walkInfos("/", "/", "/.", []*copier.StatForItem{{Name: "bin"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/bin", infos[0].Name)
+}
@@ -140,11 +140,15 @@ func (c *Container) validate() error {
 		return fmt.Errorf("cannot set on-failure action to %s without a health check", c.config.HealthCheckOnFailureAction.String())
 	}
 
+	if (c.config.HealthCheckOnFailureAction == define.HealthCheckOnFailureActionExec) != (c.config.HealthCheckOnFailureHook != "") {
+		return fmt.Errorf("a hook must be set for the exec on-failure action of a health check, and only for it: %w", define.ErrInvalidArg)
+	}
+
 	if value, exists := c.config.Labels[define.AutoUpdateLabel]; exists {
 		// TODO: we cannot reference pkg/autoupdate here due to
 		// circular dependencies.  It's worth considering moving the
 		// auto-update logic into the libpod package.
-		if value == "registry" || value == "image" {
+		if value == "registry" || value == "image" || value == "semver" {
 			if err := validateAutoUpdateImageReference(c.config.RawImageName); err != nil {
 				return err
 			}
@@ -169,6 +169,18 @@ const (
 	// KubeImageAutomountAnnotation
 	KubeImageAutomountAnnotation = "io.podman.annotations.kube.image.volumes.mount"
 
+	// KubeServiceNodePortAddressAnnotation is used by kube play to publish
+	// the node ports of a K8s Service of type NodePort or LoadBalancer on
+	// the specified host address instead of 127.0.0.1.  Use 0.0.0.0 to
+	// publish them on all addresses.
+	KubeServiceNodePortAddressAnnotation = "io.podman.annotations.kube.service.nodeport-address"
+
+	// RecordAnnotation is used to record all exec and attach sessions of a
+	// container, as if --record was set. It is expected to be a boolean.
+	// Set it in the annotations of containers.conf to record the sessions
+	// of all containers.
+	RecordAnnotation = "io.podman.annotations.record"
+
 	// PIDsLimitAnnotation is used to limit the number of PIDs
 	PIDsLimitAnnotation = "io.podman.annotations.pids-limit"
 
@@ -7,3 +7,11 @@ const AutoUpdateLabel = "io.containers.autoupdate"
 // AutoUpdateAuthfileLabel denotes the container label key to specify authfile
 // in container labels.
 const AutoUpdateAuthfileLabel = "io.containers.autoupdate.authfile"
+
+// AutoUpdateWindowLabel denotes the container label key to specify the
+// maintenance window in which auto updates are performed.
+const AutoUpdateWindowLabel = "io.containers.autoupdate.window"
+
+// AutoUpdateSignatureLabel denotes the container label key to require the
+// updated image to be signed according to the trust policy.
+const AutoUpdateSignatureLabel = "io.containers.autoupdate.signature"
@@ -60,6 +60,10 @@ type InspectContainerConfig struct {
 	StopSignal string `json:"StopSignal"`
 	// Configured startup healthcheck for the container
 	StartupHealthCheck *StartupHealthCheck `json:"StartupHealthCheck,omitempty"`
+	// Configured readiness check for the container
+	ReadinessCheck *ReadinessCheck `json:"ReadinessCheck,omitempty"`
+	// Configured monitoring of the memory events and resource pressure
+	PressureMonitor *PressureMonitor `json:"PressureMonitor,omitempty"`
 	// Configured healthcheck for the container
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 	// HealthcheckOnFailureAction defines an action to take once the container turns unhealthy.
@@ -317,6 +321,7 @@ type InspectContainerState struct {
 	StartedAt      time.Time           `json:"StartedAt"`
 	FinishedAt     time.Time           `json:"FinishedAt"`
 	Health         *HealthCheckResults `json:"Health,omitempty"`
+	Readiness      string              `json:"Readiness,omitempty"`
 	Checkpointed   bool                `json:"Checkpointed,omitempty"`
 	CgroupPath     string              `json:"CgroupPath,omitempty"`
 	CheckpointedAt time.Time           `json:"CheckpointedAt"`
@@ -840,6 +845,13 @@ type InspectExecSession struct {
 	Pid int `json:"Pid"`
 	// ProcessConfig contains information about the exec session's process.
 	ProcessConfig *InspectExecProcess `json:"ProcessConfig"`
+	// OutputBuffer is the size, in bytes, of the last output kept to be
+	// replayed when attaching to the exec session again.
+	// It is a Podman extension.
+	OutputBuffer int64 `json:"OutputBuffer,omitempty"`
+	// Record is whether the exec session is recorded. It is a Podman
+	// extension.
+	Record bool `json:"Record,omitempty"`
 }
 
 // InspectExecProcess contains information about the process in a given exec
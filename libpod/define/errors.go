@@ -25,6 +25,10 @@ var (
 	// not exist.
 	ErrNoSuchExecSession = errors.New("no such exec session")
 
+	// ErrNoSuchRecording indicates that the requested recording of an exec
+	// or attach session does not exist.
+	ErrNoSuchRecording = errors.New("no such recording")
+
 	// ErrNoSuchExitCode indicates that the requested container exit code
 	// does not exist.
 	ErrNoSuchExitCode = errors.New("no such exit code")
@@ -0,0 +1,198 @@
+package define
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultHealthCheckProbeHost is the host probes connect to if none is set.
+// The probes run in the network namespace of the container, so this is the
+// container itself.
+const defaultHealthCheckProbeHost = "localhost"
+
+// HealthCheckProbe is a healthcheck run by Podman in the network namespace of
+// the container.  Unlike a healthcheck command, it does not need any tool in
+// the image of the container.
+type HealthCheckProbe struct {
+	// Type is HealthConfigTestHTTPGet, HealthConfigTestTCPSocket or
+	// HealthConfigTestGRPC
+	Type string
+	// Address is the host:port the probe connects to
+	Address string
+	// URL is the URL requested by HTTP probes
+	URL string
+	// Headers are the HTTP headers sent by HTTP probes, as "Name: value"
+	Headers []string
+	// Service is the service checked by gRPC probes.  If empty, the
+	// overall health of the server is checked.
+	Service string
+}
+
+// Test returns the HealthConfig.Test running the probe
+func (p *HealthCheckProbe) Test() []string {
+	switch p.Type {
+	case HealthConfigTestHTTPGet:
+		return append([]string{p.Type, p.URL}, p.Headers...)
+	case HealthConfigTestGRPC:
+		if p.Service != "" {
+			return []string{p.Type, p.Address, p.Service}
+		}
+	}
+	return []string{p.Type, p.Address}
+}
+
+// String returns a description of the probe for the healthcheck log
+func (p *HealthCheckProbe) String() string {
+	switch p.Type {
+	case HealthConfigTestHTTPGet:
+		return "HTTP GET " + p.URL
+	case HealthConfigTestGRPC:
+		if p.Service != "" {
+			return fmt.Sprintf("gRPC health check of service %q on %s", p.Service, p.Address)
+		}
+		return "gRPC health check on " + p.Address
+	}
+	return "TCP connection to " + p.Address
+}
+
+// IsHealthCheckProbe returns whether a HealthConfig.Test is a probe run by
+// Podman instead of a command
+func IsHealthCheckProbe(test []string) bool {
+	if len(test) == 0 {
+		return false
+	}
+	switch test[0] {
+	case HealthConfigTestHTTPGet, HealthConfigTestTCPSocket, HealthConfigTestGRPC:
+		return true
+	}
+	return false
+}
+
+// ParseHealthCheckProbe parses the HealthConfig.Test of a probe.  It returns
+// nil if the test is not a probe.
+func ParseHealthCheckProbe(test []string) (*HealthCheckProbe, error) {
+	if !IsHealthCheckProbe(test) {
+		return nil, nil
+	}
+	if len(test) < 2 {
+		return nil, fmt.Errorf("healthcheck probe %s requires a target", test[0])
+	}
+	switch test[0] {
+	case HealthConfigTestHTTPGet:
+		probe, err := NewHTTPGetHealthCheckProbe(test[1])
+		if err != nil {
+			return nil, err
+		}
+		for _, header := range test[2:] {
+			if err := probe.AddHeader(header); err != nil {
+				return nil, err
+			}
+		}
+		return probe, nil
+	case HealthConfigTestTCPSocket:
+		if len(test) > 2 {
+			return nil, fmt.Errorf("too many arguments for healthcheck probe %s", test[0])
+		}
+		return NewTCPSocketHealthCheckProbe(test[1])
+	default:
+		if len(test) > 3 {
+			return nil, fmt.Errorf("too many arguments for healthcheck probe %s", test[0])
+		}
+		probe, err := NewGRPCHealthCheckProbe(test[1])
+		if err != nil {
+			return nil, err
+		}
+		if len(test) > 2 {
+			probe.Service = test[2]
+		}
+		return probe, nil
+	}
+}
+
+// NewHTTPGetHealthCheckProbe creates a HTTP probe from a target given as
+// [SCHEME://][HOST]:PORT[/PATH].  The scheme is http or https and defaults
+// to http, the host defaults to localhost and the path to /.
+func NewHTTPGetHealthCheckProbe(target string) (*HealthCheckProbe, error) {
+	rawURL := target
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP healthcheck target %q: %w", target, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid HTTP healthcheck target %q: scheme must be http or https", target)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("invalid HTTP healthcheck target %q: user information is not supported, use a header instead", target)
+	}
+	address, err := healthCheckProbeAddress(u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP healthcheck target %q: %w", target, err)
+	}
+	u.Host = address
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return &HealthCheckProbe{Type: HealthConfigTestHTTPGet, Address: address, URL: u.String()}, nil
+}
+
+// AddHeader adds a HTTP header, given as "Name: value", to a HTTP probe
+func (p *HealthCheckProbe) AddHeader(header string) error {
+	name, value, ok := strings.Cut(header, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid HTTP healthcheck header %q, must be NAME: VALUE", header)
+	}
+	p.Headers = append(p.Headers, name+": "+strings.TrimSpace(value))
+	return nil
+}
+
+// NewTCPSocketHealthCheckProbe creates a TCP probe from a target given as
+// [HOST:]PORT.  The host defaults to localhost.
+func NewTCPSocketHealthCheckProbe(target string) (*HealthCheckProbe, error) {
+	address, err := healthCheckProbeTarget(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TCP healthcheck target %q: %w", target, err)
+	}
+	return &HealthCheckProbe{Type: HealthConfigTestTCPSocket, Address: address}, nil
+}
+
+// NewGRPCHealthCheckProbe creates a gRPC probe from a target given as
+// [HOST:]PORT[/SERVICE].  The host defaults to localhost.
+func NewGRPCHealthCheckProbe(target string) (*HealthCheckProbe, error) {
+	hostPort, service, _ := strings.Cut(target, "/")
+	address, err := healthCheckProbeTarget(hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gRPC healthcheck target %q: %w", target, err)
+	}
+	return &HealthCheckProbe{Type: HealthConfigTestGRPC, Address: address, Service: service}, nil
+}
+
+// healthCheckProbeTarget returns the host:port of a target given as
+// [HOST:]PORT
+func healthCheckProbeTarget(target string) (string, error) {
+	if _, err := strconv.Atoi(target); err == nil {
+		target = ":" + target
+	}
+	return healthCheckProbeAddress(target)
+}
+
+// healthCheckProbeAddress validates a [HOST]:PORT and sets the default host
+func healthCheckProbeAddress(hostPort string) (string, error) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", fmt.Errorf("must be [HOST]:PORT: %w", err)
+	}
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return "", fmt.Errorf("invalid port %q", port)
+	}
+	if host == "" {
+		host = defaultHealthCheckProbeHost
+	}
+	return net.JoinHostPort(host, port), nil
+}
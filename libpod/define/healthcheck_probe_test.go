@@ -0,0 +1,69 @@
+package define
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHealthCheckProbe(t *testing.T) {
+	for _, test := range []struct {
+		target string
+		parse  func(string) (*HealthCheckProbe, error)
+		want   []string
+	}{
+		{":8080/healthz", NewHTTPGetHealthCheckProbe, []string{HealthConfigTestHTTPGet, "http://localhost:8080/healthz"}},
+		{"https://10.0.0.1:8443", NewHTTPGetHealthCheckProbe, []string{HealthConfigTestHTTPGet, "https://10.0.0.1:8443/"}},
+		{"[::1]:80/ready?full=1", NewHTTPGetHealthCheckProbe, []string{HealthConfigTestHTTPGet, "http://[::1]:80/ready?full=1"}},
+		{"5432", NewTCPSocketHealthCheckProbe, []string{HealthConfigTestTCPSocket, "localhost:5432"}},
+		{"db:5432", NewTCPSocketHealthCheckProbe, []string{HealthConfigTestTCPSocket, "db:5432"}},
+		{"9000", NewGRPCHealthCheckProbe, []string{HealthConfigTestGRPC, "localhost:9000"}},
+		{"127.0.0.1:9000/api.v1.Users", NewGRPCHealthCheckProbe, []string{HealthConfigTestGRPC, "127.0.0.1:9000", "api.v1.Users"}},
+	} {
+		probe, err := test.parse(test.target)
+		require.NoError(t, err, test.target)
+		assert.Equal(t, test.want, probe.Test(), test.target)
+
+		parsed, err := ParseHealthCheckProbe(probe.Test())
+		require.NoError(t, err, test.target)
+		assert.Equal(t, probe, parsed, test.target)
+	}
+
+	for _, test := range []struct {
+		target string
+		parse  func(string) (*HealthCheckProbe, error)
+	}{
+		{"8080/healthz", NewHTTPGetHealthCheckProbe},
+		{"ftp://:21", NewHTTPGetHealthCheckProbe},
+		{"http://user:pw@:80", NewHTTPGetHealthCheckProbe},
+		{"", NewTCPSocketHealthCheckProbe},
+		{"0", NewTCPSocketHealthCheckProbe},
+		{"db:65536", NewTCPSocketHealthCheckProbe},
+		{"db", NewTCPSocketHealthCheckProbe},
+		{"/service", NewGRPCHealthCheckProbe},
+	} {
+		_, err := test.parse(test.target)
+		assert.Error(t, err, test.target)
+	}
+}
+
+func TestParseHealthCheckProbe(t *testing.T) {
+	probe, err := ParseHealthCheckProbe([]string{HealthConfigTestCmdShell, "curl -f http://localhost/"})
+	require.NoError(t, err)
+	assert.Nil(t, probe)
+
+	probe, err = ParseHealthCheckProbe([]string{HealthConfigTestHTTPGet, ":80", "X-Probe:liveness", "Host: example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"X-Probe: liveness", "Host: example.com"}, probe.Headers)
+
+	for _, test := range [][]string{
+		{HealthConfigTestHTTPGet},
+		{HealthConfigTestHTTPGet, ":80", "X-Probe"},
+		{HealthConfigTestTCPSocket, "5432", "extra"},
+		{HealthConfigTestGRPC, "9000", "service", "extra"},
+	} {
+		_, err := ParseHealthCheckProbe(test)
+		assert.Error(t, err, test)
+	}
+}
@@ -25,6 +25,15 @@ const (
 	HealthCheckStopped string = "stopped"
 )
 
+const (
+	// ReadinessCheckReady describes a container whose readiness check
+	// passed and which is ready to serve requests
+	ReadinessCheckReady string = "ready"
+	// ReadinessCheckNotReady describes a container whose readiness check
+	// did not pass (yet) or failed
+	ReadinessCheckNotReady string = "not ready"
+)
+
 // HealthCheckStatus represents the current state of a container
 type HealthCheckStatus int
 
@@ -94,6 +103,15 @@ const (
 	HealthConfigTestCmd = "CMD"
 	// HealthConfigTestCmdShell runs commands with the system's default shell
 	HealthConfigTestCmdShell = "CMD-SHELL"
+	// HealthConfigTestHTTPGet sends a HTTP GET request from Podman to the
+	// URL, followed by the headers to send as "Name: value"
+	HealthConfigTestHTTPGet = "HTTP-GET"
+	// HealthConfigTestTCPSocket opens a TCP connection from Podman to
+	// host:port
+	HealthConfigTestTCPSocket = "TCP-SOCKET"
+	// HealthConfigTestGRPC calls the gRPC health checking protocol from
+	// Podman on host:port, optionally followed by the service to check
+	HealthConfigTestGRPC = "GRPC"
 )
 
 // HealthCheckOnFailureAction defines how Podman reacts when a container's health
@@ -112,15 +130,21 @@ const (
 	HealthCheckOnFailureActionRestart = iota
 	// HealthCheckOnFailureActionNonce instructs Podman to stop the container on an unhealthy status.
 	HealthCheckOnFailureActionStop = iota
+	// HealthCheckOnFailureActionExec instructs Podman to run a hook on the host once the container turns unhealthy.
+	HealthCheckOnFailureActionExec = iota
+	// HealthCheckOnFailureActionRollback instructs Podman to roll back the image of an auto-updated container on an unhealthy status.
+	HealthCheckOnFailureActionRollback = iota
 )
 
 // String representations for on-failure actions.
 const (
-	strHealthCheckOnFailureActionNone    = "none"
-	strHealthCheckOnFailureActionInvalid = "invalid"
-	strHealthCheckOnFailureActionKill    = "kill"
-	strHealthCheckOnFailureActionRestart = "restart"
-	strHealthCheckOnFailureActionStop    = "stop"
+	strHealthCheckOnFailureActionNone     = "none"
+	strHealthCheckOnFailureActionInvalid  = "invalid"
+	strHealthCheckOnFailureActionKill     = "kill"
+	strHealthCheckOnFailureActionRestart  = "restart"
+	strHealthCheckOnFailureActionStop     = "stop"
+	strHealthCheckOnFailureActionExec     = "exec"
+	strHealthCheckOnFailureActionRollback = "rollback"
 )
 
 // SupportedHealthCheckOnFailureActions lists all supported healthcheck restart policies.
@@ -129,6 +153,8 @@ var SupportedHealthCheckOnFailureActions = []string{
 	strHealthCheckOnFailureActionKill,
 	strHealthCheckOnFailureActionRestart,
 	strHealthCheckOnFailureActionStop,
+	strHealthCheckOnFailureActionExec + ":",
+	strHealthCheckOnFailureActionRollback,
 }
 
 // String returns the string representation of the HealthCheckOnFailureAction.
@@ -142,6 +168,10 @@ func (h HealthCheckOnFailureAction) String() string {
 		return strHealthCheckOnFailureActionRestart
 	case HealthCheckOnFailureActionStop:
 		return strHealthCheckOnFailureActionStop
+	case HealthCheckOnFailureActionExec:
+		return strHealthCheckOnFailureActionExec
+	case HealthCheckOnFailureActionRollback:
+		return strHealthCheckOnFailureActionRollback
 	default:
 		return strHealthCheckOnFailureActionInvalid
 	}
@@ -159,12 +189,38 @@ func ParseHealthCheckOnFailureAction(s string) (HealthCheckOnFailureAction, erro
 		return HealthCheckOnFailureActionRestart, nil
 	case strHealthCheckOnFailureActionStop:
 		return HealthCheckOnFailureActionStop, nil
+	case strHealthCheckOnFailureActionRollback:
+		return HealthCheckOnFailureActionRollback, nil
 	default:
 		err := fmt.Errorf("invalid on-failure action %q for health check: supported actions are %s", s, strings.Join(SupportedHealthCheckOnFailureActions, ","))
 		return HealthCheckOnFailureActionInvalid, err
 	}
 }
 
+// ParseHealthCheckOnFailure parses the specified string into a
+// HealthCheckOnFailureAction and, for the exec:PATH action, the path of the
+// hook to run.  An error is returned for an invalid input.
+func ParseHealthCheckOnFailure(s string) (HealthCheckOnFailureAction, string, error) {
+	hook, isExec := strings.CutPrefix(s, strHealthCheckOnFailureActionExec+":")
+	if !isExec {
+		action, err := ParseHealthCheckOnFailureAction(s)
+		return action, "", err
+	}
+	if !filepath.IsAbs(hook) {
+		return HealthCheckOnFailureActionInvalid, "", fmt.Errorf("invalid on-failure action %q for health check: the hook must be an absolute path", s)
+	}
+	return HealthCheckOnFailureActionExec, filepath.Clean(hook), nil
+}
+
+// FormatHealthCheckOnFailure returns the string representation of an
+// on-failure action and its hook, as parsed by ParseHealthCheckOnFailure.
+func FormatHealthCheckOnFailure(action HealthCheckOnFailureAction, hook string) string {
+	if action == HealthCheckOnFailureActionExec {
+		return strHealthCheckOnFailureActionExec + ":" + hook
+	}
+	return action.String()
+}
+
 // StartupHealthCheck is the configuration of a startup healthcheck.
 type StartupHealthCheck struct {
 	manifest.Schema2HealthConfig
@@ -174,6 +230,17 @@ type StartupHealthCheck struct {
 	Successes int `json:",omitempty"`
 }
 
+// ReadinessCheck is the configuration of a readiness check.  Unlike the
+// healthcheck, a failing readiness check never triggers an action on the
+// container, it only marks the container as not ready.
+type ReadinessCheck struct {
+	manifest.Schema2HealthConfig
+	// Successes are the number of consecutive successes required to mark
+	// the container as ready.
+	// If set to 0, a single success will mark the container as ready.
+	Successes int `json:",omitempty"`
+}
+
 type UpdateHealthCheckConfig struct {
 	// HealthLogDestination set the destination of the HealthCheck log.
 	// Directory path, local or events_logger (local use container state file)
@@ -331,11 +398,12 @@ func (u *UpdateHealthCheckConfig) GetNewGlobalHealthCheck() (GlobalHealthCheckOp
 	globalOptions.HealthMaxLogCount = u.HealthMaxLogCount
 
 	if u.HealthOnFailure != nil {
-		val, err := ParseHealthCheckOnFailureAction(*u.HealthOnFailure)
+		val, hook, err := ParseHealthCheckOnFailure(*u.HealthOnFailure)
 		if err != nil {
 			return globalOptions, err
 		}
 		globalOptions.HealthCheckOnFailureAction = &val
+		globalOptions.HealthCheckOnFailureHook = &hook
 	}
 
 	return globalOptions, nil
@@ -355,4 +423,5 @@ type GlobalHealthCheckOptions struct {
 	HealthMaxLogCount          *uint
 	HealthMaxLogSize           *uint
 	HealthCheckOnFailureAction *HealthCheckOnFailureAction
+	HealthCheckOnFailureHook   *string
 }
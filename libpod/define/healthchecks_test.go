@@ -0,0 +1,35 @@
+package define
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHealthCheckOnFailure(t *testing.T) {
+	for _, test := range []struct {
+		input  string
+		action HealthCheckOnFailureAction
+		hook   string
+		format string
+	}{
+		{"", HealthCheckOnFailureActionNone, "", "none"},
+		{"kill", HealthCheckOnFailureActionKill, "", "kill"},
+		{"rollback", HealthCheckOnFailureActionRollback, "", "rollback"},
+		{"exec:/usr/local/bin/report", HealthCheckOnFailureActionExec, "/usr/local/bin/report", "exec:/usr/local/bin/report"},
+		{"exec:/etc/hooks/../report", HealthCheckOnFailureActionExec, "/etc/report", "exec:/etc/report"},
+	} {
+		action, hook, err := ParseHealthCheckOnFailure(test.input)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.action, action, test.input)
+		assert.Equal(t, test.hook, hook, test.input)
+		assert.Equal(t, test.format, FormatHealthCheckOnFailure(action, hook), test.input)
+	}
+
+	for _, input := range []string{"exec", "exec:", "exec:report", "reboot"} {
+		action, _, err := ParseHealthCheckOnFailure(input)
+		assert.Error(t, err, input)
+		assert.Equal(t, HealthCheckOnFailureAction(HealthCheckOnFailureActionInvalid), action, input)
+	}
+}
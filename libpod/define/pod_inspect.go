@@ -23,6 +23,9 @@ type InspectPodData struct {
 	ExitPolicy string `json:"ExitPolicy,omitempty"`
 	// State represents the current state of the pod.
 	State string `json:"State"`
+	// Ready is whether all containers of the pod are running and have
+	// passed their readiness checks.
+	Ready bool `json:"Ready"`
 	// Hostname is the hostname that the pod will set.
 	Hostname string
 	// Labels is a set of key-value labels that have been applied to the
@@ -156,4 +159,7 @@ type InspectPodContainerInfo struct {
 	Name string
 	// State is the current status of the container.
 	State string
+	// Readiness is the readiness of the container.  It is only set if the
+	// container has a readiness check.
+	Readiness string `json:"Readiness,omitempty"`
 }
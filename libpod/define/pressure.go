@@ -0,0 +1,151 @@
+package define
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPressureInterval is the default interval of the checks of the
+// resource pressure of a container
+const DefaultPressureInterval = 10 * time.Second
+
+// Resources whose pressure can be monitored
+const (
+	PressureResourceCPU    = "cpu"
+	PressureResourceMemory = "memory"
+	PressureResourceIO     = "io"
+)
+
+// PressureMonitor is the configuration of the monitoring of the cgroup v2
+// memory events and resource pressure of a container.  The monitoring writes
+// memory-high and memory-max events when the container hits its memory limits
+// and pressure-threshold events when the pressure of a resource rises above
+// or falls back below its threshold.
+type PressureMonitor struct {
+	// Interval is the time between two checks
+	Interval time.Duration `json:"interval,omitempty"`
+	// CPU, Memory and IO are the thresholds of the share of time, in
+	// percent, in which some tasks of the container were stalled on the
+	// resource over the last 10 seconds.  0 disables the threshold.
+	CPU    float64 `json:"cpu,omitempty"`
+	Memory float64 `json:"memory,omitempty"`
+	IO     float64 `json:"io,omitempty"`
+}
+
+// Thresholds returns the thresholds of the monitored resources by resource
+func (p *PressureMonitor) Thresholds() map[string]float64 {
+	thresholds := make(map[string]float64)
+	for resource, threshold := range map[string]float64{
+		PressureResourceCPU:    p.CPU,
+		PressureResourceMemory: p.Memory,
+		PressureResourceIO:     p.IO,
+	} {
+		if threshold > 0 {
+			thresholds[resource] = threshold
+		}
+	}
+	return thresholds
+}
+
+// SetThreshold sets a threshold given as RESOURCE=PERCENT
+func (p *PressureMonitor) SetThreshold(threshold string) error {
+	resource, value, ok := strings.Cut(threshold, "=")
+	if !ok {
+		return fmt.Errorf("invalid pressure threshold %q, must be RESOURCE=PERCENT: %w", threshold, ErrInvalidArg)
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return fmt.Errorf("invalid pressure threshold %q, must be a percentage between 0 and 100: %w", threshold, ErrInvalidArg)
+	}
+	switch resource {
+	case PressureResourceCPU:
+		p.CPU = percent
+	case PressureResourceMemory:
+		p.Memory = percent
+	case PressureResourceIO:
+		p.IO = percent
+	default:
+		return fmt.Errorf("invalid pressure threshold %q, resource must be one of %s: %w", threshold,
+			strings.Join([]string{PressureResourceCPU, PressureResourceMemory, PressureResourceIO}, ", "), ErrInvalidArg)
+	}
+	return nil
+}
+
+// ParsePressureInterval parses the interval of the pressure checks.  An
+// interval of 0 or "disable" disables the monitoring and returns 0.
+func ParsePressureInterval(interval string) (time.Duration, error) {
+	if interval == "disable" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pressure interval %q: %w", interval, err)
+	}
+	if d < 0 || (d > 0 && d < time.Second) {
+		return 0, fmt.Errorf("pressure interval %q must be at least 1s: %w", interval, ErrInvalidArg)
+	}
+	return d, nil
+}
+
+// NewPressureMonitor creates the configuration of the pressure monitoring
+// from the interval and the thresholds given on the command line.  It
+// returns nil if the monitoring is not enabled.
+func NewPressureMonitor(interval string, thresholds []string) (*PressureMonitor, error) {
+	if interval == "" && len(thresholds) == 0 {
+		return nil, nil
+	}
+	return (&UpdatePressureMonitor{
+		PressureInterval:   &interval,
+		PressureThresholds: thresholds,
+	}).Apply(nil)
+}
+
+// UpdatePressureMonitor are the changes of the pressure monitoring of a
+// container made by podman update
+type UpdatePressureMonitor struct {
+	// PressureInterval replaces the interval of the checks, 0 or "disable"
+	// disables the monitoring.  If empty, the interval is not changed.
+	PressureInterval *string `json:"pressure_interval,omitempty"`
+	// PressureThresholds replace the thresholds of the given resources,
+	// as RESOURCE=PERCENT.  A threshold of 0 removes the threshold.
+	PressureThresholds []string `json:"pressure_thresholds,omitempty"`
+}
+
+// IsEmpty returns whether the monitoring is not changed
+func (u *UpdatePressureMonitor) IsEmpty() bool {
+	return u == nil || (u.PressureInterval == nil && len(u.PressureThresholds) == 0)
+}
+
+// Apply returns the configuration of the monitoring after applying the
+// changes to the current configuration, which may be nil.  It returns nil
+// if the monitoring is disabled.
+func (u *UpdatePressureMonitor) Apply(current *PressureMonitor) (*PressureMonitor, error) {
+	monitor := new(PressureMonitor)
+	if current != nil {
+		*monitor = *current
+	}
+	if monitor.Interval == 0 {
+		monitor.Interval = DefaultPressureInterval
+	}
+	if u.PressureInterval != nil && *u.PressureInterval != "" {
+		interval, err := ParsePressureInterval(*u.PressureInterval)
+		if err != nil {
+			return nil, err
+		}
+		if interval == 0 {
+			if len(u.PressureThresholds) > 0 {
+				return nil, fmt.Errorf("cannot set pressure thresholds when disabling the pressure monitoring: %w", ErrInvalidArg)
+			}
+			return nil, nil
+		}
+		monitor.Interval = interval
+	}
+	for _, threshold := range u.PressureThresholds {
+		if err := monitor.SetThreshold(threshold); err != nil {
+			return nil, err
+		}
+	}
+	return monitor, nil
+}
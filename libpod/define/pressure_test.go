@@ -0,0 +1,64 @@
+package define
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPressureMonitor(t *testing.T) {
+	monitor, err := NewPressureMonitor("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, monitor)
+
+	monitor, err = NewPressureMonitor("", []string{"cpu=50", "io=12.5%"})
+	require.NoError(t, err)
+	assert.Equal(t, &PressureMonitor{Interval: DefaultPressureInterval, CPU: 50, IO: 12.5}, monitor)
+	assert.Equal(t, map[string]float64{"cpu": 50, "io": 12.5}, monitor.Thresholds())
+
+	monitor, err = NewPressureMonitor("30s", nil)
+	require.NoError(t, err)
+	assert.Equal(t, &PressureMonitor{Interval: 30 * time.Second}, monitor)
+
+	monitor, err = NewPressureMonitor("disable", nil)
+	require.NoError(t, err)
+	assert.Nil(t, monitor)
+
+	for _, test := range []struct {
+		interval   string
+		thresholds []string
+	}{
+		{"500ms", nil},
+		{"-1s", nil},
+		{"soon", nil},
+		{"", []string{"cpu"}},
+		{"", []string{"cpu=101"}},
+		{"", []string{"gpu=10"}},
+		{"disable", []string{"cpu=10"}},
+	} {
+		_, err := NewPressureMonitor(test.interval, test.thresholds)
+		assert.Error(t, err, "%s %v", test.interval, test.thresholds)
+	}
+}
+
+func TestUpdatePressureMonitor(t *testing.T) {
+	assert.True(t, (*UpdatePressureMonitor)(nil).IsEmpty())
+	assert.True(t, (&UpdatePressureMonitor{}).IsEmpty())
+
+	current := &PressureMonitor{Interval: time.Minute, CPU: 50, Memory: 40}
+	interval := ""
+	update := &UpdatePressureMonitor{PressureInterval: &interval, PressureThresholds: []string{"cpu=0", "io=10"}}
+	assert.False(t, update.IsEmpty())
+	monitor, err := update.Apply(current)
+	require.NoError(t, err)
+	assert.Equal(t, &PressureMonitor{Interval: time.Minute, Memory: 40, IO: 10}, monitor)
+	// The current configuration is not modified
+	assert.Equal(t, 50.0, current.CPU)
+
+	interval = "disable"
+	monitor, err = (&UpdatePressureMonitor{PressureInterval: &interval}).Apply(current)
+	require.NoError(t, err)
+	assert.Nil(t, monitor)
+}
@@ -5,6 +5,7 @@ package libpod
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"time"
 
@@ -117,6 +118,27 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newContainerPressureEvent creates a new event for the memory events and
+// the resource pressure of a container
+func (c *Container) newContainerPressureEvent(status events.Status, attributes map[string]string) {
+	e := events.NewEvent(status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attrs := c.Labels()
+	maps.Copy(attrs, attributes)
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: attrs,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write container %s event: %q", status, err)
+	}
+}
+
 // newExecDiedEvent creates a new event for an exec session's death
 func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	e := events.NewEvent(events.ExecDied)
@@ -138,6 +160,31 @@ func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	}
 }
 
+// newRecordEvent creates a new event for the end of a recorded exec or attach
+// session
+func (c *Container) newRecordEvent(id, path, execSessionID string) {
+	e := events.NewEvent(events.Record)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attrs := c.Labels()
+	attrs["recording"] = id
+	attrs["recordingPath"] = path
+	if execSessionID != "" {
+		attrs["execID"] = execSessionID
+	}
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: attrs,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write record event: %q", err)
+	}
+}
+
 // newNetworkEvent creates a new event based on a network create/remove
 func (r *Runtime) NewNetworkEvent(status events.Status, netName, netID, netDriver string) {
 	e := events.NewEvent(status)
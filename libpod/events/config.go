@@ -48,6 +48,9 @@ type Event struct {
 	HealthFailingStreak int `json:"health_failing_streak,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:"error,omitempty"`
+	// Cursor is an opaque position of the event in the event log.  Reading
+	// with ReadOptions.AfterCursor set to it resumes right after the event.
+	Cursor string `json:"cursor,omitempty"`
 
 	Details
 }
@@ -107,6 +110,8 @@ type ReadOptions struct {
 	Stream bool
 	// Until reads "until" the given time
 	Until string
+	// AfterCursor reads the events following the event with the given cursor
+	AfterCursor string
 }
 
 // Type of event that occurred (container, volume, image, pod, etc)
@@ -174,6 +179,12 @@ const (
 	Kill Status = "kill"
 	// LoadFromArchive ...
 	LoadFromArchive Status = "loadfromarchive"
+	// MemoryHigh indicates that the memory usage of a container went above
+	// its memory reservation and the container was throttled.
+	MemoryHigh Status = "memory-high"
+	// MemoryMax indicates that the memory usage of a container reached its
+	// memory limit.
+	MemoryMax Status = "memory-max"
 	// Mount ...
 	Mount Status = "mount"
 	// NetworkConnect
@@ -182,6 +193,9 @@ const (
 	NetworkDisconnect Status = "disconnect"
 	// Pause ...
 	Pause Status = "pause"
+	// PressureThreshold indicates that the pressure of a resource of a
+	// container rose above or fell back below its threshold.
+	PressureThreshold Status = "pressure-threshold"
 	// Prune ...
 	Prune Status = "prune"
 	// Pull ...
@@ -190,6 +204,9 @@ const (
 	PullError Status = "pull-error"
 	// Push ...
 	Push Status = "push"
+	// Record indicates that a recorded exec or attach session of a
+	// container ended.
+	Record Status = "record"
 	// Refresh indicates that the system refreshed the state after a
 	// reboot.
 	Refresh Status = "refresh"
@@ -236,4 +253,8 @@ var (
 
 	// ErrEventNotFound indicates that the event was not found in the event log
 	ErrEventNotFound = errors.New("unable to find event")
+
+	// ErrInvalidCursor indicates that a cursor was not returned by the
+	// event logger
+	ErrInvalidCursor = errors.New("invalid event cursor")
 )
@@ -18,14 +18,11 @@ func (et EventerType) String() string {
 	return string(et)
 }
 
-// IsValidEventer checks if the given string is a valid eventer type.
+// IsValidEventer checks if the given string is a valid eventer type,
+// optionally followed by event sinks.
 func IsValidEventer(eventer string) bool {
-	switch EventerType(eventer) {
-	case LogFile, Journald, Null:
-		return true
-	default:
-		return false
-	}
+	_, _, err := parseEventerSpec(eventer)
+	return err == nil
 }
 
 // NewEvent creates an event struct and populates with
@@ -172,6 +169,10 @@ func StringToStatus(name string) (Status, error) {
 		return Kill, nil
 	case LoadFromArchive.String():
 		return LoadFromArchive, nil
+	case MemoryHigh.String():
+		return MemoryHigh, nil
+	case MemoryMax.String():
+		return MemoryMax, nil
 	case Mount.String():
 		return Mount, nil
 	case NetworkConnect.String():
@@ -180,6 +181,8 @@ func StringToStatus(name string) (Status, error) {
 		return NetworkDisconnect, nil
 	case Pause.String():
 		return Pause, nil
+	case PressureThreshold.String():
+		return PressureThreshold, nil
 	case Prune.String():
 		return Prune, nil
 	case Pull.String():
@@ -188,6 +191,8 @@ func StringToStatus(name string) (Status, error) {
 		return PullError, nil
 	case Push.String():
 		return Push, nil
+	case Record.String():
+		return Record, nil
 	case Refresh.String():
 		return Refresh, nil
 	case Remove.String():
@@ -5,24 +5,33 @@ package events
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/sirupsen/logrus"
 )
 
-// NewEventer creates an eventer based on the eventer type
+// NewEventer creates an eventer based on the eventer type.  The eventer type
+// may be followed by event sinks the events are also written to.
 func NewEventer(options EventerOptions) (Eventer, error) {
 	logrus.Debugf("Initializing event backend %s", options.EventerType)
-	switch EventerType(strings.ToLower(options.EventerType)) {
+	eventerType, sinks, err := parseEventerSpec(options.EventerType)
+	if err != nil {
+		return nil, err
+	}
+	var eventer Eventer
+	switch eventerType {
 	case Journald:
-		return newJournalDEventer(options)
+		eventer, err = newJournalDEventer(options)
 	case LogFile:
-		return newLogFileEventer(options)
+		eventer, err = newLogFileEventer(options)
 	case Null:
-		return newNullEventer(), nil
+		eventer = newNullEventer()
 	default:
-		return nil, fmt.Errorf("unknown event logger type: %s", strings.ToLower(options.EventerType))
+		return nil, fmt.Errorf("unknown event logger type: %s", eventerType)
+	}
+	if err != nil || len(sinks) == 0 {
+		return eventer, err
 	}
+	return newEventToSinks(eventer, sinks)
 }
 
 // newEventFromJSONString takes stringified json and converts
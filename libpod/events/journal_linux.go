@@ -164,7 +164,13 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 		return fmt.Errorf("failed to add _UID journal filter for event log: %w", err)
 	}
 
-	if len(options.Since) == 0 && len(options.Until) == 0 && options.Stream {
+	if options.AfterCursor != "" {
+		// The entry at the cursor is skipped below.  If it was vacuumed,
+		// the journal seeks to the closest entry after it.
+		if err := j.SeekCursor(options.AfterCursor); err != nil {
+			return fmt.Errorf("%w %q for the %s events backend: %v", ErrInvalidCursor, options.AfterCursor, Journald, err)
+		}
+	} else if len(options.Since) == 0 && len(options.Until) == 0 && options.Stream {
 		if err := j.SeekTail(); err != nil {
 			return fmt.Errorf("failed to seek end of journal: %w", err)
 		}
@@ -192,6 +198,7 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 				logrus.Errorf("Unable to close journal :%v", err)
 			}
 		}()
+		skipCursor := options.AfterCursor
 		for {
 			entry, err := GetNextEntry(ctx, j, options.Stream, untilTime)
 			if err != nil {
@@ -202,6 +209,13 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 			if entry == nil {
 				break
 			}
+			if skipCursor != "" {
+				cursor := skipCursor
+				skipCursor = ""
+				if entry.Cursor == cursor {
+					continue
+				}
+			}
 
 			newEvent, err := newEventFromJournalEntry(entry)
 			if err != nil {
@@ -213,6 +227,7 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 				}
 				continue
 			}
+			newEvent.Cursor = entry.Cursor
 			if applyFilters(newEvent, filterMap) {
 				options.EventChannel <- ReadResult{Event: newEvent}
 			}
@@ -11,12 +11,16 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nxadm/tail"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/podman/v6/pkg/util"
+	"go.podman.io/storage/pkg/ioutils"
 	"go.podman.io/storage/pkg/lockfile"
+	"go.podman.io/storage/pkg/stringid"
 	"golang.org/x/sys/unix"
 )
 
@@ -51,6 +55,12 @@ func (e EventLogFile) Write(ee Event) error {
 	lock.Lock()
 	defer lock.Unlock()
 
+	cursor, err := e.nextCursor()
+	if err != nil {
+		return err
+	}
+	ee.Cursor = cursor
+
 	eventJSONString, err := ee.ToJSONString()
 	if err != nil {
 		return err
@@ -63,6 +73,72 @@ func (e EventLogFile) Write(ee Event) error {
 	return e.writeString(eventJSONString)
 }
 
+// logFileCursor is the position of an event in the log file.  The ID of the
+// log is created along with the file storing the last cursor, so cursors of
+// a recreated log, for example after a reboot with the log on a tmpfs, do
+// not match the events of the previous one.
+type logFileCursor struct {
+	logID string
+	seq   uint64
+}
+
+func (c logFileCursor) String() string {
+	return c.logID + ":" + strconv.FormatUint(c.seq, 10)
+}
+
+// parseLogFileCursor parses a cursor of an event in the log file
+func parseLogFileCursor(cursor string) (logFileCursor, error) {
+	logID, seq, ok := strings.Cut(cursor, ":")
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if !ok || logID == "" || err != nil {
+		return logFileCursor{}, fmt.Errorf("%w %q for the %s events backend", ErrInvalidCursor, cursor, LogFile)
+	}
+	return logFileCursor{logID: logID, seq: n}, nil
+}
+
+// cursorFile returns the path of the file storing the cursor of the last
+// event written to the log file.  It is stored next to the log file so it
+// survives log-file rotations.
+func (e EventLogFile) cursorFile() string {
+	return e.options.LogFilePath + ".cursor"
+}
+
+// lastCursor returns the cursor of the last event written to the log file.
+// It returns false if no cursor has been recorded yet.
+func (e EventLogFile) lastCursor() (logFileCursor, bool, error) {
+	data, err := os.ReadFile(e.cursorFile())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return logFileCursor{}, false, nil
+		}
+		return logFileCursor{}, false, err
+	}
+	cursor, err := parseLogFileCursor(strings.TrimSpace(string(data)))
+	if err != nil {
+		// Cursors recorded before logs had an ID are started anew.
+		return logFileCursor{}, false, nil
+	}
+	return cursor, true, nil
+}
+
+// nextCursor returns the cursor of the next event written to the log file.
+// The cursors are increasing sequence numbers within the log.  The lock of
+// the log file must be held.
+func (e EventLogFile) nextCursor() (string, error) {
+	last, ok, err := e.lastCursor()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		last = logFileCursor{logID: stringid.GenerateRandomID()[:16]}
+	}
+	next := logFileCursor{logID: last.logID, seq: last.seq + 1}.String()
+	if err := ioutils.AtomicWriteFileWithOpts(e.cursorFile(), []byte(next), 0o600, &ioutils.AtomicFileWriterOptions{NoSync: true}); err != nil {
+		return "", fmt.Errorf("writing event cursor file %s: %w", e.cursorFile(), err)
+	}
+	return next, nil
+}
+
 func (e EventLogFile) writeString(s string) error {
 	f, err := os.OpenFile(e.options.LogFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o700)
 	if err != nil {
@@ -113,6 +189,22 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse event filters: %w", err)
 	}
+	var afterCursor logFileCursor
+	if options.AfterCursor != "" {
+		afterCursor, err = parseLogFileCursor(options.AfterCursor)
+		if err != nil {
+			return err
+		}
+		last, ok, err := e.lastCursor()
+		if err != nil {
+			return err
+		}
+		if !ok || last.logID != afterCursor.logID {
+			return fmt.Errorf("%w %q for the %s events backend: the event log was recreated", ErrInvalidCursor, options.AfterCursor, LogFile)
+		}
+		// The events following the cursor may be anywhere in the log file
+		options.FromStart = true
+	}
 	t, err := e.getTail(options)
 	if err != nil {
 		return err
@@ -153,6 +245,7 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 		var line *tail.Line
 		var ok bool
 		var skipRotate bool
+		resumed := options.AfterCursor == ""
 		for {
 			select {
 			case <-ctx.Done():
@@ -201,6 +294,15 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 			if skipRotate {
 				continue
 			}
+			if !resumed {
+				// Skip the events up to the cursor, including the
+				// events written before cursors were recorded
+				cursor, err := parseLogFileCursor(event.Cursor)
+				if err != nil || cursor.logID != afterCursor.logID || cursor.seq <= afterCursor.seq {
+					continue
+				}
+				resumed = true
+			}
 			if applyFilters(event, filterMap) {
 				options.EventChannel <- ReadResult{Event: event}
 			}
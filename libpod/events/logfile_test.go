@@ -3,10 +3,14 @@
 package events
 
 import (
+	"context"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -137,3 +141,76 @@ func TestRenameLog(t *testing.T) {
 	require.NoError(t, os.Remove(target.Name()))
 	require.Equal(t, beforeRename, afterRename)
 }
+
+func TestLogFileCursors(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "events.log")
+	// Rotate the log file every few events, the cursors keep increasing
+	eventer, err := newLogFileEventer(EventerOptions{LogFilePath: logFile, LogFileMaxSize: 1000})
+	require.NoError(t, err)
+
+	for i := range 10 {
+		e := NewEvent(Create)
+		e.Type = Volume
+		e.Name = strconv.Itoa(i)
+		require.NoError(t, eventer.Write(e))
+	}
+
+	readEvents := func(afterCursor string) []*Event {
+		eventChannel := make(chan ReadResult)
+		err := eventer.Read(context.Background(), ReadOptions{
+			EventChannel: eventChannel,
+			Filters:      []string{"type=volume"},
+			AfterCursor:  afterCursor,
+			FromStart:    true,
+		})
+		require.NoError(t, err)
+		var events []*Event
+		for result := range eventChannel {
+			require.NoError(t, result.Error)
+			events = append(events, result.Event)
+		}
+		return events
+	}
+
+	// The oldest events were dropped by the rotations
+	all := readEvents("")
+	require.NotEmpty(t, all)
+	require.Less(t, len(all), 10)
+	logID, _, _ := strings.Cut(all[0].Cursor, ":")
+	require.NotEmpty(t, logID)
+	for i, e := range all {
+		assert.Equal(t, logID+":"+strconv.Itoa(10-len(all)+i+1), e.Cursor)
+	}
+	last := all[len(all)-1]
+	assert.Equal(t, "9", last.Name)
+
+	resumed := readEvents(all[len(all)-3].Cursor)
+	require.Len(t, resumed, 2)
+	assert.Equal(t, "8", resumed[0].Name)
+	assert.Equal(t, "9", resumed[1].Name)
+	assert.Empty(t, readEvents(last.Cursor))
+
+	eventChannel := make(chan ReadResult)
+	err = eventer.Read(context.Background(), ReadOptions{EventChannel: eventChannel, AfterCursor: "s=abc"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	// A recreated log, e.g. after a reboot, starts counting again but
+	// does not accept the cursors of the previous log
+	require.NoError(t, os.Remove(logFile))
+	require.NoError(t, os.Remove(logFile+".cursor"))
+	eventer, err = newLogFileEventer(EventerOptions{LogFilePath: logFile, LogFileMaxSize: 1000})
+	require.NoError(t, err)
+	e := NewEvent(Create)
+	e.Type = Volume
+	e.Name = "recreated"
+	require.NoError(t, eventer.Write(e))
+	recreated := readEvents("")
+	require.Len(t, recreated, 1)
+	assert.NotEqual(t, logID+":1", recreated[0].Cursor)
+	assert.True(t, strings.HasSuffix(recreated[0].Cursor, ":1"))
+
+	err = eventer.Read(context.Background(), ReadOptions{EventChannel: make(chan ReadResult), AfterCursor: "0:0"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	err = eventer.Read(context.Background(), ReadOptions{EventChannel: make(chan ReadResult), AfterCursor: last.Cursor})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,184 @@
+package events
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Webhook posts batches of events as JSON to an HTTP endpoint
+	Webhook EventerType = "webhook"
+	// Syslog sends events as RFC 5424 messages to a syslog server
+	Syslog EventerType = "syslog"
+	// UnixSocket sends events as JSON datagrams to unix sockets
+	UnixSocket EventerType = "unix"
+)
+
+// Defaults of the options of the event sinks
+const (
+	defaultWebhookBatch   = 1
+	defaultWebhookFlush   = time.Second
+	defaultWebhookRetries = 3
+	defaultWebhookTimeout = 5 * time.Second
+	defaultSyslogFacility = 3 // daemon
+	defaultSyslogTag      = "podman"
+	defaultSyslogPort     = "514"
+)
+
+// syslogFacilities maps the names of the syslog facilities to their codes
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// sinkConfig is the configuration of an event sink.  Event sinks are set as
+// URLs following the event logger in the events_logger setting, their
+// options are set in the fragment of the URL.
+type sinkConfig struct {
+	// Type is the type of the sink
+	Type EventerType
+	// URL is the URL of the sink without the fragment
+	URL *url.URL
+	// Filters limit the events written to the sink, using the syntax of
+	// `podman events --filter`
+	Filters []string
+
+	// Options of the webhook sink: the maximum number of events posted at
+	// once, the maximum time events are held back to be batched, the number
+	// of times a failed post is retried, and the timeout of a post
+	Batch   int
+	Flush   time.Duration
+	Retries int
+	Timeout time.Duration
+
+	// Options of the syslog sink: the facility and the app name of the
+	// messages
+	Facility int
+	Tag      string
+}
+
+// parseEventerSpec parses the value of the events_logger setting, the event
+// logger optionally followed by a comma-separated list of event sink URLs,
+// e.g. "journald,https://example.com/events#batch=10&filter=type=container".
+func parseEventerSpec(spec string) (EventerType, []sinkConfig, error) {
+	parts := strings.Split(spec, ",")
+	eventerType := EventerType(strings.ToLower(strings.TrimSpace(parts[0])))
+	switch eventerType {
+	case LogFile, Journald, Null:
+	default:
+		return "", nil, fmt.Errorf("unknown event logger type: %s", eventerType)
+	}
+
+	sinks := make([]sinkConfig, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		sink, err := parseSinkURL(strings.TrimSpace(part))
+		if err != nil {
+			return "", nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return eventerType, sinks, nil
+}
+
+// parseSinkURL parses the URL of an event sink
+func parseSinkURL(rawURL string) (sinkConfig, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return sinkConfig{}, fmt.Errorf("invalid event sink %q: %w", rawURL, err)
+	}
+	options, err := url.ParseQuery(u.EscapedFragment())
+	if err != nil {
+		return sinkConfig{}, fmt.Errorf("invalid options of event sink %q: %w", rawURL, err)
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	sink := sinkConfig{URL: u}
+	switch u.Scheme {
+	case "http", "https":
+		sink.Type = Webhook
+		sink.Batch = defaultWebhookBatch
+		sink.Flush = defaultWebhookFlush
+		sink.Retries = defaultWebhookRetries
+		sink.Timeout = defaultWebhookTimeout
+	case "syslog", "syslog+udp", "syslog+tcp":
+		sink.Type = Syslog
+		if u.Hostname() == "" {
+			return sinkConfig{}, fmt.Errorf("event sink %q has no host", rawURL)
+		}
+		if u.Port() == "" {
+			u.Host += ":" + defaultSyslogPort
+		}
+		sink.Facility = defaultSyslogFacility
+		sink.Tag = defaultSyslogTag
+	case "syslog+unix", "unix":
+		sink.Type = Syslog
+		if u.Scheme == "unix" {
+			sink.Type = UnixSocket
+		}
+		if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+			return sinkConfig{}, fmt.Errorf("event sink %q must have an absolute path and no host", rawURL)
+		}
+		sink.Facility = defaultSyslogFacility
+		sink.Tag = defaultSyslogTag
+	default:
+		return sinkConfig{}, fmt.Errorf("unsupported event sink %q", rawURL)
+	}
+
+	for key, values := range options {
+		if key == "filter" {
+			for _, filter := range values {
+				if !strings.Contains(filter, "=") {
+					return sinkConfig{}, fmt.Errorf("event sink %q: %s is an invalid filter", rawURL, filter)
+				}
+			}
+			sink.Filters = append(sink.Filters, values...)
+			continue
+		}
+		value := values[len(values)-1]
+		if err := sink.setOption(key, value); err != nil {
+			return sinkConfig{}, fmt.Errorf("event sink %q: invalid option %s=%s: %w", rawURL, key, value, err)
+		}
+	}
+	return sink, nil
+}
+
+// setOption sets an option of the sink other than a filter
+func (s *sinkConfig) setOption(key, value string) error {
+	var err error
+	switch {
+	case s.Type == Webhook && key == "batch":
+		s.Batch, err = strconv.Atoi(value)
+		if err == nil && s.Batch < 1 {
+			err = fmt.Errorf("must be at least 1")
+		}
+	case s.Type == Webhook && key == "flush":
+		s.Flush, err = time.ParseDuration(value)
+	case s.Type == Webhook && key == "retries":
+		s.Retries, err = strconv.Atoi(value)
+		if err == nil && s.Retries < 0 {
+			err = fmt.Errorf("must not be negative")
+		}
+	case s.Type == Webhook && key == "timeout":
+		s.Timeout, err = time.ParseDuration(value)
+	case s.Type == Syslog && key == "facility":
+		facility, ok := syslogFacilities[value]
+		if !ok {
+			return fmt.Errorf("unknown facility")
+		}
+		s.Facility = facility
+	case s.Type == Syslog && key == "tag":
+		if value == "" || strings.ContainsAny(value, " \t\n") {
+			return fmt.Errorf("must be a non-empty word")
+		}
+		s.Tag = value
+	default:
+		return fmt.Errorf("unsupported option for %s sink", s.Type)
+	}
+	return err
+}
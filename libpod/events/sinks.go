@@ -0,0 +1,477 @@
+//go:build linux || freebsd
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookQueueSize is the number of events queued for a webhook,
+	// events are dropped when the queue is full
+	webhookQueueSize = 1024
+	// webhookCloseTimeout is the time pending events are given to be posted
+	// when a webhook sink is closed, the remaining events are dropped
+	webhookCloseTimeout = 2 * time.Second
+)
+
+// sinkWriter writes events to an event sink
+type sinkWriter interface {
+	// Write an event to the sink
+	Write(event *Event) error
+	// Close flushes the pending events and releases the resources of the sink
+	Close() error
+}
+
+// eventSink is an event sink along with the filters of the events written
+// to it
+type eventSink struct {
+	name    string
+	filters map[string][]EventFilter
+	writer  sinkWriter
+}
+
+// EventToSinks writes events to an event logger and to event sinks.  Events
+// are only read from the event logger.
+type EventToSinks struct {
+	Eventer
+	sinks []*eventSink
+}
+
+// newEventToSinks creates an eventer writing to the given event logger and
+// to the sinks
+func newEventToSinks(eventer Eventer, configs []sinkConfig) (*EventToSinks, error) {
+	e := &EventToSinks{Eventer: eventer}
+	for _, config := range configs {
+		filters, err := generateEventFilters(config.Filters, "", "")
+		if err != nil {
+			e.Close()
+			return nil, fmt.Errorf("event sink %s: %w", config.URL.Redacted(), err)
+		}
+		var writer sinkWriter
+		switch config.Type {
+		case Webhook:
+			writer = newWebhookSink(config)
+		case Syslog:
+			writer = newSyslogSink(config)
+		case UnixSocket:
+			writer = &unixSocketSink{path: config.URL.Path}
+		default:
+			e.Close()
+			return nil, fmt.Errorf("unsupported event sink type %s", config.Type)
+		}
+		e.sinks = append(e.sinks, &eventSink{
+			name:    config.URL.Redacted(),
+			filters: filters,
+			writer:  writer,
+		})
+	}
+	return e, nil
+}
+
+// Write an event to the event logger and to the sinks whose filters match
+// it.  Failures to write to a sink are logged, they do not fail the write.
+func (e *EventToSinks) Write(event Event) error {
+	err := e.Eventer.Write(event)
+	for _, sink := range e.sinks {
+		if !applyFilters(&event, sink.filters) {
+			continue
+		}
+		if sinkErr := sink.writer.Write(&event); sinkErr != nil {
+			logrus.Warnf("Unable to write event to sink %s: %v", sink.name, sinkErr)
+		}
+	}
+	return err
+}
+
+// Close flushes the pending events of the sinks and closes them
+func (e *EventToSinks) Close() error {
+	var errs []error
+	for _, sink := range e.sinks {
+		if err := sink.writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing event sink %s: %w", sink.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// webhookSink posts batches of events as a JSON array to an HTTP endpoint.
+// Events are posted by a goroutine, so slow or unavailable endpoints do not
+// block the writers.
+type webhookSink struct {
+	url     string
+	batch   int
+	flush   time.Duration
+	retries int
+	client  *http.Client
+
+	// lock protects closed and sending to events
+	lock   sync.RWMutex
+	closed bool
+	events chan *Event
+	done   chan struct{}
+	// failing is set while the endpoint cannot be posted to
+	failing atomic.Bool
+	// ctx is canceled to abort posting once the sink is closed and the
+	// close timeout expired
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newWebhookSink(config sinkConfig) *webhookSink {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &webhookSink{
+		url:     config.URL.String(),
+		batch:   config.Batch,
+		flush:   config.Flush,
+		retries: config.Retries,
+		client:  &http.Client{Timeout: config.Timeout},
+		events:  make(chan *Event, webhookQueueSize),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	go w.run()
+	return w
+}
+
+// Write queues an event to be posted
+func (w *webhookSink) Write(event *Event) error {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if w.closed {
+		return errors.New("sink is closed")
+	}
+	select {
+	case w.events <- event:
+		return nil
+	default:
+		return errors.New("too many pending events, dropping event")
+	}
+}
+
+// Close posts the queued events and stops posting.  Failed posts are not
+// retried anymore, and events which could not be posted within
+// webhookCloseTimeout are dropped so that Close does not hold up the shutdown
+// of Podman.  If the last post failed, the queued events are dropped right
+// away.
+func (w *webhookSink) Close() error {
+	w.lock.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.events)
+	}
+	w.lock.Unlock()
+
+	if w.failing.Load() {
+		w.cancel()
+	}
+	timer := time.NewTimer(webhookCloseTimeout)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+	case <-timer.C:
+		// Aborting the posts makes run drop the remaining events
+		// right away.
+		w.cancel()
+		<-w.done
+	}
+	w.cancel()
+	return nil
+}
+
+// isClosed returns whether the sink is closed
+func (w *webhookSink) isClosed() bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.closed
+}
+
+// run collects the queued events into batches and posts them
+func (w *webhookSink) run() {
+	defer close(w.done)
+	var pending []*Event
+	timer := time.NewTimer(w.flush)
+	timer.Stop()
+	for {
+		select {
+		case event, ok := <-w.events:
+			if !ok {
+				w.post(pending)
+				return
+			}
+			pending = append(pending, event)
+			if len(pending) >= w.batch {
+				timer.Stop()
+				w.post(pending)
+				pending = nil
+			} else if len(pending) == 1 {
+				timer.Reset(w.flush)
+			}
+		case <-timer.C:
+			w.post(pending)
+			pending = nil
+		}
+	}
+}
+
+// post posts events, retrying with an exponential backoff on failures.  The
+// events are dropped once the sink is aborted.
+func (w *webhookSink) post(events []*Event) {
+	if len(events) == 0 {
+		return
+	}
+	if w.ctx.Err() != nil {
+		logrus.Warnf("Dropping %d events for webhook %s: sink closed before they could be posted", len(events), w.url)
+		return
+	}
+	body, err := json.Marshal(events)
+	if err != nil {
+		logrus.Errorf("Unable to encode events for webhook %s: %v", w.url, err)
+		return
+	}
+	backoff := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		retry, err := w.postOnce(body)
+		w.failing.Store(err != nil)
+		if err == nil {
+			return
+		}
+		if w.ctx.Err() != nil {
+			logrus.Warnf("Dropping %d events for webhook %s: sink closed before they could be posted: %v", len(events), w.url, err)
+			return
+		}
+		if !retry || attempt >= w.retries || w.isClosed() {
+			logrus.Errorf("Unable to post %d events to webhook %s: %v", len(events), w.url, err)
+			return
+		}
+		logrus.Debugf("Posting events to webhook %s failed, retrying in %s: %v", w.url, backoff, err)
+		select {
+		case <-w.ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postOnce posts the body and returns whether a failure may be retried
+func (w *webhookSink) postOnce(body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	// Client errors are not going to go away
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// syslogSink sends events as RFC 5424 messages to a syslog server over UDP,
+// TCP with octet-counting framing, or a unix datagram socket
+type syslogSink struct {
+	network  string
+	address  string
+	facility int
+	tag      string
+	hostname string
+
+	lock sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogSink(config sinkConfig) *syslogSink {
+	s := &syslogSink{
+		network:  "udp",
+		address:  config.URL.Host,
+		facility: config.Facility,
+		tag:      config.Tag,
+		hostname: "-",
+	}
+	switch config.URL.Scheme {
+	case "syslog+tcp":
+		s.network = "tcp"
+	case "syslog+unix":
+		s.network = "unixgram"
+		s.address = config.URL.Path
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		s.hostname = hostname
+	}
+	return s
+}
+
+// Write sends an event, reconnecting once if the connection was lost
+func (s *syslogSink) Write(event *Event) error {
+	msg := formatSyslogMessage(event, s.facility, s.hostname, s.tag, os.Getpid())
+	if s.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var err error
+	for range 2 {
+		if s.conn == nil {
+			s.conn, err = net.DialTimeout(s.network, s.address, defaultWebhookTimeout)
+			if err != nil {
+				return err
+			}
+		}
+		if err = s.conn.SetWriteDeadline(time.Now().Add(defaultWebhookTimeout)); err == nil {
+			_, err = io.WriteString(s.conn, msg)
+		}
+		if err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// Close closes the connection to the syslog server
+func (s *syslogSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// formatSyslogMessage formats an event as an RFC 5424 message
+func formatSyslogMessage(event *Event, facility int, hostname, tag string, pid int) string {
+	severity := 6 // informational
+	if event.Error != "" || (event.ContainerExitCode != nil && *event.ContainerExitCode != 0) || event.HealthStatus == "unhealthy" {
+		severity = 4 // warning
+	}
+	msgID := "-"
+	if event.Type != "" {
+		msgID = string(event.Type)
+	}
+
+	// 32473 is the private enterprise number reserved for documentation
+	var sd strings.Builder
+	sd.WriteString("[podman@32473")
+	for _, param := range []struct{ name, value string }{
+		{"type", string(event.Type)},
+		{"status", string(event.Status)},
+		{"id", event.ID},
+		{"name", event.Name},
+		{"image", event.Image},
+		{"network", event.Network},
+		{"pod_id", event.PodID},
+		{"health_status", event.HealthStatus},
+		{"error", event.Error},
+	} {
+		if param.value != "" {
+			fmt.Fprintf(&sd, " %s=\"%s\"", param.name, escapeSDParam(param.value))
+		}
+	}
+	if event.ContainerExitCode != nil {
+		fmt.Fprintf(&sd, " exit_code=\"%d\"", *event.ContainerExitCode)
+	}
+	sd.WriteString("]")
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		facility*8+severity,
+		event.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		hostname, tag, pid, msgID, sd.String(), event.ToHumanReadable(false))
+}
+
+// escapeSDParam escapes the characters which must be escaped in the value
+// of a structured data parameter
+func escapeSDParam(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// unixSocketSink sends each event as a JSON datagram to a unix socket or, if
+// its path is a directory, to every unix socket in the directory.  Listeners
+// subscribe to the events by binding a datagram socket in the directory.
+type unixSocketSink struct {
+	path string
+}
+
+// Write sends an event to the sockets.  Sockets nobody listens on anymore
+// are skipped.
+func (u *unixSocketSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	sockets := []string{u.path}
+	if info, err := os.Stat(u.path); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(u.path)
+		if err != nil {
+			return err
+		}
+		sockets = sockets[:0]
+		for _, entry := range entries {
+			if entry.Type()&os.ModeSocket != 0 {
+				sockets = append(sockets, filepath.Join(u.path, entry.Name()))
+			}
+		}
+	}
+
+	var errs []error
+	for _, socket := range sockets {
+		if err := sendDatagram(socket, data); err != nil {
+			if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, os.ErrNotExist) {
+				logrus.Debugf("Skipping event socket %s without listener: %v", socket, err)
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close does nothing, the sockets are only opened to send an event
+func (u *unixSocketSink) Close() error {
+	return nil
+}
+
+// sendDatagram sends data to a unix datagram socket
+func sendDatagram(socket string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unixgram", socket)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetWriteDeadline(time.Now().Add(defaultWebhookTimeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write(data)
+	return err
+}
@@ -0,0 +1,296 @@
+//go:build linux || freebsd
+
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventerSpec(t *testing.T) {
+	eventerType, sinks, err := parseEventerSpec("journald")
+	require.NoError(t, err)
+	assert.Equal(t, Journald, eventerType)
+	assert.Empty(t, sinks)
+
+	eventerType, sinks, err = parseEventerSpec("file, https://example.com/events#batch=10&flush=2s&filter=type=container&filter=event=die, syslog+tcp://logs.example.com#facility=local0&tag=ctr, unix:///run/podman-events")
+	require.NoError(t, err)
+	assert.Equal(t, LogFile, eventerType)
+	require.Len(t, sinks, 3)
+
+	assert.Equal(t, Webhook, sinks[0].Type)
+	assert.Equal(t, "https://example.com/events", sinks[0].URL.String())
+	assert.Equal(t, 10, sinks[0].Batch)
+	assert.Equal(t, 2*time.Second, sinks[0].Flush)
+	assert.Equal(t, defaultWebhookRetries, sinks[0].Retries)
+	assert.Equal(t, []string{"type=container", "event=die"}, sinks[0].Filters)
+
+	assert.Equal(t, Syslog, sinks[1].Type)
+	assert.Equal(t, "logs.example.com:514", sinks[1].URL.Host)
+	assert.Equal(t, 16, sinks[1].Facility)
+	assert.Equal(t, "ctr", sinks[1].Tag)
+
+	assert.Equal(t, UnixSocket, sinks[2].Type)
+	assert.Equal(t, "/run/podman-events", sinks[2].URL.Path)
+
+	for _, spec := range []string{
+		"",
+		"syslog",
+		"file,ftp://example.com",
+		"file,https://example.com#batch=0",
+		"file,https://example.com#facility=daemon",
+		"file,https://example.com#filter=container",
+		"file,syslog://",
+		"file,syslog://example.com#facility=nope",
+		"file,unix://host/path",
+	} {
+		_, _, err := parseEventerSpec(spec)
+		assert.Error(t, err, spec)
+		assert.False(t, IsValidEventer(spec), spec)
+	}
+	assert.True(t, IsValidEventer("none,unix:///run/events"))
+}
+
+func TestEventToSinksFilters(t *testing.T) {
+	dir := t.TempDir()
+	all := listenDatagram(t, filepath.Join(dir, "all"))
+	died := listenDatagram(t, filepath.Join(dir, "died"))
+
+	_, sinks, err := parseEventerSpec("none,unix://" + all.LocalAddr().String() + ",unix://" + died.LocalAddr().String() + "#filter=event=died")
+	require.NoError(t, err)
+	eventer, err := newEventToSinks(newNullEventer(), sinks)
+	require.NoError(t, err)
+	defer eventer.Close()
+	assert.Equal(t, Null.String(), eventer.String())
+
+	for _, status := range []Status{Start, Exited} {
+		e := NewEvent(status)
+		e.Type = Container
+		e.ID = "abc"
+		require.NoError(t, eventer.Write(e))
+	}
+
+	assert.Equal(t, Start, readDatagramEvent(t, all).Status)
+	assert.Equal(t, Exited, readDatagramEvent(t, all).Status)
+	assert.Equal(t, Exited, readDatagramEvent(t, died).Status)
+}
+
+func TestUnixSocketSinkDirectory(t *testing.T) {
+	dir := t.TempDir()
+	first := listenDatagram(t, filepath.Join(dir, "first.sock"))
+	second := listenDatagram(t, filepath.Join(dir, "second.sock"))
+	// Sockets nobody listens on anymore are skipped
+	stale := listenDatagram(t, filepath.Join(dir, "stale.sock"))
+	stale.Close()
+
+	sink := &unixSocketSink{path: dir}
+	e := NewEvent(Pull)
+	e.Type = Image
+	e.Name = "quay.io/example"
+	require.NoError(t, sink.Write(&e))
+
+	assert.Equal(t, "quay.io/example", readDatagramEvent(t, first).Name)
+	assert.Equal(t, "quay.io/example", readDatagramEvent(t, second).Name)
+}
+
+func TestWebhookSinkBatching(t *testing.T) {
+	var lock sync.Mutex
+	var batches [][]Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var batch []Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		lock.Lock()
+		batches = append(batches, batch)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	sink, err := parseSinkURL(server.URL + "#batch=2&flush=1h")
+	require.NoError(t, err)
+	w := newWebhookSink(sink)
+	for _, name := range []string{"a", "b", "c"} {
+		e := NewEvent(Create)
+		e.Type = Volume
+		e.Name = name
+		require.NoError(t, w.Write(&e))
+	}
+	// Closing posts the events which are held back
+	require.NoError(t, w.Close())
+	assert.Error(t, w.Write(&Event{}))
+
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], 2)
+	assert.Equal(t, "a", batches[0][0].Name)
+	assert.Equal(t, "b", batches[0][1].Name)
+	require.Len(t, batches[1], 1)
+	assert.Equal(t, "c", batches[1][0].Name)
+}
+
+func TestWebhookSinkRetries(t *testing.T) {
+	var lock sync.Mutex
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusBadRequest}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.WriteHeader(statuses[min(requests, len(statuses)-1)])
+		requests++
+	}))
+	defer server.Close()
+
+	sink, err := parseSinkURL(server.URL + "#retries=3")
+	require.NoError(t, err)
+	w := newWebhookSink(sink)
+
+	// The server error is retried
+	w.post([]*Event{{Name: "retried"}})
+	assert.Equal(t, 2, requests)
+	// Client errors are not retried
+	w.post([]*Event{{Name: "rejected"}})
+	assert.Equal(t, 3, requests)
+	require.NoError(t, w.Close())
+}
+
+func TestWebhookSinkCloseTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := parseSinkURL(server.URL + "#retries=100")
+	require.NoError(t, err)
+	w := newWebhookSink(sink)
+	e := NewEvent(Create)
+	require.NoError(t, w.Write(&e))
+
+	// The retries would take a long time, the event is dropped instead.
+	start := time.Now()
+	require.NoError(t, w.Close())
+	assert.Less(t, time.Since(start), webhookCloseTimeout+time.Second)
+}
+
+func TestWebhookSinkCloseNoRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := parseSinkURL(server.URL + "#retries=100")
+	require.NoError(t, err)
+	w := newWebhookSink(sink)
+	e := NewEvent(Create)
+	require.NoError(t, w.Write(&e))
+
+	// Failed posts are not retried once the sink is closed, so an
+	// unavailable endpoint does not hold up the shutdown.
+	start := time.Now()
+	require.NoError(t, w.Close())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestWebhookSinkCloseFailing(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := parseSinkURL(server.URL + "#retries=0")
+	require.NoError(t, err)
+	w := newWebhookSink(sink)
+	w.post([]*Event{{Name: "failed"}})
+	require.Equal(t, int32(1), requests.Load())
+
+	// The endpoint is failing, the queued event is dropped without
+	// posting it again.
+	e := NewEvent(Create)
+	require.NoError(t, w.Write(&e))
+	require.NoError(t, w.Close())
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestFormatSyslogMessage(t *testing.T) {
+	exitCode := 1
+	e := Event{
+		Type:              Container,
+		Status:            Exited,
+		ID:                "abc",
+		Name:              `we"ird]`,
+		Image:             "quay.io/example",
+		ContainerExitCode: &exitCode,
+		Time:              time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC),
+	}
+	msg := formatSyslogMessage(&e, 16, "host", "podman", 42)
+	assert.Equal(t, `<132>1 2024-05-01T12:30:00.123456Z host podman 42 container [podman@32473 type="container" status="died" id="abc" name="we\"ird\]" image="quay.io/example" exit_code="1"] `+e.ToHumanReadable(false), msg)
+
+	e = Event{Type: Image, Status: Pull, Name: "quay.io/example", Time: e.Time}
+	msg = formatSyslogMessage(&e, 3, "host", "podman", 42)
+	assert.True(t, strings.HasPrefix(msg, `<30>1 2024-05-01T12:30:00.123456Z host podman 42 image [podman@32473 type="image" status="pull" name="quay.io/example"] `), msg)
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- string(data)
+	}()
+
+	sink, err := parseSinkURL("syslog+tcp://" + listener.Addr().String())
+	require.NoError(t, err)
+	s := newSyslogSink(sink)
+	e := NewEvent(Remove)
+	e.Type = Network
+	e.Network = "net"
+	require.NoError(t, s.Write(&e))
+	require.NoError(t, s.Close())
+
+	data := <-received
+	length, msg, ok := strings.Cut(data, " ")
+	require.True(t, ok)
+	assert.Equal(t, length, strconv.Itoa(len(msg)))
+	assert.True(t, strings.HasPrefix(msg, "<30>1 "), msg)
+	assert.Contains(t, msg, ` network="net"`)
+}
+
+func listenDatagram(t *testing.T, path string) *net.UnixConn {
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readDatagramEvent(t *testing.T, conn *net.UnixConn) *Event {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 65536)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	e := new(Event)
+	require.NoError(t, json.Unmarshal(buf[:n], e))
+	return e
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -44,7 +45,7 @@ func (r *Runtime) HealthCheck(ctx context.Context, name string) (define.HealthCh
 
 	hcStatus, logStatus, err := container.runHealthCheck(ctx, isStartupHC)
 	if !isStartupHC {
-		if err := container.processHealthCheckStatus(logStatus); err != nil {
+		if err := container.processHealthCheckStatus(ctx, logStatus); err != nil {
 			return hcStatus, err
 		}
 	}
@@ -81,23 +82,15 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 		logrus.Debugf("Running startup healthcheck for container %s", c.ID())
 		hcCommand = c.config.StartupHealthCheckConfig.Test
 	}
-	if len(hcCommand) < 1 {
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
-	}
-	switch hcCommand[0] {
-	case "", define.HealthConfigTestNone:
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
-	case define.HealthConfigTestCmd:
-		newCommand = hcCommand[1:]
-	case define.HealthConfigTestCmdShell:
-		// TODO: SHELL command from image not available in Container - use Docker default
-		newCommand = []string{"/bin/sh", "-c", strings.Join(hcCommand[1:], " ")}
-	default:
-		// command supplied on command line - pass as-is
-		newCommand = hcCommand
+	probe, err := define.ParseHealthCheckProbe(hcCommand)
+	if err != nil {
+		return define.HealthCheckInternalError, "", err
 	}
-	if len(newCommand) < 1 || newCommand[0] == "" {
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
+	if probe == nil {
+		newCommand = healthCheckCommand(hcCommand)
+		if newCommand == nil {
+			return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
+		}
 	}
 
 	streams := new(define.AttachStreams)
@@ -110,11 +103,19 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	streams.AttachError = true
 	streams.AttachInput = true
 
-	logrus.Debugf("executing health check command %s for %s", strings.Join(newCommand, " "), c.ID())
 	hcResult := define.HealthCheckSuccess
-	config := new(ExecConfig)
-	config.Command = newCommand
-	exitCode, hcErr := c.healthCheckExec(config, c.HealthCheckConfig().Timeout, streams)
+	var (
+		exitCode int
+		hcErr    error
+	)
+	if probe != nil {
+		exitCode, hcErr = c.runHealthCheckProbe(probe, c.HealthCheckConfig().Timeout, output)
+	} else {
+		logrus.Debugf("executing health check command %s for %s", strings.Join(newCommand, " "), c.ID())
+		config := new(ExecConfig)
+		config.Command = newCommand
+		exitCode, hcErr = c.healthCheckExec(config, c.HealthCheckConfig().Timeout, streams)
+	}
 	timeEnd := time.Now()
 	if hcErr != nil {
 		hcResult = define.HealthCheckFailure
@@ -185,7 +186,36 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	return hcResult, healthCheckResult.Status, hcErr
 }
 
-func (c *Container) processHealthCheckStatus(status string) error {
+// healthCheckCommand returns the command to execute in the container for the
+// specified healthcheck test.  It returns nil if the test does not define a
+// command.
+func healthCheckCommand(test []string) []string {
+	if len(test) < 1 {
+		return nil
+	}
+	var command []string
+	switch test[0] {
+	case "", define.HealthConfigTestNone:
+		return nil
+	case define.HealthConfigTestHTTPGet, define.HealthConfigTestTCPSocket, define.HealthConfigTestGRPC:
+		// probes are run by Podman, not in the container
+		return nil
+	case define.HealthConfigTestCmd:
+		command = test[1:]
+	case define.HealthConfigTestCmdShell:
+		// TODO: SHELL command from image not available in Container - use Docker default
+		command = []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+	default:
+		// command supplied on command line - pass as-is
+		command = test
+	}
+	if len(command) < 1 || command[0] == "" {
+		return nil
+	}
+	return command
+}
+
+func (c *Container) processHealthCheckStatus(ctx context.Context, status string) error {
 	if status != define.HealthCheckUnhealthy {
 		return nil
 	}
@@ -212,6 +242,16 @@ func (c *Container) processHealthCheckStatus(status string) error {
 			return fmt.Errorf("stopping container after health-check turned unhealthy: %w", err)
 		}
 
+	case define.HealthCheckOnFailureActionExec:
+		if err := c.runHealthCheckOnFailureHook(); err != nil {
+			return fmt.Errorf("running on-failure hook after health-check turned unhealthy: %w", err)
+		}
+
+	case define.HealthCheckOnFailureActionRollback:
+		if err := c.runHealthCheckRollbackHooks(ctx); err != nil {
+			return fmt.Errorf("rolling back container after health-check turned unhealthy: %w", err)
+		}
+
 	default: // Should not happen but better be safe than sorry
 		return fmt.Errorf("unsupported on-failure action %d", c.config.HealthCheckOnFailureAction)
 	}
@@ -219,6 +259,80 @@ func (c *Container) processHealthCheckStatus(status string) error {
 	return nil
 }
 
+// runHealthCheckOnFailureHook runs the hook of the exec on-failure action on
+// the host.  The hook is passed the ID and name of the container as arguments
+// and the last entry of the healthcheck log as JSON on stdin.  It only runs
+// once the container turns unhealthy, not on every failing healthcheck after.
+func (c *Container) runHealthCheckOnFailureHook() error {
+	results, turned, err := c.turnedUnhealthy()
+	if err != nil {
+		return err
+	}
+	if !turned || len(results.Log) == 0 {
+		return nil
+	}
+	input, err := json.Marshal(results.Log[len(results.Log)-1])
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if timeout := c.HealthCheckConfig().Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	hook := c.config.HealthCheckOnFailureHook
+	logrus.Debugf("Running health check on-failure hook %s for container %s", hook, c.ID())
+	cmd := exec.CommandContext(ctx, hook, c.ID(), c.Name())
+	cmd.Stdin = bytes.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("hook %s timed out: %w", hook, ctx.Err())
+		}
+		return fmt.Errorf("hook %s: %w: %s", hook, err, strings.TrimSpace(string(output)))
+	}
+	logrus.Debugf("Health check on-failure hook %s for container %s: %s", hook, c.ID(), output)
+	return nil
+}
+
+// runHealthCheckRollbackHooks runs the rollback hooks of the runtime, which
+// roll back the last auto-update of the container.  As the hook of the exec
+// on-failure action, they only run once the container turns unhealthy.
+func (c *Container) runHealthCheckRollbackHooks(ctx context.Context) error {
+	_, turned, err := c.turnedUnhealthy()
+	if err != nil {
+		return err
+	}
+	if !turned {
+		return nil
+	}
+	if len(c.runtime.healthCheckRollbackHooks) == 0 {
+		return errors.New("rollbacks are not supported by this runtime")
+	}
+	var errs []error
+	for _, hook := range c.runtime.healthCheckRollbackHooks {
+		if err := hook(ctx, c.runtime, c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// turnedUnhealthy returns the healthcheck log of the container and whether
+// its last healthcheck turned it unhealthy, as opposed to failing again
+// after it already was.
+func (c *Container) turnedUnhealthy() (define.HealthCheckResults, bool, error) {
+	c.lock.Lock()
+	results, err := c.readHealthCheckLog()
+	c.lock.Unlock()
+	if err != nil {
+		return results, false, err
+	}
+	return results, results.FailingStreak == max(c.HealthCheckConfig().Retries, 1), nil
+}
+
 func checkHealthCheckCanBeRun(c *Container) (define.HealthCheckStatus, error) {
 	cstate, err := c.State()
 	if err != nil {
@@ -479,3 +593,58 @@ func (c *Container) healthCheckStatus() (string, error) {
 
 	return results.Status, nil
 }
+
+// WaitForHealthy waits for the healthcheck of the container to pass.  It
+// returns immediately for containers without a healthcheck.  An error is
+// returned if the container turns unhealthy or stops before it turned healthy.
+func (c *Container) WaitForHealthy(ctx context.Context) error {
+	if !c.HasHealthCheck() {
+		return nil
+	}
+	for {
+		state, err := c.State()
+		if err != nil {
+			return err
+		}
+		if state != define.ContainerStateRunning {
+			return fmt.Errorf("container %s is %s and will not become healthy: %w", c.ID(), state, define.ErrCtrStateInvalid)
+		}
+
+		c.lock.Lock()
+		status, err := c.healthCheckStatus()
+		unitName := c.state.HCUnitName
+		isStartup := c.config.StartupHealthCheckConfig != nil && !c.state.StartupHCPassed
+		c.lock.Unlock()
+		if err != nil {
+			return err
+		}
+		switch status {
+		case define.HealthCheckHealthy:
+			return nil
+		case define.HealthCheckUnhealthy:
+			return fmt.Errorf("container %s is unhealthy", c.ID())
+		}
+
+		interval := probePollInterval
+		if unitName == "" {
+			// There is no timer running the healthcheck, so run
+			// it ourselves.
+			if _, err := c.runtime.HealthCheck(ctx, c.ID()); err != nil {
+				logrus.Debugf("Healthcheck of container %s: %v", c.ID(), err)
+			}
+			interval = c.HealthCheckConfig().Interval
+			if isStartup {
+				interval = c.config.StartupHealthCheckConfig.Interval
+			}
+			if interval <= 0 {
+				interval = probePollInterval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for container %s to become healthy: %w", c.ID(), ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
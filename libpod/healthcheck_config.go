@@ -72,7 +72,7 @@ func (h *StartupHealthCheckConfig) GetInterval() time.Duration {
 }
 
 func (h *HealthCheckConfig) SetCurrentConfigTo(healthCheckOptions *define.HealthCheckOptions) {
-	healthCheckOptions.Cmd = strings.Join(h.Test, " ")
+	healthCheckOptions.Cmd = healthCheckTestToCmd(h.Test)
 	healthCheckOptions.Interval = h.Interval.String()
 	healthCheckOptions.Retries = h.Retries
 	healthCheckOptions.Timeout = h.Timeout.String()
@@ -80,13 +80,25 @@ func (h *HealthCheckConfig) SetCurrentConfigTo(healthCheckOptions *define.Health
 }
 
 func (h *StartupHealthCheckConfig) SetCurrentConfigTo(healthCheckOptions *define.HealthCheckOptions) {
-	healthCheckOptions.Cmd = strings.Join(h.Test, " ")
+	healthCheckOptions.Cmd = healthCheckTestToCmd(h.Test)
 	healthCheckOptions.Interval = h.Interval.String()
 	healthCheckOptions.Retries = h.Retries
 	healthCheckOptions.Timeout = h.Timeout.String()
 	healthCheckOptions.Successes = h.Successes
 }
 
+// healthCheckTestToCmd returns the test of a healthcheck as the command
+// parsed by MakeHealthCheckFromCli.  Probes are passed as JSON array, so
+// that their HTTP headers are kept intact.
+func healthCheckTestToCmd(test []string) string {
+	if define.IsHealthCheckProbe(test) {
+		if data, err := json.Marshal(test); err == nil {
+			return string(data)
+		}
+	}
+	return strings.Join(test, " ")
+}
+
 func (h *HealthCheckConfig) IsHealthCheckCommandSet(updateHealthCheckConfig define.UpdateHealthCheckConfig) bool {
 	return updateHealthCheckConfig.IsHealthCheckCommandSet(h.Schema2HealthConfig)
 }
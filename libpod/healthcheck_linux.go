@@ -9,9 +9,11 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
+	"path"
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/cgroups"
 	systemdCommon "go.podman.io/common/pkg/systemd"
 	"go.podman.io/podman/v6/pkg/errorhandling"
 	"go.podman.io/podman/v6/pkg/rootless"
@@ -27,6 +29,21 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 
 	hcUnitName := c.hcUnitName(isStartup, false)
 
+	if err := c.createTransientTimer(hcUnitName, interval, "healthcheck", "run", "--ignore-result", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.HCUnitName = hcUnitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s healthcheck unit name: %w", c.ID(), err)
+	}
+
+	return nil
+}
+
+// createTransientTimer creates a transient systemd timer and service running
+// podman with the specified arguments every interval.
+func (c *Container) createTransientTimer(unitName, interval string, args ...string) error {
 	podman, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("failed to get path for podman for a health check timer: %w", err)
@@ -42,11 +59,11 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 	}
 
 	// StartLimitIntervalSec=0 so we don't hit the restart limit
-	cmd = append(cmd, "--unit", hcUnitName, fmt.Sprintf("--on-unit-inactive=%s", interval), "--timer-property=AccuracySec=1s", "--property=StartLimitIntervalSec=0", podman)
+	cmd = append(cmd, "--unit", unitName, fmt.Sprintf("--on-unit-inactive=%s", interval), "--timer-property=AccuracySec=1s", "--property=StartLimitIntervalSec=0", podman)
 
 	cmd = append(cmd, specgenutil.GlobalPodmanArgs(c.runtime.storageConfig, c.runtime.config, logrus.IsLevelEnabled(logrus.DebugLevel))...)
 
-	cmd = append(cmd, "healthcheck", "run", "--ignore-result", c.ID())
+	cmd = append(cmd, args...)
 
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
@@ -62,12 +79,6 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 		}
 		return fmt.Errorf("failed to execute systemd-run: %w", err)
 	}
-
-	c.state.HCUnitName = hcUnitName
-	if err := c.save(); err != nil {
-		return fmt.Errorf("saving container %s healthcheck unit name: %w", c.ID(), err)
-	}
-
 	return nil
 }
 
@@ -93,13 +104,18 @@ func (c *Container) startTimer(isStartup bool) error {
 		hcUnitName = c.hcUnitName(isStartup, true)
 	}
 
+	return startTransientUnit(hcUnitName)
+}
+
+// startTransientUnit starts the service of a transient systemd timer.
+func startTransientUnit(unitName string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return fmt.Errorf("unable to get systemd connection to start healthchecks: %w", err)
 	}
 	defer conn.Close()
 
-	startFile := fmt.Sprintf("%s.service", hcUnitName)
+	startFile := fmt.Sprintf("%s.service", unitName)
 	startChan := make(chan string)
 	if _, err := conn.RestartUnitContext(context.Background(), startFile, "fail", startChan); err != nil {
 		return err
@@ -117,6 +133,14 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	if c.disableHealthCheckSystemd(isStartup) {
 		return nil
 	}
+	if unitName == "" {
+		unitName = c.hcUnitName(isStartup, true)
+	}
+	return removeTransientUnit(ctx, unitName)
+}
+
+// removeTransientUnit stops a transient systemd timer and its service.
+func removeTransientUnit(ctx context.Context, unitName string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return fmt.Errorf("unable to get systemd connection to remove healthchecks: %w", err)
@@ -127,9 +151,6 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	// clean up as much as possible.
 	stopErrors := []error{}
 
-	if unitName == "" {
-		unitName = c.hcUnitName(isStartup, true)
-	}
 	// Stop the timer before the service to make sure the timer does not
 	// fire after the service is stopped.
 	timerChan := make(chan string)
@@ -142,8 +163,15 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd health-check timer %q: %w", timerFile, err))
 	}
 
-	serviceChan := make(chan string)
 	serviceFile := fmt.Sprintf("%s.service", unitName)
+	if runsInUnit(serviceFile) {
+		// The service runs this process, for instance a healthcheck
+		// recreating its container as on-failure action.  Stopping it
+		// would kill the process, it stops on its own once it exits.
+		logrus.Debugf("Not stopping systemd service %q running this process", serviceFile)
+		return errorhandling.JoinErrors(stopErrors)
+	}
+	serviceChan := make(chan string)
 	if _, err := conn.StopUnitContext(ctx, serviceFile, "ignore-dependencies", serviceChan); err != nil {
 		if !strings.HasSuffix(err.Error(), ".service not loaded.") {
 			stopErrors = append(stopErrors, fmt.Errorf("removing health-check service %q: %w", serviceFile, err))
@@ -161,6 +189,17 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	return errorhandling.JoinErrors(stopErrors)
 }
 
+// runsInUnit returns whether the current process runs in the given systemd
+// unit.
+func runsInUnit(unit string) bool {
+	cgroup, err := cgroups.GetOwnCgroup()
+	if err != nil {
+		logrus.Debugf("Failed to get own cgroup: %v", err)
+		return false
+	}
+	return path.Base(cgroup) == unit
+}
+
 func (c *Container) disableHealthCheckSystemd(isStartup bool) bool {
 	if !systemdCommon.RunsOnSystemd() || os.Getenv("DISABLE_HC_SYSTEMD") == "true" {
 		return true
@@ -176,6 +215,116 @@ func (c *Container) disableHealthCheckSystemd(isStartup bool) bool {
 	return false
 }
 
+// createReadinessTimer creates the systemd timer for the readiness check of a
+// container.
+func (c *Container) createReadinessTimer() error {
+	if c.disableReadinessCheckSystemd() {
+		return nil
+	}
+
+	unitName := fmt.Sprintf("%s-readiness-%x", c.ID(), rand.Int())
+	interval := c.config.ReadinessCheckConfig.Interval.String()
+	if err := c.createTransientTimer(unitName, interval, "healthcheck", "run", "--readiness", "--ignore-result", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.ReadinessUnitName = unitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s readiness check unit name: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// startReadinessTimer starts the systemd timer for the readiness check of a
+// container.
+func (c *Container) startReadinessTimer() error {
+	if c.state.ReadinessUnitName == "" {
+		return nil
+	}
+	return startTransientUnit(c.state.ReadinessUnitName)
+}
+
+// removeReadinessTimer removes the systemd timer for the readiness check of a
+// container.
+func (c *Container) removeReadinessTimer(ctx context.Context) error {
+	if c.state.ReadinessUnitName == "" {
+		return nil
+	}
+	if err := removeTransientUnit(ctx, c.state.ReadinessUnitName); err != nil {
+		return err
+	}
+	c.state.ReadinessUnitName = ""
+	return nil
+}
+
+func (c *Container) disableReadinessCheckSystemd() bool {
+	if !systemdCommon.RunsOnSystemd() || os.Getenv("DISABLE_HC_SYSTEMD") == "true" {
+		return true
+	}
+	return c.config.ReadinessCheckConfig.Interval == 0
+}
+
+// createPressureTimer creates the systemd timer for the pressure monitoring
+// of a container.
+func (c *Container) createPressureTimer() error {
+	if c.disablePressureCheckSystemd() {
+		return nil
+	}
+
+	unitName := fmt.Sprintf("%s-pressure-%x", c.ID(), rand.Int())
+	interval := c.config.PressureMonitorConfig.Interval.String()
+	if err := c.createTransientTimer(unitName, interval, "container", "check-pressure", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.PressureUnitName = unitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s pressure monitoring unit name: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// startPressureTimer starts the systemd timer for the pressure monitoring of
+// a container.
+func (c *Container) startPressureTimer() error {
+	if c.state.PressureUnitName == "" {
+		return nil
+	}
+	return startTransientUnit(c.state.PressureUnitName)
+}
+
+// removePressureTimer removes the systemd timer for the pressure monitoring
+// of a container.
+func (c *Container) removePressureTimer(ctx context.Context) error {
+	if c.state.PressureUnitName == "" {
+		return nil
+	}
+	if err := removeTransientUnit(ctx, c.state.PressureUnitName); err != nil {
+		return err
+	}
+	c.state.PressureUnitName = ""
+	return nil
+}
+
+func (c *Container) disablePressureCheckSystemd() bool {
+	if pressureTimerUnavailable() != nil {
+		return true
+	}
+	return c.config.PressureMonitorConfig == nil || c.config.PressureMonitorConfig.Interval == 0
+}
+
+// pressureTimerUnavailable returns why no systemd timer can run the checks of
+// the pressure monitoring, or nil if it can.
+func pressureTimerUnavailable() error {
+	if !systemdCommon.RunsOnSystemd() {
+		return errors.New("the host does not run systemd")
+	}
+	if os.Getenv("DISABLE_HC_SYSTEMD") == "true" {
+		return errors.New("systemd timers are disabled by DISABLE_HC_SYSTEMD")
+	}
+	return nil
+}
+
 // Systemd unit name for the healthcheck systemd unit.
 // Bare indicates that a random suffix should not be applied to the name. This
 // was default behavior previously, and is used for backwards compatibility.
@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"context"
+	"errors"
 )
 
 // createTimer systemd timers for healthchecks of a container
@@ -21,3 +22,45 @@ func (c *Container) startTimer(_ bool) error {
 func (c *Container) removeTransientFiles(_ context.Context, _ bool, _ string) error {
 	return nil
 }
+
+// createReadinessTimer creates the systemd timer for the readiness check of a
+// container
+func (c *Container) createReadinessTimer() error {
+	return nil
+}
+
+// startReadinessTimer starts the systemd timer for the readiness check of a
+// container
+func (c *Container) startReadinessTimer() error {
+	return nil
+}
+
+// removeReadinessTimer removes the systemd timer for the readiness check of a
+// container
+func (c *Container) removeReadinessTimer(_ context.Context) error {
+	return nil
+}
+
+// createPressureTimer creates the systemd timer for the pressure monitoring
+// of a container
+func (c *Container) createPressureTimer() error {
+	return nil
+}
+
+// pressureTimerUnavailable returns why no systemd timer can run the checks of
+// the pressure monitoring
+func pressureTimerUnavailable() error {
+	return errors.New("podman was built without systemd support")
+}
+
+// startPressureTimer starts the systemd timer for the pressure monitoring of
+// a container
+func (c *Container) startPressureTimer() error {
+	return nil
+}
+
+// removePressureTimer removes the systemd timer for the pressure monitoring
+// of a container
+func (c *Container) removePressureTimer(_ context.Context) error {
+	return nil
+}
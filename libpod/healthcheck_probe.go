@@ -0,0 +1,238 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/libpod/define"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxHealthCheckProbeBody is the maximum length of the body of the response
+// to a HTTP probe recorded in the healthcheck log, as in Kubernetes
+const maxHealthCheckProbeBody = 10 * 1024
+
+// healthCheckProbeDialFunc dials a connection in the network namespace of a
+// container
+type healthCheckProbeDialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// runHealthCheckProbe runs a healthcheck probe from Podman in the network
+// namespace of the container and writes its result to output.  It returns
+// the exit code a healthcheck command would, 0 if the probe passed and 1 if
+// it failed.
+func (c *Container) runHealthCheckProbe(probe *define.HealthCheckProbe, timeout time.Duration, output io.Writer) (int, error) {
+	dial, err := c.healthCheckProbeDialer()
+	if err != nil {
+		return -1, err
+	}
+	logrus.Debugf("running health check probe %s for %s", probe, c.ID())
+	return runHealthCheckProbe(probe, dial, timeout, output)
+}
+
+func runHealthCheckProbe(probe *define.HealthCheckProbe, dial healthCheckProbeDialFunc, timeout time.Duration, output io.Writer) (int, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var err error
+	switch probe.Type {
+	case define.HealthConfigTestHTTPGet:
+		err = httpGetHealthCheckProbe(ctx, probe, dial, output)
+	case define.HealthConfigTestTCPSocket:
+		err = tcpSocketHealthCheckProbe(ctx, probe, dial, output)
+	case define.HealthConfigTestGRPC:
+		err = grpcHealthCheckProbe(ctx, probe, dial, output)
+	default:
+		return -1, fmt.Errorf("unknown health check probe %q", probe.Type)
+	}
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return -1, fmt.Errorf("%w of %s", define.ErrHealthCheckTimeout, timeout.String())
+		}
+		fmt.Fprintf(output, "%s failed: %v\n", probe, err)
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// httpGetHealthCheckProbe passes if the status of the response is 2xx or 3xx.
+// Like Kubernetes, the certificate of HTTPS servers is not verified.
+func httpGetHealthCheckProbe(ctx context.Context, probe *define.HealthCheckProbe, dial healthCheckProbeDialFunc, output io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "podman-healthcheck")
+	for _, header := range probe.Headers {
+		name, value, _ := strings.Cut(header, ":")
+		value = strings.TrimSpace(value)
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Add(name, value)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:       dial,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // Probes check the health, not the identity of the server
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHealthCheckProbeBody))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "%s: %s\n%s\n", probe, resp.Status, body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("HTTP status %s", resp.Status)
+	}
+	return nil
+}
+
+// tcpSocketHealthCheckProbe passes if a connection can be opened
+func tcpSocketHealthCheckProbe(ctx context.Context, probe *define.HealthCheckProbe, dial healthCheckProbeDialFunc, output io.Writer) error {
+	conn, err := dial(ctx, "tcp", probe.Address)
+	if err != nil {
+		return err
+	}
+	if err := conn.Close(); err != nil {
+		logrus.Debugf("Closing health check probe connection to %s: %v", probe.Address, err)
+	}
+	fmt.Fprintf(output, "%s: connected\n", probe)
+	return nil
+}
+
+// grpcHealthCheckProbe passes if the server reports the service as serving
+// as defined by the gRPC health checking protocol,
+// https://github.com/grpc/grpc/blob/master/doc/health-checking.md
+func grpcHealthCheckProbe(ctx context.Context, probe *define.HealthCheckProbe, dial healthCheckProbeDialFunc, output io.Writer) error {
+	conn, err := grpc.NewClient("passthrough:///"+probe.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return dial(ctx, "tcp", address)
+		}),
+		grpc.WithUserAgent("podman-healthcheck"),
+	)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	req := &grpcHealthCheckRequest{Service: probe.Service}
+	resp := new(grpcHealthCheckResponse)
+	if err := conn.Invoke(ctx, "/grpc.health.v1.Health/Check", req, resp, grpc.ForceCodec(grpcHealthCheckCodec{})); err != nil {
+		return err
+	}
+	if resp.Status != grpcHealthServing {
+		return fmt.Errorf("service status %s", resp.Status)
+	}
+	fmt.Fprintf(output, "%s: %s\n", probe, resp.Status)
+	return nil
+}
+
+// grpcHealthCheckRequest is the grpc.health.v1.HealthCheckRequest message
+type grpcHealthCheckRequest struct {
+	Service string
+}
+
+// grpcHealthCheckResponse is the grpc.health.v1.HealthCheckResponse message
+type grpcHealthCheckResponse struct {
+	Status grpcHealthServingStatus
+}
+
+// grpcHealthServingStatus is the grpc.health.v1.HealthCheckResponse.ServingStatus enum
+type grpcHealthServingStatus uint64
+
+const (
+	grpcHealthUnknown grpcHealthServingStatus = iota
+	grpcHealthServing
+	grpcHealthNotServing
+	grpcHealthServiceUnknown
+)
+
+func (s grpcHealthServingStatus) String() string {
+	switch s {
+	case grpcHealthUnknown:
+		return "UNKNOWN"
+	case grpcHealthServing:
+		return "SERVING"
+	case grpcHealthNotServing:
+		return "NOT_SERVING"
+	case grpcHealthServiceUnknown:
+		return "SERVICE_UNKNOWN"
+	}
+	return fmt.Sprintf("%d", uint64(s))
+}
+
+// grpcHealthCheckCodec encodes the messages of the gRPC health checking
+// protocol in the protobuf wire format, so that the probe does not need the
+// generated code of the protocol
+type grpcHealthCheckCodec struct{}
+
+func (grpcHealthCheckCodec) Name() string {
+	return "proto"
+}
+
+func (grpcHealthCheckCodec) Marshal(v any) ([]byte, error) {
+	req, ok := v.(*grpcHealthCheckRequest)
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal %T as health check request", v)
+	}
+	var data []byte
+	if req.Service != "" {
+		data = protowire.AppendTag(data, 1, protowire.BytesType)
+		data = protowire.AppendString(data, req.Service)
+	}
+	return data, nil
+}
+
+func (grpcHealthCheckCodec) Unmarshal(data []byte, v any) error {
+	resp, ok := v.(*grpcHealthCheckResponse)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal health check response into %T", v)
+	}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if num == 1 && typ == protowire.VarintType {
+			status, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			resp.Status = grpcHealthServingStatus(status)
+			data = data[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"net"
+)
+
+// healthCheckProbeDialer returns a function dialing connections in the
+// network of the container.  Only containers using the network of the host
+// are supported, attaching to the jail of a container would move the whole
+// Podman process into it.
+func (c *Container) healthCheckProbeDialer() (healthCheckProbeDialFunc, error) {
+	if c.state.NetNS != "" {
+		return nil, errors.New("health check probes are not supported for containers with their own network on FreeBSD")
+	}
+	return (&net.Dialer{}).DialContext, nil
+}
@@ -0,0 +1,51 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"go.podman.io/common/pkg/netns"
+)
+
+// healthCheckProbeDialer returns a function dialing connections in the
+// network namespace of the container.  The addresses are resolved before
+// entering the namespace, so the resolver cannot run on a thread outside of
+// it, and dialed one after the other on the thread locked in the namespace.
+func (c *Container) healthCheckProbeDialer() (healthCheckProbeDialFunc, error) {
+	netNSPath, _, err := getContainerNetNS(c)
+	if err != nil {
+		return nil, err
+	}
+	if netNSPath == "" {
+		// The container uses the network namespace of the host
+		return (&net.Dialer{}).DialContext, nil
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var conn net.Conn
+		err = netns.WithNetNSPath(netNSPath, func(_ netns.NetNS) error {
+			dialer := net.Dialer{FallbackDelay: -1}
+			var errs error
+			for _, addr := range addrs {
+				var err error
+				conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+				if err == nil {
+					return nil
+				}
+				errs = errors.Join(errs, err)
+			}
+			return errs
+		})
+		return conn, err
+	}, nil
+}
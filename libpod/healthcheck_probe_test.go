@@ -0,0 +1,161 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/podman/v6/libpod/define"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var testProbeDial = (&net.Dialer{}).DialContext
+
+func TestHTTPGetHealthCheckProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/slow":
+			time.Sleep(time.Second)
+		case r.Header.Get("X-Probe") != "liveness" || r.Host != "example.com":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/fail":
+			http.Error(w, "broken", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	newProbe := func(path string) *define.HealthCheckProbe {
+		probe, err := define.ParseHealthCheckProbe([]string{define.HealthConfigTestHTTPGet, ":" + port + path, "X-Probe: liveness", "Host: example.com"})
+		require.NoError(t, err)
+		return probe
+	}
+
+	output := new(bytes.Buffer)
+	exitCode, err := runHealthCheckProbe(newProbe("/healthz"), testProbeDial, time.Minute, output)
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, output.String(), "200 OK\nok")
+
+	output.Reset()
+	exitCode, err = runHealthCheckProbe(newProbe("/fail"), testProbeDial, time.Minute, output)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Contains(t, output.String(), "broken")
+	assert.Contains(t, output.String(), "failed: HTTP status 500 Internal Server Error")
+
+	output.Reset()
+	exitCode, err = runHealthCheckProbe(newProbe("/slow"), testProbeDial, 100*time.Millisecond, output)
+	assert.ErrorIs(t, err, define.ErrHealthCheckTimeout)
+	assert.Equal(t, -1, exitCode)
+}
+
+func TestTCPSocketHealthCheckProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	probe, err := define.NewTCPSocketHealthCheckProbe(listener.Addr().String())
+	require.NoError(t, err)
+
+	output := new(bytes.Buffer)
+	exitCode, err := runHealthCheckProbe(probe, testProbeDial, time.Minute, output)
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "TCP connection to "+listener.Addr().String()+": connected\n", output.String())
+
+	require.NoError(t, listener.Close())
+	output.Reset()
+	exitCode, err = runHealthCheckProbe(probe, testProbeDial, time.Minute, output)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Contains(t, output.String(), "connection refused")
+}
+
+// testGRPCHealthServerCodec is the server side of grpcHealthCheckCodec
+type testGRPCHealthServerCodec struct{}
+
+func (testGRPCHealthServerCodec) Name() string {
+	return "proto"
+}
+
+func (testGRPCHealthServerCodec) Marshal(v any) ([]byte, error) {
+	resp := v.(*grpcHealthCheckResponse)
+	data := protowire.AppendTag(nil, 1, protowire.VarintType)
+	return protowire.AppendVarint(data, uint64(resp.Status)), nil
+}
+
+func (testGRPCHealthServerCodec) Unmarshal(data []byte, v any) error {
+	req := v.(*grpcHealthCheckRequest)
+	if len(data) == 0 {
+		return nil
+	}
+	_, _, n := protowire.ConsumeTag(data)
+	service, m := protowire.ConsumeString(data[n:])
+	if m < 0 {
+		return protowire.ParseError(m)
+	}
+	req.Service = service
+	return nil
+}
+
+func TestGRPCHealthCheckProbe(t *testing.T) {
+	server := grpc.NewServer(grpc.ForceServerCodec(testGRPCHealthServerCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "grpc.health.v1.Health",
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Check",
+			Handler: func(_ any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := new(grpcHealthCheckRequest)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				switch req.Service {
+				case "":
+					return &grpcHealthCheckResponse{Status: grpcHealthServing}, nil
+				case "db":
+					return &grpcHealthCheckResponse{Status: grpcHealthNotServing}, nil
+				}
+				return &grpcHealthCheckResponse{Status: grpcHealthServiceUnknown}, nil
+			},
+		}},
+	}, nil)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	for _, test := range []struct {
+		service  string
+		exitCode int
+		output   string
+	}{
+		{service: "", exitCode: 0, output: "SERVING"},
+		{service: "db", exitCode: 1, output: "service status NOT_SERVING"},
+		{service: "cache", exitCode: 1, output: "service status SERVICE_UNKNOWN"},
+	} {
+		target := listener.Addr().String()
+		if test.service != "" {
+			target += "/" + test.service
+		}
+		probe, err := define.NewGRPCHealthCheckProbe(target)
+		require.NoError(t, err)
+		output := new(bytes.Buffer)
+		exitCode, err := runHealthCheckProbe(probe, testProbeDial, time.Minute, output)
+		require.NoError(t, err)
+		assert.Equal(t, test.exitCode, exitCode, test.service)
+		assert.Contains(t, output.String(), test.output, test.service)
+	}
+}
@@ -0,0 +1,260 @@
+//go:build linux || freebsd
+
+package logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.podman.io/podman/v6/libpod/recording"
+	"go.podman.io/podman/v6/pkg/detached"
+)
+
+// execOutputKeeperKey is the reexec key of the process keeping the output of
+// an exec session.
+const execOutputKeeperKey = "podman-exec-output-keeper"
+
+func init() {
+	detached.Register(execOutputKeeperKey, runExecOutputKeeper)
+}
+
+// ExecOutputConfig is the configuration of the process keeping the output of
+// an exec session.
+type ExecOutputConfig struct {
+	// Path is the path of the file the output is kept in.  The older half
+	// of the output is kept in the same file with a ".1" suffix.
+	Path string `json:"path,omitempty"`
+	// Size is the number of bytes of the output to keep.  If 0, no output
+	// is kept.
+	Size int64 `json:"size,omitempty"`
+	// Recording is the path of the recording of the exec session, which
+	// must exist.  The output is added to it, so it is recorded whether a
+	// client is attached to the session or not.  If empty, the output is
+	// not recorded.
+	Recording string `json:"recording,omitempty"`
+}
+
+// StartExecOutputKeeper spawns a detached process keeping the last
+// config.Size bytes of the output of an exec session and recording it.
+// Conmon is pointed at the FIFO at fifoPath and writes the output in the
+// k8s-file format, the keeper reads it and writes it to config.Path.
+// Whenever the file holds config.Size bytes of output, it is moved aside and
+// a new file is started, so the two files always hold at least the last
+// config.Size bytes.  It exits once conmon closes the FIFO.
+//
+// The keeper is passed to started once it is started, so the caller can move
+// it into the cgroup of conmon.
+//
+// The returned file is a write end of the FIFO.  It keeps the keeper from
+// reading the end of the FIFO before conmon opened it, and must be closed
+// once conmon is started or failed to start.
+func StartExecOutputKeeper(fifoPath string, config *ExecOutputConfig, started func(pid int)) (*os.File, error) {
+	if err := createFIFO(fifoPath); err != nil {
+		return nil, err
+	}
+	writer, err := spawnFIFOReader(execOutputKeeperKey, fifoPath, config, started)
+	if err != nil {
+		return nil, fmt.Errorf("starting exec output keeper: %w", err)
+	}
+	return writer, nil
+}
+
+// runExecOutputKeeper is the entry point of the keeper process.  It expects
+// the configuration on stdin and the read end of the FIFO as fd 4.
+func runExecOutputKeeper(ready func() error) error {
+	fifo := os.NewFile(4, "fifo")
+	if fifo == nil {
+		return errors.New("internal error: expected the output FIFO as file descriptor 4")
+	}
+	var config ExecOutputConfig
+	if err := json.NewDecoder(os.Stdin).Decode(&config); err != nil {
+		return fmt.Errorf("decoding exec output configuration: %w", err)
+	}
+	var writer *ExecOutputWriter
+	if config.Size > 0 {
+		w, err := NewExecOutputWriter(config)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		writer = w
+	}
+	var recorder *recording.Recorder
+	if config.Recording != "" {
+		r, err := recording.Create(config.Recording, recording.Header{})
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		recorder = r
+	}
+
+	if err := ready(); err != nil {
+		return err
+	}
+
+	// Errors cannot be reported anymore, so keep reading until conmon
+	// closes the FIFO.
+	reader := bufio.NewReader(fifo)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// A line without a trailing newline was cut off.
+			return nil
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if writer != nil {
+			_ = writer.Write(line)
+		}
+		if recorder != nil {
+			if logLine, err := NewLogLine(line); err == nil {
+				recorder.Record(recording.Output, []byte(execOutputData(logLine)))
+			}
+		}
+	}
+}
+
+// ExecOutputWriter writes the output of an exec session, keeping at least
+// its last bytes in two files.
+type ExecOutputWriter struct {
+	config ExecOutputConfig
+	file   *os.File
+	// written is the number of bytes of output in file.
+	written int64
+}
+
+// NewExecOutputWriter creates the file at config.Path and returns a writer
+// for it.
+func NewExecOutputWriter(config ExecOutputConfig) (*ExecOutputWriter, error) {
+	if config.Size <= 0 {
+		return nil, fmt.Errorf("invalid exec output size %d", config.Size)
+	}
+	w := &ExecOutputWriter{config: config}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *ExecOutputWriter) open() error {
+	f, err := os.OpenFile(w.config.Path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.written = 0
+	return nil
+}
+
+// Write writes a log line in the k8s-file format, without the trailing
+// newline.
+func (w *ExecOutputWriter) Write(line string) error {
+	logLine, err := NewLogLine(line)
+	if err != nil {
+		return err
+	}
+	if _, err := w.file.WriteString(line + "\n"); err != nil {
+		return err
+	}
+	w.written += int64(len(execOutputData(logLine)))
+	if w.written < w.config.Size {
+		return nil
+	}
+
+	// The file holds enough output on its own, the previous one is not
+	// needed anymore.
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(w.config.Path, w.config.Path+".1"); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// Close closes the file.
+func (w *ExecOutputWriter) Close() error {
+	return w.file.Close()
+}
+
+// execOutputData returns the output a log line holds.
+func execOutputData(logLine *LogLine) string {
+	if logLine.Partial() {
+		return logLine.Msg
+	}
+	return logLine.Msg + "\n"
+}
+
+// ReadExecOutput reads the last size bytes of the output kept at path and
+// passes them to write, one line at a time, along with the device ("stdout"
+// or "stderr") they were written to.  It is not an error if no output was
+// kept.
+func ReadExecOutput(path string, size int64, write func(device string, data []byte) error) error {
+	var lines []*LogLine
+	var total int64
+	for _, p := range []string{path + ".1", path} {
+		read, err := readExecOutputFile(p)
+		if err != nil {
+			return err
+		}
+		for _, logLine := range read {
+			total += int64(len(execOutputData(logLine)))
+		}
+		lines = append(lines, read...)
+	}
+
+	skip := total - size
+	for _, logLine := range lines {
+		data := execOutputData(logLine)
+		if skip >= int64(len(data)) {
+			skip -= int64(len(data))
+			continue
+		}
+		if skip > 0 {
+			data = data[skip:]
+			skip = 0
+		}
+		if err := write(logLine.Device, []byte(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readExecOutputFile reads the complete log lines of a file written by an
+// ExecOutputWriter.
+func readExecOutputFile(path string) ([]*LogLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []*LogLine
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// A line without a trailing newline is still being
+			// written, it is received over the attach socket
+			// instead.
+			if errors.Is(err, io.EOF) {
+				return lines, nil
+			}
+			return nil, err
+		}
+		logLine, err := NewLogLine(strings.TrimSuffix(line, "\n"))
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, logLine)
+	}
+}
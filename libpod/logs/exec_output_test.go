@@ -0,0 +1,140 @@
+//go:build linux || freebsd
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type execOutputChunk struct {
+	device string
+	data   string
+}
+
+func readExecOutputChunks(t *testing.T, path string, size int64) []execOutputChunk {
+	var got []execOutputChunk
+	err := ReadExecOutput(path, size, func(device string, data []byte) error {
+		got = append(got, execOutputChunk{device: device, data: string(data)})
+		return nil
+	})
+	require.NoError(t, err)
+	return got
+}
+
+func TestReadExecOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		size int64
+		want []execOutputChunk
+	}{
+		{
+			name: "empty log",
+			log:  "",
+			size: 1024,
+			want: nil,
+		},
+		{
+			name: "stdout and stderr",
+			size: 1024,
+			log: "2024-01-01T00:00:00.000000000+00:00 stdout F hello world\n" +
+				"2024-01-01T00:00:01.000000000+00:00 stderr F oops\n",
+			want: []execOutputChunk{
+				{device: "stdout", data: "hello world\n"},
+				{device: "stderr", data: "oops\n"},
+			},
+		},
+		{
+			name: "partial lines",
+			size: 1024,
+			log: "2024-01-01T00:00:00.000000000+00:00 stdout P abc\n" +
+				"2024-01-01T00:00:00.000000000+00:00 stdout F def\n",
+			want: []execOutputChunk{
+				{device: "stdout", data: "abc"},
+				{device: "stdout", data: "def\n"},
+			},
+		},
+		{
+			name: "incomplete last line is skipped",
+			size: 1024,
+			log: "2024-01-01T00:00:00.000000000+00:00 stdout F first\n" +
+				"2024-01-01T00:00:01.000000000+00:00 stdout F sec",
+			want: []execOutputChunk{
+				{device: "stdout", data: "first\n"},
+			},
+		},
+		{
+			name: "only the last bytes are read",
+			size: 11,
+			log: "2024-01-01T00:00:00.000000000+00:00 stdout F first line\n" +
+				"2024-01-01T00:00:01.000000000+00:00 stderr F second line\n" +
+				"2024-01-01T00:00:02.000000000+00:00 stdout F third\n",
+			want: []execOutputChunk{
+				{device: "stderr", data: "line\n"},
+				{device: "stdout", data: "third\n"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "exec_log")
+			require.NoError(t, os.WriteFile(logPath, []byte(tt.log), 0o600))
+			assert.Equal(t, tt.want, readExecOutputChunks(t, logPath, tt.size))
+		})
+	}
+}
+
+func TestReadExecOutputMissingLog(t *testing.T) {
+	err := ReadExecOutput(filepath.Join(t.TempDir(), "exec_log"), 1024, func(string, []byte) error {
+		t.Fatal("write called for a missing log")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestReadExecOutputInvalidLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "exec_log")
+	require.NoError(t, os.WriteFile(logPath, []byte("garbage\n"), 0o600))
+
+	err := ReadExecOutput(logPath, 1024, func(string, []byte) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestExecOutputWriter(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "exec_log")
+	w, err := NewExecOutputWriter(ExecOutputConfig{Path: logPath, Size: 8})
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		"2024-01-01T00:00:00.000000000+00:00 stdout F one",
+		"2024-01-01T00:00:01.000000000+00:00 stdout F two",
+		"2024-01-01T00:00:02.000000000+00:00 stdout P thr",
+		"2024-01-01T00:00:02.000000000+00:00 stdout F ee",
+		"2024-01-01T00:00:03.000000000+00:00 stderr F four",
+	} {
+		require.NoError(t, w.Write(line))
+	}
+	require.NoError(t, w.Close())
+
+	// The first two lines filled a file which was rotated away by the
+	// next two lines, only the last two files are kept.
+	assert.Equal(t, []execOutputChunk{
+		{device: "stdout", data: "thr"},
+		{device: "stdout", data: "ee\n"},
+		{device: "stderr", data: "four\n"},
+	}, readExecOutputChunks(t, logPath, 1024))
+	// The last 7 bytes are read.
+	assert.Equal(t, []execOutputChunk{
+		{device: "stdout", data: "e\n"},
+		{device: "stderr", data: "four\n"},
+	}, readExecOutputChunks(t, logPath, 7))
+	// Newer output is never dropped in favor of older output.
+	assert.Equal(t, []execOutputChunk{
+		{device: "stderr", data: "ur\n"},
+	}, readExecOutputChunks(t, logPath, 3))
+}
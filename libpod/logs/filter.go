@@ -0,0 +1,155 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// LogFilter selects the lines of a log to return.  Lines split into partial
+// lines are matched as a whole, so all parts of a line are either returned
+// or skipped.
+type LogFilter struct {
+	// Grep matches the message of a line
+	Grep *regexp.Regexp
+	// Stream is the stream a line is logged to, stdout or stderr, if set
+	Stream string
+	// JSONFields are the values of the fields of the lines logged as JSON
+	// objects, all fields must match
+	JSONFields map[string]string
+}
+
+// NewLogFilter creates a log filter from the options given on the command
+// line or in the query of an API request.  JSON fields are given as
+// FIELD=VALUE.  It returns nil if the filter would match every line.
+func NewLogFilter(grep, stream string, jsonFields []string) (*LogFilter, error) {
+	if grep == "" && stream == "" && len(jsonFields) == 0 {
+		return nil, nil
+	}
+	filter := new(LogFilter)
+	if grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log filter regular expression %q: %w", grep, err)
+		}
+		filter.Grep = re
+	}
+	switch stream {
+	case "", "stdout", "stderr":
+		filter.Stream = stream
+	default:
+		return nil, fmt.Errorf("invalid log stream %q, must be stdout or stderr", stream)
+	}
+	for _, field := range jsonFields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid JSON field filter %q, must be FIELD=VALUE", field)
+		}
+		if filter.JSONFields == nil {
+			filter.JSONFields = make(map[string]string)
+		}
+		filter.JSONFields[key] = value
+	}
+	return filter, nil
+}
+
+// Match returns whether the filter matches a line of the log, given as its
+// partial lines followed by the full line
+func (f *LogFilter) Match(line []*LogLine) bool {
+	if f == nil {
+		return true
+	}
+	if len(line) == 0 {
+		return false
+	}
+	if f.Stream != "" && line[len(line)-1].Device != f.Stream {
+		return false
+	}
+	msg := line[0].Msg
+	if len(line) > 1 {
+		var sb strings.Builder
+		for _, l := range line {
+			sb.WriteString(l.Msg)
+		}
+		msg = sb.String()
+	}
+	if f.Grep != nil && !f.Grep.MatchString(msg) {
+		return false
+	}
+	if len(f.JSONFields) > 0 {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(msg), &fields); err != nil {
+			return false
+		}
+		for key, want := range f.JSONFields {
+			raw, ok := fields[key]
+			if !ok || jsonFieldValue(raw) != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// jsonFieldValue returns the value of a JSON field as compared to a filter,
+// strings are compared unquoted, other values as they are logged
+func jsonFieldValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// Lines returns a function which is passed the lines of a log in order and
+// returns the lines to print.  The partial lines of a line are held back
+// until the line is complete and then returned along with the line if the
+// filter matches.  The returned function returns the held back partial lines
+// if it is passed nil at the end of the log.
+func (f *LogFilter) Lines() func(*LogLine) []*LogLine {
+	pending := make(map[string][]*LogLine)
+	return func(l *LogLine) []*LogLine {
+		if f == nil {
+			if l == nil {
+				return nil
+			}
+			return []*LogLine{l}
+		}
+		if l == nil {
+			var lines []*LogLine
+			for _, device := range slices.Sorted(maps.Keys(pending)) {
+				if f.Match(pending[device]) {
+					lines = append(lines, pending[device]...)
+				}
+				delete(pending, device)
+			}
+			return lines
+		}
+		line := append(pending[l.Device], l)
+		if l.Partial() {
+			pending[l.Device] = line
+			return nil
+		}
+		delete(pending, l.Device)
+		if f.Match(line) {
+			return line
+		}
+		return nil
+	}
+}
+
+// Filter returns the lines of a complete log matching the filter
+func (f *LogFilter) Filter(lines []*LogLine) []*LogLine {
+	if f == nil {
+		return lines
+	}
+	stream := f.Lines()
+	filtered := make([]*LogLine, 0, len(lines))
+	for _, l := range lines {
+		filtered = append(filtered, stream(l)...)
+	}
+	return append(filtered, stream(nil)...)
+}
@@ -0,0 +1,176 @@
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestStreamLogLine(device, typ, msg string) *LogLine {
+	l := makeTestLogLine(typ, msg)
+	l.Device = device
+	return l
+}
+
+func TestNewLogFilter(t *testing.T) {
+	filter, err := NewLogFilter("", "", nil)
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = NewLogFilter("^err", "stderr", []string{"level=error", "code=500"})
+	require.NoError(t, err)
+	assert.Equal(t, "^err", filter.Grep.String())
+	assert.Equal(t, "stderr", filter.Stream)
+	assert.Equal(t, map[string]string{"level": "error", "code": "500"}, filter.JSONFields)
+
+	for _, tt := range []struct {
+		grep, stream string
+		jsonFields   []string
+	}{
+		{grep: "("},
+		{stream: "stdin"},
+		{jsonFields: []string{"level"}},
+		{jsonFields: []string{"=error"}},
+	} {
+		_, err := NewLogFilter(tt.grep, tt.stream, tt.jsonFields)
+		assert.Error(t, err, "%+v", tt)
+	}
+}
+
+func TestLogFilterMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		grep       string
+		stream     string
+		jsonFields []string
+		line       []*LogLine
+		want       bool
+	}{
+		{
+			name: "grep",
+			grep: "wor",
+			line: []*LogLine{makeTestLogLine(FullLogType, "hello world")},
+			want: true,
+		},
+		{
+			name: "grep no match",
+			grep: "^world",
+			line: []*LogLine{makeTestLogLine(FullLogType, "hello world")},
+		},
+		{
+			name: "grep across partial lines",
+			grep: "lo wo",
+			line: []*LogLine{makeTestLogLine(PartialLogType, "hel"), makeTestLogLine(PartialLogType, "lo w"), makeTestLogLine(FullLogType, "orld")},
+			want: true,
+		},
+		{
+			name:   "stream",
+			stream: "stderr",
+			line:   []*LogLine{makeTestStreamLogLine("stderr", FullLogType, "oops")},
+			want:   true,
+		},
+		{
+			name:   "stream no match",
+			stream: "stderr",
+			line:   []*LogLine{makeTestLogLine(FullLogType, "fine")},
+		},
+		{
+			name:       "json fields",
+			jsonFields: []string{"level=error", "code=500", "retry=true"},
+			line:       []*LogLine{makeTestLogLine(FullLogType, `{"level":"error","code":500,"retry":true,"msg":"failed"}`)},
+			want:       true,
+		},
+		{
+			name:       "json field no match",
+			jsonFields: []string{"level=error", "code=500"},
+			line:       []*LogLine{makeTestLogLine(FullLogType, `{"level":"error","code":"503"}`)},
+		},
+		{
+			name:       "json field missing",
+			jsonFields: []string{"level=error"},
+			line:       []*LogLine{makeTestLogLine(FullLogType, `{"severity":"error"}`)},
+		},
+		{
+			name:       "not json",
+			jsonFields: []string{"level=error"},
+			line:       []*LogLine{makeTestLogLine(FullLogType, `level=error`)},
+		},
+		{
+			name:       "json across partial lines",
+			jsonFields: []string{"level=error"},
+			line:       []*LogLine{makeTestLogLine(PartialLogType, `{"level":`), makeTestLogLine(FullLogType, `"error"}`)},
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewLogFilter(tt.grep, tt.stream, tt.jsonFields)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Match(tt.line))
+		})
+	}
+}
+
+func TestLogFilterLines(t *testing.T) {
+	filter, err := NewLogFilter("b", "", nil)
+	require.NoError(t, err)
+	lines := filter.Lines()
+
+	assert.Empty(t, lines(makeTestLogLine(FullLogType, "a")))
+	// Partial lines are held back until the line is complete
+	assert.Empty(t, lines(makeTestLogLine(PartialLogType, "x")))
+	assert.Empty(t, lines(makeTestStreamLogLine("stderr", FullLogType, "c")))
+	assert.Equal(t, []*LogLine{makeTestLogLine(PartialLogType, "x"), makeTestLogLine(FullLogType, "b")},
+		lines(makeTestLogLine(FullLogType, "b")))
+	// The partial lines left at the end of the log are matched as well
+	assert.Empty(t, lines(makeTestLogLine(PartialLogType, "b")))
+	assert.Equal(t, []*LogLine{makeTestLogLine(PartialLogType, "b")}, lines(nil))
+	assert.Empty(t, lines(nil))
+
+	// A nil filter returns every line as it is
+	var none *LogFilter
+	lines = none.Lines()
+	assert.Equal(t, []*LogLine{makeTestLogLine(PartialLogType, "x")}, lines(makeTestLogLine(PartialLogType, "x")))
+	assert.Empty(t, lines(nil))
+}
+
+func TestGetTailLogFilter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "log")
+	err := os.WriteFile(file, []byte(`2023-08-07T19:56:34.223758260-06:00 stdout F match1
+2023-08-07T19:56:34.223758260-06:00 stderr F other1
+2023-08-07T19:56:34.223758260-06:00 stdout P mat
+2023-08-07T19:56:34.223758260-06:00 stdout F ch2
+2023-08-07T19:56:34.223758260-06:00 stdout F other2
+2023-08-07T19:56:34.223758260-06:00 stdout F match3
+2023-08-07T19:56:34.223758260-06:00 stdout F other3
+`), 0o600)
+	require.NoError(t, err)
+
+	filter, err := NewLogFilter("match", "", nil)
+	require.NoError(t, err)
+	got, err := getTailLog(file, 2, filter, NewLogLine)
+	require.NoError(t, err)
+	assert.Equal(t, []*LogLine{
+		makeTestLogLine(PartialLogType, "mat"),
+		makeTestLogLine(FullLogType, "ch2"),
+		makeTestLogLine(FullLogType, "match3"),
+	}, got)
+
+	got, err = getTailLog(file, 5, filter, NewLogLine)
+	require.NoError(t, err)
+	assert.Equal(t, []*LogLine{
+		makeTestLogLine(FullLogType, "match1"),
+		makeTestLogLine(PartialLogType, "mat"),
+		makeTestLogLine(FullLogType, "ch2"),
+		makeTestLogLine(FullLogType, "match3"),
+	}, got)
+
+	filter, err = NewLogFilter("", "stderr", nil)
+	require.NoError(t, err)
+	got, err = getTailLog(file, 1, filter, NewLogLine)
+	require.NoError(t, err)
+	assert.Equal(t, []*LogLine{makeTestStreamLogLine("stderr", FullLogType, "other1")}, got)
+}
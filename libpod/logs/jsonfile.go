@@ -0,0 +1,227 @@
+package logs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nxadm/tail"
+)
+
+// jsonFileCompressSuffix is the suffix of the compressed rotated files of the
+// json-file log driver
+const jsonFileCompressSuffix = ".gz"
+
+// JSONLogRecord is a record of the json-file log driver, in the layout of
+// the json-file log driver of Docker
+type JSONLogRecord struct {
+	// Log is the logged data, including the trailing newline unless the
+	// record is a partial line
+	Log string `json:"log"`
+	// Stream is stdout or stderr
+	Stream string `json:"stream"`
+	// Time is the time the data was logged
+	Time time.Time `json:"time"`
+}
+
+// NewJSONLogLine creates a logLine struct from a record of the json-file log
+// driver.  Lines in the format of the k8s-file log driver are accepted as
+// well, they are found in the logs of containers created before json-file
+// was a driver on its own.
+func NewJSONLogLine(line string) (*LogLine, error) {
+	if !strings.HasPrefix(line, "{") {
+		return NewLogLine(line)
+	}
+	var record JSONLogRecord
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid container log record: %w", line, err)
+	}
+	l := LogLine{
+		Time:         record.Time,
+		Device:       record.Stream,
+		ParseLogType: PartialLogType,
+		Msg:          record.Log,
+	}
+	if msg, ok := strings.CutSuffix(record.Log, "\n"); ok {
+		l.ParseLogType = FullLogType
+		l.Msg = msg
+	}
+	return &l, nil
+}
+
+// JSONLogRecord converts a log line to a record of the json-file log driver
+func (l *LogLine) JSONLogRecord() *JSONLogRecord {
+	record := JSONLogRecord{
+		Log:    l.Msg,
+		Stream: l.Device,
+		Time:   l.Time,
+	}
+	if !l.Partial() {
+		record.Log += "\n"
+	}
+	return &record
+}
+
+// RotatedJSONLogFiles returns the rotated files of the json-file log at
+// path, the newest first.  Rotated files are named after the log with the
+// suffix .1 for the newest, optionally followed by .gz if compressed.
+func RotatedJSONLogFiles(path string) ([]string, error) {
+	matches, err := filepath.Glob(globEscape(path) + ".*")
+	if err != nil {
+		return nil, err
+	}
+	byIndex := make(map[int]string)
+	for _, match := range matches {
+		suffix, compressed := strings.CutSuffix(strings.TrimPrefix(match, path+"."), jsonFileCompressSuffix)
+		index, err := strconv.Atoi(suffix)
+		if err != nil || index < 1 {
+			continue
+		}
+		// While a rotated file is compressed, both the file and the
+		// compressed file exist for a moment.
+		if _, ok := byIndex[index]; ok && compressed {
+			continue
+		}
+		byIndex[index] = match
+	}
+	indexes := make([]int, 0, len(byIndex))
+	for index := range byIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	rotated := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		rotated = append(rotated, byIndex[index])
+	}
+	return rotated, nil
+}
+
+// globEscape escapes the characters of a path which have a meaning in a glob
+func globEscape(path string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`).Replace(path)
+}
+
+// readJSONLogSegment reads all lines of a rotated file of a json-file log
+func readJSONLogSegment(path string) ([]*LogLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, jsonFileCompressSuffix) {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing log file %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var lines []*LogLine
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimSuffix(line, "\n"); line != "" {
+			nll, parseErr := NewJSONLogLine(line)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			lines = append(lines, nll)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return lines, nil
+			}
+			return nil, fmt.Errorf("reading log file %s: %w", path, err)
+		}
+	}
+}
+
+// countLogLines returns the number of lines the log lines are printed as,
+// partial lines are printed along with the next full line
+func countLogLines(lines []*LogLine) int {
+	count := 0
+	for i, l := range lines {
+		if !l.Partial() || i == len(lines)-1 {
+			count++
+		}
+	}
+	return count
+}
+
+// GetJSONLogFile returns an hp tail of the json-file log of a container given
+// options, along with the lines of the log to print before the lines read by
+// the tail.  These lines are read from the rotated files of the log.
+func GetJSONLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error) {
+	var (
+		whence  int
+		logTail []*LogLine
+	)
+	rotated, err := RotatedJSONLogFiles(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	// whence 0=origin, 2=end
+	switch {
+	case options.Tail < 0:
+		for i := len(rotated) - 1; i >= 0; i-- {
+			lines, err := readJSONLogSegment(rotated[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			logTail = append(logTail, lines...)
+		}
+	case options.Tail > 0:
+		whence = 2
+		logTail, err = getTailLog(path, int(options.Tail), options.Filter, NewJSONLogLine)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Continue with the rotated files until enough lines were read.
+		for _, segment := range rotated {
+			if countLogLines(logTail) >= int(options.Tail) {
+				break
+			}
+			lines, err := readJSONLogSegment(segment)
+			if err != nil {
+				return nil, nil, err
+			}
+			logTail = append(options.Filter.Filter(lines), logTail...)
+		}
+		logTail = trimLogTail(logTail, int(options.Tail))
+	default:
+		whence = 2
+	}
+	seek := tail.SeekInfo{
+		Offset: 0,
+		Whence: whence,
+	}
+
+	t, err := tail.TailFile(path, tail.Config{MustExist: true, Poll: true, Follow: options.Follow, Location: &seek, Logger: tail.DiscardingLogger, ReOpen: options.Follow})
+	return t, logTail, err
+}
+
+// trimLogTail returns the log lines printed as the last tail lines
+func trimLogTail(lines []*LogLine, tail int) []*LogLine {
+	count := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !lines[i].Partial() || i == len(lines)-1 {
+			count++
+		}
+		if count > tail {
+			return lines[i+1:]
+		}
+	}
+	return lines
+}
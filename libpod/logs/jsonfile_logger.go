@@ -0,0 +1,230 @@
+//go:build linux || freebsd
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/pkg/detached"
+	"go.podman.io/storage/pkg/reexec"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// jsonFileLoggerKey is the reexec key of the json-file logger process.
+	jsonFileLoggerKey = "podman-json-file-logger"
+	// jsonFileWriterKey is the reexec key of the process started by the
+	// json-file logger to write the log.
+	jsonFileWriterKey = "podman-json-file-writer"
+	// jsonFileWriterRestartDelay is the time the logger waits before it
+	// starts a new writer after the previous one failed.
+	jsonFileWriterRestartDelay = time.Second
+)
+
+func init() {
+	detached.Register(jsonFileLoggerKey, runJSONFileLogger)
+	reexec.Register(jsonFileWriterKey, jsonFileWriterMain)
+}
+
+// StartJSONFileLogger spawns a detached process writing the json-file log of
+// a container.  Conmon only writes logs in the k8s-file format, so it is
+// pointed at the FIFO at fifoPath instead of the log file.  The logger reads
+// the lines conmon writes to the FIFO, converts them to json-file records
+// and writes them to the log file, rotating it as configured.  It exits once
+// conmon closes the FIFO.
+//
+// The conversion runs in a child process of the logger, which is restarted
+// if it dies.  Conmon blocks writing the FIFO while it is full, so output of
+// the container is held back rather than lost while the log is written
+// slowly or the writer is restarted.
+//
+// The logger is passed to started once it is started, before it starts the
+// process writing the log, so the caller can move it into the cgroup of
+// conmon.
+//
+// The returned file is a write end of the FIFO.  It keeps the logger from
+// reading the end of the FIFO before conmon opened it, and must be closed
+// once conmon is started or failed to start.
+func StartJSONFileLogger(fifoPath string, config *JSONFileConfig, started func(pid int)) (*os.File, error) {
+	if err := createFIFO(fifoPath); err != nil {
+		return nil, err
+	}
+	writer, err := spawnFIFOReader(jsonFileLoggerKey, fifoPath, config, started)
+	if err != nil {
+		return nil, fmt.Errorf("starting json-file logger: %w", err)
+	}
+	return writer, nil
+}
+
+// createFIFO creates the FIFO at fifoPath, replacing any file left behind.
+func createFIFO(fifoPath string) error {
+	if err := os.Remove(fifoPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := unix.Mkfifo(fifoPath, 0o600); err != nil {
+		return fmt.Errorf("creating log FIFO %s: %w", fifoPath, err)
+	}
+	return nil
+}
+
+// spawnFIFOReader starts the detached process registered with key, passing
+// it config on stdin and the read end of the existing FIFO at fifoPath as fd
+// 4, and calls started with its PID.  It returns a write end of the FIFO.
+func spawnFIFOReader(key, fifoPath string, config any, started func(pid int)) (*os.File, error) {
+	// Opening the read end first does not block, and the write end can be
+	// opened without blocking once there is a reader.
+	reader, err := os.OpenFile(fifoPath, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	writer, err := os.OpenFile(fifoPath, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.SetNonblock(int(reader.Fd()), false); err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		writer.Close()
+		return nil, err
+	}
+	if err := detached.SpawnWithHook(key, data, []*os.File{reader}, started); err != nil {
+		writer.Close()
+		return nil, err
+	}
+	return writer, nil
+}
+
+// runJSONFileLogger is the entry point of the logger process.  It expects the
+// configuration on stdin and the read end of the FIFO as fd 4.
+//
+// The logger supervises the process writing the log: it keeps the read end
+// of the FIFO open, so conmon can keep writing even if the writer dies, and
+// starts a new writer until one read the FIFO to its end, that is until
+// conmon exited.
+func runJSONFileLogger(ready func() error) error {
+	fifo := os.NewFile(4, "fifo")
+	if fifo == nil {
+		return errors.New("internal error: expected the log FIFO as file descriptor 4")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("reading logger configuration: %w", err)
+	}
+	var config JSONFileConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("decoding logger configuration: %w", err)
+	}
+	// Report a log file which cannot be written to the caller rather
+	// than failing in every writer.
+	writer, err := NewJSONFileWriter(config)
+	if err != nil {
+		return err
+	}
+	writer.Close()
+
+	if err := ready(); err != nil {
+		return err
+	}
+	signal.Ignore(syscall.SIGHUP)
+
+	for {
+		cmd := reexec.Command(jsonFileWriterKey)
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.ExtraFiles = []*os.File{fifo}
+		err := cmd.Run()
+		if err == nil {
+			return nil
+		}
+		logrus.Errorf("json-file log writer for %s exited unexpectedly, restarting it: %v", config.Path, err)
+		time.Sleep(jsonFileWriterRestartDelay)
+	}
+}
+
+// jsonFileWriterMain is the entry point of the writer process started by the
+// logger.  It expects the configuration on stdin and the read end of the FIFO
+// as fd 3, and exits once it read the FIFO to its end.
+func jsonFileWriterMain() {
+	if err := runJSONFileWriter(); err != nil {
+		logrus.Error(err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func runJSONFileWriter() error {
+	fifo := os.NewFile(3, "fifo")
+	if fifo == nil {
+		return errors.New("internal error: expected the log FIFO as file descriptor 3")
+	}
+	var config JSONFileConfig
+	if err := json.NewDecoder(os.Stdin).Decode(&config); err != nil {
+		return fmt.Errorf("decoding logger configuration: %w", err)
+	}
+	writer, err := NewJSONFileWriter(config)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	// Lines which cannot be parsed or written are skipped, only a failure
+	// to read the FIFO makes the logger start a new writer.
+	reader := &fifoReader{fifo: fifo}
+	_ = CopyToJSONFile(writer, reader)
+	return reader.err
+}
+
+// fifoReader records the first error other than io.EOF reading the FIFO.
+type fifoReader struct {
+	fifo *os.File
+	err  error
+}
+
+func (r *fifoReader) Read(p []byte) (int, error) {
+	n, err := r.fifo.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// CopyToJSONFile reads log lines in the k8s-file format from r and writes
+// them to the json-file log until the end of r.  Lines which cannot be parsed
+// or written are skipped, the first such error is returned.
+func CopyToJSONFile(w *JSONFileWriter, r io.Reader) error {
+	reader := bufio.NewReader(r)
+	var firstErr error
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimSuffix(line, "\n"); line != "" {
+			nll, lineErr := NewLogLine(line)
+			if lineErr == nil {
+				lineErr = w.Write(nll)
+			}
+			if lineErr != nil && firstErr == nil {
+				firstErr = lineErr
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return firstErr
+			}
+			return err
+		}
+	}
+}
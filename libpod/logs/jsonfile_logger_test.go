@@ -0,0 +1,33 @@
+//go:build linux || freebsd
+
+package logs
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFIFOReader(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("line\n")
+	require.NoError(t, err)
+	w.Close()
+
+	// The end of the FIFO is not a failure.
+	reader := &fifoReader{fifo: r}
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line\n", string(data))
+	assert.NoError(t, reader.err)
+
+	// Reading a closed FIFO is.
+	r.Close()
+	_, err = reader.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.Equal(t, err, reader.err)
+}
@@ -0,0 +1,177 @@
+package logs
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jsonLogTime = time.Date(2023, 8, 7, 19, 56, 34, 223758260, time.UTC)
+
+func makeTestJSONLogLine(typ, msg string) *LogLine {
+	l := makeTestLogLine(typ, msg)
+	l.Time = jsonLogTime
+	return l
+}
+
+func TestNewJSONLogLine(t *testing.T) {
+	l, err := NewJSONLogLine(`{"log":"hello\n","stream":"stderr","time":"2023-08-07T19:56:34.22375826Z"}`)
+	require.NoError(t, err)
+	assert.Equal(t, &LogLine{Device: "stderr", ParseLogType: FullLogType, Msg: "hello", Time: jsonLogTime}, l)
+
+	l, err = NewJSONLogLine(`{"log":"hel","stream":"stdout","time":"2023-08-07T19:56:34.22375826Z"}`)
+	require.NoError(t, err)
+	assert.True(t, l.Partial())
+	assert.Equal(t, "hel", l.Msg)
+
+	// Logs of containers created before json-file was a driver on its own
+	l, err = NewJSONLogLine("2023-08-07T19:56:34.223758260-06:00 stdout F line1")
+	require.NoError(t, err)
+	assert.Equal(t, makeTestLogLine(FullLogType, "line1"), l)
+
+	_, err = NewJSONLogLine(`{"log":`)
+	assert.Error(t, err)
+}
+
+func TestJSONLogRecordRoundTrip(t *testing.T) {
+	for _, want := range []*LogLine{
+		makeTestJSONLogLine(FullLogType, "full"),
+		makeTestJSONLogLine(PartialLogType, "partial"),
+		makeTestJSONLogLine(FullLogType, ""),
+	} {
+		data, err := json.Marshal(want.JSONLogRecord())
+		require.NoError(t, err)
+		got, err := NewJSONLogLine(string(data))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestJSONFileWriterRotation(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "ctr.log")
+		// Every record is 75 bytes, so the log is rotated after 2 records.
+		w, err := NewJSONFileWriter(JSONFileConfig{Path: path, MaxSize: 160, MaxFiles: 3, Compress: compress})
+		require.NoError(t, err)
+		for _, msg := range []string{"line1", "line2", "line3", "line4", "line5", "line6", "line7"} {
+			require.NoError(t, w.Write(makeTestJSONLogLine(FullLogType, msg)))
+		}
+		require.NoError(t, w.Close())
+
+		rotated, err := RotatedJSONLogFiles(path)
+		require.NoError(t, err)
+		suffix := ""
+		if compress {
+			suffix = jsonFileCompressSuffix
+		}
+		assert.Equal(t, []string{path + ".1" + suffix, path + ".2" + suffix}, rotated)
+
+		// The oldest records were removed along with the oldest file
+		lines, err := readJSONLogSegment(rotated[1])
+		require.NoError(t, err)
+		assert.Equal(t, []*LogLine{makeTestJSONLogLine(FullLogType, "line3"), makeTestJSONLogLine(FullLogType, "line4")}, lines)
+		lines, err = readJSONLogSegment(path)
+		require.NoError(t, err)
+		assert.Equal(t, []*LogLine{makeTestJSONLogLine(FullLogType, "line7")}, lines)
+	}
+}
+
+func TestJSONFileWriterTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	w, err := NewJSONFileWriter(JSONFileConfig{Path: path, MaxSize: 100})
+	require.NoError(t, err)
+	for _, msg := range []string{"line1", "line2", "line3"} {
+		require.NoError(t, w.Write(makeTestJSONLogLine(FullLogType, msg)))
+	}
+	require.NoError(t, w.Close())
+
+	rotated, err := RotatedJSONLogFiles(path)
+	require.NoError(t, err)
+	assert.Empty(t, rotated)
+	lines, err := readJSONLogSegment(path)
+	require.NoError(t, err)
+	assert.Equal(t, []*LogLine{makeTestJSONLogLine(FullLogType, "line3")}, lines)
+}
+
+func TestRotatedJSONLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctr[1].log")
+	for _, name := range []string{".2.gz", ".1", ".1.gz", ".10", ".0", ".fifo", ".3.gz.tmp"} {
+		require.NoError(t, os.WriteFile(path+name, nil, 0o600))
+	}
+	rotated, err := RotatedJSONLogFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".1", path + ".2.gz", path + ".10"}, rotated)
+}
+
+func TestGetJSONLogFileTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	w, err := NewJSONFileWriter(JSONFileConfig{Path: path, MaxSize: 160, MaxFiles: 3, Compress: true})
+	require.NoError(t, err)
+	var want []*LogLine
+	for _, msg := range []string{"line1", "line2", "line3", "line4", "line5"} {
+		l := makeTestJSONLogLine(FullLogType, msg)
+		require.NoError(t, w.Write(l))
+		want = append(want, l)
+	}
+	require.NoError(t, w.Close())
+
+	// All lines are read from the rotated files, the tail reads the log
+	// file from its start.
+	tail, lines, err := GetJSONLogFile(path, &LogOptions{Tail: -1})
+	require.NoError(t, err)
+	tail.Cleanup()
+	assert.Equal(t, want[:4], lines)
+
+	// The tail crosses into the rotated files
+	tail, lines, err = GetJSONLogFile(path, &LogOptions{Tail: 3})
+	require.NoError(t, err)
+	tail.Cleanup()
+	assert.Equal(t, want[2:], lines)
+
+	tail, lines, err = GetJSONLogFile(path, &LogOptions{Tail: 10})
+	require.NoError(t, err)
+	tail.Cleanup()
+	assert.Equal(t, want, lines)
+}
+
+func TestCopyToJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	w, err := NewJSONFileWriter(JSONFileConfig{Path: path})
+	require.NoError(t, err)
+	err = CopyToJSONFile(w, strings.NewReader(`2023-08-07T19:56:34.223758260-06:00 stdout P lin
+invalid
+2023-08-07T19:56:34.223758260-06:00 stdout F e2
+`))
+	assert.Error(t, err, "invalid line")
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"log":"lin","stream":"stdout","time":"2023-08-07T19:56:34.22375826-06:00"}
+{"log":"e2\n","stream":"stdout","time":"2023-08-07T19:56:34.22375826-06:00"}
+`, string(data))
+}
+
+func TestReadJSONLogSegmentCompressed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log.1.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(`{"log":"line1\n","stream":"stdout","time":"2023-08-07T19:56:34.22375826Z"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	lines, err := readJSONLogSegment(path)
+	require.NoError(t, err)
+	assert.Equal(t, []*LogLine{makeTestJSONLogLine(FullLogType, "line1")}, lines)
+}
@@ -0,0 +1,161 @@
+package logs
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// JSONFileConfig is the configuration of the json-file log of a container
+type JSONFileConfig struct {
+	// Path is the path of the log file
+	Path string `json:"path"`
+	// MaxSize is the size the log file is rotated at, 0 or less
+	// disables the rotation
+	MaxSize int64 `json:"maxSize,omitempty"`
+	// MaxFiles is the maximum number of log files kept, including the
+	// log file written to.  The log file is truncated instead of rotated
+	// if it is 1 or less.
+	MaxFiles int `json:"maxFiles,omitempty"`
+	// Compress compresses the rotated log files with gzip
+	Compress bool `json:"compress,omitempty"`
+}
+
+// JSONFileWriter writes log lines as records of the json-file log driver
+// and rotates the log file once it reaches its maximum size
+type JSONFileWriter struct {
+	config JSONFileConfig
+	file   *os.File
+	size   int64
+}
+
+// NewJSONFileWriter opens the json-file log to append records to it
+func NewJSONFileWriter(config JSONFileConfig) (*JSONFileWriter, error) {
+	w := &JSONFileWriter{config: config}
+	if err := w.open(os.O_APPEND); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *JSONFileWriter) open(flag int) error {
+	f, err := os.OpenFile(w.config.Path, os.O_WRONLY|os.O_CREATE|flag, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// Write appends a log line to the log, rotating the log first if the line
+// does not fit into it anymore
+func (w *JSONFileWriter) Write(l *LogLine) error {
+	data, err := json.Marshal(l.JSONLogRecord())
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if w.config.MaxSize > 0 && w.size > 0 && w.size+int64(len(data)) > w.config.MaxSize {
+		if err := w.rotate(); err != nil {
+			return fmt.Errorf("rotating log file %s: %w", w.config.Path, err)
+		}
+	}
+	n, err := w.file.Write(data)
+	w.size += int64(n)
+	return err
+}
+
+// Close closes the log file
+func (w *JSONFileWriter) Close() error {
+	return w.file.Close()
+}
+
+// rotatedName returns the name of the rotated log file with the given index
+func (w *JSONFileWriter) rotatedName(index int, compressed bool) string {
+	name := fmt.Sprintf("%s.%d", w.config.Path, index)
+	if compressed {
+		name += jsonFileCompressSuffix
+	}
+	return name
+}
+
+// rotate moves the log file to the rotated files and starts a new log file.
+// Like Docker, the oldest rotated files are removed to keep at most MaxFiles
+// files.
+func (w *JSONFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	if w.config.MaxFiles <= 1 {
+		return w.open(os.O_TRUNC)
+	}
+
+	for _, compressed := range []bool{false, true} {
+		if err := os.Remove(w.rotatedName(w.config.MaxFiles-1, compressed)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		for i := w.config.MaxFiles - 2; i >= 1; i-- {
+			if err := os.Rename(w.rotatedName(i, compressed), w.rotatedName(i+1, compressed)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	if err := os.Rename(w.config.Path, w.rotatedName(1, false)); err != nil {
+		return err
+	}
+	if err := w.open(os.O_TRUNC); err != nil {
+		return err
+	}
+	if w.config.Compress {
+		if err := compressLogFile(w.rotatedName(1, false), w.rotatedName(1, true)); err != nil {
+			return fmt.Errorf("compressing rotated log file: %w", err)
+		}
+	}
+	return nil
+}
+
+// compressLogFile compresses the file at src to dest and removes src.  The
+// compressed file is written to a temporary file first, so readers never see
+// a partially written file.
+func compressLogFile(src, dest string) (retErr error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dest + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			out.Close()
+			os.Remove(tmp)
+		}
+	}()
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
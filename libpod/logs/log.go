@@ -44,6 +44,8 @@ type LogOptions struct {
 	Multi      bool
 	WaitGroup  *sync.WaitGroup
 	UseName    bool
+	// Filter selects the lines to return, all lines are returned if nil
+	Filter *LogFilter
 }
 
 // LogLine describes the information for each line of a log
@@ -69,7 +71,7 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 		whence = 2
 	}
 	if options.Tail > 0 {
-		logTail, err = getTailLog(path, int(options.Tail))
+		logTail, err = getTailLog(path, int(options.Tail), options.Filter, NewLogLine)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -83,12 +85,15 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 	return t, logTail, err
 }
 
-func getTailLog(path string, tail int) ([]*LogLine, error) {
+func getTailLog(path string, tail int, filter *LogFilter, newLogLine func(string) (*LogLine, error)) ([]*LogLine, error) {
 	var (
 		nllCounter int
 		leftover   string
 		tailLog    []*LogLine
-		eof        bool
+		// line holds the partial lines and the full line of the line
+		// read last
+		line []*LogLine
+		eof  bool
 	)
 	f, err := os.Open(path)
 	if err != nil {
@@ -100,7 +105,39 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 		return nil, err
 	}
 
-	first := true
+	// addLine adds the line read last to the tail if it matches the filter
+	// and returns whether the tail is complete.  We explicitly need to read
+	// up to the next full line before adding a line because we must keep
+	// all partial lines, https://github.com/containers/podman/issues/19545
+	// Even if the last line is partial we need to count it as it will be
+	// printed as line.
+	addLine := func() bool {
+		if len(line) == 0 {
+			return false
+		}
+		if filter.Match(line) {
+			nllCounter++
+			// because we add lines in the inverse order we must invert the slice in the end
+			for i := len(line) - 1; i >= 0; i-- {
+				tailLog = append(tailLog, line[i])
+			}
+		}
+		line = nil
+		return nllCounter >= tail
+	}
+	// readLine reads a line of the log in inverse order and returns whether
+	// the tail is complete
+	readLine := func(s string) (bool, error) {
+		nll, err := newLogLine(s)
+		if err != nil {
+			return false, err
+		}
+		if !nll.Partial() && addLine() {
+			return true, nil
+		}
+		line = append([]*LogLine{nll}, line...)
+		return false, nil
+	}
 
 	for {
 		s, err := rr.Read()
@@ -118,38 +155,29 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 			if lines[i] == "" {
 				continue
 			}
-			nll, err := NewLogLine(lines[i])
+			done, err := readLine(lines[i])
 			if err != nil {
 				return nil, err
 			}
-			if !nll.Partial() || first {
-				nllCounter++
-				// Even if the last line is partial we need to count it as it will be printed as line.
-				// Because we read backwards the first line we read is the last line in the log.
-				first = false
-			}
-			// We explicitly need to check for more lines than tail because we have
-			// to read to next full line and must keep all partial lines
-			// https://github.com/containers/podman/issues/19545
-			if nllCounter > tail {
-				// because we add lines in the inverse order we must invert the slice in the end
+			if done {
 				return reverseLog(tailLog), nil
 			}
-			// only append after the return here because we do not want to include the next full line
-			tailLog = append(tailLog, nll)
 		}
 		leftover = lines[0]
 
 		// eof was reached
 		if eof {
 			// when we have still a line and do not have enough tail lines already
-			if leftover != "" && nllCounter < tail {
-				nll, err := NewLogLine(leftover)
+			if leftover != "" {
+				done, err := readLine(leftover)
 				if err != nil {
 					return nil, err
 				}
-				tailLog = append(tailLog, nll)
+				if done {
+					return reverseLog(tailLog), nil
+				}
 			}
+			addLine()
 			// because we add lines in the inverse order we must invert the slice in the end
 			return reverseLog(tailLog), nil
 		}
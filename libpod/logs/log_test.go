@@ -103,7 +103,7 @@ func TestGetTailLog(t *testing.T) {
 			_, err = f.WriteString(tt.fileContent)
 			assert.NoError(t, err, "write log file")
 			f.Close()
-			got, err := getTailLog(file, tt.tail)
+			got, err := getTailLog(file, tt.tail, nil, NewLogLine)
 			assert.NoError(t, err, "getTailLog()")
 			assert.Equal(t, tt.want, got, "log lines")
 		})
@@ -126,12 +126,12 @@ func TestGetTailLogBigFiles(t *testing.T) {
 	f.Close()
 
 	// try a big tail greater than the lines
-	got, err := getTailLog(file, 5000)
+	got, err := getTailLog(file, 5000, nil, NewLogLine)
 	assert.NoError(t, err, "getTailLog()")
 	assert.Equal(t, want, got, "all log lines")
 
 	// try a smaller than lines tail
-	got, err = getTailLog(file, 100)
+	got, err = getTailLog(file, 100, nil, NewLogLine)
 	assert.NoError(t, err, "getTailLog()")
 	// this will return the last 200 lines because of partial + full and we only count full lines for tail.
 	assert.Equal(t, want[1800:2000], got, "tail 100 log lines")
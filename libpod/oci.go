@@ -8,6 +8,7 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"go.podman.io/common/pkg/resize"
 	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/libpod/recording"
 )
 
 // OCIRuntime is an implementation of an OCI runtime.
@@ -91,6 +92,16 @@ type OCIRuntime interface { //nolint:interfacebloat
 	// does not attach to it. Returns the PID of the exec session and an
 	// error (if starting the exec session failed)
 	ExecContainerDetached(ctr *Container, sessionID string, options *ExecOptions, stdin bool) (int, error)
+	// ExecAttach attaches to a running exec session. If the session keeps
+	// an output buffer of outputBuffer bytes, its contents are replayed
+	// before live output is forwarded. Returns when the session exits or
+	// the client detaches.
+	ExecAttach(ctr *Container, sessionID string, outputBuffer int64, streams *define.AttachStreams, detachKeys *string) error
+	// ExecHTTPAttach attaches to a running exec session over a hijacked
+	// HTTP session. The HTTP attach maintains the same invariants as
+	// HTTPAttach.
+	ExecHTTPAttach(ctr *Container, sessionID string, outputBuffer int64, r *http.Request, w http.ResponseWriter,
+		streams *HTTPAttachStreams, detachKeys *string, isTerminal bool, cancel <-chan bool, hijackDone chan<- bool) error
 	// ExecAttachResize resizes the terminal of a running exec session. Only
 	// allowed with sessions that were created with a TTY.
 	ExecAttachResize(ctr *Container, sessionID string, newSize resize.TerminalSize) error
@@ -230,6 +241,14 @@ type ExecOptions struct {
 	ExitCommandDelay uint
 	// Privileged indicates the execed process will be launched in Privileged mode
 	Privileged bool
+	// OutputBuffer is the size of the last output of the exec session that
+	// is kept to be replayed when attaching to the session again. If 0, no
+	// output is kept.
+	OutputBuffer int64
+	// Recording is the path of the recording of the exec session, its
+	// output is added to the recording. If empty, the session is not
+	// recorded.
+	Recording string
 }
 
 // HTTPAttachStreams informs the HTTPAttach endpoint which of the container's
@@ -239,4 +258,9 @@ type HTTPAttachStreams struct {
 	Stdin  bool
 	Stdout bool
 	Stderr bool
+
+	// recorder records the session, if set.
+	recorder *recording.Recorder
+	// recordOutput is set if the output is recorded along with the input.
+	recordOutput bool
 }
@@ -628,6 +628,8 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 
 	logrus.Debugf("Forwarding attach output for container %s", ctr.ID())
 
+	streamBuf := recordHTTPAttach(streams, httpBuf, isTerminal)
+
 	stdoutChan := make(chan error)
 	stdinChan := make(chan error)
 
@@ -643,11 +645,11 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 			// anything from here.
 			logrus.Debugf("Performing terminal HTTP attach for container %s", ctr.ID())
 			if attachStdout {
-				err = httpAttachTerminalCopy(conn, httpBuf, ctr.ID())
+				err = httpAttachTerminalCopy(conn, streamBuf, ctr.ID())
 			}
 		} else {
 			logrus.Debugf("Performing non-terminal HTTP attach for container %s", ctr.ID())
-			err = httpAttachNonTerminalCopy(conn, httpBuf, ctr.ID(), attachStdin, attachStdout, attachStderr)
+			err = httpAttachNonTerminalCopy(conn, streamBuf, ctr.ID(), attachStdin, attachStdout, attachStderr)
 		}
 		stdoutChan <- err
 		logrus.Debugf("STDOUT/ERR copy completed")
@@ -655,7 +657,7 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 	// Next, STDIN. Avoid entirely if attachStdin unset.
 	if attachStdin {
 		go func() {
-			_, err := detach.Copy(conn, httpBuf, isDetach)
+			_, err := detach.Copy(conn, streamBuf, isDetach)
 			logrus.Debugf("STDIN copy completed")
 			stdinChan <- err
 		}()
@@ -1042,8 +1044,24 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		pidfile = filepath.Join(ctr.state.RunDir, "pidfile")
 	}
 
+	logPath := ctr.LogPath()
+	if ctr.LogDriver() == define.JSONLogging {
+		// Conmon writes the log in the k8s-file format to a FIFO, the
+		// json-file logger converts it and writes the actual log.
+		logPath = ctr.jsonFileLogFIFO()
+		fifoWriter, err := logs.StartJSONFileLogger(logPath, ctr.jsonFileLoggerConfig(), func(pid int) {
+			r.moveToConmonCgroup(ctr, pid)
+		})
+		if err != nil {
+			return 0, err
+		}
+		// Conmon opened the FIFO once it returned the PID of the
+		// container, or it failed.
+		defer errorhandling.CloseQuiet(fifoWriter)
+	}
+
 	persistDir := filepath.Join(r.persistDir, ctr.ID())
-	args, err := r.sharedConmonArgs(ctr, ctr.ID(), ctr.bundlePath(), pidfile, ctr.LogPath(), r.exitsDir, persistDir, ociLog, ctr.LogDriver(), logTag, logLabels)
+	args, err := r.sharedConmonArgs(ctr, ctr.ID(), ctr.bundlePath(), pidfile, logPath, r.exitsDir, persistDir, ociLog, ctr.LogDriver(), logTag, ctr.LogSizeMax(), logLabels)
 	if err != nil {
 		return 0, err
 	}
@@ -1306,8 +1324,8 @@ func validJournaldFieldName(s string) bool {
 }
 
 // sharedConmonArgs takes common arguments for exec and create/restore and formats them for the conmon CLI
-// func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath, logPath, exitDir, persistDir, ociLogPath, logDriver, logTag string, logLabels map[string]string) ([]string, error) {
-func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath, logPath, exitDir, persistDir, ociLogPath, logDriver, logTag string, logLabels map[string]string) ([]string, error) {
+// func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath, logPath, exitDir, persistDir, ociLogPath, logDriver, logTag string, logSizeMax int64, logLabels map[string]string) ([]string, error) {
+func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath, logPath, exitDir, persistDir, ociLogPath, logDriver, logTag string, logSizeMax int64, logLabels map[string]string) ([]string, error) {
 	// Make the persists directory for the container after the ctr ID is appended to it in the caller
 	// This is needed as conmon writes the exit and oom file in the given persist directory path as just "exit" and "oom"
 	// So creating a directory with the container ID under the persist dir will help keep track of which container the
@@ -1351,7 +1369,7 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 		logDriverArg = define.PassthroughLogging
 	//lint:ignore ST1015 the default case has to be here
 	default: //nolint:gocritic
-		// No case here should happen except JSONLogging, but keep this here in case the options are extended
+		// No case here should happen, but keep this here in case the options are extended
 		logrus.Errorf("%s logging specified but not supported. Choosing k8s-file logging instead", ctr.LogDriver())
 		fallthrough
 	case "":
@@ -1371,9 +1389,9 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 	logrus.Debugf("%s messages will be logged to syslog", r.conmonPath)
 	args = append(args, "--syslog")
 
-	size := ctr.LogSizeMax()
-	if size > 0 {
-		args = append(args, "--log-size-max", strconv.FormatInt(size, 10))
+	// The json-file logger rotates the log on its own.
+	if logSizeMax > 0 && logDriver != define.JSONLogging {
+		args = append(args, "--log-size-max", strconv.FormatInt(logSizeMax, 10))
 	}
 
 	if ociLogPath != "" {
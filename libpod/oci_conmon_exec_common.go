@@ -21,6 +21,7 @@ import (
 	"go.podman.io/common/pkg/detach"
 	"go.podman.io/common/pkg/resize"
 	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/libpod/logs"
 	"go.podman.io/podman/v6/pkg/errorhandling"
 	"go.podman.io/podman/v6/pkg/lookup"
 	"go.podman.io/podman/v6/pkg/pidhandle"
@@ -199,6 +200,203 @@ func (r *ConmonOCIRuntime) ExecContainerDetached(ctr *Container, sessionID strin
 	return pid, err
 }
 
+// ExecAttach attaches to a running exec session.
+// The output kept by the session, if any, is replayed to the output streams
+// before live output is forwarded. As the attach socket is connected first,
+// output produced while replaying may be seen twice, but none is lost.
+func (r *ConmonOCIRuntime) ExecAttach(ctr *Container, sessionID string, outputBuffer int64, streams *define.AttachStreams, detachKeys *string) error {
+	if streams == nil {
+		return fmt.Errorf("must provide streams to ExecAttach: %w", define.ErrInvalidArg)
+	}
+	if !streams.AttachOutput && !streams.AttachError && !streams.AttachInput {
+		return fmt.Errorf("must provide at least one stream to attach to: %w", define.ErrInvalidArg)
+	}
+
+	detachString := config.DefaultDetachKeys
+	if detachKeys != nil {
+		detachString = *detachKeys
+	}
+	keys, err := processDetachKeys(detachString)
+	if err != nil {
+		return err
+	}
+
+	sockPath, err := ctr.execAttachSocketPath(sessionID)
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Attaching to container %s exec session %s", ctr.ID(), sessionID)
+
+	conn, err := openUnixSocket(sockPath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to container's exec session attach socket: %v: %w", sockPath, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.Errorf("Unable to close socket: %q", err)
+		}
+	}()
+
+	err = logs.ReadExecOutput(ctr.execLogPath(sessionID), outputBuffer, func(device string, data []byte) error {
+		switch {
+		case device == "stdout" && streams.AttachOutput:
+			_, err := streams.OutputStream.Write(data)
+			return err
+		case device == "stderr" && streams.AttachError:
+			_, err := streams.ErrorStream.Write(data)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("replaying output of container %s exec session %s: %w", ctr.ID(), sessionID, err)
+	}
+
+	receiveStdoutError, stdinDone := setupStdioChannels(streams, conn, keys)
+
+	return readStdio(conn, streams, receiveStdoutError, stdinDone)
+}
+
+// ExecHTTPAttach attaches to a running exec session over a hijacked HTTP
+// session. The caller must handle closing the HTTP connection after this
+// returns. If isTerminal is set we will stream raw, otherwise with an 8-byte
+// header to multiplex STDOUT and STDERR.
+func (r *ConmonOCIRuntime) ExecHTTPAttach(ctr *Container, sessionID string, outputBuffer int64, req *http.Request, w http.ResponseWriter,
+	streams *HTTPAttachStreams, detachKeys *string, isTerminal bool, cancel <-chan bool, hijackDone chan<- bool,
+) (deferredErr error) {
+	attachStdout := true
+	attachStderr := true
+	attachStdin := true
+	if streams != nil {
+		if !streams.Stdin && !streams.Stdout && !streams.Stderr {
+			return fmt.Errorf("must specify at least one stream to attach to: %w", define.ErrInvalidArg)
+		}
+		attachStdout = streams.Stdout
+		attachStderr = streams.Stderr
+		attachStdin = streams.Stdin
+	}
+
+	detachString := ctr.runtime.config.Engine.DetachKeys
+	if detachKeys != nil {
+		detachString = *detachKeys
+	}
+	keys, err := processDetachKeys(detachString)
+	if err != nil {
+		return err
+	}
+
+	sockPath, err := ctr.execAttachSocketPath(sessionID)
+	if err != nil {
+		return err
+	}
+
+	conn, err := openUnixSocket(sockPath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to container's exec session attach socket: %v: %w", sockPath, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.Errorf("Unable to close container %s exec session %s attach socket: %q", ctr.ID(), sessionID, err)
+		}
+	}()
+
+	logrus.Debugf("Going to hijack container %s exec session %s attach connection", ctr.ID(), sessionID)
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return fmt.Errorf("unable to hijack connection")
+	}
+
+	httpCon, httpBuf, err := hijacker.Hijack()
+	if err != nil {
+		return fmt.Errorf("hijacking connection: %w", err)
+	}
+
+	hijackDone <- true
+
+	writeHijackHeader(req, httpBuf, isTerminal)
+
+	defer func() {
+		hijackWriteErrorAndClose(deferredErr, ctr.ID(), isTerminal, httpCon, httpBuf)
+	}()
+
+	// Force a flush after the header is written.
+	if err := httpBuf.Flush(); err != nil {
+		return fmt.Errorf("flushing HTTP hijack header: %w", err)
+	}
+
+	streamBuf := recordHTTPAttach(streams, httpBuf, isTerminal)
+
+	err = logs.ReadExecOutput(ctr.execLogPath(sessionID), outputBuffer, func(device string, data []byte) error {
+		var stream byte
+		switch {
+		case device == "stdout" && attachStdout:
+			stream = 1
+		case device == "stderr" && attachStderr && !isTerminal:
+			stream = 2
+		default:
+			return nil
+		}
+		if !isTerminal {
+			if _, err := streamBuf.Write(makeHTTPAttachHeader(stream, uint32(len(data)))); err != nil {
+				return err
+			}
+		}
+		_, err := streamBuf.Write(data)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("replaying output of container %s exec session %s: %w", ctr.ID(), sessionID, err)
+	}
+
+	if err := streamBuf.Flush(); err != nil {
+		return fmt.Errorf("flushing replayed output of container %s exec session %s: %w", ctr.ID(), sessionID, err)
+	}
+
+	logrus.Debugf("Forwarding attach output for container %s exec session %s", ctr.ID(), sessionID)
+
+	stdoutChan := make(chan error)
+	stdinChan := make(chan error)
+
+	go func() {
+		var err error
+		if isTerminal {
+			if attachStdout {
+				err = httpAttachTerminalCopy(conn, streamBuf, ctr.ID())
+			}
+		} else {
+			err = httpAttachNonTerminalCopy(conn, streamBuf, ctr.ID(), attachStdin, attachStdout, attachStderr)
+		}
+		stdoutChan <- err
+		logrus.Debugf("STDOUT/ERR copy completed")
+	}()
+	if attachStdin {
+		go func() {
+			_, err := detach.Copy(conn, streamBuf, keys)
+			logrus.Debugf("STDIN copy completed")
+			stdinChan <- err
+		}()
+	}
+
+	for {
+		select {
+		case err := <-stdoutChan:
+			return err
+		case err := <-stdinChan:
+			if err != nil {
+				return err
+			}
+			// copy stdin is done, close it
+			if connErr := socketCloseWrite(conn); connErr != nil {
+				logrus.Errorf("Unable to close conn: %v", connErr)
+			}
+		case <-cancel:
+			return nil
+		}
+	}
+}
+
 // ExecAttachResize resizes the TTY of the given exec session.
 func (r *ConmonOCIRuntime) ExecAttachResize(ctr *Container, sessionID string, newSize resize.TerminalSize) error {
 	controlFile, err := openControlFile(ctr, ctr.execBundlePath(sessionID))
@@ -399,7 +597,31 @@ func (r *ConmonOCIRuntime) startExec(c *Container, sessionID string, options *Ex
 	}
 	defer processFile.Close()
 
-	args, err := r.sharedConmonArgs(c, sessionID, c.execBundlePath(sessionID), c.execPidPath(sessionID), c.execLogPath(sessionID), c.execExitFileDir(sessionID), c.execPersistDir(sessionID), ociLog, define.NoLogging, c.config.LogTag, nil)
+	// The output of the exec session is only logged if it is to be
+	// replayed when attaching again or recorded.  Conmon writes it to a
+	// FIFO, the output keeper only keeps the last bytes of it and adds it
+	// to the recording.
+	logDriver := define.NoLogging
+	logPath := c.execLogPath(sessionID)
+	if options.OutputBuffer > 0 || options.Recording != "" {
+		logDriver = define.KubernetesLogging
+		logPath = c.execLogFIFO(sessionID)
+		fifoWriter, err := logs.StartExecOutputKeeper(logPath, &logs.ExecOutputConfig{
+			Path:      c.execLogPath(sessionID),
+			Size:      options.OutputBuffer,
+			Recording: options.Recording,
+		}, func(pid int) {
+			r.moveToConmonCgroup(c, pid)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		// Conmon opened the FIFO once it started the exec session, or
+		// it failed.
+		defer errorhandling.CloseQuiet(fifoWriter)
+	}
+
+	args, err := r.sharedConmonArgs(c, sessionID, c.execBundlePath(sessionID), c.execPidPath(sessionID), logPath, c.execExitFileDir(sessionID), c.execPersistDir(sessionID), ociLog, logDriver, c.config.LogTag, 0, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -419,6 +641,10 @@ func (r *ConmonOCIRuntime) startExec(c *Container, sessionID string, options *Ex
 
 	if attachStdin {
 		args = append(args, "-i")
+		// Keep STDIN open for the next client to attach.
+		if options.OutputBuffer > 0 {
+			args = append(args, "--leave-stdin-open")
+		}
 	}
 
 	// Append container ID and command
@@ -597,6 +823,8 @@ func attachExecHTTP(c *Container, sessionID string, r *http.Request, w http.Resp
 		return fmt.Errorf("flushing HTTP hijack header: %w", err)
 	}
 
+	streamBuf := recordHTTPAttach(streams, httpBuf, isTerminal)
+
 	go func() {
 		// Wait for conmon to succeed, when return.
 		if err := execCmd.Wait(); err != nil {
@@ -630,7 +858,7 @@ func attachExecHTTP(c *Container, sessionID string, r *http.Request, w http.Resp
 	if attachStdin {
 		go func() {
 			logrus.Debugf("Beginning STDIN copy")
-			_, err := detach.Copy(conn, httpBuf, detachKeys)
+			_, err := detach.Copy(conn, streamBuf, detachKeys)
 			logrus.Debugf("STDIN copy completed")
 			stdinChan <- err
 		}()
@@ -653,11 +881,11 @@ func attachExecHTTP(c *Container, sessionID string, r *http.Request, w http.Resp
 			// anything from here.
 			logrus.Debugf("Performing terminal HTTP attach for container %s", c.ID())
 			if attachStdout {
-				err = httpAttachTerminalCopy(conn, httpBuf, c.ID())
+				err = httpAttachTerminalCopy(conn, streamBuf, c.ID())
 			}
 		} else {
 			logrus.Debugf("Performing non-terminal HTTP attach for container %s", c.ID())
-			err = httpAttachNonTerminalCopy(conn, httpBuf, c.ID(), attachStdin, attachStdout, attachStderr)
+			err = httpAttachNonTerminalCopy(conn, streamBuf, c.ID(), attachStdin, attachStdout, attachStderr)
 		}
 		stdoutChan <- err
 		logrus.Debugf("STDOUT/ERR copy completed")
@@ -28,6 +28,12 @@ func (r *ConmonOCIRuntime) moveConmonToCgroupAndSignal(_ *Container, _ *exec.Cmd
 	return nil
 }
 
+// moveToConmonCgroup moves the process with the given PID to the cgroup of
+// the conmon processes of the container
+func (r *ConmonOCIRuntime) moveToConmonCgroup(_ *Container, _ int) {
+	// No equivalent to cgroup on FreeBSD
+}
+
 func moveToRuntimeCgroup() error {
 	return errors.New("moveToRuntimeCgroup not supported on freebsd")
 }
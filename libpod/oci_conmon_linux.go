@@ -172,6 +172,17 @@ func createUnitName(prefix string, name string) string {
 // moveConmonToCgroupAndSignal gets a container's cgroupParent and moves the conmon process to that cgroup
 // it then signals for conmon to start by sending nonce data down the start fd
 func (r *ConmonOCIRuntime) moveConmonToCgroupAndSignal(ctr *Container, cmd *exec.Cmd, startFd *os.File) error {
+	r.moveToConmonCgroup(ctr, cmd.Process.Pid)
+
+	/* We set the cgroup, now the child can start creating children */
+	return writeConmonPipeData(startFd)
+}
+
+// moveToConmonCgroup moves the process with the given PID to the cgroup of
+// the conmon processes of the container.  Besides conmon, that is used for the
+// helper processes conmon writes the logs to, which must not be stopped
+// along with the cgroup of the Podman process starting them.
+func (r *ConmonOCIRuntime) moveToConmonCgroup(ctr *Container, pid int) {
 	mustCreateCgroup := !ctr.config.NoCgroups
 
 	// If cgroup creation is disabled - just signal.
@@ -211,23 +222,20 @@ func (r *ConmonOCIRuntime) moveConmonToCgroupAndSignal(ctr *Container, cmd *exec
 			}
 
 			logrus.Infof("Running conmon under slice %s and unitName %s", realCgroupParent, unitName)
-			if err := systemd.RunUnderSystemdScope(cmd.Process.Pid, realCgroupParent, unitName); err != nil {
+			if err := systemd.RunUnderSystemdScope(pid, realCgroupParent, unitName); err != nil {
 				logrus.StandardLogger().Logf(logLevel, "Failed to add conmon to systemd sandbox cgroup: %v", err)
 			}
 		} else {
 			control, err := cgroups.New(cgroupPath, &cgroupResources)
 			if err != nil {
 				logrus.StandardLogger().Logf(logLevel, "Failed to add conmon to cgroupfs sandbox cgroup: %v", err)
-			} else if err := control.AddPid(cmd.Process.Pid); err != nil {
+			} else if err := control.AddPid(pid); err != nil {
 				// we need to remove this defer and delete the cgroup once conmon exits
 				// maybe need a conmon monitor?
 				logrus.StandardLogger().Logf(logLevel, "Failed to add conmon to cgroupfs sandbox cgroup: %v", err)
 			}
 		}
 	}
-
-	/* We set the cgroup, now the child can start creating children */
-	return writeConmonPipeData(startFd)
 }
 
 // GetLimits converts spec resource limits to cgroup consumable limits
@@ -146,6 +146,18 @@ func (r *MissingRuntime) ExecContainerDetached(_ *Container, _ string, _ *ExecOp
 	return -1, r.printError()
 }
 
+// ExecAttach is not available as the runtime is missing
+func (r *MissingRuntime) ExecAttach(_ *Container, _ string, _ int64, _ *define.AttachStreams, _ *string) error {
+	return r.printError()
+}
+
+// ExecHTTPAttach is not available as the runtime is missing
+func (r *MissingRuntime) ExecHTTPAttach(_ *Container, _ string, _ int64, _ *http.Request, _ http.ResponseWriter,
+	_ *HTTPAttachStreams, _ *string, _ bool, _ <-chan bool, _ chan<- bool,
+) error {
+	return r.printError()
+}
+
 // ExecAttachResize is not available as the runtime is missing.
 func (r *MissingRuntime) ExecAttachResize(_ *Container, _ string, _ resize.TerminalSize) error {
 	return r.printError()
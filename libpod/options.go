@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"maps"
@@ -396,6 +397,52 @@ func WithRenumber() RuntimeOption {
 	}
 }
 
+// WithRefreshHook adds a function which is run when the runtime refreshes its
+// state after a reboot, for example to restart processes which do not survive
+// a reboot.  Errors of the hook are logged.
+func WithRefreshHook(hook func(*Runtime) error) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.refreshHooks = append(rt.refreshHooks, hook)
+
+		return nil
+	}
+}
+
+// WithPodReadinessHook adds a function which is run when a pod becomes ready
+// because the readiness checks of all of its containers passed, or not ready
+// because one of them failed or its container was restarted.  The hook must
+// not lock the containers of the pod.  Errors of the hook are logged.
+func WithPodReadinessHook(hook func(r *Runtime, pod *Pod, ready bool) error) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.podReadinessHooks = append(rt.podReadinessHooks, hook)
+
+		return nil
+	}
+}
+
+// WithHealthCheckRollbackHook adds a function which is run when a container
+// with the rollback health check on-failure action turns unhealthy.  The
+// hook is run without holding the lock of the container.
+func WithHealthCheckRollbackHook(hook func(ctx context.Context, r *Runtime, c *Container) error) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.healthCheckRollbackHooks = append(rt.healthCheckRollbackHooks, hook)
+
+		return nil
+	}
+}
+
 // WithEventsLogger sets the events backend to use.
 // Currently supported values are "file" for file backend and "journald" for
 // journald backend.
@@ -474,6 +521,35 @@ func WithMaxLogSize(limit int64) CtrCreateOption {
 	}
 }
 
+// WithLogMaxFiles sets the maximum number of log files kept by the json-file
+// log driver when rotating the log.
+func WithLogMaxFiles(maxFiles int) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if maxFiles < 1 {
+			return fmt.Errorf("maximum number of log files must be at least 1: %w", define.ErrInvalidArg)
+		}
+		ctr.config.LogMaxFiles = maxFiles
+
+		return nil
+	}
+}
+
+// WithLogCompress sets whether the json-file log driver compresses the
+// rotated log files.
+func WithLogCompress(compress bool) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.LogCompress = compress
+
+		return nil
+	}
+}
+
 // WithShmDir sets the directory that should be mounted on /dev/shm.
 func WithShmDir(dir string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1472,6 +1548,18 @@ func WithHealthCheckOnFailureAction(action define.HealthCheckOnFailureAction) Ct
 	}
 }
 
+// WithHealthCheckOnFailureHook sets the hook run by the exec on-failure action
+// of the health-check config
+func WithHealthCheckOnFailureHook(hook string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.HealthCheckOnFailureHook = hook
+		return nil
+	}
+}
+
 // WithPreserveFDs forwards from the process running Libpod into the container
 // the given number of extra FDs (starting after the standard streams) to the created container
 func WithPreserveFDs(fd uint) CtrCreateOption {
@@ -1901,6 +1989,36 @@ func WithStartupHealthcheck(startupHC *define.StartupHealthCheck) CtrCreateOptio
 	}
 }
 
+// WithReadinessCheck sets a readiness check for the container.
+func WithReadinessCheck(readinessCheck *define.ReadinessCheck) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.ReadinessCheckConfig = new(define.ReadinessCheck)
+		if err := JSONDeepCopy(readinessCheck, ctr.config.ReadinessCheckConfig); err != nil {
+			return fmt.Errorf("error copying readiness check into container: %w", err)
+		}
+		return nil
+	}
+}
+
+// WithPressureMonitor sets the monitoring of the memory events and resource
+// pressure of the container.
+func WithPressureMonitor(monitor *define.PressureMonitor) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if monitor == nil {
+			return nil
+		}
+		ctr.config.PressureMonitorConfig = new(define.PressureMonitor)
+		*ctr.config.PressureMonitorConfig = *monitor
+		return nil
+	}
+}
+
 // Pod Creation Options
 
 // WithPodCreateCommand adds the full command plus arguments of the current
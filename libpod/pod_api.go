@@ -9,6 +9,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
@@ -591,6 +592,71 @@ func containerStatusFromContainers(allCtrs []*Container) (map[string]define.Cont
 	return status, nil
 }
 
+// WaitForReady waits for the readiness checks of all containers of the pod to
+// pass.  Init containers are ignored.  An error is returned if the pod did not
+// become ready within timeout.  A timeout of 0 waits until ctx is done.
+func (p *Pod) WaitForReady(ctx context.Context, timeout time.Duration) error {
+	p.lock.Lock()
+	if !p.valid {
+		p.lock.Unlock()
+		return define.ErrPodRemoved
+	}
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	p.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("pod %s did not become ready within %s: %w", p.Name(), timeout, context.DeadlineExceeded))
+		defer cancel()
+	}
+	for _, ctr := range allCtrs {
+		if len(ctr.config.InitContainerType) > 0 {
+			continue
+		}
+		if err := ctr.WaitForReady(ctx, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadinessChecksPassed returns whether the readiness checks of all containers
+// of the pod passed.  Containers without a readiness check are ignored.
+func (p *Pod) ReadinessChecksPassed() (bool, error) {
+	p.lock.Lock()
+	if !p.valid {
+		p.lock.Unlock()
+		return false, define.ErrPodRemoved
+	}
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	p.lock.Unlock()
+	if err != nil {
+		return false, err
+	}
+	return readinessChecksPassed(allCtrs, "")
+}
+
+// readinessChecksPassed returns whether the readiness checks of all containers
+// except init containers and the one with the skipped ID passed.
+func readinessChecksPassed(ctrs []*Container, skip string) (bool, error) {
+	for _, ctr := range ctrs {
+		if len(ctr.config.InitContainerType) > 0 || ctr.ID() == skip {
+			continue
+		}
+		readiness, err := ctr.ReadinessStatus()
+		if err != nil {
+			return false, err
+		}
+		if readiness == define.ReadinessCheckNotReady {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // Inspect returns a PodInspect struct to describe the pod.
 func (p *Pod) Inspect() (*define.InspectPodData, error) {
 	p.lock.Lock()
@@ -605,6 +671,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 	}
 	ctrs := make([]define.InspectPodContainerInfo, 0, len(containers))
 	ctrStatuses := make(map[string]define.ContainerStatus, len(containers))
+	podReady := len(containers) > 0
 	for _, c := range containers {
 		containerStatus := "unknown"
 		// Ignoring possible errors here because we don't want this to be
@@ -613,14 +680,22 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		if err == nil {
 			containerStatus = containerState.String()
 		}
+		readiness, err := c.ReadinessStatus()
+		if err != nil {
+			logrus.Debugf("Getting readiness of container %s: %v", c.ID(), err)
+		}
 		ctrs = append(ctrs, define.InspectPodContainerInfo{
-			ID:    c.ID(),
-			Name:  c.Name(),
-			State: containerStatus,
+			ID:        c.ID(),
+			Name:      c.Name(),
+			State:     containerStatus,
+			Readiness: readiness,
 		})
 		// Do not add init containers fdr status
 		if len(c.config.InitContainerType) < 1 {
 			ctrStatuses[c.ID()] = c.state.State
+			if c.state.State != define.ContainerStateRunning || readiness == define.ReadinessCheckNotReady {
+				podReady = false
+			}
 		}
 	}
 	slices.SortFunc(ctrs, func(a, b define.InspectPodContainerInfo) int { return strings.Compare(a.ID, b.ID) })
@@ -722,6 +797,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		CreateCommand:       p.config.CreateCommand,
 		ExitPolicy:          string(p.config.ExitPolicy),
 		State:               podState,
+		Ready:               podReady,
 		Hostname:            p.config.Hostname,
 		Labels:              p.Labels(),
 		CreateCgroup:        p.config.UsePodCgroup,
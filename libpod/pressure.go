@@ -0,0 +1,125 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/libpod/events"
+)
+
+// cgroupPressure is a sample of the memory events and resource pressure of
+// the cgroup of a container
+type cgroupPressure struct {
+	// memoryHigh and memoryMax are the numbers of times the memory usage
+	// of the cgroup went above its high boundary and reached its limit
+	memoryHigh uint64
+	memoryMax  uint64
+	// avg10 is the share of time, in percent, in which some tasks of the
+	// cgroup were stalled on a resource over the last 10 seconds
+	avg10 map[string]float64
+}
+
+// warnPressureTimer warns that the pressure monitoring of the container is
+// configured but its checks are not run, as no systemd timer can run them.
+func (c *Container) warnPressureTimer() {
+	if c.config.PressureMonitorConfig == nil || c.config.PressureMonitorConfig.Interval == 0 {
+		return
+	}
+	if err := pressureTimerUnavailable(); err != nil {
+		logrus.Warnf("The pressure monitoring of container %s is not run periodically: %v", c.ID(), err)
+	}
+}
+
+// PressureCheck checks the memory events and resource pressure of the
+// container and writes events for the changes since the last check.
+func (r *Runtime) PressureCheck(ctx context.Context, name string) error {
+	ctr, err := r.LookupContainer(name)
+	if err != nil {
+		return fmt.Errorf("unable to look up %s to check its resource pressure: %w", name, err)
+	}
+	return ctr.runPressureCheck(ctx)
+}
+
+// runPressureCheck samples the memory events and resource pressure of the
+// container and records them in the state of the container.
+func (c *Container) runPressureCheck(_ context.Context) error {
+	config := c.config.PressureMonitorConfig
+	if config == nil {
+		return fmt.Errorf("container %s has no pressure monitoring: %w", c.ID(), define.ErrInvalidArg)
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+	if c.state.State != define.ContainerStateRunning {
+		return fmt.Errorf("container %s is not running: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+
+	sample, err := c.readCgroupPressure(config.Thresholds())
+	if err != nil {
+		return err
+	}
+	c.updatePressure(sample)
+	return c.save()
+}
+
+// updatePressure writes events for the memory events and the threshold
+// crossings since the last check and records the sample in the state.
+// NOTE: The caller must lock and sync the container.
+func (c *Container) updatePressure(sample *cgroupPressure) {
+	if sample.memoryHigh > c.state.MemoryHighEvents {
+		c.newContainerPressureEvent(events.MemoryHigh, map[string]string{
+			"count": strconv.FormatUint(sample.memoryHigh-c.state.MemoryHighEvents, 10),
+		})
+	}
+	if sample.memoryMax > c.state.MemoryMaxEvents {
+		c.newContainerPressureEvent(events.MemoryMax, map[string]string{
+			"count": strconv.FormatUint(sample.memoryMax-c.state.MemoryMaxEvents, 10),
+		})
+	}
+	c.state.MemoryHighEvents = sample.memoryHigh
+	c.state.MemoryMaxEvents = sample.memoryMax
+
+	var exceeded []string
+	for _, resource := range []string{define.PressureResourceCPU, define.PressureResourceMemory, define.PressureResourceIO} {
+		threshold, ok := c.config.PressureMonitorConfig.Thresholds()[resource]
+		if !ok {
+			continue
+		}
+		avg10, ok := sample.avg10[resource]
+		if !ok {
+			continue
+		}
+		above := avg10 >= threshold
+		if above {
+			exceeded = append(exceeded, resource)
+		}
+		// Only crossings of the threshold are reported, not every
+		// sample above it.
+		if above == slices.Contains(c.state.PressureExceeded, resource) {
+			continue
+		}
+		state := "above"
+		if !above {
+			state = "below"
+		}
+		logrus.Debugf("Pressure of %s of container %s is %s its threshold: %.2f%%", resource, c.ID(), state, avg10)
+		c.newContainerPressureEvent(events.PressureThreshold, map[string]string{
+			"resource":  resource,
+			"avg10":     strconv.FormatFloat(avg10, 'f', 2, 64),
+			"threshold": strconv.FormatFloat(threshold, 'f', -1, 64),
+			"state":     state,
+		})
+	}
+	c.state.PressureExceeded = exceeded
+}
@@ -0,0 +1,14 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"go.podman.io/podman/v6/libpod/define"
+)
+
+// readCgroupPressure is not supported on FreeBSD, which has no cgroups
+func (c *Container) readCgroupPressure(_ map[string]float64) (*cgroupPressure, error) {
+	return nil, fmt.Errorf("pressure monitoring: %w", define.ErrOSNotSupported)
+}
@@ -0,0 +1,99 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.podman.io/podman/v6/libpod/define"
+)
+
+// readCgroupPressure reads the memory events and the pressure of the given
+// resources from the cgroup v2 files of the container.
+// NOTE: The caller must lock and sync the container.
+func (c *Container) readCgroupPressure(thresholds map[string]float64) (*cgroupPressure, error) {
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join("/sys/fs/cgroup", cgroupPath)
+
+	sample := &cgroupPressure{avg10: make(map[string]float64)}
+	content, err := os.ReadFile(filepath.Join(dir, "memory.events"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("pressure monitoring of container %s requires cgroup v2 with the memory controller: %w", c.ID(), define.ErrOSNotSupported)
+		}
+		return nil, err
+	}
+	events, err := parseMemoryEvents(content)
+	if err != nil {
+		return nil, err
+	}
+	sample.memoryHigh = events["high"]
+	sample.memoryMax = events["max"]
+
+	for resource := range thresholds {
+		content, err := os.ReadFile(filepath.Join(dir, resource+".pressure"))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("pressure monitoring of container %s requires a kernel with pressure stall information: %w", c.ID(), define.ErrOSNotSupported)
+			}
+			return nil, err
+		}
+		avg10, err := parsePressureAvg10(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s pressure of container %s: %w", resource, c.ID(), err)
+		}
+		sample.avg10[resource] = avg10
+	}
+	return sample, nil
+}
+
+// parseMemoryEvents parses the counters of a memory.events file, e.g.
+// "high 3\nmax 1\noom 0\n".
+func parseMemoryEvents(content []byte) (map[string]uint64, error) {
+	counters := make(map[string]uint64)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory event %q: %w", scanner.Text(), err)
+		}
+		counters[key] = count
+	}
+	return counters, scanner.Err()
+}
+
+// parsePressureAvg10 returns the avg10 value of the "some" line of a pressure
+// stall information file, e.g.
+// "some avg10=1.53 avg60=0.87 avg300=0.11 total=58922\n".
+func parsePressureAvg10(content []byte) (float64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "some" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if value, ok := strings.CutPrefix(field, "avg10="); ok {
+				return strconv.ParseFloat(value, 64)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("no avg10 value of some tasks found")
+}
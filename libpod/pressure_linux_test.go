@@ -0,0 +1,95 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/libpod/events"
+)
+
+// recordingEventer records the events written to it
+type recordingEventer struct {
+	events []events.Event
+}
+
+func (r *recordingEventer) Write(event events.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingEventer) Read(_ context.Context, _ events.ReadOptions) error {
+	return nil
+}
+
+func (r *recordingEventer) String() string {
+	return events.Null.String()
+}
+
+func TestParseMemoryEvents(t *testing.T) {
+	counters, err := parseMemoryEvents([]byte("low 0\nhigh 12\nmax 3\noom 1\noom_kill 1\noom_group_kill 0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), counters["high"])
+	assert.Equal(t, uint64(3), counters["max"])
+	assert.Equal(t, uint64(1), counters["oom_kill"])
+
+	_, err = parseMemoryEvents([]byte("high lots\n"))
+	assert.Error(t, err)
+}
+
+func TestParsePressureAvg10(t *testing.T) {
+	avg10, err := parsePressureAvg10([]byte("some avg10=12.34 avg60=5.00 avg300=1.00 total=123456\nfull avg10=50.00 avg60=0.00 avg300=0.00 total=0\n"))
+	require.NoError(t, err)
+	assert.InDelta(t, 12.34, avg10, 0.001)
+
+	_, err = parsePressureAvg10([]byte("full avg10=1.00 avg60=0.00 avg300=0.00 total=0\n"))
+	assert.Error(t, err)
+	_, err = parsePressureAvg10([]byte("some avg10=nope avg60=0.00 avg300=0.00 total=0\n"))
+	assert.Error(t, err)
+}
+
+func TestUpdatePressure(t *testing.T) {
+	eventer := &recordingEventer{}
+	ctr := &Container{
+		config: &ContainerConfig{
+			ContainerRootFSConfig: ContainerRootFSConfig{RootfsImageName: "quay.io/example"},
+			ContainerMiscConfig: ContainerMiscConfig{
+				PressureMonitorConfig: &define.PressureMonitor{CPU: 50, IO: 20},
+			},
+		},
+		state:   &ContainerState{},
+		runtime: &Runtime{eventer: eventer},
+	}
+	ctr.config.ID = "abc"
+	ctr.config.Labels = map[string]string{"app": "web"}
+
+	// Memory events are reported by their increase, the pressure only
+	// when it crosses the threshold.
+	ctr.updatePressure(&cgroupPressure{memoryHigh: 2, avg10: map[string]float64{"cpu": 75, "io": 5}})
+	require.Len(t, eventer.events, 2)
+	assert.Equal(t, events.MemoryHigh, eventer.events[0].Status)
+	assert.Equal(t, "2", eventer.events[0].Attributes["count"])
+	assert.Equal(t, "web", eventer.events[0].Attributes["app"])
+	assert.Equal(t, events.PressureThreshold, eventer.events[1].Status)
+	assert.Equal(t, map[string]string{"app": "web", "resource": "cpu", "avg10": "75.00", "threshold": "50", "state": "above"}, eventer.events[1].Attributes)
+	assert.Equal(t, []string{"cpu"}, ctr.state.PressureExceeded)
+
+	eventer.events = nil
+	ctr.updatePressure(&cgroupPressure{memoryHigh: 2, memoryMax: 1, avg10: map[string]float64{"cpu": 80, "io": 5}})
+	require.Len(t, eventer.events, 1)
+	assert.Equal(t, events.MemoryMax, eventer.events[0].Status)
+	assert.Equal(t, "1", eventer.events[0].Attributes["count"])
+
+	eventer.events = nil
+	ctr.updatePressure(&cgroupPressure{memoryHigh: 2, memoryMax: 1, avg10: map[string]float64{"cpu": 10, "io": 25}})
+	require.Len(t, eventer.events, 2)
+	assert.Equal(t, "cpu", eventer.events[0].Attributes["resource"])
+	assert.Equal(t, "below", eventer.events[0].Attributes["state"])
+	assert.Equal(t, "io", eventer.events[1].Attributes["resource"])
+	assert.Equal(t, "above", eventer.events[1].Attributes["state"])
+	assert.Equal(t, []string{"io"}, ctr.state.PressureExceeded)
+}
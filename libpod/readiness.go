@@ -0,0 +1,236 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/libpod/define"
+)
+
+// probePollInterval is the interval in which the state of a container is
+// checked while waiting for it to become ready or healthy and its checks are
+// run by a systemd timer.
+const probePollInterval = 500 * time.Millisecond
+
+// ReadinessCheck runs the readiness check of the container and returns the
+// resulting readiness of the container.
+func (r *Runtime) ReadinessCheck(ctx context.Context, name string) (string, error) {
+	ctr, err := r.LookupContainer(name)
+	if err != nil {
+		return "", fmt.Errorf("unable to look up %s to perform a readiness check: %w", name, err)
+	}
+	return ctr.runReadinessCheck(ctx)
+}
+
+// runReadinessCheck executes the readiness check of the container and records
+// the result in the state of the container.
+func (c *Container) runReadinessCheck(_ context.Context) (string, error) {
+	config := c.config.ReadinessCheckConfig
+	if config == nil {
+		return "", fmt.Errorf("container %s has no defined readiness check", c.ID())
+	}
+	probe, err := define.ParseHealthCheckProbe(config.Test)
+	if err != nil {
+		return "", err
+	}
+	command := healthCheckCommand(config.Test)
+	if probe == nil && command == nil {
+		return "", fmt.Errorf("container %s has no defined readiness check", c.ID())
+	}
+
+	state, err := c.State()
+	if err != nil {
+		return "", err
+	}
+	if state != define.ContainerStateRunning {
+		return "", fmt.Errorf("container %s is not running: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+
+	startedTime, err := c.StartedTime()
+	if err != nil {
+		return "", err
+	}
+	if config.StartPeriod > 0 && time.Now().Before(startedTime.Add(config.StartPeriod)) {
+		// Like K8s, do not probe before the initial delay passed.
+		logrus.Debugf("Readiness check for %s not run in start-period", c.ID())
+		return c.ReadinessStatus()
+	}
+
+	output := &bytes.Buffer{}
+	streams := &define.AttachStreams{
+		OutputStream: output,
+		ErrorStream:  output,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	var (
+		exitCode int
+		execErr  error
+	)
+	if probe != nil {
+		exitCode, execErr = c.runHealthCheckProbe(probe, config.Timeout, output)
+	} else {
+		logrus.Debugf("executing readiness check command %s for %s", strings.Join(command, " "), c.ID())
+		exitCode, execErr = c.healthCheckExec(&ExecConfig{Command: command}, config.Timeout, streams)
+	}
+	passed := execErr == nil && exitCode == 0
+	if !passed {
+		logrus.Debugf("Readiness check for container %s failed (exit code %d, error %v): %s", c.ID(), exitCode, execErr, output.String())
+	}
+
+	status, changed, err := c.recordReadiness(passed)
+	if err != nil {
+		return "", err
+	}
+	if changed {
+		// The container must not be locked, the readiness of the
+		// other containers of the pod is looked at.
+		c.readinessChanged(status == define.ReadinessCheckReady)
+	}
+	return status, nil
+}
+
+// recordReadiness locks the container and records the result of a readiness
+// check.  It returns the resulting readiness and whether it changed.
+func (c *Container) recordReadiness(passed bool) (string, bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return "", false, err
+		}
+	}
+
+	wasReady := c.state.Ready
+	if err := c.updateReadiness(passed); err != nil {
+		return "", false, err
+	}
+	return c.readinessStatus(), c.state.Ready != wasReady, nil
+}
+
+// readinessChanged runs the pod readiness hooks of the runtime if the
+// readiness of the pod of the container changed along with the readiness of
+// the container.  Errors of the hooks are logged.
+// NOTE: The container must not be locked if it became ready.
+func (c *Container) readinessChanged(ready bool) {
+	if c.config.Pod == "" || len(c.runtime.podReadinessHooks) == 0 {
+		return
+	}
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		logrus.Errorf("Looking up pod of container %s: %v", c.ID(), err)
+		return
+	}
+	if ready {
+		// The pod is ready once all of its other containers are.
+		ctrs, err := c.runtime.state.PodContainers(pod)
+		if err != nil {
+			logrus.Errorf("Looking up containers of pod %s: %v", pod.ID(), err)
+			return
+		}
+		if ready, err = readinessChecksPassed(ctrs, c.ID()); err != nil {
+			logrus.Errorf("Getting readiness of pod %s: %v", pod.ID(), err)
+			return
+		}
+		if !ready {
+			return
+		}
+	}
+	for _, hook := range c.runtime.podReadinessHooks {
+		if err := hook(c.runtime, pod, ready); err != nil {
+			logrus.Errorf("Running pod readiness hook of pod %s: %v", pod.Name(), err)
+		}
+	}
+}
+
+// updateReadiness records the result of a readiness check.  The container is
+// marked as ready after the configured number of consecutive successes and as
+// not ready after the configured number of consecutive failures.
+// NOTE: The caller must lock and sync the container.
+func (c *Container) updateReadiness(passed bool) error {
+	config := c.config.ReadinessCheckConfig
+	if passed {
+		c.state.ReadinessFailureCount = 0
+		c.state.ReadinessSuccessCount++
+		if !c.state.Ready && c.state.ReadinessSuccessCount >= max(config.Successes, 1) {
+			logrus.Debugf("Container %s is ready", c.ID())
+			c.state.Ready = true
+		}
+	} else {
+		c.state.ReadinessSuccessCount = 0
+		c.state.ReadinessFailureCount++
+		if c.state.Ready && c.state.ReadinessFailureCount >= max(config.Retries, 1) {
+			logrus.Debugf("Container %s is not ready anymore", c.ID())
+			c.state.Ready = false
+		}
+	}
+	return c.save()
+}
+
+// WaitForReady waits for the readiness check of the container to pass.  It
+// returns immediately for containers without a readiness check.  An error is
+// returned if the container stops before it became ready, or if it did not
+// become ready within timeout.  A timeout of 0 waits until ctx is done.
+func (c *Container) WaitForReady(ctx context.Context, timeout time.Duration) error {
+	if c.config.ReadinessCheckConfig == nil {
+		return nil
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("container %s did not become ready within %s: %w", c.ID(), timeout, context.DeadlineExceeded))
+		defer cancel()
+	}
+	for {
+		state, err := c.State()
+		if err != nil {
+			return err
+		}
+		if state != define.ContainerStateRunning {
+			return fmt.Errorf("container %s is %s and will not become ready: %w", c.ID(), state, define.ErrCtrStateInvalid)
+		}
+
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			c.lock.Unlock()
+			return err
+		}
+		status := c.readinessStatus()
+		unitName := c.state.ReadinessUnitName
+		c.lock.Unlock()
+		if status == define.ReadinessCheckReady {
+			return nil
+		}
+
+		interval := probePollInterval
+		if unitName == "" {
+			// There is no timer running the readiness check,
+			// so run it ourselves.
+			status, err = c.runReadinessCheck(ctx)
+			if err != nil {
+				return err
+			}
+			if status == define.ReadinessCheckReady {
+				return nil
+			}
+			interval = c.config.ReadinessCheckConfig.Interval
+			if interval <= 0 {
+				interval = probePollInterval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if cause := context.Cause(ctx); cause != ctx.Err() {
+				return cause
+			}
+			return fmt.Errorf("waiting for container %s to become ready: %w", c.ID(), ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// PlayOptions are the options of Play.
+type PlayOptions struct {
+	// Speed is the playback speed, 1 plays the recording in real time.
+	// If it is zero, the output is written without pauses.
+	Speed float64
+	// IdleTimeLimit limits the pauses between events, if set.
+	IdleTimeLimit time.Duration
+}
+
+// Play writes the output events of the recording to w, pausing between them
+// for the time that passed between the events in the session.
+func Play(ctx context.Context, r *Reader, w io.Writer, options PlayOptions) error {
+	var last time.Duration
+	for {
+		event, err := r.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if event.Type != Output {
+			continue
+		}
+
+		pause := max(event.Time-last, 0)
+		last = event.Time
+		if options.IdleTimeLimit > 0 {
+			pause = min(pause, options.IdleTimeLimit)
+		}
+		if options.Speed > 0 && pause > 0 {
+			timer := time.NewTimer(time.Duration(float64(pause) / options.Speed))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if _, err := io.WriteString(w, event.Data); err != nil {
+			return err
+		}
+	}
+}
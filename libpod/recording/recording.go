@@ -0,0 +1,328 @@
+// Package recording records the streams of exec and attach sessions in the
+// asciicast v2 format, and plays them back.
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the asciicast version of the recordings.
+const Version = 2
+
+// EventType is the type of an event of a recording.
+type EventType string
+
+const (
+	// Output is data written to the terminal.
+	Output EventType = "o"
+	// Input is data read from the terminal.
+	Input EventType = "i"
+	// Resize is a resize of the terminal, its data is COLUMNSxROWS.
+	Resize EventType = "r"
+)
+
+// Header is the first line of a recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Event is an event of a recording.
+type Event struct {
+	// Time is the time of the event, relative to the timestamp of the
+	// recording.
+	Time time.Duration
+	Type EventType
+	Data string
+}
+
+// MarshalJSON encodes the event as a [time, type, data] array.
+func (e Event) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	seconds := json.Number(strconv.FormatFloat(e.Time.Seconds(), 'f', 6, 64))
+	if err := enc.Encode([]any{seconds, e.Type, e.Data}); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON decodes an event from a [time, type, data] array.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("invalid event %s: expected 3 fields, got %d", data, len(fields))
+	}
+	var seconds float64
+	if err := json.Unmarshal(fields[0], &seconds); err != nil {
+		return fmt.Errorf("invalid event time %s: %w", fields[0], err)
+	}
+	if err := json.Unmarshal(fields[1], &e.Type); err != nil {
+		return fmt.Errorf("invalid event type %s: %w", fields[1], err)
+	}
+	if err := json.Unmarshal(fields[2], &e.Data); err != nil {
+		return fmt.Errorf("invalid event data %s: %w", fields[2], err)
+	}
+	e.Time = time.Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// Recorder appends the events of a session to a recording.
+// It is safe for concurrent use.
+type Recorder struct {
+	lock  sync.Mutex
+	file  *os.File
+	path  string
+	start time.Time
+	// pending holds the trailing bytes of an incomplete UTF-8 sequence of
+	// each event type, they are prepended to the next event of that type.
+	pending map[EventType][]byte
+	err     error
+}
+
+// Create creates the recording at path, or opens it to append the events of
+// another session if it already exists. The header is only written to new
+// recordings, its version and timestamp are set by Create.
+func Create(path string, header Header) (*Recorder, error) {
+	start := time.Unix(time.Now().Unix(), 0)
+	existing, err := readHeader(path)
+	switch {
+	case err == nil:
+		start = time.Unix(existing.Timestamp, 0)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{
+		file:    file,
+		path:    path,
+		start:   start,
+		pending: make(map[EventType][]byte),
+	}
+	if existing != nil {
+		// Sessions appended to a recording may have another terminal size.
+		if header.Width > 0 && header.Height > 0 && (header.Width != existing.Width || header.Height != existing.Height) {
+			r.Resize(header.Width, header.Height)
+		}
+		return r, nil
+	}
+
+	header.Version = Version
+	header.Timestamp = start.Unix()
+	if header.Width == 0 || header.Height == 0 {
+		header.Width, header.Height = 80, 24
+	}
+	data, err := json.Marshal(header)
+	if err == nil {
+		_, err = file.Write(append(data, '\n'))
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("writing header of recording %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// readHeader reads the header of an existing recording.
+func readHeader(path string) (*Header, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader, err := NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading recording %s: %w", path, err)
+	}
+	return reader.Header(), nil
+}
+
+// Path returns the path of the recording.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Record adds an event with the given data to the recording.
+// Errors are not returned, the recording stops at the first error and Close
+// returns it.
+func (r *Recorder) Record(eventType EventType, data []byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if pending := r.pending[eventType]; len(pending) > 0 {
+		data = append(pending, data...)
+		r.pending[eventType] = nil
+	}
+	if n := incompleteRuneLen(data); n > 0 {
+		r.pending[eventType] = bytes.Clone(data[len(data)-n:])
+		data = data[:len(data)-n]
+	}
+	r.write(eventType, data)
+}
+
+// Resize adds a resize event to the recording.
+func (r *Recorder) Resize(width, height uint16) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.write(Resize, fmt.Appendf(nil, "%dx%d", width, height))
+}
+
+// write writes an event to the recording.
+// MUST BE CALLED with the lock held.
+func (r *Recorder) write(eventType EventType, data []byte) {
+	if len(data) == 0 || r.err != nil || r.file == nil {
+		return
+	}
+	line, err := Event{Time: time.Since(r.start), Type: eventType, Data: string(data)}.MarshalJSON()
+	if err == nil {
+		// Write the event at once so events appended to the recording
+		// by other processes are not interleaved.
+		_, err = r.file.Write(append(line, '\n'))
+	}
+	if err != nil {
+		r.err = fmt.Errorf("writing recording %s: %w", r.path, err)
+	}
+}
+
+// Close writes pending data and closes the recording.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.file == nil {
+		return r.err
+	}
+	for _, eventType := range []EventType{Output, Input} {
+		r.write(eventType, r.pending[eventType])
+		r.pending[eventType] = nil
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	r.file = nil
+	return r.err
+}
+
+// Writer returns a writer which records all data written to w as events of
+// the given type.
+func (r *Recorder) Writer(eventType EventType, w io.Writer) io.Writer {
+	return &recordingWriter{recorder: r, eventType: eventType, w: w}
+}
+
+// Reader returns a reader which records all data read from rd as events of
+// the given type.
+func (r *Recorder) Reader(eventType EventType, rd io.Reader) io.Reader {
+	return &recordingReader{recorder: r, eventType: eventType, r: rd}
+}
+
+type recordingWriter struct {
+	recorder  *Recorder
+	eventType EventType
+	w         io.Writer
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.recorder.Record(w.eventType, p[:n])
+	return n, err
+}
+
+type recordingReader struct {
+	recorder  *Recorder
+	eventType EventType
+	r         io.Reader
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.recorder.Record(r.eventType, p[:n])
+	return n, err
+}
+
+// incompleteRuneLen returns the length of an incomplete UTF-8 sequence at
+// the end of data.
+func incompleteRuneLen(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if utf8.FullRune(data[i:]) {
+				return 0
+			}
+			return len(data) - i
+		}
+	}
+	return 0
+}
+
+// Reader reads the events of a recording.
+type Reader struct {
+	reader *bufio.Reader
+	header Header
+}
+
+// NewReader reads the header of the recording from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{reader: bufio.NewReader(r)}
+	line, err := reader.reader.ReadBytes('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || len(line) == 0) {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("recording is empty")
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(line, &reader.header); err != nil {
+		return nil, fmt.Errorf("invalid recording header: %w", err)
+	}
+	if reader.header.Version != Version {
+		return nil, fmt.Errorf("unsupported recording version %d, only version %d is supported", reader.header.Version, Version)
+	}
+	return reader, nil
+}
+
+// Header returns the header of the recording.
+func (r *Reader) Header() *Header {
+	return &r.header
+}
+
+// Next returns the next event of the recording, or io.EOF after the last
+// event.
+func (r *Reader) Next() (*Event, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || len(line) == 0) {
+			return nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		event := new(Event)
+		if err := json.Unmarshal(line, event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
@@ -0,0 +1,215 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRecording(t *testing.T, path string) (*Header, []Event) {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	reader, err := NewReader(file)
+	require.NoError(t, err)
+	var events []Event
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		events = append(events, *event)
+	}
+	return reader.Header(), events
+}
+
+func eventData(events []Event) []string {
+	data := make([]string, 0, len(events))
+	for _, e := range events {
+		data = append(data, string(e.Type)+":"+e.Data)
+	}
+	return data
+}
+
+func TestEventJSON(t *testing.T) {
+	event := Event{Time: 1500 * time.Millisecond, Type: Output, Data: "<a> & \"b\"\r\n"}
+	data, err := event.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `[1.500000,"o","<a> & \"b\"\r\n"]`, string(data))
+
+	var decoded Event
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, event, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`[1.5,"o"]`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`["1.5","o","x"]`), &decoded))
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.cast")
+	recorder, err := Create(path, Header{Width: 100, Height: 30, Command: "sh"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	w := recorder.Writer(Output, &out)
+	_, err = w.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	in := recorder.Reader(Input, strings.NewReader("ls\n"))
+	_, err = io.ReadAll(in)
+	require.NoError(t, err)
+
+	recorder.Resize(120, 40)
+	require.NoError(t, recorder.Close())
+	assert.Equal(t, "hello\n", out.String())
+
+	header, events := readRecording(t, path)
+	assert.Equal(t, Version, header.Version)
+	assert.Equal(t, uint16(100), header.Width)
+	assert.Equal(t, uint16(30), header.Height)
+	assert.Equal(t, "sh", header.Command)
+	assert.NotZero(t, header.Timestamp)
+	assert.Equal(t, []string{"o:hello\n", "i:ls\n", "r:120x40"}, eventData(events))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestRecorderDefaultSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.cast")
+	recorder, err := Create(path, Header{})
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	header, events := readRecording(t, path)
+	assert.Equal(t, uint16(80), header.Width)
+	assert.Equal(t, uint16(24), header.Height)
+	assert.Empty(t, events)
+}
+
+func TestRecorderAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.cast")
+	recorder, err := Create(path, Header{Width: 80, Height: 24})
+	require.NoError(t, err)
+	recorder.Record(Output, []byte("first"))
+	require.NoError(t, recorder.Close())
+
+	recorder, err = Create(path, Header{Width: 100, Height: 50})
+	require.NoError(t, err)
+	recorder.Record(Output, []byte("second"))
+	require.NoError(t, recorder.Close())
+
+	header, events := readRecording(t, path)
+	assert.Equal(t, uint16(80), header.Width)
+	assert.Equal(t, []string{"o:first", "r:100x50", "o:second"}, eventData(events))
+}
+
+func TestRecorderSplitRunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.cast")
+	recorder, err := Create(path, Header{})
+	require.NoError(t, err)
+
+	data := []byte("a€b")
+	// Split the three bytes of the euro sign across events.
+	recorder.Record(Output, data[:2])
+	recorder.Record(Input, []byte("x"))
+	recorder.Record(Output, data[2:3])
+	recorder.Record(Output, data[3:])
+	// An incomplete sequence at the end is written on Close.
+	recorder.Record(Output, data[1:2])
+	require.NoError(t, recorder.Close())
+
+	_, events := readRecording(t, path)
+	assert.Equal(t, []string{"o:a", "i:x", "o:€b", "o:\uFFFD"}, eventData(events))
+}
+
+func TestIncompleteRuneLen(t *testing.T) {
+	euro := []byte("€")
+	tests := []struct {
+		data []byte
+		want int
+	}{
+		{nil, 0},
+		{[]byte("abc"), 0},
+		{euro, 0},
+		{euro[:1], 1},
+		{euro[:2], 2},
+		{append([]byte("ab"), euro[:2]...), 2},
+		{[]byte{0x80, 0x80, 0x80, 0x80}, 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, incompleteRuneLen(tt.data), "%q", tt.data)
+	}
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	_, err := NewReader(strings.NewReader(""))
+	assert.ErrorContains(t, err, "recording is empty")
+
+	_, err = NewReader(strings.NewReader("garbage\n"))
+	assert.ErrorContains(t, err, "invalid recording header")
+
+	_, err = NewReader(strings.NewReader(`{"version": 1, "width": 80, "height": 24}` + "\n"))
+	assert.ErrorContains(t, err, "unsupported recording version 1")
+}
+
+func TestPlay(t *testing.T) {
+	recording := `{"version": 2, "width": 80, "height": 24}
+[0.010000, "o", "hello "]
+[0.020000, "i", "ignored"]
+[0.030000, "r", "100x40"]
+
+[0.040000, "o", "world\n"]
+`
+	reader, err := NewReader(strings.NewReader(recording))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	start := time.Now()
+	require.NoError(t, Play(context.Background(), reader, &out, PlayOptions{Speed: 1}))
+	assert.Equal(t, "hello world\n", out.String())
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestPlayIdleTimeLimit(t *testing.T) {
+	recording := `{"version": 2, "width": 80, "height": 24}
+[0.000000, "o", "a"]
+[3600.000000, "o", "b"]
+`
+	reader, err := NewReader(strings.NewReader(recording))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	start := time.Now()
+	require.NoError(t, Play(context.Background(), reader, &out, PlayOptions{Speed: 2, IdleTimeLimit: 10 * time.Millisecond}))
+	assert.Equal(t, "ab", out.String())
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestPlayCanceled(t *testing.T) {
+	recording := `{"version": 2, "width": 80, "height": 24}
+[3600.000000, "o", "a"]
+`
+	reader, err := NewReader(strings.NewReader(recording))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	assert.ErrorIs(t, Play(ctx, reader, &out, PlayOptions{Speed: 1}), context.Canceled)
+	assert.Empty(t, out.String())
+}
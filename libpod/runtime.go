@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -34,10 +35,12 @@ import (
 	"go.podman.io/podman/v6/libpod/lock"
 	"go.podman.io/podman/v6/libpod/namesgenerator"
 	"go.podman.io/podman/v6/libpod/plugin"
+	"go.podman.io/podman/v6/libpod/recording"
 	"go.podman.io/podman/v6/libpod/shutdown"
 	"go.podman.io/podman/v6/pkg/domain/entities"
 	"go.podman.io/podman/v6/pkg/domain/entities/reports"
 	"go.podman.io/podman/v6/pkg/rootless"
+	"go.podman.io/podman/v6/pkg/syncmap"
 	"go.podman.io/podman/v6/pkg/systemd"
 	"go.podman.io/podman/v6/pkg/util"
 	"go.podman.io/storage"
@@ -113,6 +116,14 @@ type Runtime struct {
 	// a database migration, as we can't actually get to the migration code
 	// with an existing Bolt database otherwise.
 	noBoltError bool
+	// refreshHooks are run once the state has been refreshed after a
+	// reboot.
+	refreshHooks []func(*Runtime) error
+	// podReadinessHooks are run when a pod becomes ready or not ready.
+	podReadinessHooks []func(*Runtime, *Pod, bool) error
+	// healthCheckRollbackHooks are run when a container with the rollback
+	// health check on-failure action turns unhealthy.
+	healthCheckRollbackHooks []func(context.Context, *Runtime, *Container) error
 
 	// valid indicates whether the runtime is ready to use.
 	// valid is set to true when a runtime is returned from GetRuntime(),
@@ -125,6 +136,11 @@ type Runtime struct {
 
 	// secretsManager manages secrets
 	secretsManager *secrets.SecretsManager
+
+	// sessionRecorders holds the recorders of the exec and attach sessions
+	// recorded by this process, by exec session or container ID, so that
+	// resizes of the sessions are recorded.
+	sessionRecorders *syncmap.Map[string, *recording.Recorder]
 }
 
 // SetXdgDirs ensures the XDG_RUNTIME_DIR env and XDG_CONFIG_HOME variables are set.
@@ -181,6 +197,7 @@ func NewRuntime(ctx context.Context, options ...RuntimeOption) (*Runtime, error)
 
 func newRuntimeFromConfig(ctx context.Context, conf *config.Config, options ...RuntimeOption) (*Runtime, error) {
 	runtime := new(Runtime)
+	runtime.sessionRecorders = syncmap.New[string, *recording.Recorder]()
 
 	if conf.Engine.OCIRuntime == "" {
 		conf.Engine.OCIRuntime = "crun"
@@ -876,6 +893,13 @@ func (r *Runtime) Shutdown(force bool) error {
 		r.shutdownArtifactStore()
 	}
 
+	// Flush the events pending in the event sinks
+	if closer, ok := r.eventer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logrus.Errorf("Closing event sinks: %v", err)
+		}
+	}
+
 	if err := r.state.Close(); err != nil {
 		if lastError != nil {
 			logrus.Error(lastError)
@@ -969,6 +993,12 @@ func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 	}
 	defer file.Close()
 
+	for _, hook := range r.refreshHooks {
+		if err := hook(r); err != nil {
+			logrus.Errorf("Running refresh hook: %v", err)
+		}
+	}
+
 	r.NewSystemEvent(events.Refresh)
 
 	return nil
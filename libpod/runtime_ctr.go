@@ -617,6 +617,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 	if err := r.state.AddContainer(ctr); err != nil {
 		return nil, err
 	}
+	ctr.warnPressureTimer()
 
 	if ctr.runtime.config.Engine.EventsContainerCreateInspectData {
 		if err := ctr.newContainerEventWithInspectData(events.Create, define.HealthCheckResults{}, true); err != nil {
@@ -0,0 +1,152 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/buildah/copier"
+	"go.podman.io/common/libimage"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/copy"
+)
+
+// StatImage stats the specified path in the root filesystem of the image and
+// returns a file info.  The image is mounted on demand.
+func (r *Runtime) StatImage(ctx context.Context, image *libimage.Image, imagePath string) (*define.FileInfo, error) {
+	mountPoint, unmount, err := mountImage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	defer unmount()
+
+	info, _, err := statImage(mountPoint, imagePath)
+	return info, err
+}
+
+// CopyImageToArchive copies the contents from the specified path in the root
+// filesystem of the image to the tarStream.  The image is mounted on demand
+// and unmounted once the returned function has been called.
+func (r *Runtime) CopyImageToArchive(ctx context.Context, image *libimage.Image, imagePath string, tarStream io.Writer) (func() error, error) {
+	mountPoint, unmount, err := mountImage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+
+	info, resolvedPath, err := statImage(mountPoint, imagePath)
+	if err != nil {
+		unmount()
+		return nil, err
+	}
+
+	logrus.Debugf("Image copy *from* %q (resolved: %q) on image %s", imagePath, resolvedPath, image.ID())
+
+	return func() error {
+		defer unmount()
+		getOptions := copier.GetOptions{
+			// Unless the specified points to ".", we want to copy the base directory.
+			KeepDirectoryNames: info.IsDir && filepath.Base(imagePath) != ".",
+		}
+		return copier.Get(mountPoint, "", getOptions, []string{resolvedPath}, tarStream)
+	}, nil
+}
+
+// WalkImage lists the contents of the directory at the specified path in the
+// root filesystem of the image, descending into subdirectories down to the
+// specified depth.  The image is mounted on demand.
+func (r *Runtime) WalkImage(ctx context.Context, image *libimage.Image, imagePath string, depth int) ([]*define.FileInfo, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("depth must be at least 1: %w", define.ErrInvalidArg)
+	}
+
+	mountPoint, unmount, err := mountImage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	defer unmount()
+
+	info, resolvedPath, err := statImage(mountPoint, imagePath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir {
+		return nil, fmt.Errorf("%q is not a directory: %w", imagePath, define.ErrInvalidArg)
+	}
+
+	items, err := secureWalk(mountPoint, resolvedPath, depth)
+	if err != nil {
+		return nil, err
+	}
+	return walkInfos(filepath.Join("/", imagePath), mountPoint, resolvedPath, items)
+}
+
+// mountImage mounts the image and returns the mount point along with a
+// function to unmount it again.
+func mountImage(ctx context.Context, image *libimage.Image) (string, func(), error) {
+	mountPoint, err := image.Mount(ctx, nil, "")
+	if err != nil {
+		return "", nil, err
+	}
+	unmount := func() {
+		if err := image.Unmount(false); err != nil {
+			logrus.Errorf("Unmounting image %s: %v", image.ID(), err)
+		}
+	}
+	return mountPoint, unmount, nil
+}
+
+// statImage stats the specified path on the mount point of an image.  It
+// returns the file info along with the resolved path, which is absolute to
+// the host's root.  Relative paths are relative to the root of the image.
+func statImage(mountPoint string, imagePath string) (*define.FileInfo, string, error) {
+	// Make sure that "/" copies the *contents* of the mount point and not
+	// the directory.
+	if imagePath == "/" {
+		imagePath = "/."
+	}
+
+	// Wildcards are not allowed.
+	if strings.Contains(imagePath, "*") {
+		return nil, "", copy.ErrENOENT
+	}
+
+	absImagePath := filepath.Join("/", imagePath)
+	resolvedPath := filepath.Join(mountPoint, absImagePath)
+	statInfo, statErr := secureStat(mountPoint, resolvedPath)
+	if statErr != nil {
+		if statInfo == nil {
+			return nil, "", statErr
+		}
+		// See (*Container).stat for why the error string is looked into.
+		if os.IsNotExist(statErr) || strings.Contains(statErr.Error(), "o such file or directory") {
+			statErr = copy.ErrENOENT
+		}
+	}
+
+	// Symlinks are already evaluated and always relative to the mount
+	// point.
+	if statInfo.IsSymlink {
+		absImagePath = statInfo.ImmediateTarget
+	}
+
+	// Preserve the base path as specified by the user.
+	absImagePath = copy.PreserveBasePath(imagePath, absImagePath)
+	resolvedPath = copy.PreserveBasePath(imagePath, resolvedPath)
+
+	info := &define.FileInfo{
+		IsDir:      statInfo.IsDir,
+		Name:       filepath.Base(absImagePath),
+		Size:       statInfo.Size,
+		Mode:       statInfo.Mode,
+		ModTime:    statInfo.ModTime,
+		LinkTarget: absImagePath,
+	}
+
+	return info, resolvedPath, statErr
+}
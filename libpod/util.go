@@ -22,6 +22,7 @@ import (
 	"go.podman.io/common/libnetwork/types"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/libpod/recording"
 	"go.podman.io/podman/v6/pkg/api/handlers/utils/apiutil"
 	"go.podman.io/storage/pkg/fileutils"
 	"golang.org/x/sys/unix"
@@ -166,6 +167,77 @@ func makeHTTPAttachHeader(stream byte, length uint32) []byte {
 	return header
 }
 
+// recordHTTPAttach returns a reader and writer of the hijacked connection of
+// an HTTP attach session, which record the session if the streams are
+// recorded. They must be used after the hijack header was written.
+func recordHTTPAttach(streams *HTTPAttachStreams, httpBuf *bufio.ReadWriter, tty bool) *bufio.ReadWriter {
+	if streams == nil || streams.recorder == nil {
+		return httpBuf
+	}
+	reader := bufio.NewReader(streams.recorder.Reader(recording.Input, httpBuf))
+	if !streams.recordOutput {
+		return bufio.NewReadWriter(reader, httpBuf.Writer)
+	}
+	writer := &httpAttachRecorder{
+		httpBuf:     httpBuf,
+		recorder:    streams.recorder,
+		multiplexed: !tty,
+	}
+	return bufio.NewReadWriter(reader, bufio.NewWriter(writer))
+}
+
+// httpAttachRecorder records the output written to the hijacked connection of
+// an HTTP attach session. Every write is flushed to the connection.
+type httpAttachRecorder struct {
+	httpBuf  *bufio.ReadWriter
+	recorder *recording.Recorder
+	// multiplexed is set if the output is framed with the headers made by
+	// makeHTTPAttachHeader.
+	multiplexed bool
+	// header holds the part of a frame header written so far.
+	header []byte
+	// stream and remaining are the stream and the remaining length of the
+	// current frame.
+	stream    byte
+	remaining uint32
+}
+
+func (w *httpAttachRecorder) Write(p []byte) (int, error) {
+	n, err := w.httpBuf.Write(p)
+	w.record(p[:n])
+	if err != nil {
+		return n, err
+	}
+	return n, w.httpBuf.Flush()
+}
+
+func (w *httpAttachRecorder) record(p []byte) {
+	if !w.multiplexed {
+		w.recorder.Record(recording.Output, p)
+		return
+	}
+	for len(p) > 0 {
+		if w.remaining == 0 {
+			n := min(8-len(w.header), len(p))
+			w.header = append(w.header, p[:n]...)
+			p = p[n:]
+			if len(w.header) == 8 {
+				w.stream = w.header[0]
+				w.remaining = binary.BigEndian.Uint32(w.header[4:])
+				w.header = w.header[:0]
+			}
+			continue
+		}
+		n := min(int(w.remaining), len(p))
+		// Only record STDOUT and STDERR.
+		if w.stream == 1 || w.stream == 2 {
+			w.recorder.Record(recording.Output, p[:n])
+		}
+		w.remaining -= uint32(n)
+		p = p[n:]
+	}
+}
+
 // writeHijackHeader writes a header appropriate for the type of HTTP Hijack
 // that occurred in a hijacked HTTP connection used for attach.
 func writeHijackHeader(r *http.Request, conn io.Writer, tty bool) {
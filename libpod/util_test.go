@@ -3,10 +3,19 @@
 package libpod
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/podman/v6/libpod/recording"
 )
 
 func Test_sortMounts(t *testing.T) {
@@ -212,3 +221,94 @@ func Test_discoverGPUVendorFromCDI(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordHTTPAttach(t *testing.T) {
+	var output []byte
+	output = append(output, makeHTTPAttachHeader(1, 6)...)
+	output = append(output, "hello\n"...)
+	output = append(output, makeHTTPAttachHeader(0, 3)...)
+	output = append(output, "foo"...)
+	output = append(output, makeHTTPAttachHeader(2, 4)...)
+	output = append(output, "err\n"...)
+
+	path := filepath.Join(t.TempDir(), "rec.cast")
+	recorder, err := recording.Create(path, recording.Header{})
+	require.NoError(t, err)
+
+	var conn bytes.Buffer
+	httpBuf := bufio.NewReadWriter(bufio.NewReader(strings.NewReader("ls\n")), bufio.NewWriter(&conn))
+	streamBuf := recordHTTPAttach(&HTTPAttachStreams{recorder: recorder, recordOutput: true}, httpBuf, false)
+
+	// Split the frames across writes.
+	for _, b := range output {
+		_, err := streamBuf.Write([]byte{b})
+		require.NoError(t, err)
+		require.NoError(t, streamBuf.Flush())
+	}
+	input, err := io.ReadAll(streamBuf)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	assert.Equal(t, output, conn.Bytes())
+	assert.Equal(t, "ls\n", string(input))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := recording.NewReader(file)
+	require.NoError(t, err)
+	var out, in string
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		switch event.Type {
+		case recording.Output:
+			out += event.Data
+		case recording.Input:
+			in += event.Data
+		}
+	}
+	assert.Equal(t, "hello\nerr\n", out)
+	assert.Equal(t, "ls\n", in)
+}
+
+func TestRecordHTTPAttachInputOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.cast")
+	recorder, err := recording.Create(path, recording.Header{})
+	require.NoError(t, err)
+
+	var conn bytes.Buffer
+	httpBuf := bufio.NewReadWriter(bufio.NewReader(strings.NewReader("ls\n")), bufio.NewWriter(&conn))
+	streamBuf := recordHTTPAttach(&HTTPAttachStreams{recorder: recorder}, httpBuf, true)
+
+	_, err = streamBuf.WriteString("hello\n")
+	require.NoError(t, err)
+	require.NoError(t, streamBuf.Flush())
+	input, err := io.ReadAll(streamBuf)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	assert.Equal(t, "hello\n", conn.String())
+	assert.Equal(t, "ls\n", string(input))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := recording.NewReader(file)
+	require.NoError(t, err)
+	event, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, recording.Input, event.Type)
+	assert.Equal(t, "ls\n", event.Data)
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestRecordHTTPAttachNoRecorder(t *testing.T) {
+	httpBuf := bufio.NewReadWriter(bufio.NewReader(strings.NewReader("")), bufio.NewWriter(io.Discard))
+	assert.Same(t, httpBuf, recordHTTPAttach(nil, httpBuf, true))
+	assert.Same(t, httpBuf, recordHTTPAttach(&HTTPAttachStreams{}, httpBuf, true))
+}
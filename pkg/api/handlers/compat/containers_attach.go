@@ -24,6 +24,7 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 		Stdin      bool   `schema:"stdin"`
 		Stdout     bool   `schema:"stdout"`
 		Stderr     bool   `schema:"stderr"`
+		Record     bool   `schema:"record"`
 	}{
 		Stream: true,
 	}
@@ -85,7 +86,7 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 	// HTTPAttach will handle everything about the connection from here on
 	// (including closing it and writing errors to it).
 	hijackChan := make(chan bool, 1)
-	err = ctr.HTTPAttach(r, w, streams, detachKeys, nil, query.Stream, query.Logs, hijackChan)
+	err = ctr.HTTPAttach(r, w, streams, detachKeys, nil, query.Stream, query.Logs, query.Record, hijackChan)
 
 	if <-hijackChan {
 		// If connection was Hijacked, we have to signal it's being closed
@@ -32,6 +32,10 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 		Until      string `schema:"until"`
 		Timestamps bool   `schema:"timestamps"`
 		Tail       string `schema:"tail"`
+		// Libpod only
+		Grep      string   `schema:"grep"`
+		Stream    string   `schema:"stream"`
+		JSONField []string `schema:"jsonField"`
 	}{
 		Tail: "all",
 	}
@@ -82,6 +86,12 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	filter, err := logs.NewLogFilter(query.Grep, query.Stream, query.JSONField)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
 	options := &logs.LogOptions{
 		Details:    true,
 		Follow:     query.Follow,
@@ -89,6 +99,7 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 		Until:      until,
 		Tail:       tail,
 		Timestamps: query.Timestamps,
+		Filter:     filter,
 	}
 
 	var wg sync.WaitGroup
@@ -3,6 +3,7 @@
 package compat
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -29,9 +30,10 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	// NOTE: the "filters" parameter is extracted separately for backwards
 	// compat via `filterFromRequest()`.
 	query := struct {
-		Since  string `schema:"since"`
-		Until  string `schema:"until"`
-		Stream bool   `schema:"stream"`
+		Since       string `schema:"since"`
+		Until       string `schema:"until"`
+		Stream      bool   `schema:"stream"`
+		AfterCursor string `schema:"afterCursor"`
 	}{
 		Stream: true,
 	}
@@ -40,7 +42,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(query.Since) > 0 || len(query.Until) > 0 {
+	if len(query.Since) > 0 || len(query.Until) > 0 || len(query.AfterCursor) > 0 {
 		fromStart = true
 	}
 
@@ -58,9 +60,14 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		EventChannel: eventChannel,
 		Since:        query.Since,
 		Until:        query.Until,
+		AfterCursor:  query.AfterCursor,
 	}
 	err = runtime.Events(r.Context(), readOpts)
 	if err != nil {
+		if errors.Is(err, events.ErrInvalidCursor) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
 		utils.InternalServerError(w, err)
 		return
 	}
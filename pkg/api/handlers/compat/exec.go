@@ -60,6 +60,12 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 	libpodConfig.WorkDir = input.WorkingDir
 	libpodConfig.Privileged = input.Privileged
 	libpodConfig.User = input.User
+	if input.OutputBuffer < 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("OutputBuffer must not be negative"))
+		return
+	}
+	libpodConfig.OutputBuffer = input.OutputBuffer
+	libpodConfig.Record = input.Record
 
 	if input.Tty {
 		util.ExecAddTERM(ctr.Env(), libpodConfig.Environment)
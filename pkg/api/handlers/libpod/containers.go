@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strconv"
@@ -20,6 +21,7 @@ import (
 	"go.podman.io/podman/v6/pkg/api/handlers/compat"
 	"go.podman.io/podman/v6/pkg/api/handlers/utils"
 	api "go.podman.io/podman/v6/pkg/api/types"
+	"go.podman.io/podman/v6/pkg/copy"
 	"go.podman.io/podman/v6/pkg/domain/entities"
 	"go.podman.io/podman/v6/pkg/domain/infra/abi"
 	"go.podman.io/podman/v6/pkg/specgenutil"
@@ -162,6 +164,17 @@ func WaitContainer(w http.ResponseWriter, r *http.Request) {
 
 func UnmountContainer(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Force bool `schema:"force"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
 	name := utils.GetName(r)
 	conn, err := runtime.LookupContainer(name)
 	if err != nil {
@@ -170,7 +183,7 @@ func UnmountContainer(w http.ResponseWriter, r *http.Request) {
 	}
 	// TODO In future it might be an improvement that libpod unmount return a
 	// "container not mounted" error so we can surface that to the endpoint user
-	if err := conn.Unmount(false); err != nil {
+	if err := conn.Unmount(query.Force); err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
@@ -215,6 +228,110 @@ func ShowMountedContainers(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, response)
 }
 
+// WalkContainer lists the contents of a directory in the container.
+func WalkContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Path  string `schema:"path"`
+		Depth int    `schema:"depth"`
+	}{
+		Depth: 1,
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Path == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("missing `path` parameter"))
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	infos, err := ctr.Walk(r.Context(), query.Path, query.Depth)
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, copy.ErrENOENT):
+			utils.Error(w, http.StatusNotFound, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, infos)
+}
+
+// CloneContainer creates a copy of an existing container.
+func CloneContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	conf, err := runtime.GetConfigNoCopy()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	// Set the defaults of podman container clone before decoding, so the
+	// fields left unset in the request keep them.
+	options := entities.ContainerCloneOptions{
+		CreateOpts: entities.ContainerCreateOptions{
+			CgroupsMode:          conf.Cgroups(),
+			HealthLogDestination: define.DefaultHealthCheckLocalDestination,
+			HealthMaxLogCount:    define.DefaultHealthMaxLogCount,
+			HealthMaxLogSize:     define.DefaultHealthMaxLogSize,
+			ImageVolume:          conf.Engine.ImageVolumeMode,
+			LogDriver:            conf.Containers.LogDriver,
+			MemorySwappiness:     -1,
+			Pull:                 conf.Engine.PullPolicy,
+			ReadWriteTmpFS:       true,
+			SdNotifyMode:         define.SdNotifyModeContainer,
+			SeccompPolicy:        "default",
+			StopTimeout:          conf.Engine.StopTimeout,
+			Systemd:              "true",
+			Timezone:             conf.TZ(),
+			Ulimit:               conf.Ulimits(),
+			Umask:                conf.Umask(),
+			Volume:               conf.Volumes(),
+		},
+	}
+	if err := utils.ReadJSONFromBody(r, &options); err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if options.ID == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("missing ID of the container to clone"))
+		return
+	}
+	if options.Force && !options.Destroy {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("cannot set force without destroy: %w", define.ErrInvalidArg))
+		return
+	}
+	if _, err := runtime.LookupContainer(options.ID); err != nil {
+		utils.ContainerNotFound(w, options.ID, err)
+		return
+	}
+	options.CreateOpts.IsClone = true
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.ContainerClone(r.Context(), options)
+	if err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, report)
+}
+
 func Checkpoint(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
@@ -311,6 +428,7 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 		TCPEstablished  bool   `schema:"tcpEstablished"`
 		TCPClose        bool   `schema:"tcpClose"`
 		Import          bool   `schema:"import"`
+		ImportPrevious  bool   `schema:"importPrevious"`
 		Name            string `schema:"name"`
 		IgnoreRootFS    bool   `schema:"ignoreRootFS"`
 		IgnoreVolumes   bool   `schema:"ignoreVolumes"`
@@ -344,7 +462,32 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var names []string
-	if query.Import {
+	if query.ImportPrevious {
+		// The pre-checkpoint archive and, if imported, the checkpoint
+		// archive are uploaded as parts of a multipart request.
+		archives, err := saveRestoreArchives(r)
+		defer func() {
+			for _, archive := range archives {
+				os.Remove(archive)
+			}
+		}()
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		options.ImportPrevious = archives["importPrevious"]
+		if options.ImportPrevious == "" {
+			utils.Error(w, http.StatusBadRequest, errors.New("missing importPrevious archive"))
+			return
+		}
+		if query.Import {
+			options.Import = archives["import"]
+			if options.Import == "" {
+				utils.Error(w, http.StatusBadRequest, errors.New("missing import archive"))
+				return
+			}
+		}
+	} else if query.Import {
 		t, err := os.CreateTemp("", "restore")
 		if err != nil {
 			utils.InternalServerError(w, err)
@@ -356,7 +499,8 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		options.Import = t.Name()
-	} else {
+	}
+	if !query.Import {
 		name := utils.GetName(r)
 		if _, err := runtime.LookupContainer(name); err != nil {
 			// If container was not found, check if this is a checkpoint image
@@ -390,6 +534,50 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, reports[0])
 }
 
+// saveRestoreArchives saves the "import" and "importPrevious" parts of a
+// multipart restore request to temporary files and returns their paths keyed
+// by the part names.  The caller is responsible for removing the files, even
+// in case of an error.
+func saveRestoreArchives(r *http.Request) (map[string]string, error) {
+	archives := make(map[string]string)
+	multireader, err := r.MultipartReader()
+	if err != nil {
+		return archives, fmt.Errorf("importPrevious requires a multipart request: %w", err)
+	}
+	for {
+		part, err := multireader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return archives, nil
+		}
+		if err != nil {
+			return archives, fmt.Errorf("reading multipart request: %w", err)
+		}
+		name := part.FormName()
+		if name != "import" && name != "importPrevious" {
+			part.Close()
+			return archives, fmt.Errorf("unexpected part %q in multipart request", name)
+		}
+		if _, ok := archives[name]; ok {
+			part.Close()
+			return archives, fmt.Errorf("duplicate part %q in multipart request", name)
+		}
+		t, err := os.CreateTemp("", "restore")
+		if err != nil {
+			part.Close()
+			return archives, err
+		}
+		archives[name] = t.Name()
+		_, err = io.Copy(t, part)
+		part.Close()
+		if closeErr := t.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return archives, fmt.Errorf("saving %s archive: %w", name, err)
+		}
+	}
+}
+
 func InitContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
@@ -522,6 +710,7 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 		Env:                             options.Env,
 		UnsetEnv:                        options.UnsetEnv,
 		Rlimits:                         rlimits,
+		PressureMonitor:                 &options.UpdatePressureMonitor,
 	}
 
 	err = ctr.Update(updateOptions)
@@ -0,0 +1,70 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func restoreRequest(t *testing.T, parts map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, content := range parts {
+		part, err := writer.CreateFormFile(name, name+".tar.gz")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	req, err := http.NewRequest(http.MethodPost, "/containers/import/restore", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func removeArchives(archives map[string]string) {
+	for _, archive := range archives {
+		os.Remove(archive)
+	}
+}
+
+func TestSaveRestoreArchives(t *testing.T) {
+	t.Run("import and importPrevious", func(t *testing.T) {
+		archives, err := saveRestoreArchives(restoreRequest(t, map[string]string{
+			"import":         "checkpoint",
+			"importPrevious": "pre-checkpoint",
+		}))
+		defer removeArchives(archives)
+		require.NoError(t, err)
+		require.Len(t, archives, 2)
+
+		data, err := os.ReadFile(archives["import"])
+		require.NoError(t, err)
+		assert.Equal(t, "checkpoint", string(data))
+		data, err = os.ReadFile(archives["importPrevious"])
+		require.NoError(t, err)
+		assert.Equal(t, "pre-checkpoint", string(data))
+	})
+
+	t.Run("unexpected part", func(t *testing.T) {
+		archives, err := saveRestoreArchives(restoreRequest(t, map[string]string{"foo": "bar"}))
+		defer removeArchives(archives)
+		assert.ErrorContains(t, err, `unexpected part "foo"`)
+	})
+
+	t.Run("not multipart", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/containers/import/restore", strings.NewReader("checkpoint"))
+		require.NoError(t, err)
+		archives, err := saveRestoreArchives(req)
+		defer removeArchives(archives)
+		assert.ErrorContains(t, err, "importPrevious requires a multipart request")
+	})
+}
@@ -0,0 +1,159 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/api/handlers/utils"
+	"go.podman.io/podman/v6/pkg/api/server/idle"
+	api "go.podman.io/podman/v6/pkg/api/types"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	"go.podman.io/podman/v6/pkg/domain/infra/abi"
+)
+
+// ExecList lists the exec sessions of a container.
+func ExecList(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.ContainerExecList(r.Context(), name, entities.ExecListOptions{})
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+// ExecRecording sends a recording of an exec or attach session of a
+// container.
+func ExecRecording(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	path, err := ctr.RecordingPath(mux.Vars(r)["id"])
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchRecording):
+			utils.Error(w, http.StatusNotFound, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/x-asciicast")
+	if _, err := io.Copy(w, file); err != nil {
+		logrus.Errorf("Sending recording %s of container %s: %v", path, ctr.ID(), err)
+	}
+}
+
+// ExecAttach attaches to a running exec session.
+func ExecAttach(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+
+	query := struct {
+		DetachKeys string `schema:"detachKeys"`
+		Stdin      bool   `schema:"stdin"`
+		Stdout     bool   `schema:"stdout"`
+		Stderr     bool   `schema:"stderr"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	// Detach keys: explicitly set to "" is very different from unset
+	var detachKeys *string
+	if _, found := r.URL.Query()["detachKeys"]; found {
+		detachKeys = &query.DetachKeys
+	}
+
+	// Without any of the stream parameters, the streams the exec session
+	// was created with are used.
+	streams := &libpod.HTTPAttachStreams{Stdin: true, Stdout: true, Stderr: true}
+	useStreams := false
+	if _, found := r.URL.Query()["stdin"]; found {
+		streams.Stdin = query.Stdin
+		useStreams = true
+	}
+	if _, found := r.URL.Query()["stdout"]; found {
+		streams.Stdout = query.Stdout
+		useStreams = true
+	}
+	if _, found := r.URL.Query()["stderr"]; found {
+		streams.Stderr = query.Stderr
+		useStreams = true
+	}
+	if !useStreams {
+		streams = nil
+	}
+	if useStreams && !streams.Stdout && !streams.Stderr && !streams.Stdin {
+		utils.Error(w, http.StatusBadRequest, errors.New("at least one of stdin, stdout, stderr must be true"))
+		return
+	}
+
+	sessionID := mux.Vars(r)["id"]
+	sessionCtr, err := runtime.GetExecSessionContainer(sessionID)
+	if err != nil {
+		utils.SessionNotFound(w, sessionID, err)
+		return
+	}
+
+	logErr := func(e error) {
+		logrus.Error(fmt.Errorf("attaching to container %s exec session %s: %w", sessionCtr.ID(), sessionID, e))
+	}
+
+	hijackChan := make(chan bool, 1)
+	err = sessionCtr.ExecHTTPAttach(sessionID, r, w, streams, detachKeys, nil, hijackChan)
+
+	if <-hijackChan {
+		// If connection was Hijacked, we have to signal it's being closed
+		t := r.Context().Value(api.IdleTrackerKey).(*idle.Tracker)
+		defer t.Close()
+
+		if err != nil && !errors.Is(err, define.ErrDetach) {
+			// Cannot report error to client as a 500 as the Upgrade set status to 101
+			logErr(err)
+		}
+		return
+	}
+
+	// If the Hijack failed we are going to assume we can still inform client of failure
+	switch {
+	case errors.Is(err, define.ErrCtrStateInvalid), errors.Is(err, define.ErrExecSessionStateInvalid):
+		utils.Error(w, http.StatusConflict, err)
+	case errors.Is(err, define.ErrNoSuchExecSession):
+		utils.SessionNotFound(w, sessionID, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+	logErr(err)
+}
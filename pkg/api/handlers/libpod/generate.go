@@ -3,12 +3,15 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
 	"go.podman.io/podman/v6/pkg/api/handlers/utils"
 	api "go.podman.io/podman/v6/pkg/api/types"
 	"go.podman.io/podman/v6/pkg/domain/entities"
@@ -142,3 +145,55 @@ func GenerateKube(w http.ResponseWriter, r *http.Request) {
 	// https://mailarchive.ietf.org/arch/msg/media-types/e9ZNC0hDXKXeFlAVRWxLCCaG9GI/
 	utils.WriteResponse(w, http.StatusOK, report.Reader)
 }
+
+func GenerateSpec(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Compact bool `schema:"compact"`
+		Name    bool `schema:"name"`
+	}{
+		Name: true,
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	if _, err := runtime.LookupContainer(name); err != nil {
+		if !errors.Is(err, define.ErrNoSuchCtr) {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if _, podErr := runtime.LookupPod(name); podErr != nil {
+			if errors.Is(podErr, define.ErrNoSuchPod) {
+				utils.ContainerNotFound(w, name, fmt.Errorf("could not find a pod or container with the id %s: %w", name, err))
+				return
+			}
+			utils.InternalServerError(w, podErr)
+			return
+		}
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	options := &entities.GenerateSpecOptions{
+		ID:      name,
+		Compact: query.Compact,
+		Name:    query.Name,
+	}
+	report, err := containerEngine.GenerateSpec(r.Context(), options)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("generating spec: %w", err))
+		return
+	}
+
+	// The spec is already encoded, write it as is to preserve the
+	// requested formatting.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(report.Data); err != nil {
+		logrus.Errorf("Writing spec: %v", err)
+	}
+}
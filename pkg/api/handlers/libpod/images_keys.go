@@ -0,0 +1,93 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.podman.io/image/v5/signature/signer"
+	"go.podman.io/image/v5/signature/sigstore"
+	"go.podman.io/image/v5/signature/sigstore/fulcio"
+	"go.podman.io/image/v5/signature/sigstore/rekor"
+	"go.podman.io/podman/v6/pkg/api/handlers/utils"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+// readKeyMaterial decodes the key material remote clients forward in the
+// request body to decrypt, encrypt or sign images.  It returns nil if the
+// request has no body.
+func readKeyMaterial(r *http.Request) (*entities.ImageKeyMaterial, error) {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil, nil
+	}
+	keys := new(entities.ImageKeyMaterial)
+	if err := utils.ReadJSONFromBody(r, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// applyKeyMaterial sets the encryption and signing options of a push from the
+// forwarded key material.  The returned function closes the signers and must
+// be called once the push is done.
+func applyKeyMaterial(keys *entities.ImageKeyMaterial, options *entities.ImagePushOptions) (func(), error) {
+	if keys == nil {
+		return func() {}, nil
+	}
+	if keys.DecryptConfig != nil {
+		return nil, errors.New("decryption keys cannot be used when pushing")
+	}
+	options.OciEncryptConfig = keys.EncryptConfig
+	options.OciEncryptLayers = keys.EncryptLayers
+	if keys.EncryptConfig != nil && keys.EncryptLayers == nil {
+		// An empty list of layers does not survive the JSON round
+		// trip.  Encrypt all layers unless told otherwise.
+		options.OciEncryptLayers = &[]int{}
+	}
+	if keys.Sigstore == nil {
+		return func() {}, nil
+	}
+	s, err := sigstoreSigner(keys.Sigstore)
+	if err != nil {
+		return nil, err
+	}
+	options.Signers = append(options.Signers, s)
+	return func() { s.Close() }, nil
+}
+
+// sigstoreSigner creates a signer from the forwarded sigstore key material.
+// The private key is only kept in memory.
+func sigstoreSigner(keys *entities.SigstoreKeyMaterial) (*signer.Signer, error) {
+	var opts []sigstore.Option
+	if keys.PrivateKey != nil {
+		path, closeFile, err := memoryFile("sigstore-private-key", keys.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		// The key is read when creating the signer.
+		defer closeFile()
+		passphrase := keys.PrivateKeyPassphrase
+		if passphrase == nil {
+			passphrase = []byte{}
+		}
+		opts = append(opts, sigstore.WithPrivateKeyFile(path, passphrase))
+	}
+	if keys.FulcioURL != "" {
+		fulcioURL, err := url.Parse(keys.FulcioURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Fulcio URL %q: %w", keys.FulcioURL, err)
+		}
+		opts = append(opts, fulcio.WithFulcioAndPreexistingOIDCIDToken(fulcioURL, keys.OIDCIDToken))
+	}
+	if keys.RekorURL != "" {
+		rekorURL, err := url.Parse(keys.RekorURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Rekor URL %q: %w", keys.RekorURL, err)
+		}
+		opts = append(opts, rekor.WithRekor(rekorURL))
+	}
+	return sigstore.NewSigner(opts...)
+}
@@ -0,0 +1,11 @@
+//go:build !remote
+
+package libpod
+
+import "errors"
+
+// memoryFile is not supported on FreeBSD, which lacks a way to refer to
+// anonymous, memory-backed files by path.
+func memoryFile(_ string, _ []byte) (string, func(), error) {
+	return "", nil, errors.New("forwarding private keys is not supported on FreeBSD")
+}
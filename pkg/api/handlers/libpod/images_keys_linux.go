@@ -0,0 +1,25 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// memoryFile returns the path to an anonymous, memory-backed file holding
+// data, along with a function to close it.  The data never hits the disk.
+func memoryFile(name string, data []byte) (string, func(), error) {
+	fd, err := unix.MemfdCreate(name, unix.MFD_CLOEXEC)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating in-memory file: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), name)
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", nil, fmt.Errorf("writing in-memory file: %w", err)
+	}
+	return fmt.Sprintf("/proc/self/fd/%d", fd), func() { f.Close() }, nil
+}
@@ -0,0 +1,82 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	encconfig "github.com/containers/ocicrypt/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/signature/sigstore"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+func TestReadKeyMaterial(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/images/pull", nil)
+	require.NoError(t, err)
+	keys, err := readKeyMaterial(req)
+	assert.NoError(t, err)
+	assert.Nil(t, keys)
+
+	body := `{"decryptConfig":{"Parameters":{"privkeys":["a2V5"]}}}`
+	req, err = http.NewRequest(http.MethodPost, "/images/pull", strings.NewReader(body))
+	require.NoError(t, err)
+	keys, err = readKeyMaterial(req)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key")}, keys.DecryptConfig.Parameters["privkeys"])
+
+	req, err = http.NewRequest(http.MethodPost, "/images/pull", strings.NewReader("{"))
+	require.NoError(t, err)
+	_, err = readKeyMaterial(req)
+	assert.Error(t, err)
+}
+
+func TestApplyKeyMaterial(t *testing.T) {
+	options := entities.ImagePushOptions{}
+	closeSigners, err := applyKeyMaterial(nil, &options)
+	require.NoError(t, err)
+	closeSigners()
+	assert.Nil(t, options.OciEncryptConfig)
+	assert.Nil(t, options.OciEncryptLayers)
+
+	// Encrypt all layers unless told otherwise.
+	keys := &entities.ImageKeyMaterial{EncryptConfig: &encconfig.EncryptConfig{}}
+	_, err = applyKeyMaterial(keys, &options)
+	require.NoError(t, err)
+	assert.Equal(t, keys.EncryptConfig, options.OciEncryptConfig)
+	assert.Equal(t, &[]int{}, options.OciEncryptLayers)
+
+	keys.EncryptLayers = &[]int{-1}
+	_, err = applyKeyMaterial(keys, &options)
+	require.NoError(t, err)
+	assert.Equal(t, &[]int{-1}, options.OciEncryptLayers)
+
+	keys = &entities.ImageKeyMaterial{DecryptConfig: &encconfig.DecryptConfig{}}
+	_, err = applyKeyMaterial(keys, &options)
+	assert.Error(t, err)
+}
+
+func TestSigstoreSigner(t *testing.T) {
+	passphrase := []byte("secret")
+	keyPair, err := sigstore.GenerateKeyPair(passphrase)
+	require.NoError(t, err)
+
+	keys := &entities.SigstoreKeyMaterial{
+		PrivateKey:           keyPair.PrivateKey,
+		PrivateKeyPassphrase: passphrase,
+	}
+	s, err := sigstoreSigner(keys)
+	require.NoError(t, err)
+	s.Close()
+
+	keys.PrivateKeyPassphrase = []byte("wrong")
+	_, err = sigstoreSigner(keys)
+	assert.Error(t, err)
+
+	// Neither a private key nor Fulcio.
+	_, err = sigstoreSigner(&entities.SigstoreKeyMaterial{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/api/handlers/utils"
+	api "go.podman.io/podman/v6/pkg/api/types"
+	"go.podman.io/podman/v6/pkg/copy"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	"go.podman.io/storage"
+)
+
+// MountImage mounts the root filesystem of an image.
+func MountImage(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	image, _, err := runtime.LibimageRuntime().LookupImage(name, nil)
+	if err != nil {
+		utils.ImageNotFound(w, name, err)
+		return
+	}
+	mountPoint, err := image.Mount(r.Context(), nil, "")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	report, err := imageMountReport(image, mountPoint)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// UnmountImage unmounts the root filesystem of an image.
+func UnmountImage(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Force bool `schema:"force"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	name := utils.GetName(r)
+	image, _, err := runtime.LibimageRuntime().LookupImage(name, nil)
+	if err != nil {
+		utils.ImageNotFound(w, name, err)
+		return
+	}
+	mountPoint, err := image.Mountpoint()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	// Skip if the image isn't mounted.
+	if mountPoint != "" {
+		if err := image.Unmount(query.Force); err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+// ShowMountedImages reports the mounted images.
+func ShowMountedImages(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	images, err := runtime.LibimageRuntime().ListImages(r.Context(), nil)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	reports := []*entities.ImageMountReport{}
+	for _, image := range images {
+		mountPoint, err := image.Mountpoint()
+		if err != nil {
+			if errors.Is(err, storage.ErrImageUnknown) {
+				continue
+			}
+			utils.InternalServerError(w, err)
+			return
+		}
+		if mountPoint == "" {
+			continue
+		}
+		report, err := imageMountReport(image, mountPoint)
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		reports = append(reports, report)
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+func imageMountReport(image *libimage.Image, mountPoint string) (*entities.ImageMountReport, error) {
+	tags, err := image.RepoTags()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ImageMountReport{
+		Id:           image.ID(),
+		Name:         string(image.Digest()),
+		Repositories: tags,
+		Path:         mountPoint,
+	}, nil
+}
+
+// ImageArchive stats a path in the root filesystem of an image and, unless
+// only the header is requested, copies its content to a tar archive.
+func ImageArchive(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Path string `schema:"path"`
+	}{}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("couldn't decode the query: %w", err))
+		return
+	}
+	if query.Path == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("missing `path` parameter"))
+		return
+	}
+
+	name := utils.GetName(r)
+	image, _, err := runtime.LibimageRuntime().LookupImage(name, nil)
+	if err != nil {
+		utils.ImageNotFound(w, name, err)
+		return
+	}
+
+	// The file info may be set even in case of an error, see
+	// compat.Archive.
+	info, err := runtime.StatImage(r.Context(), image, query.Path)
+	if info != nil {
+		statHeader, err := copy.EncodeFileInfo(info)
+		if err != nil {
+			utils.Error(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Add(copy.XDockerContainerPathStatHeader, statHeader)
+	}
+
+	if errors.Is(err, copy.ErrENOENT) {
+		utils.Error(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Our work is done when the user is interested in the header only.
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	copyFunc, err := runtime.CopyImageToArchive(r.Context(), image, query.Path, w)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+	if err := copyFunc(); err != nil {
+		logrus.Error(err.Error())
+	}
+}
+
+// WalkImage lists the contents of a directory in the root filesystem of an
+// image.
+func WalkImage(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Path  string `schema:"path"`
+		Depth int    `schema:"depth"`
+	}{
+		Depth: 1,
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Path == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("missing `path` parameter"))
+		return
+	}
+
+	name := utils.GetName(r)
+	image, _, err := runtime.LibimageRuntime().LookupImage(name, nil)
+	if err != nil {
+		utils.ImageNotFound(w, name, err)
+		return
+	}
+	infos, err := runtime.WalkImage(r.Context(), image, query.Path, query.Depth)
+	if err != nil {
+		switch {
+		case errors.Is(err, copy.ErrENOENT):
+			utils.Error(w, http.StatusNotFound, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, infos)
+}
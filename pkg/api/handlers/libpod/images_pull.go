@@ -83,7 +83,20 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	keys, err := readKeyMaterial(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
 	pullOptions := &libimage.PullOptions{}
+	if keys != nil {
+		if keys.EncryptConfig != nil || keys.Sigstore != nil {
+			utils.Error(w, http.StatusBadRequest, errors.New("only decryption keys can be used when pulling"))
+			return
+		}
+		pullOptions.OciDecryptConfig = keys.DecryptConfig
+	}
 	pullOptions.AllTags = query.AllTags
 	pullOptions.Architecture = query.Arch
 	pullOptions.OS = query.OS
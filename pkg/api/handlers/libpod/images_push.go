@@ -107,6 +107,18 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
 	}
 
+	keys, err := readKeyMaterial(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	closeSigners, err := applyKeyMaterial(keys, &options)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer closeSigners()
+
 	imageEngine := abi.ImageEngine{Libpod: runtime}
 
 	// Let's keep thing simple when running in quiet mode and push directly.
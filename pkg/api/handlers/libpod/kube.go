@@ -112,6 +112,7 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		NoHosts          bool              `schema:"noHosts"`
 		NoTrunc          bool              `schema:"noTrunc"`
 		Replace          bool              `schema:"replace"`
+		Update           bool              `schema:"update"`
 		PublishPorts     []string          `schema:"publishPorts"`
 		PublishAllPorts  bool              `schema:"publishAllPorts"`
 		ServiceContainer bool              `schema:"serviceContainer"`
@@ -190,6 +191,7 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		PublishAllPorts:    query.PublishAllPorts,
 		Quiet:              true,
 		Replace:            query.Replace,
+		Update:             query.Update,
 		ServiceContainer:   query.ServiceContainer,
 		StaticIPs:          staticIPs,
 		StaticMACs:         staticMACs,
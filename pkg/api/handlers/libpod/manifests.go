@@ -419,6 +419,22 @@ func ManifestPush(w http.ResponseWriter, r *http.Request) {
 		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
 	}
 
+	keys, err := readKeyMaterial(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if keys != nil && (keys.DecryptConfig != nil || keys.EncryptConfig != nil) {
+		utils.Error(w, http.StatusBadRequest, errors.New("only signing keys can be used when pushing a manifest list"))
+		return
+	}
+	closeSigners, err := applyKeyMaterial(keys, &options)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer closeSigners()
+
 	imageEngine := abi.ImageEngine{Libpod: runtime}
 	source := utils.GetName(r)
 
@@ -180,8 +180,9 @@ func InstallQuadlets(w http.ResponseWriter, r *http.Request) {
 
 	// Parse query parameters
 	query := struct {
-		Replace       bool `schema:"replace"`
-		ReloadSystemd bool `schema:"reload-systemd"`
+		Replace        bool `schema:"replace"`
+		ReloadSystemd  bool `schema:"reload-systemd"`
+		RestartChanged bool `schema:"restart-changed"`
 	}{
 		Replace:       false,
 		ReloadSystemd: true, // Default to true like CLI
@@ -191,6 +192,10 @@ func InstallQuadlets(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
 		return
 	}
+	if query.RestartChanged && !query.ReloadSystemd {
+		utils.Error(w, http.StatusBadRequest, errors.New("restart-changed requires reload-systemd"))
+		return
+	}
 
 	multipart, err := utils.ValidateContentType(r)
 	if err != nil {
@@ -238,8 +243,9 @@ func InstallQuadlets(w http.ResponseWriter, r *http.Request) {
 
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
 	installOptions := entities.QuadletInstallOptions{
-		Replace:       query.Replace,
-		ReloadSystemd: query.ReloadSystemd,
+		Replace:        query.Replace,
+		ReloadSystemd:  query.ReloadSystemd,
+		RestartChanged: query.RestartChanged,
 	}
 
 	installReport, err := containerEngine.QuadletInstall(r.Context(), filePaths, installOptions)
@@ -259,15 +265,84 @@ func InstallQuadlets(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, installReport)
 }
 
+// ValidateQuadlets handles POST /libpod/quadlets/validate to validate quadlet files
+func ValidateQuadlets(w http.ResponseWriter, r *http.Request) {
+	contextDirectory, err := os.MkdirTemp("", "libpod_quadlet")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer func() {
+		if err := os.RemoveAll(contextDirectory); err != nil {
+			logrus.Warn(fmt.Errorf("failed to remove libpod_quadlet tmp directory %q: %w", contextDirectory, err))
+		}
+	}()
+
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	multipart, err := utils.ValidateContentType(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var filePaths []string
+	if multipart {
+		filePaths, err = processMultipartQuadlets(contextDirectory, r)
+	} else {
+		filePaths, err = extractQuadletFiles(contextDirectory, r.Body)
+	}
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	if len(filePaths) == 0 {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("no files found in request"))
+		return
+	}
+
+	// Other files are only extracted to resolve relative paths
+	var quadletPaths []string
+	for _, filePath := range filePaths {
+		if quadlet.IsExtSupported(filePath) {
+			quadletPaths = append(quadletPaths, filePath)
+		}
+	}
+	if len(quadletPaths) == 0 {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("no quadlet files found in request"))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	validateReport, err := containerEngine.QuadletValidate(r.Context(), quadletPaths)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	// Report the paths of the files as they were sent
+	quadletDir := filepath.Join(contextDirectory, "quadlets")
+	for i, d := range validateReport.Diagnostics {
+		if rel, err := filepath.Rel(quadletDir, d.File); err == nil {
+			validateReport.Diagnostics[i].File = rel
+		}
+		validateReport.Diagnostics[i].Message = strings.ReplaceAll(d.Message, quadletDir+"/", "")
+	}
+
+	utils.WriteResponse(w, http.StatusOK, validateReport)
+}
+
 // RemoveQuadlet handles DELETE /libpod/quadlets/{name} to remove a quadlet file
 func RemoveQuadlet(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 
 	query := struct {
-		Force         bool `schema:"force"`
-		Ignore        bool `schema:"ignore"`
-		ReloadSystemd bool `schema:"reload-systemd"`
+		Force          bool `schema:"force"`
+		Ignore         bool `schema:"ignore"`
+		ReloadSystemd  bool `schema:"reload-systemd"`
+		RestartChanged bool `schema:"restart-changed"`
 	}{
 		ReloadSystemd: true, // Default to true like CLI
 	}
@@ -276,6 +351,10 @@ func RemoveQuadlet(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
 		return
 	}
+	if query.RestartChanged && !query.ReloadSystemd {
+		utils.Error(w, http.StatusBadRequest, errors.New("restart-changed requires reload-systemd"))
+		return
+	}
 
 	name := utils.GetName(r)
 	if name == "" {
@@ -285,9 +364,10 @@ func RemoveQuadlet(w http.ResponseWriter, r *http.Request) {
 
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
 	removeOptions := entities.QuadletRemoveOptions{
-		Force:         query.Force,
-		Ignore:        query.Ignore,
-		ReloadSystemd: query.ReloadSystemd,
+		Force:          query.Force,
+		Ignore:         query.Ignore,
+		ReloadSystemd:  query.ReloadSystemd,
+		RestartChanged: query.RestartChanged,
 	}
 
 	removeReport, err := containerEngine.QuadletRemove(r.Context(), []string{name}, removeOptions)
@@ -320,11 +400,12 @@ func RemoveQuadlets(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 
 	query := struct {
-		All           bool     `schema:"all"`
-		Force         bool     `schema:"force"`
-		Ignore        bool     `schema:"ignore"`
-		ReloadSystemd bool     `schema:"reload-systemd"`
-		Quadlets      []string `schema:"quadlets"`
+		All            bool     `schema:"all"`
+		Force          bool     `schema:"force"`
+		Ignore         bool     `schema:"ignore"`
+		ReloadSystemd  bool     `schema:"reload-systemd"`
+		RestartChanged bool     `schema:"restart-changed"`
+		Quadlets       []string `schema:"quadlets"`
 	}{
 		ReloadSystemd: true, // Default to true like CLI
 	}
@@ -333,6 +414,10 @@ func RemoveQuadlets(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
 		return
 	}
+	if query.RestartChanged && !query.ReloadSystemd {
+		utils.Error(w, http.StatusBadRequest, errors.New("restart-changed requires reload-systemd"))
+		return
+	}
 
 	// Validate that either all=true OR at least one quadlet name is provided
 	if !query.All && len(query.Quadlets) == 0 {
@@ -348,10 +433,11 @@ func RemoveQuadlets(w http.ResponseWriter, r *http.Request) {
 
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
 	removeOptions := entities.QuadletRemoveOptions{
-		Force:         query.Force,
-		All:           query.All,
-		Ignore:        query.Ignore,
-		ReloadSystemd: query.ReloadSystemd,
+		Force:          query.Force,
+		All:            query.All,
+		Ignore:         query.Ignore,
+		ReloadSystemd:  query.ReloadSystemd,
+		RestartChanged: query.RestartChanged,
 	}
 
 	removeReport, err := containerEngine.QuadletRemove(r.Context(), query.Quadlets, removeOptions)
@@ -52,6 +52,14 @@ type networkConnectRequestLibpod entities.NetworkConnectOptions
 // swagger:model
 type networkUpdateRequestLibpod entities.NetworkUpdateOptions
 
+// Container clone
+// swagger:model
+type containerCloneRequestLibpod entities.ContainerCloneOptions
+
+// Key material to decrypt, encrypt or sign images
+// swagger:model
+type imageKeyMaterialLibpod entities.ImageKeyMaterial
+
 // Container update
 // swagger:model
 type containerUpdateRequest struct {
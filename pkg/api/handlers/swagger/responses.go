@@ -226,6 +226,13 @@ type execSessionInspect struct {
 	Body define.InspectExecSession
 }
 
+// Exec Session List
+// swagger:response
+type execSessionList struct {
+	// in:body
+	Body []define.InspectExecSession
+}
+
 // Image summary for compat API
 // swagger:response
 type imageList struct {
@@ -324,6 +331,27 @@ type containerCreateResponse struct {
 	Body entities.ContainerCreateResponse
 }
 
+// List files
+// swagger:response
+type fileInfoListResponse struct {
+	// in:body
+	Body []define.FileInfo
+}
+
+// Mount image
+// swagger:response
+type imageMountResponse struct {
+	// in:body
+	Body entities.ImageMountReport
+}
+
+// Mounted images
+// swagger:response
+type imageMountListResponse struct {
+	// in:body
+	Body []entities.ImageMountReport
+}
+
 // Update container
 // swagger:response
 type containerUpdateResponse struct {
@@ -541,3 +569,10 @@ type quadletRemoveResponse struct {
 	// in:body
 	Body entities.QuadletRemoveReport
 }
+
+// Quadlet validate
+// swagger:response
+type quadletValidateResponse struct {
+	// in:body
+	Body entities.QuadletValidateReport
+}
@@ -90,6 +90,7 @@ type UpdateEntities struct {
 	specs.LinuxResources
 	define.UpdateHealthCheckConfig
 	define.UpdateContainerDevicesLimits
+	define.UpdatePressureMonitor
 	Env      []string
 	UnsetEnv []string
 	Rlimits  []specs.POSIXRlimit `json:"r_limits,omitempty"`
@@ -243,6 +244,11 @@ type HistoryResponse struct {
 
 type ExecCreateConfig struct {
 	dockerContainer.ExecCreateRequest
+	// OutputBuffer is the size of the last output kept to be replayed
+	// when attaching to the exec session again (libpod only).
+	OutputBuffer int64 `json:"OutputBuffer,omitempty"`
+	// Record records the exec session (libpod only).
+	Record bool `json:"Record,omitempty"`
 }
 
 type ExecStartConfig struct {
@@ -764,6 +764,31 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     500:
 	//       $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/create"), s.APIHandler(libpod.CreateContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/clone libpod ContainerCloneLibpod
+	// ---
+	//   tags:
+	//    - containers
+	//   summary: Clone a container
+	//   description: Create a copy of an existing container with the same configuration, optionally changed by the options of podman container clone.
+	//   produces:
+	//   - application/json
+	//   parameters:
+	//    - in: body
+	//      name: clone
+	//      description: options for cloning the container; the ID field names the container to clone
+	//      schema:
+	//        $ref: "#/definitions/containerCloneRequestLibpod"
+	//      required: true
+	//   responses:
+	//     201:
+	//       $ref: "#/responses/containerCreateResponse"
+	//     400:
+	//       $ref: "#/responses/badParamError"
+	//     404:
+	//       $ref: "#/responses/containerNotFound"
+	//     500:
+	//       $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/clone"), s.APIHandler(libpod.CloneContainer)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/containers/json libpod ContainerListLibpod
 	// ---
 	// tags:
@@ -1028,6 +1053,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: force
+	//    type: boolean
+	//    default: false
+	//    description: Unmount the container even if other processes have it mounted.
 	// responses:
 	//   204:
 	//     description: ok
@@ -1036,6 +1066,44 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/unmount"), s.APIHandler(libpod.UnmountContainer)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/walk libpod ContainerWalkLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: List the files of a container
+	// description: |
+	//   List the contents of a directory in the container, descending into subdirectories down to the specified depth.
+	//   The files are sorted by name, which is the path of the file in the container.  The size, mode and modification time of
+	//   symbolic links are those of their target, and the link target is set to the content of the link.
+	//   The container is mounted on demand, so it does not need to be mounted or running.  Use the archive endpoint to stat or copy the listed files.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: path
+	//    type: string
+	//    required: true
+	//    description: Path to a directory in the container
+	//  - in: query
+	//    name: depth
+	//    type: integer
+	//    default: 1
+	//    description: Number of directory levels to list
+	// responses:
+	//   200:
+	//     $ref: "#/responses/fileInfoListResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/walk"), s.APIHandler(libpod.WalkContainer)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/containers/{name}/logs libpod ContainerLogsLibpod
 	// ---
 	// tags:
@@ -1081,11 +1149,28 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: string
 	//    description: Only return this number of log lines from the end of the logs
 	//    default: all
+	//  - in: query
+	//    name: grep
+	//    type: string
+	//    description: Only return the log lines matching this regular expression
+	//  - in: query
+	//    name: stream
+	//    type: string
+	//    enum: [stdout, stderr]
+	//    description: Only return the log lines of this stream
+	//  - in: query
+	//    name: jsonField
+	//    type: array
+	//    items:
+	//       type: string
+	//    description: Only return the log lines logged as JSON objects with these fields, as FIELD=VALUE
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     description:  logs returned as a stream in response body.
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//      $ref: "#/responses/containerNotFound"
 	//   500:
@@ -1533,6 +1618,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    required: false
 	//    type: boolean
 	//    description: Attach to container STDIN
+	//  - in: query
+	//    name: record
+	//    required: false
+	//    type: boolean
+	//    description: Record the attach session. The recording can be retrieved with the container recordings endpoint.
 	// produces:
 	// - application/json
 	// responses:
@@ -1700,6 +1790,13 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    description:  import the restore from a checkpoint tar.gz
 	//  - in: query
+	//    name: importPrevious
+	//    type: boolean
+	//    description: |
+	//      restore a container from a checkpoint on top of a pre-checkpoint.
+	//      The request body must be multipart/form-data with the pre-checkpoint tar.gz
+	//      in the "importPrevious" part and, if used with import, the checkpoint tar.gz in the "import" part.
+	//  - in: query
 	//    name: ignoreRootFS
 	//    type: boolean
 	//    description: do not include root file-system changes when exporting. can only be used with import
@@ -31,9 +31,15 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//   type: string
 	//   in: query
 	//   description: JSON encoded map[string][]string of constraints
+	// - name: afterCursor
+	//   type: string
+	//   in: query
+	//   description: start streaming events right after the event with this cursor
 	// responses:
 	//   200:
 	//     description: returns a string of json data describing an event
+	//   400:
+	//     "$ref": "#/responses/badParamError"
 	//   500:
 	//     "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/events"), s.StreamBufferedAPIHandler(compat.GetEvents)).Methods(http.MethodGet)
@@ -60,6 +66,10 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//   type: string
 	//   in: query
 	//   description: JSON encoded map[string][]string of constraints
+	// - name: afterCursor
+	//   type: string
+	//   in: query
+	//   description: start streaming events right after the event with this cursor
 	// - name: stream
 	//   type: boolean
 	//   in: query
@@ -68,6 +78,8 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	// responses:
 	//   200:
 	//     description: returns a string of json data describing an event
+	//   400:
+	//     "$ref": "#/responses/badParamError"
 	//   500:
 	//     "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/events"), s.APIHandler(compat.GetEvents)).Methods(http.MethodGet)
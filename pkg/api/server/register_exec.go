@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"go.podman.io/podman/v6/pkg/api/handlers/compat"
+	"go.podman.io/podman/v6/pkg/api/handlers/libpod"
 )
 
 func (s *APIServer) registerExecHandlers(r *mux.Router) error {
@@ -239,6 +240,17 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//        WorkingDir:
 	//          type: string
 	//          description: The working directory for the exec process inside the container.
+	//        OutputBuffer:
+	//          type: integer
+	//          format: int64
+	//          default: 0
+	//          description: |
+	//           Size, in bytes, of the output kept to be replayed when attaching to the exec session again. Only the last bytes of the output are kept. If set, STDIN is kept open when a client detaches.
+	//        Record:
+	//          type: boolean
+	//          default: false
+	//          description: |
+	//           Record the exec session. Its output is recorded even while no client is attached, its input while a client is attached. The recording has the ID of the exec session and can be retrieved with the container recordings endpoint.
 	// produces:
 	// - application/json
 	// responses:
@@ -251,6 +263,59 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/containers/{name}/exec"), s.APIHandler(compat.ExecCreateHandler)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/exec libpod ContainerExecListLibpod
+	// ---
+	// tags:
+	//   - exec
+	// summary: List exec sessions
+	// description: List the exec sessions of a container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: name or ID of container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/execSessionList"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/containers/{name}/exec"), s.APIHandler(libpod.ExecList)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/recordings/{id} libpod ContainerExecRecordingLibpod
+	// ---
+	// tags:
+	//   - exec
+	// summary: Get a session recording
+	// description: |
+	//   Get a recording of an exec or attach session of a container, in the asciicast v2 format.
+	//   Sessions are recorded when they are started with record set, or when the container has the io.podman.annotations.record annotation.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: name or ID of container
+	//  - in: path
+	//    name: id
+	//    type: string
+	//    required: true
+	//    description: ID of the recording, the ID of the exec session for exec sessions. It may be truncated as long as it is unique.
+	// produces:
+	// - application/x-asciicast
+	// responses:
+	//   200:
+	//     description: recording is returned in body
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/containers/{name}/recordings/{id}"), s.APIHandler(libpod.ExecRecording)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/exec/{id}/start libpod ExecStartLibpod
 	// ---
 	// tags:
@@ -295,6 +360,52 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/exec/{id}/start"), s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/exec/{id}/attach libpod ExecAttachLibpod
+	// ---
+	// tags:
+	//   - exec
+	// summary: Attach to a running exec instance
+	// description: |
+	//   Hijacks the connection to forward the standard streams of a running exec session, e.g. one started detached or one a client detached from.
+	//   If the exec session was created with an output buffer, the output kept is sent first.
+	//   The session is not removed when the attach ends.
+	// parameters:
+	//  - in: path
+	//    name: id
+	//    type: string
+	//    required: true
+	//    description: Exec instance ID
+	//  - in: query
+	//    name: detachKeys
+	//    required: false
+	//    type: string
+	//    description: keys to use for detaching from the exec session
+	//  - in: query
+	//    name: stdin
+	//    type: boolean
+	//    description: attach to stdin; defaults to the setting the exec session was created with
+	//  - in: query
+	//    name: stdout
+	//    type: boolean
+	//    description: attach to stdout; defaults to the setting the exec session was created with
+	//  - in: query
+	//    name: stderr
+	//    type: boolean
+	//    description: attach to stderr; defaults to the setting the exec session was created with
+	// produces:
+	// - application/json
+	// responses:
+	//   101:
+	//     description: No error, connection has been hijacked for transporting streams.
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/execSessionNotFound"
+	//   409:
+	//	   description: container is not running or the exec session is not running
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/exec/{id}/attach"), s.APIHandler(libpod.ExecAttach)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/exec/{id}/resize libpod ExecResizeLibpod
 	// ---
 	// tags:
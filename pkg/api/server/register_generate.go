@@ -152,5 +152,41 @@ func (s *APIServer) registerGenerateHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/generate/kube"), s.APIHandler(libpod.GenerateKube)).Methods(http.MethodGet)
+
+	// swagger:operation GET /libpod/generate/{name}/spec libpod GenerateSpecLibpod
+	// ---
+	// tags:
+	//  - containers
+	//  - pods
+	// summary: Generate a spec
+	// description: Generate a JSON spec based on a pod or container that can be used to create a new one.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: Name or ID of the container or pod.
+	//  - in: query
+	//    name: compact
+	//    type: boolean
+	//    default: false
+	//    description: Print the JSON in a compact format.
+	//  - in: query
+	//    name: name
+	//    type: boolean
+	//    default: true
+	//    description: Assign a new, unused name to the generated spec.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: JSON spec describing the container or pod
+	//     schema:
+	//       type: object
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/generate/{name:.*}/spec"), s.APIHandler(libpod.GenerateSpec)).Methods(http.MethodGet)
 	return nil
 }
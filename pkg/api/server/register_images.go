@@ -836,6 +836,11 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: X-Registry-Auth
 	//    type: string
 	//    description: A base64-encoded auth configuration.
+	//  - in: body
+	//    name: request
+	//    description: Keys to encrypt or sign the image with. Only kept in memory.
+	//    schema:
+	//      $ref: "#/definitions/imageKeyMaterialLibpod"
 	// produces:
 	// - application/json
 	// responses:
@@ -1182,6 +1187,11 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     name: X-Registry-Auth
 	//     description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
 	//     type: string
+	//   - in: body
+	//     name: request
+	//     description: Keys to decrypt the image with. Only kept in memory.
+	//     schema:
+	//       $ref: "#/definitions/imageKeyMaterialLibpod"
 	// produces:
 	// - application/json
 	// responses:
@@ -1539,6 +1549,142 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/images/{name}/changes"), s.APIHandler(compat.Changes)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/images/{name}/mount libpod ImageMountLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Mount an image
+	// description: Mount the root filesystem of an image.  The mount point is a path on the server.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageMountResponse"
+	//   404:
+	//     $ref: "#/responses/imageNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/mount"), s.APIHandler(libpod.MountImage)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/images/{name}/unmount libpod ImageUnmountLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Unmount an image
+	// description: Unmount the root filesystem of an image.  Nothing is done if the image is not mounted.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	//  - in: query
+	//    name: force
+	//    type: boolean
+	//    default: false
+	//    description: Unmount the image even if other processes have it mounted.
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/imageNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/unmount"), s.APIHandler(libpod.UnmountImage)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/images/showmounted libpod ImageShowMountedLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Show mounted images
+	// description: Lists all mounted images and their mount points.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageMountListResponse"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/images/showmounted"), s.APIHandler(libpod.ShowMountedImages)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/images/{name}/archive libpod ImageArchiveLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Copy files from an image
+	// description: |
+	//   Copy a tar archive of files from the root filesystem of an image.  A HEAD request only returns the
+	//   X-Docker-Container-Path-Stat header with the base64 encoded JSON file info of the path.
+	//   The image is mounted on demand, so it does not need to be mounted.
+	// produces:
+	// - application/x-tar
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	//  - in: query
+	//    name: path
+	//    type: string
+	//    required: true
+	//    description: Path to a file or directory in the image
+	// responses:
+	//   200:
+	//     description: no error
+	//     schema:
+	//       type: string
+	//       format: binary
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/imageNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/archive"), s.APIHandler(libpod.ImageArchive)).Methods(http.MethodGet, http.MethodHead)
+	// swagger:operation GET /libpod/images/{name}/walk libpod ImageWalkLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: List the files of an image
+	// description: |
+	//   List the contents of a directory in the root filesystem of an image, descending into subdirectories down to the specified depth.
+	//   The files are sorted by name, which is the path of the file in the image.  The size, mode and modification time of
+	//   symbolic links are those of their target, and the link target is set to the content of the link.
+	//   The image is mounted on demand, so it does not need to be mounted.  Use the archive endpoint to stat or copy the listed files.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	//  - in: query
+	//    name: path
+	//    type: string
+	//    required: true
+	//    description: Path to a directory in the image
+	//  - in: query
+	//    name: depth
+	//    type: integer
+	//    default: 1
+	//    description: Number of directory levels to list
+	// responses:
+	//   200:
+	//     $ref: "#/responses/fileInfoListResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/imageNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/walk"), s.APIHandler(libpod.WalkImage)).Methods(http.MethodGet)
 
 	// swagger:operation POST /libpod/build libpod ImageBuildLibpod
 	// ---
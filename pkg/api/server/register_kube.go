@@ -104,6 +104,11 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: replace existing pods and containers
 	//  - in: query
+	//    name: update
+	//    type: boolean
+	//    default: false
+	//    description: perform a rolling update of the pods of Deployments which were created by a previous run
+	//  - in: query
 	//    name: serviceContainer
 	//    type: boolean
 	//    default: false
@@ -138,7 +143,9 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    name: wait
 	//    type: boolean
 	//    default: false
-	//    description: Clean up all objects created when a SIGTERM is received or pods exit.
+	//    description: |
+	//      Clean up all objects created when a SIGTERM is received or pods exit.
+	//      The request does not return before the readiness probes of all containers passed.
 	//  - in: query
 	//    name: build
 	//    type: boolean
@@ -94,6 +94,11 @@ func (s *APIServer) registerManifestHandlers(r *mux.Router) error {
 	//    description: "silences extra stream data on push"
 	//    type: boolean
 	//    default: true
+	//  - in: body
+	//    name: request
+	//    description: Keys to sign the images with. Only kept in memory.
+	//    schema:
+	//      $ref: "#/definitions/imageKeyMaterialLibpod"
 	// responses:
 	//   200:
 	//     schema:
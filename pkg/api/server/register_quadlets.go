@@ -102,6 +102,11 @@ func (s *APIServer) registerQuadletHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: true
 	//    description: Reload systemd after installing quadlets
+	//  - in: query
+	//    name: restart-changed
+	//    type: boolean
+	//    default: false
+	//    description: Restart the running units whose generated systemd units were changed by the installation, requires reload-systemd
 	//  - in: body
 	//    name: request
 	//    description: |
@@ -132,6 +137,38 @@ func (s *APIServer) registerQuadletHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/quadlets"), s.APIHandler(libpod.InstallQuadlets)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/quadlets/validate libpod QuadletValidateLibpod
+	// ---
+	// tags:
+	//   - quadlets
+	// summary: Validate quadlet files
+	// description: |
+	//   Convert one or more quadlet files like the Quadlet generator does, without installing them, and report
+	//   the problems found in them. References to other quadlets are resolved against the uploaded files and the
+	//   installed quadlets. Additional files, such as kube yaml files, can be sent along to resolve relative paths.
+	// consumes:
+	// - application/x-tar
+	// - multipart/form-data
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: body
+	//    name: request
+	//    description: |
+	//      Quadlet files to validate. Can be provided as:
+	//      - application/x-tar: A tar archive containing the quadlet files and optionally additional files
+	//      - multipart/form-data: The quadlet files as form data and optionally additional files
+	//    schema:
+	//      type: string
+	//      format: binary
+	// responses:
+	//   200:
+	//     $ref: "#/responses/quadletValidateResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/quadlets/validate"), s.APIHandler(libpod.ValidateQuadlets)).Methods(http.MethodPost)
 	// swagger:operation DELETE /libpod/quadlets libpod QuadletDeleteAllLibpod
 	// ---
 	// tags:
@@ -169,6 +206,11 @@ func (s *APIServer) registerQuadletHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: true
 	//    description: Reload systemd after removing quadlets
+	//  - in: query
+	//    name: restart-changed
+	//    type: boolean
+	//    default: false
+	//    description: Restart the running units whose generated systemd units were changed by the removal and stop the removed ones, requires reload-systemd
 	// responses:
 	//   200:
 	//     $ref: "#/responses/quadletRemoveResponse"
@@ -207,6 +249,11 @@ func (s *APIServer) registerQuadletHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: true
 	//    description: Reload systemd after removing the quadlet
+	//  - in: query
+	//    name: restart-changed
+	//    type: boolean
+	//    default: false
+	//    description: Restart the running units whose generated systemd units were changed by the removal and stop the removed ones, requires reload-systemd
 	// responses:
 	//   200:
 	//     $ref: "#/responses/quadletRemoveResponse"
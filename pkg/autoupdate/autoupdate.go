@@ -6,14 +6,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
 	"go.podman.io/podman/v6/libpod"
 	"go.podman.io/podman/v6/libpod/define"
 	"go.podman.io/podman/v6/libpod/events"
@@ -32,6 +37,9 @@ const (
 	PolicyRegistryImage = "registry"
 	// PolicyLocalImage is the policy to run auto-update based on a local image
 	PolicyLocalImage = "local"
+	// PolicySemverImage is the policy to update to the latest release on the
+	// registry matching a semver pattern like 1.4.x.
+	PolicySemverImage = "semver"
 )
 
 // Map for easy lookups of supported policies.
@@ -41,6 +49,7 @@ var supportedPolicies = map[string]Policy{
 	"image":                     PolicyRegistryImage, // Deprecated in favor of PolicyRegistryImage
 	string(PolicyRegistryImage): PolicyRegistryImage,
 	string(PolicyLocalImage):    PolicyLocalImage,
+	string(PolicySemverImage):   PolicySemverImage,
 }
 
 // updater includes shared state for auto-updating one or more containers.
@@ -48,8 +57,11 @@ type updater struct {
 	conn             *dbus.Conn                  // DBUS connection
 	options          *entities.AutoUpdateOptions // User-specified options
 	unitToTasks      map[string][]*task          // Keeps track of tasks per unit
+	containerTasks   []*task                     // Tasks of containers not running in a systemd unit
 	updatedRawImages map[string]bool             // Keeps track of updated images
+	halted           string                      // Unit or container whose failed update halted the rollout (see options.MaxUnits)
 	runtime          *libpod.Runtime             // The libpod runtime
+	now              time.Time                   // Start of the run, checked against maintenance windows
 }
 
 const (
@@ -58,18 +70,26 @@ const (
 	statusNotUpdated = "false"       // No update was needed
 	statusPending    = "pending"     // The update is pending (see options.DryRun)
 	statusRolledBack = "rolled back" // Rollback after a failed update
+	statusSkipped    = "skipped"     // The update is skipped (see task.reason)
 )
 
 // task includes data and state for updating a container
 type task struct {
-	authfile     string            // Container-specific authfile
-	auto         *updater          // Reverse pointer to the updater
-	container    *libpod.Container // Container to update
-	policy       Policy            // Update policy
-	image        *libimage.Image   // Original image before the update
-	rawImageName string            // The container's raw image name
-	status       string            // Auto-update status
-	unit         string            // Name of the systemd unit
+	authfile     string             // Container-specific authfile
+	auto         *updater           // Reverse pointer to the updater
+	container    *libpod.Container  // Container to update
+	policy       Policy             // Update policy
+	image        *libimage.Image    // Original image before the update
+	rawImageName string             // The container's raw image name
+	status       string             // Auto-update status
+	reason       string             // Explanation of the status
+	unit         string             // Name of the systemd unit
+	window       *maintenanceWindow // Maintenance window of the container, if any
+	signature    bool               // The trust policy must require a signature
+	semver       *semverPattern     // Pattern of the releases to follow (see PolicySemverImage)
+	semverRepo   reference.Named    // Repository of the releases to follow
+	semverTag    string             // Tag of the latest release matching the pattern
+	pinned       string             // Digest the image is referenced by, if any
 }
 
 // LookupPolicy looks up the corresponding Policy for the specified
@@ -118,88 +138,211 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 		options:          &options,
 		runtime:          runtime,
 		updatedRawImages: make(map[string]bool),
+		now:              time.Now(),
 	}
 
 	// Find auto-update tasks and assemble them by unit.
 	allErrors := auto.assembleTasks(ctx)
 
 	// Nothing to do.
-	if len(auto.unitToTasks) == 0 {
+	if len(auto.unitToTasks) == 0 && len(auto.containerTasks) == 0 {
 		return nil, allErrors
 	}
 
 	// Connect to DBUS.
-	conn, err := systemd.ConnectToDBUS()
-	if err != nil {
-		logrus.Error(err.Error())
-		allErrors = append(allErrors, err)
-		return nil, allErrors
+	var allReports []*entities.AutoUpdateReport
+	if len(auto.unitToTasks) > 0 {
+		conn, err := systemd.ConnectToDBUS()
+		if err != nil {
+			logrus.Error(err.Error())
+			allErrors = append(allErrors, err)
+			if len(auto.containerTasks) == 0 {
+				return nil, allErrors
+			}
+			// Containers not running in systemd units can be
+			// updated nonetheless.
+			for _, tasks := range auto.unitToTasks {
+				for _, task := range tasks {
+					task.reason = err.Error()
+					allReports = append(allReports, task.report())
+				}
+			}
+			auto.unitToTasks = nil
+		} else {
+			defer conn.Close()
+			auto.conn = conn
+		}
 	}
-	defer conn.Close()
-	auto.conn = conn
 
 	runtime.NewSystemEvent(events.AutoUpdate)
 
 	// Update all images/container according to their auto-update policy.
-	var allReports []*entities.AutoUpdateReport
-	for unit, tasks := range auto.unitToTasks {
-		unitErrors := auto.updateUnit(ctx, unit, tasks)
-		allErrors = append(allErrors, unitErrors...)
-		for _, task := range tasks {
+	// Units are sorted such that a staged rollout updates them in a
+	// stable order across runs.
+	units := slices.Sorted(maps.Keys(auto.unitToTasks))
+	allErrors = append(allErrors, auto.updateUnits(ctx, units)...)
+	for _, unit := range units {
+		for _, task := range auto.unitToTasks[unit] {
 			allReports = append(allReports, task.report())
 		}
 	}
 
+	// Containers not running in systemd units are recreated by Podman,
+	// sorted by name for the same reason.
+	slices.SortFunc(auto.containerTasks, func(a, b *task) int {
+		return strings.Compare(a.container.Name(), b.container.Name())
+	})
+	for _, task := range auto.containerTasks {
+		containerErrors := auto.updateContainer(ctx, task)
+		allErrors = append(allErrors, containerErrors...)
+		allReports = append(allReports, task.report())
+	}
+
 	return allReports, allErrors
 }
 
-// updateUnit auto updates the tasks in the specified systemd unit.
-func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []error {
+// updateUnits auto updates the specified systemd units.  The images of the
+// units are updated one unit after another, and the units with an updated
+// image are restarted in batches of options.MaxUnits: a batch is restarted
+// once the units of the previous batch are back up.  Without a limit, every
+// unit is restarted right after updating its images.  If a unit of a batch
+// fails to restart with the updated images, the remaining units are skipped.
+func (u *updater) updateUnits(ctx context.Context, units []string) []error {
+	batchSize := max(u.options.MaxUnits, 1)
 	var errors []error
-	tasksUpdated := false
+	var batch []string
+	updatedTasks := make(map[string][]*task)
+	for i, unit := range units {
+		tasks := u.unitToTasks[unit]
+		if u.halted != "" {
+			u.skipHalted(tasks...)
+		} else {
+			updated, unitErrors := u.updateUnitImages(ctx, unit, tasks)
+			errors = append(errors, unitErrors...)
+			if len(updated) > 0 {
+				updatedTasks[unit] = updated
+				batch = append(batch, unit)
+			}
+		}
+		if len(batch) == batchSize || (len(batch) > 0 && i == len(units)-1) {
+			errors = append(errors, u.restartUnits(ctx, batch, updatedTasks)...)
+			batch = nil
+		}
+	}
+	return errors
+}
+
+// skipHalted marks the tasks as skipped as the staged rollout is halted.
+func (u *updater) skipHalted(tasks ...*task) {
+	for _, task := range tasks {
+		task.status = statusSkipped
+		task.reason = fmt.Sprintf("staged rollout: halted as updating %s failed", u.halted)
+	}
+}
+
+// updateUnitImages updates the images of the tasks in the specified systemd
+// unit.  It returns the tasks with an updated image, the unit must be
+// restarted for them to use it.
+func (u *updater) updateUnitImages(ctx context.Context, unit string, tasks []*task) ([]*task, []error) {
+	var errors []error
+	var updatedTasks []*task
+
+	// Restarting the unit affects all its containers, so the unit is only
+	// updated within the maintenance windows of all of them.
+	for _, task := range tasks {
+		if task.window != nil && !task.window.contains(u.now) {
+			for _, t := range tasks {
+				t.status = statusSkipped
+				t.reason = fmt.Sprintf("outside of maintenance window %q of container %s", task.window, task.container.ID())
+			}
+			return nil, nil
+		}
+	}
 
 	for _, task := range tasks {
 		err := func() error { // Use an anonymous function to avoid spaghetti continue's
 			updateAvailable, err := task.updateAvailable(ctx)
 			if err != nil {
 				task.status = statusFailed
-				return fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err)
+				err = fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err)
+				task.reason = err.Error()
+				return err
 			}
 
 			if !updateAvailable {
 				task.status = statusNotUpdated
+				task.reason = task.upToDateReason()
 				return nil
 			}
 
 			if u.options.DryRun {
 				task.status = statusPending
+				task.reason = "update available"
+				if task.semverTag != "" {
+					task.reason = "update to " + task.semverTag + " available"
+				}
 				return nil
 			}
 
 			if err := task.update(ctx); err != nil {
 				task.status = statusFailed
-				return fmt.Errorf("updating image for container %s: %w", task.container.ID(), err)
+				err = fmt.Errorf("updating image for container %s: %w", task.container.ID(), err)
+				task.reason = err.Error()
+				return err
 			}
 
-			tasksUpdated = true
+			updatedTasks = append(updatedTasks, task)
 			return nil
 		}()
 		if err != nil {
 			errors = append(errors, err)
 		}
 	}
+	return updatedTasks, errors
+}
 
-	// If no task has been updated, we can jump directly to the next unit.
-	if !tasksUpdated {
-		return errors
+// restartUnits restarts the batch of systemd units at once to use their
+// updated images, and waits for all of them to be back up.  Units which fail
+// to restart are rolled back if rollbacks are enabled, and halt a staged
+// rollout.
+func (u *updater) restartUnits(ctx context.Context, batch []string, updatedTasks map[string][]*task) []error {
+	var errors []error
+	for i, updateError := range u.restartSystemdUnits(ctx, batch) {
+		unit := batch[i]
+		unitErrors := u.finishUnit(ctx, unit, u.unitToTasks[unit], updatedTasks[unit], updateError)
+		errors = append(errors, unitErrors...)
+		if updateError != nil && u.options.MaxUnits > 0 && u.halted == "" {
+			u.halted = "unit " + unit
+		}
 	}
+	return errors
+}
 
-	updateError := u.restartSystemdUnit(ctx, unit)
+// finishUnit sets the status of the tasks in the specified systemd unit after
+// restarting it with the updated images, and rolls back the update if the
+// restart failed.
+func (u *updater) finishUnit(ctx context.Context, unit string, tasks, updatedTasks []*task, updateError error) []error {
+	var errors []error
 	for _, task := range tasks {
 		if updateError == nil {
 			task.status = statusUpdated
+			task.reason = ""
+			if task.semverTag != "" {
+				task.reason = "updated to " + task.semverTag
+			}
 		} else {
 			task.status = statusFailed
+			task.reason = fmt.Sprintf("restarting unit %s: %v", unit, updateError)
+		}
+	}
+
+	// Record the images used before the update, so the update can be
+	// rolled back once a container turns unhealthy.
+	if updateError == nil {
+		for _, task := range updatedTasks {
+			if err := task.recordRollbackImage(); err != nil {
+				errors = append(errors, fmt.Errorf("recording rollback image for container %s: %w", task.container.ID(), err))
+			}
 		}
 	}
 
@@ -220,16 +363,18 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 	}
 
 	if err := u.restartSystemdUnit(ctx, unit); err != nil {
+		err = fmt.Errorf("restarting unit %s during rollback: %w", unit, err)
 		for _, task := range tasks {
 			task.status = statusFailed
+			task.reason = err.Error()
 		}
-		err = fmt.Errorf("restarting unit %s during rollback: %w", unit, err)
 		errors = append(errors, err)
 		return errors
 	}
 
 	for _, task := range tasks {
 		task.status = statusRolledBack
+		task.reason = fmt.Sprintf("restarting unit %s with the updated image failed: %v", unit, updateError)
 	}
 
 	return errors
@@ -244,7 +389,16 @@ func (t *task) report() *entities.AutoUpdateReport {
 		Policy:        string(t.policy),
 		SystemdUnit:   t.unit,
 		Updated:       t.status,
+		Reason:        t.reason,
+	}
+}
+
+// upToDateReason explains why the task needs no update.
+func (t *task) upToDateReason() string {
+	if t.pinned != "" {
+		return "image is pinned to digest " + t.pinned
 	}
+	return "image is up to date"
 }
 
 // updateAvailable returns whether an update for the task is available.
@@ -254,6 +408,8 @@ func (t *task) updateAvailable(ctx context.Context) (bool, error) {
 		return t.registryUpdateAvailable(ctx)
 	case PolicyLocalImage:
 		return t.localUpdateAvailable()
+	case PolicySemverImage:
+		return t.semverUpdateAvailable(ctx)
 	default:
 		return false, fmt.Errorf("unexpected auto-update policy %s for container %s", t.policy, t.container.ID())
 	}
@@ -267,6 +423,8 @@ func (t *task) update(ctx context.Context) error {
 	case PolicyLocalImage:
 		// Nothing to do as the image is already available in the local storage.
 		return nil
+	case PolicySemverImage:
+		return t.semverUpdate(ctx)
 	default:
 		return fmt.Errorf("unexpected auto-update policy %s for container %s", t.policy, t.container.ID())
 	}
@@ -274,6 +432,11 @@ func (t *task) update(ctx context.Context) error {
 
 // registryUpdateAvailable returns whether a new image on the registry is available.
 func (t *task) registryUpdateAvailable(ctx context.Context) (bool, error) {
+	// An image referenced by digest cannot change.
+	if t.pinned != "" {
+		return false, nil
+	}
+
 	// The newer image has already been pulled for another task, so we know
 	// there's a newer one available.
 	if _, exists := t.auto.updatedRawImages[t.rawImageName]; exists {
@@ -284,6 +447,11 @@ func (t *task) registryUpdateAvailable(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	if t.signature {
+		if err := t.checkSignatureRequired(remoteRef); err != nil {
+			return false, err
+		}
+	}
 	options := &libimage.HasDifferentDigestOptions{
 		AuthFilePath:          t.authfile,
 		InsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
@@ -331,23 +499,42 @@ func (t *task) rollbackImage() error {
 
 // restartSystemdUnit restarts the systemd unit the container is running in.
 func (u *updater) restartSystemdUnit(ctx context.Context, unit string) error {
-	restartChan := make(chan string)
-	if _, err := u.conn.RestartUnitContext(ctx, unit, "replace", restartChan); err != nil {
-		return err
+	return u.restartSystemdUnits(ctx, []string{unit})[0]
+}
+
+// restartSystemdUnits restarts the systemd units at once and waits for all
+// restarts to finish.  It returns the error of restarting each unit.
+func (u *updater) restartSystemdUnits(ctx context.Context, units []string) []error {
+	errors := make([]error, len(units))
+	restartChans := make([]chan string, len(units))
+	for i, unit := range units {
+		// The channels are buffered as systemd may finish the restarts
+		// in any order.
+		restartChan := make(chan string, 1)
+		if _, err := u.conn.RestartUnitContext(ctx, unit, "replace", restartChan); err != nil {
+			errors[i] = err
+			continue
+		}
+		restartChans[i] = restartChan
 	}
 
-	// Wait for the restart to finish and actually check if it was
+	// Wait for the restarts to finish and actually check if they were
 	// successful or not.
-	result := <-restartChan
+	for i, unit := range units {
+		if restartChans[i] == nil {
+			continue
+		}
+		result := <-restartChans[i]
 
-	switch result {
-	case "done":
-		logrus.Infof("Successfully restarted systemd unit %q", unit)
-		return nil
+		switch result {
+		case "done":
+			logrus.Infof("Successfully restarted systemd unit %q", unit)
 
-	default:
-		return fmt.Errorf("error restarting systemd unit %q expected %q but received %q", unit, "done", result)
+		default:
+			errors[i] = fmt.Errorf("error restarting systemd unit %q expected %q but received %q", unit, "done", result)
+		}
 	}
+	return errors
 }
 
 // assembleTasks assembles update tasks per unit and populates a mapping from
@@ -399,15 +586,21 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			continue
 		}
 
-		// Make sure the container runs in a systemd unit which is
-		// stored as a label at container creation.
+		// Check if the container runs in a systemd unit which is
+		// stored as a label at container creation.  Otherwise, Podman
+		// recreates the container itself, which is not supported for
+		// containers in pods.
 		unit, exists, err := u.systemdUnitForContainer(ctr, labels)
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		if !exists {
-			errs = append(errs, fmt.Errorf("auto-updating container %q: no %s label found", ctr.ID(), systemdDefine.EnvVariable))
+		if !exists && ctr.PodID() != "" {
+			errs = append(errs, fmt.Errorf("auto-updating container %q: no %s label found, containers in pods must run in a systemd unit", ctr.ID(), systemdDefine.EnvVariable))
+			continue
+		}
+		if !exists && ctr.AutoRemove() {
+			errs = append(errs, fmt.Errorf("auto-updating container %q: no %s label found, containers removed on exit must run in a systemd unit", ctr.ID(), systemdDefine.EnvVariable))
 			continue
 		}
 
@@ -442,13 +635,65 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			status:       statusFailed, // must be updated later on
 		}
 
+		if value, ok := labels[define.AutoUpdateWindowLabel]; ok {
+			t.window, err = parseMaintenanceWindow(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("auto-updating container %q: %w", ctr.ID(), err))
+				continue
+			}
+		}
+
+		switch value := labels[define.AutoUpdateSignatureLabel]; value {
+		case "", "optional":
+		case "required":
+			if policy == PolicyLocalImage {
+				errs = append(errs, fmt.Errorf("auto-updating container %q: the %s label requires the %s or %s policy", ctr.ID(), define.AutoUpdateSignatureLabel, PolicyRegistryImage, PolicySemverImage))
+				continue
+			}
+			t.signature = true
+		default:
+			errs = append(errs, fmt.Errorf("auto-updating container %q: invalid value %q of the %s label: must be required or optional", ctr.ID(), value, define.AutoUpdateSignatureLabel))
+			continue
+		}
+
+		if policy == PolicyRegistryImage {
+			t.pinned = pinnedDigest(rawImageName)
+		}
+
+		if policy == PolicySemverImage {
+			t.semver, t.semverRepo, err = parseSemverPattern(rawImageName)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("auto-updating container %q: %w", ctr.ID(), err))
+				continue
+			}
+		}
+
 		// Add the task to the unit.
+		if unit == "" {
+			u.containerTasks = append(u.containerTasks, &t)
+			continue
+		}
 		u.unitToTasks[unit] = append(u.unitToTasks[unit], &t)
 	}
 
 	return errs
 }
 
+// pinnedDigest returns the digest of an image name like
+// quay.io/podman/stable@sha256:..., or an empty string if the image is not
+// referenced by digest.
+func pinnedDigest(rawImageName string) string {
+	named, err := reference.ParseNormalizedNamed(rawImageName)
+	if err != nil {
+		return ""
+	}
+	digested, ok := named.(reference.Digested)
+	if !ok {
+		return ""
+	}
+	return digested.Digest().String()
+}
+
 // systemdUnitForContainer returns the name of the container's systemd unit.
 // If the container is part of a pod, the pod's infra container's systemd unit
 // is returned.  This allows for auto update to restart the pod's systemd unit.
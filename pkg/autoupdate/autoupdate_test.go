@@ -0,0 +1,17 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinnedDigest(t *testing.T) {
+	digest := "sha256:5ba5ac39f4a8a6dbfb6fe6a5c0cb3fdd7e85d32e0f2ef0aef4ad1b1e27bdb2b4"
+	assert.Equal(t, digest, pinnedDigest("quay.io/podman/stable@"+digest))
+	assert.Equal(t, digest, pinnedDigest("quay.io/podman/stable:latest@"+digest))
+	assert.Empty(t, pinnedDigest("quay.io/podman/stable:latest"))
+	assert.Empty(t, pinnedDigest("not a reference"))
+}
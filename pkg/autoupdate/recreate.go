@@ -0,0 +1,210 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/specgen"
+	"go.podman.io/podman/v6/pkg/specgen/generate"
+)
+
+// oldContainerSuffix is appended to the name of a container not running in a
+// systemd unit while it is recreated, so that the new container can take over
+// its name.
+const oldContainerSuffix = "-autoupdate-old"
+
+// updateContainer auto updates a task for a container which does not run in
+// a systemd unit.  Podman recreates the container itself: it stops the
+// container, creates a new one from the configuration of the old one with the
+// updated image, starts it and waits for it to become healthy.  If that fails
+// and rollbacks are enabled, the new container is removed and the old one
+// started again with the previous image.  Containers are recreated one after
+// another, a failure halts a staged rollout like a unit failing to restart.
+func (u *updater) updateContainer(ctx context.Context, task *task) []error {
+	if u.halted != "" {
+		u.skipHalted(task)
+		return nil
+	}
+	if task.window != nil && !task.window.contains(u.now) {
+		task.status = statusSkipped
+		task.reason = fmt.Sprintf("outside of maintenance window %q", task.window)
+		return nil
+	}
+
+	updateAvailable, err := task.updateAvailable(ctx)
+	if err != nil {
+		task.status = statusFailed
+		err = fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err)
+		task.reason = err.Error()
+		return []error{err}
+	}
+	if !updateAvailable {
+		task.status = statusNotUpdated
+		task.reason = task.upToDateReason()
+		return nil
+	}
+	if u.options.DryRun {
+		task.status = statusPending
+		task.reason = "update available"
+		if task.semverTag != "" {
+			task.reason = "update to " + task.semverTag + " available"
+		}
+		return nil
+	}
+
+	if err := task.update(ctx); err != nil {
+		task.status = statusFailed
+		err = fmt.Errorf("updating image for container %s: %w", task.container.ID(), err)
+		task.reason = err.Error()
+		return []error{err}
+	}
+
+	newCtr, updateError := task.recreate(ctx)
+	if updateError == nil {
+		task.status = statusUpdated
+		task.reason = "recreated as container " + newCtr.ID()
+		if task.semverTag != "" {
+			task.reason = fmt.Sprintf("updated to %s, %s", task.semverTag, task.reason)
+		}
+		var errors []error
+		if err := task.recordRollbackImage(); err != nil {
+			errors = append(errors, fmt.Errorf("recording rollback image for container %s: %w", task.container.ID(), err))
+		}
+		if err := u.runtime.RemoveContainer(ctx, task.container, true, false, nil); err != nil {
+			errors = append(errors, fmt.Errorf("removing container %s after update: %w", task.container.ID(), err))
+		}
+		return errors
+	}
+
+	updateError = fmt.Errorf("recreating container %s during update: %w", task.container.ID(), updateError)
+	if u.options.MaxUnits > 0 {
+		u.halted = "container " + task.container.Name()
+	}
+	task.status = statusFailed
+	task.reason = updateError.Error()
+	if !u.options.Rollback {
+		if newCtr != nil {
+			task.reason += fmt.Sprintf(", the new container is %s", newCtr.ID())
+		}
+		return []error{updateError}
+	}
+
+	errors := []error{updateError}
+	if err := task.rollbackContainer(ctx, newCtr); err != nil {
+		err = fmt.Errorf("rolling back container %s: %w", task.container.ID(), err)
+		task.reason = err.Error()
+		return append(errors, err)
+	}
+	task.status = statusRolledBack
+	task.reason = fmt.Sprintf("recreating the container with the updated image failed: %v", updateError)
+	return errors
+}
+
+// recreate stops the container of the task and replaces it by a new container
+// with the same configuration and name running the updated image.  The old
+// container is kept under another name, so the caller can remove it or roll
+// back to it.  The new container is returned if it has been created, even if
+// starting it failed.
+func (t *task) recreate(ctx context.Context) (*libpod.Container, error) {
+	name := t.container.Name()
+	if err := t.container.Stop(); err != nil {
+		return nil, fmt.Errorf("stopping container: %w", err)
+	}
+	renamed, err := t.auto.runtime.RenameContainer(ctx, t.container, name+oldContainerSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("renaming container: %w", err)
+	}
+	t.container = renamed
+
+	spec := specgen.NewSpecGenerator(t.rawImageName, false)
+	if _, _, err := generate.ConfigToSpec(t.auto.runtime, spec, t.container.ID()); err != nil {
+		return nil, err
+	}
+	spec.Name = name
+	spec.Image = t.rawImageName
+	spec.RawImageName = t.rawImageName
+	warnings, err := generate.CompleteSpec(ctx, t.auto.runtime, spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		logrus.Warnf("Recreating container %s: %s", name, w)
+	}
+	terminal := t.container.Terminal()
+	spec.Terminal = &terminal
+
+	rtSpec, spec, opts, err := generate.MakeContainer(ctx, t.auto.runtime, spec, true, t.container)
+	if err != nil {
+		return nil, err
+	}
+	newCtr, err := generate.ExecuteCreate(ctx, t.auto.runtime, rtSpec, spec, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Recreated container %s as %s", t.container.ID(), newCtr.ID())
+
+	if err := newCtr.Start(ctx, true); err != nil {
+		return newCtr, fmt.Errorf("starting container %s: %w", newCtr.ID(), err)
+	}
+	if newCtr.HasHealthCheck() {
+		waitCtx, cancel := context.WithTimeout(ctx, healthyTimeout(newCtr))
+		defer cancel()
+		if err := newCtr.WaitForHealthy(waitCtx); err != nil {
+			return newCtr, err
+		}
+	}
+	return newCtr, nil
+}
+
+// rollbackContainer removes the new container, if any, tags the previous
+// image again and starts the old container under its original name.
+func (t *task) rollbackContainer(ctx context.Context, newCtr *libpod.Container) error {
+	if newCtr != nil {
+		if err := t.auto.runtime.RemoveContainer(ctx, newCtr, true, false, nil); err != nil {
+			return fmt.Errorf("removing container %s: %w", newCtr.ID(), err)
+		}
+	}
+	if err := t.rollbackImage(); err != nil {
+		return err
+	}
+	if name, renamed := strings.CutSuffix(t.container.Name(), oldContainerSuffix); renamed {
+		ctr, err := t.auto.runtime.RenameContainer(ctx, t.container, name)
+		if err != nil {
+			return err
+		}
+		t.container = ctr
+	}
+	state, err := t.container.State()
+	if err != nil {
+		return err
+	}
+	if state == define.ContainerStateRunning {
+		return nil
+	}
+	return t.container.Start(ctx, true)
+}
+
+// healthyTimeout returns how long to wait for a recreated container to become
+// healthy: the start period of its healthcheck and the time all retries of
+// the healthcheck may take.
+func healthyTimeout(c *libpod.Container) time.Duration {
+	hc := c.HealthCheckConfig()
+	if hc == nil {
+		return 0
+	}
+	interval, timeout := hc.Interval, hc.Timeout
+	if interval <= 0 {
+		interval, _ = time.ParseDuration(define.DefaultHealthCheckInterval)
+	}
+	if timeout <= 0 {
+		timeout, _ = time.ParseDuration(define.DefaultHealthCheckTimeout)
+	}
+	return hc.StartPeriod + time.Duration(max(hc.Retries, 1)+1)*(interval+timeout)
+}
@@ -0,0 +1,203 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	"go.podman.io/podman/v6/pkg/systemd"
+	systemdDefine "go.podman.io/podman/v6/pkg/systemd/define"
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+// rollbackImageDir returns the directory recording the images to roll back
+// to.  For each updated raw image name, it contains a file named after the
+// digest of the name with the ID of the image used before the update.
+func (u *updater) rollbackImageDir() (string, error) {
+	cfg, err := u.runtime.GetConfigNoCopy()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cfg.Engine.StaticDir, "auto-update", "rollback"), nil
+}
+
+func (u *updater) rollbackImagePath(rawImageName string) (string, error) {
+	dir, err := u.rollbackImageDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, digest.FromString(rawImageName).Encoded()), nil
+}
+
+// recordRollbackImage records the image the task used before the update, so
+// that the update can be rolled back once the container turns unhealthy.
+func (t *task) recordRollbackImage() error {
+	path, err := t.auto.rollbackImagePath(t.rawImageName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(path, []byte(t.image.ID()), 0o600)
+}
+
+// lookupRollbackImage returns the ID of the image used before the last update
+// of the raw image name.  An empty string is returned if none was recorded.
+func (u *updater) lookupRollbackImage(rawImageName string) (string, error) {
+	path, err := u.rollbackImagePath(rawImageName)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// removeRollbackImage removes the image recorded for the raw image name, so
+// the same update is not rolled back twice.
+func (u *updater) removeRollbackImage(rawImageName string) error {
+	path, err := u.rollbackImagePath(rawImageName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// RollbackUnhealthy rolls back the last auto-update of a container which
+// turned unhealthy.  It is run by libpod as the rollback on-failure action of
+// the health check (see libpod.WithHealthCheckRollbackHook).  As for a failed
+// update, the image used before the update is tagged again and the systemd
+// unit of the container restarted.  A container not running in a systemd
+// unit is recreated with the previous image instead, and removed once the
+// new container is healthy.
+func RollbackUnhealthy(ctx context.Context, runtime *libpod.Runtime, ctr *libpod.Container) error {
+	labels := ctr.Labels()
+	policy, err := LookupPolicy(labels[define.AutoUpdateLabel])
+	if err != nil {
+		return fmt.Errorf("rolling back container %s: %w", ctr.ID(), err)
+	}
+	if policy == PolicyDefault {
+		return fmt.Errorf("rolling back container %s: container is not auto-updated", ctr.ID())
+	}
+
+	auto := updater{
+		options:          &entities.AutoUpdateOptions{Rollback: true},
+		runtime:          runtime,
+		updatedRawImages: make(map[string]bool),
+	}
+	unit, exists, err := auto.systemdUnitForContainer(ctr, labels)
+	if err != nil {
+		return err
+	}
+	if !exists && ctr.PodID() != "" {
+		return fmt.Errorf("rolling back container %s: no %s label found, containers in pods must run in a systemd unit", ctr.ID(), systemdDefine.EnvVariable)
+	}
+	if !exists && ctr.AutoRemove() {
+		return fmt.Errorf("rolling back container %s: no %s label found, containers removed on exit must run in a systemd unit", ctr.ID(), systemdDefine.EnvVariable)
+	}
+
+	rawImageName := ctr.RawImageName()
+	imageID, err := auto.lookupRollbackImage(rawImageName)
+	if err != nil {
+		return fmt.Errorf("rolling back container %s: %w", ctr.ID(), err)
+	}
+	if imageID == "" {
+		return fmt.Errorf("rolling back container %s: no previous image of %s recorded by auto-update", ctr.ID(), rawImageName)
+	}
+	image, _, err := runtime.LibimageRuntime().LookupImage(imageID, nil)
+	if err != nil {
+		return fmt.Errorf("rolling back container %s: looking up previous image of %s: %w", ctr.ID(), rawImageName, err)
+	}
+
+	t := task{
+		auto:         &auto,
+		container:    ctr,
+		policy:       policy,
+		image:        image,
+		rawImageName: rawImageName,
+		unit:         unit,
+	}
+	if !exists {
+		return t.rollbackRecreate(ctx)
+	}
+
+	if err := t.rollbackImage(); err != nil {
+		return fmt.Errorf("rolling back image for container %s in unit %s: %w", ctr.ID(), unit, err)
+	}
+	// Remove the record before restarting the unit, as this may stop the
+	// unit running the healthcheck.
+	if err := auto.removeRollbackImage(rawImageName); err != nil {
+		logrus.Errorf("Removing rollback image of %s: %v", rawImageName, err)
+	}
+
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	auto.conn = conn
+
+	logrus.Infof("Rolling back unhealthy container %s in unit %s to image %s", ctr.ID(), unit, imageID)
+	if err := auto.restartSystemdUnit(ctx, unit); err != nil {
+		return fmt.Errorf("restarting unit %s during rollback: %w", unit, err)
+	}
+	return nil
+}
+
+// rollbackRecreate rolls back a container not running in a systemd unit by
+// recreating it with the previous image of the task.  If the new container
+// fails to start or to become healthy, it is removed and the unhealthy
+// container started again with the updated image.
+func (t *task) rollbackRecreate(ctx context.Context) error {
+	ctr := t.container
+	updatedID, _ := ctr.Image()
+	updated, _, err := t.auto.runtime.LibimageRuntime().LookupImage(updatedID, nil)
+	if err != nil {
+		return fmt.Errorf("rolling back container %s: looking up image: %w", ctr.ID(), err)
+	}
+
+	if err := t.rollbackImage(); err != nil {
+		return fmt.Errorf("rolling back image for container %s: %w", ctr.ID(), err)
+	}
+	// Remove the record before recreating the container, so the new
+	// container is not rolled back again if it turns unhealthy.
+	if err := t.auto.removeRollbackImage(t.rawImageName); err != nil {
+		logrus.Errorf("Removing rollback image of %s: %v", t.rawImageName, err)
+	}
+
+	logrus.Infof("Rolling back unhealthy container %s to image %s", ctr.ID(), t.image.ID())
+	newCtr, rollbackErr := t.recreate(ctx)
+	if rollbackErr == nil {
+		if err := t.auto.runtime.RemoveContainer(ctx, t.container, true, false, nil); err != nil {
+			return fmt.Errorf("removing container %s after rollback: %w", ctr.ID(), err)
+		}
+		return nil
+	}
+
+	rollbackErr = fmt.Errorf("recreating container %s during rollback: %w", ctr.ID(), rollbackErr)
+	t.image = updated
+	if err := t.rollbackContainer(ctx, newCtr); err != nil {
+		return errors.Join(rollbackErr, fmt.Errorf("restoring container %s: %w", ctr.ID(), err))
+	}
+	return rollbackErr
+}
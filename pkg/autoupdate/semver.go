@@ -0,0 +1,152 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/types"
+)
+
+// semverPattern is the tag of an image followed by the semver policy, e.g.,
+// 1.4.x to follow the patch releases of 1.4.  An optional "v" prefix of the
+// pattern must be used by the tags of the releases as well.
+type semverPattern struct {
+	pattern string
+	prefix  string
+	match   semver.Range
+}
+
+// parseSemverPattern parses the tag of the raw image name of a container with
+// the semver policy.
+func parseSemverPattern(rawImageName string) (*semverPattern, reference.Named, error) {
+	named, err := reference.ParseNormalizedNamed(rawImageName)
+	if err != nil {
+		return nil, nil, err
+	}
+	tagged, ok := named.(reference.NamedTagged)
+	if !ok {
+		return nil, nil, fmt.Errorf("semver auto-update policy requires a tag like 1.4.x in image %q", rawImageName)
+	}
+	p := &semverPattern{pattern: tagged.Tag()}
+	version, hasPrefix := strings.CutPrefix(p.pattern, "v")
+	if hasPrefix {
+		p.prefix = "v"
+	}
+	if !strings.Contains(version, "x") {
+		return nil, nil, fmt.Errorf("semver auto-update policy requires a tag like 1.4.x in image %q", rawImageName)
+	}
+	// The semver package expands 1.x but not 1.x.x.
+	p.match, err = semver.ParseRange(strings.Replace(version, ".x.x", ".x", 1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid semver pattern %q in image %q: %w", p.pattern, rawImageName, err)
+	}
+	return p, reference.TrimNamed(named), nil
+}
+
+// latest returns the tag of the latest release matching the pattern.  Pre-
+// releases are never matched.  An empty string is returned if no tag
+// matches.
+func (p *semverPattern) latest(tags []string) string {
+	var latestTag string
+	var latest semver.Version
+	for _, tag := range tags {
+		s, ok := strings.CutPrefix(tag, p.prefix)
+		if !ok || (p.prefix == "" && strings.HasPrefix(tag, "v")) {
+			continue
+		}
+		version, err := semver.Parse(s)
+		if err != nil || len(version.Pre) > 0 || !p.match(version) {
+			continue
+		}
+		if latestTag == "" || version.GT(latest) {
+			latestTag, latest = tag, version
+		}
+	}
+	return latestTag
+}
+
+// semverUpdateAvailable returns whether the latest release on the registry
+// matching the pattern differs from the image of the container.
+func (t *task) semverUpdateAvailable(ctx context.Context) (bool, error) {
+	sys := t.auto.runtime.SystemContext()
+	if sys == nil {
+		sys = &types.SystemContext{}
+	}
+	sysCopy := *sys
+	sysCopy.AuthFilePath = t.authfile
+	sysCopy.DockerInsecureSkipTLSVerify = t.auto.options.InsecureSkipTLSVerify
+
+	repoRef, err := docker.NewReference(reference.TagNameOnly(t.semverRepo))
+	if err != nil {
+		return false, err
+	}
+	tags, err := docker.GetRepositoryTags(ctx, &sysCopy, repoRef)
+	if err != nil {
+		return false, fmt.Errorf("listing tags of %s: %w", t.semverRepo.Name(), err)
+	}
+	t.semverTag = t.semver.latest(tags)
+	if t.semverTag == "" {
+		return false, fmt.Errorf("no tag of %s matches %s", t.semverRepo.Name(), t.semver.pattern)
+	}
+
+	remoteRef, err := docker.ParseReference("//" + t.semverImageName())
+	if err != nil {
+		return false, err
+	}
+	if t.signature {
+		if err := t.checkSignatureRequired(remoteRef); err != nil {
+			return false, err
+		}
+	}
+	// The release has already been pulled for another task.
+	if _, exists := t.auto.updatedRawImages[t.rawImageName]; exists {
+		return true, nil
+	}
+	options := &libimage.HasDifferentDigestOptions{
+		AuthFilePath:          t.authfile,
+		InsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
+	}
+	return t.image.HasDifferentDigest(ctx, remoteRef, options)
+}
+
+// semverUpdate pulls down the latest release matching the pattern and tags it
+// with the raw image name of the container, so that restarting its unit runs
+// the release.
+func (t *task) semverUpdate(ctx context.Context) error {
+	// The release has already been pulled for another task.
+	if _, exists := t.auto.updatedRawImages[t.rawImageName]; exists {
+		return nil
+	}
+
+	pullOptions := &libimage.PullOptions{}
+	pullOptions.AuthFilePath = t.authfile
+	pullOptions.Writer = os.Stderr
+	pullOptions.InsecureSkipTLSVerify = t.auto.options.InsecureSkipTLSVerify
+	images, err := t.auto.runtime.LibimageRuntime().Pull(ctx, t.semverImageName(), config.PullPolicyAlways, pullOptions)
+	if err != nil {
+		return err
+	}
+	if len(images) != 1 {
+		return fmt.Errorf("internal error: pulling %s returned %d images", t.semverImageName(), len(images))
+	}
+	if err := images[0].Tag(t.rawImageName); err != nil {
+		return err
+	}
+
+	t.auto.updatedRawImages[t.rawImageName] = true
+	return nil
+}
+
+// semverImageName returns the name of the latest release matching the pattern.
+func (t *task) semverImageName() string {
+	return t.semverRepo.Name() + ":" + t.semverTag
+}
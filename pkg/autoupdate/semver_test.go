@@ -0,0 +1,36 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemverPattern(t *testing.T) {
+	tags := []string{"latest", "1.3.9", "1.4", "1.4.1", "1.4.10", "1.4.2", "1.4.11-rc.1", "1.5.0", "2.0.0", "v1.4.20", "v1.5.1"}
+
+	for _, test := range []struct {
+		rawImageName string
+		repo         string
+		latest       string
+	}{
+		{"quay.io/example/app:1.4.x", "quay.io/example/app", "1.4.10"},
+		{"quay.io/example/app:1.x", "quay.io/example/app", "1.5.0"},
+		{"quay.io/example/app:1.x.x", "quay.io/example/app", "1.5.0"},
+		{"quay.io/example/app:v1.x", "quay.io/example/app", "v1.5.1"},
+		{"quay.io/example/app:3.x", "quay.io/example/app", ""},
+	} {
+		pattern, repo, err := parseSemverPattern(test.rawImageName)
+		require.NoError(t, err, test.rawImageName)
+		assert.Equal(t, test.repo, repo.String(), test.rawImageName)
+		assert.Equal(t, test.latest, pattern.latest(tags), test.rawImageName)
+	}
+
+	for _, rawImageName := range []string{"quay.io/example/app", "quay.io/example/app:1.4", "quay.io/example/app:latest", "quay.io/example/app:x"} {
+		_, _, err := parseSemverPattern(rawImageName)
+		assert.Error(t, err, rawImageName)
+	}
+}
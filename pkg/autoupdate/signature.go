@@ -0,0 +1,71 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.podman.io/image/v5/signature"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/podman/v6/libpod/define"
+)
+
+// signatureRequired returns whether the trust policy requires the image to be
+// signed.  Pulling the image then verifies the signature, so images without a
+// valid signature are never updated to.
+func signatureRequired(policy *signature.Policy, ref types.ImageReference) (bool, error) {
+	for _, requirement := range policyRequirements(policy, ref) {
+		data, err := json.Marshal(requirement)
+		if err != nil {
+			return false, err
+		}
+		var common struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(data, &common); err != nil {
+			return false, err
+		}
+		switch common.Type {
+		case "signedBy", "sigstoreSigned":
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// policyRequirements returns the requirements of the trust policy applying to
+// the image, looked up as by the signature package.
+func policyRequirements(policy *signature.Policy, ref types.ImageReference) signature.PolicyRequirements {
+	if scopes, ok := policy.Transports[ref.Transport().Name()]; ok {
+		if requirements, ok := scopes[ref.PolicyConfigurationIdentity()]; ok {
+			return requirements
+		}
+		for _, namespace := range ref.PolicyConfigurationNamespaces() {
+			if requirements, ok := scopes[namespace]; ok {
+				return requirements
+			}
+		}
+		if requirements, ok := scopes[""]; ok {
+			return requirements
+		}
+	}
+	return policy.Default
+}
+
+// checkSignatureRequired returns an error unless the trust policy requires
+// the image of the task to be signed.
+func (t *task) checkSignatureRequired(ref types.ImageReference) error {
+	policy, err := signature.DefaultPolicy(t.auto.runtime.SystemContext())
+	if err != nil {
+		return err
+	}
+	required, err := signatureRequired(policy, ref)
+	if err != nil {
+		return err
+	}
+	if !required {
+		return fmt.Errorf("the trust policy does not require a signature for %s but the %s label does", ref.PolicyConfigurationIdentity(), define.AutoUpdateSignatureLabel)
+	}
+	return nil
+}
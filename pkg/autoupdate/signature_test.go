@@ -0,0 +1,42 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/signature"
+)
+
+func TestSignatureRequired(t *testing.T) {
+	policy, err := signature.NewPolicyFromBytes([]byte(`{
+	"default": [{"type": "insecureAcceptAnything"}],
+	"transports": {
+		"docker": {
+			"quay.io/signed": [{"type": "signedBy", "keyType": "GPGKeys", "keyPath": "/etc/pki/key.gpg"}],
+			"quay.io/signed/unsigned": [{"type": "insecureAcceptAnything"}],
+			"quay.io/sigstore/app:1.4.x": [{"type": "sigstoreSigned", "keyPath": "/etc/pki/key.pub"}],
+			"quay.io/rejected": [{"type": "reject"}]
+		}
+	}
+}`))
+	require.NoError(t, err)
+
+	for image, want := range map[string]bool{
+		"quay.io/signed/app:latest":     true,
+		"quay.io/signed/unsigned:1":     false,
+		"quay.io/sigstore/app:1.4.x":    true,
+		"quay.io/sigstore/app:latest":   false,
+		"quay.io/rejected/app:latest":   false,
+		"docker.io/library/alpine:3.20": false,
+	} {
+		ref, err := docker.ParseReference("//" + image)
+		require.NoError(t, err)
+		required, err := signatureRequired(policy, ref)
+		require.NoError(t, err)
+		assert.Equal(t, want, required, image)
+	}
+}
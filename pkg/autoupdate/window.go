@@ -0,0 +1,132 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maintenanceWindow is a recurring period of time in which a container may be
+// auto-updated.  It is specified in the io.containers.autoupdate.window label
+// as "[DAYS ]HH:MM-HH:MM" in local time, where DAYS is a comma-separated list
+// of weekdays or ranges of weekdays, e.g., "Mon-Fri,Sun 22:00-02:00".  The
+// days refer to the start of the window, which may end on the next day.
+// Several windows are separated by semicolons.
+type maintenanceWindow struct {
+	spec    string
+	periods []maintenancePeriod
+}
+
+type maintenancePeriod struct {
+	days       [7]bool       // Weekdays the period starts on
+	start, end time.Duration // Time of the day the period starts and ends
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseMaintenanceWindow parses the value of the window label.
+func parseMaintenanceWindow(spec string) (*maintenanceWindow, error) {
+	window := &maintenanceWindow{spec: spec}
+	for s := range strings.SplitSeq(spec, ";") {
+		period, err := parseMaintenancePeriod(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %w", spec, err)
+		}
+		window.periods = append(window.periods, period)
+	}
+	return window, nil
+}
+
+func parseMaintenancePeriod(s string) (maintenancePeriod, error) {
+	var period maintenancePeriod
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		for day := range period.days {
+			period.days[day] = true
+		}
+	case 2:
+		for r := range strings.SplitSeq(fields[0], ",") {
+			first, last, isRange := strings.Cut(r, "-")
+			if !isRange {
+				last = first
+			}
+			from, ok := weekdays[strings.ToLower(first)]
+			if !ok {
+				return period, fmt.Errorf("invalid weekday %q", first)
+			}
+			to, ok := weekdays[strings.ToLower(last)]
+			if !ok {
+				return period, fmt.Errorf("invalid weekday %q", last)
+			}
+			for day := from; ; day = (day + 1) % 7 {
+				period.days[day] = true
+				if day == to {
+					break
+				}
+			}
+		}
+	default:
+		return period, fmt.Errorf("must be [DAYS ]HH:MM-HH:MM")
+	}
+
+	start, end, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return period, fmt.Errorf("must be [DAYS ]HH:MM-HH:MM")
+	}
+	var err error
+	if period.start, err = parseTimeOfDay(start); err != nil {
+		return period, err
+	}
+	if period.end, err = parseTimeOfDay(end); err != nil {
+		return period, err
+	}
+	if period.start == period.end {
+		return period, fmt.Errorf("start and end of %q must differ", fields[len(fields)-1])
+	}
+	return period, nil
+}
+
+// parseTimeOfDay parses HH:MM into the duration since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, must be HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains returns whether t is in the maintenance window.
+func (w *maintenanceWindow) contains(t time.Time) bool {
+	timeOfDay := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	for _, p := range w.periods {
+		if p.start < p.end {
+			if p.days[t.Weekday()] && timeOfDay >= p.start && timeOfDay < p.end {
+				return true
+			}
+			continue
+		}
+		// The period ends on the next day.
+		if p.days[t.Weekday()] && timeOfDay >= p.start {
+			return true
+		}
+		if p.days[(t.Weekday()+6)%7] && timeOfDay < p.end {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *maintenanceWindow) String() string {
+	return w.spec
+}
@@ -0,0 +1,62 @@
+//go:build !remote && (linux || freebsd)
+
+package autoupdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	// 2026-10-16 is a Friday.
+	at := func(day int, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.Local)
+	}
+
+	for _, test := range []struct {
+		spec string
+		in   []time.Time
+		out  []time.Time
+	}{
+		{
+			spec: "02:00-04:00",
+			in:   []time.Time{at(16, 2, 0), at(17, 3, 59)},
+			out:  []time.Time{at(16, 1, 59), at(16, 4, 0)},
+		},
+		{
+			spec: "Sat,Sun 02:00-04:00",
+			in:   []time.Time{at(17, 2, 30), at(18, 3, 0)},
+			out:  []time.Time{at(16, 2, 30), at(19, 3, 0)},
+		},
+		{
+			// Ends on the next day, the days refer to the start
+			spec: "Mon-Fri 22:00-02:00",
+			in:   []time.Time{at(16, 23, 0), at(17, 1, 0), at(13, 0, 30)},
+			out:  []time.Time{at(17, 23, 0), at(18, 1, 0), at(19, 1, 0)},
+		},
+		{
+			// Ranges of weekdays wrap around
+			spec: "fri-mon 10:00-11:00; Wed 12:00-13:00",
+			in:   []time.Time{at(16, 10, 0), at(19, 10, 30), at(14, 12, 0)},
+			out:  []time.Time{at(13, 10, 0), at(14, 10, 0), at(16, 12, 0)},
+		},
+	} {
+		window, err := parseMaintenanceWindow(test.spec)
+		require.NoError(t, err, test.spec)
+		assert.Equal(t, test.spec, window.String())
+		for _, in := range test.in {
+			assert.True(t, window.contains(in), "%s in %q", in, test.spec)
+		}
+		for _, out := range test.out {
+			assert.False(t, window.contains(out), "%s not in %q", out, test.spec)
+		}
+	}
+
+	for _, spec := range []string{"", "02:00", "2am-4am", "02:00-02:00", "Someday 02:00-04:00", "Mon 02:00-04:00 UTC", "25:00-26:00", "Mon;02:00-04:00"} {
+		_, err := parseMaintenanceWindow(spec)
+		assert.Error(t, err, spec)
+	}
+}
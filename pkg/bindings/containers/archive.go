@@ -106,3 +106,30 @@ func CopyToArchive(ctx context.Context, nameOrID string, path string, writer io.
 		return err
 	}, nil
 }
+
+// Walk lists the contents of the directory at path in the container,
+// descending into subdirectories down to the depth set in the options.  The
+// name of each file is its path in the container.
+func Walk(ctx context.Context, nameOrID string, path string, options *WalkOptions) ([]*copy.FileInfo, error) {
+	if options == nil {
+		options = new(WalkOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("path", path)
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/walk", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var infos []*copy.FileInfo
+	return infos, response.Process(&infos)
+}
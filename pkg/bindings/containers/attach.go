@@ -1,6 +1,7 @@
 package containers
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/tls"
@@ -455,6 +456,86 @@ func ExecStartAndAttach(ctx context.Context, sessionID string, options *ExecStar
 	}
 	defer socket.Close()
 
+	return execCopyStreams(ctx, sessionID, cw, socket, needTTY, isTerm, terminalFile, terminalOutFile,
+		options.GetOutputStream(), options.GetErrorStream(), options.InputStream,
+		options.GetAttachOutput(), options.GetAttachError(), options.GetAttachInput())
+}
+
+// ExecAttach attaches to a running exec session, e.g. one started detached or
+// one a client detached from. If the exec session keeps an output buffer, its
+// contents are replayed first. The exec session is not removed once the
+// attach ends.
+func ExecAttach(ctx context.Context, sessionID string, options *ExecAttachOptions) error {
+	if options == nil {
+		options = new(ExecAttachOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	terminalFile := os.Stdin
+	terminalOutFile := os.Stdout
+
+	logrus.Debugf("Attaching to exec session ID %q", sessionID)
+
+	// We need to inspect the exec session first to determine whether to use
+	// -t.
+	respStruct, err := ExecInspect(ctx, sessionID, nil)
+	if err != nil {
+		return err
+	}
+	isTerm := true
+	if respStruct.ProcessConfig != nil {
+		isTerm = respStruct.ProcessConfig.Tty
+	}
+
+	// If we are in TTY mode, we need to set raw mode for the terminal.
+	needTTY := terminalFile != nil && terminal.IsTerminal(int(terminalFile.Fd())) && isTerm
+
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+
+	if needTTY {
+		cleanup, err := setupTTYRawMode(terminalFile)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	cw, socket, err := newUpgradeRequest(ctx, conn, nil, fmt.Sprintf("/exec/%s/attach", sessionID), params)
+	if err != nil {
+		return err
+	}
+	defer socket.Close()
+
+	// Unless set, the streams the exec session was created with are used.
+	attachInput := respStruct.OpenStdin
+	if options.Changed("AttachInput") {
+		attachInput = options.GetAttachInput()
+	}
+	attachOutput := respStruct.OpenStdout
+	if options.Changed("AttachOutput") {
+		attachOutput = options.GetAttachOutput()
+	}
+	attachError := respStruct.OpenStderr
+	if options.Changed("AttachError") {
+		attachError = options.GetAttachError()
+	}
+
+	return execCopyStreams(ctx, sessionID, cw, socket, needTTY, isTerm, terminalFile, terminalOutFile,
+		options.GetOutputStream(), options.GetErrorStream(), options.InputStream,
+		attachOutput, attachError, attachInput)
+}
+
+// execCopyStreams copies the standard streams of an exec session between the
+// given hijacked connection and the client until the connection is closed.
+func execCopyStreams(ctx context.Context, sessionID string, cw *closeWrite, socket io.ReadWriteCloser, needTTY, isTerm bool, terminalFile, terminalOutFile *os.File,
+	outputStream, errorStream io.Writer, inputStream *bufio.Reader, attachOutput, attachError, attachInput bool,
+) error {
 	if needTTY {
 		winChange := make(chan os.Signal, 1)
 		winCtx, winCancel := context.WithCancel(ctx)
@@ -464,10 +545,10 @@ func ExecStartAndAttach(ctx context.Context, sessionID string, options *ExecStar
 		attachHandleResize(ctx, winCtx, winChange, true, sessionID, terminalFile, terminalOutFile)
 	}
 
-	if options.GetAttachInput() {
+	if attachInput {
 		go func() {
 			logrus.Debugf("Copying STDIN to socket")
-			_, err := detach.Copy(socket, options.InputStream, []byte{})
+			_, err := detach.Copy(socket, inputStream, []byte{})
 			// Ignore "closed network connection" as it occurs when the exec ends, which is expected.
 			// This avoids noisy logs but does not fix the goroutine leak
 			// https://github.com/containers/podman/issues/25344
@@ -484,11 +565,11 @@ func ExecStartAndAttach(ctx context.Context, sessionID string, options *ExecStar
 	buffer := make([]byte, 1024)
 	if isTerm {
 		logrus.Debugf("Handling terminal attach to exec")
-		if !options.GetAttachOutput() {
+		if !attachOutput {
 			return fmt.Errorf("exec session %s has a terminal and must have STDOUT enabled", sessionID)
 		}
 		// If not multiplex'ed, read from server and write to stdout
-		_, err := detach.Copy(options.GetOutputStream(), socket, []byte{})
+		_, err := detach.Copy(outputStream, socket, []byte{})
 		if err != nil {
 			return err
 		}
@@ -510,22 +591,22 @@ func ExecStartAndAttach(ctx context.Context, sessionID string, options *ExecStar
 
 			switch fd {
 			case 0:
-				if options.GetAttachInput() {
+				if attachInput {
 					// Write STDIN to STDOUT (echoing characters
 					// typed by another attach session)
-					if _, err := options.GetOutputStream().Write(frame); err != nil {
+					if _, err := outputStream.Write(frame); err != nil {
 						return err
 					}
 				}
 			case 1:
-				if options.GetAttachOutput() {
-					if _, err := options.GetOutputStream().Write(frame); err != nil {
+				if attachOutput {
+					if _, err := outputStream.Write(frame); err != nil {
 						return err
 					}
 				}
 			case 2:
-				if options.GetAttachError() {
-					if _, err := options.GetErrorStream().Write(frame); err != nil {
+				if attachError {
+					if _, err := errorStream.Write(frame); err != nil {
 						return err
 					}
 				}
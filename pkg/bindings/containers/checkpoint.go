@@ -2,9 +2,12 @@ package containers
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"go.podman.io/podman/v6/pkg/bindings"
 	"go.podman.io/podman/v6/pkg/domain/entities/types"
@@ -86,17 +89,34 @@ func Restore(ctx context.Context, nameOrID string, options *RestoreOptions) (*ty
 		// TODO: remove ImportAchive with 5.0
 		i = options.GetImportAchive()
 	}
+	var header http.Header
 	if i != "" {
 		params.Set("import", "true")
+		// Hard-code the name since it will be ignored in any case.
+		nameOrID = "import"
+	}
+	if p := options.GetImportPrevious(); p != "" {
+		// Upload both archives as parts of a multipart request.
+		params.Set("importPrevious", "true")
+		archives := map[string]string{"importPrevious": p}
+		if i != "" {
+			archives["import"] = i
+		}
+		body, contentType, err := multipartArchives(archives)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		r = body
+		header = http.Header{"Content-Type": []string{contentType}}
+	} else if i != "" {
 		r, err = os.Open(i)
 		if err != nil {
 			return nil, err
 		}
-		// Hard-code the name since it will be ignored in any case.
-		nameOrID = "import"
 	}
 
-	response, err := conn.DoRequest(ctx, r, http.MethodPost, "/containers/%s/restore", params, nil, nameOrID)
+	response, err := conn.DoRequest(ctx, r, http.MethodPost, "/containers/%s/restore", params, header, nameOrID)
 	if err != nil {
 		return nil, err
 	}
@@ -104,3 +124,42 @@ func Restore(ctx context.Context, nameOrID string, options *RestoreOptions) (*ty
 
 	return &report, response.Process(&report)
 }
+
+// multipartArchives returns a multipart/form-data body which uploads the
+// specified archives, keyed by their part names, along with its content type.
+func multipartArchives(archives map[string]string) (io.ReadCloser, string, error) {
+	files := make(map[string]*os.File, len(archives))
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	for name, path := range archives {
+		f, err := os.Open(path)
+		if err != nil {
+			closeFiles()
+			return nil, "", err
+		}
+		files[name] = f
+	}
+
+	bodyReader, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+	go func() {
+		defer closeFiles()
+		err := func() error {
+			for name, f := range files {
+				part, err := writer.CreateFormFile(name, filepath.Base(f.Name()))
+				if err != nil {
+					return err
+				}
+				if _, err := io.Copy(part, f); err != nil {
+					return fmt.Errorf("uploading %s archive: %w", name, err)
+				}
+			}
+			return writer.Close()
+		}()
+		bodyWriter.CloseWithError(err)
+	}()
+	return bodyReader, writer.FormDataContentType(), nil
+}
@@ -0,0 +1,32 @@
+package containers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.podman.io/podman/v6/pkg/bindings"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+)
+
+// Clone creates a copy of the container named by the ID of the options.  The
+// create options of the clone change the configuration of the copy.
+func Clone(ctx context.Context, options entities.ContainerCloneOptions) (*entities.ContainerCreateReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsoniter.MarshalToString(options)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, strings.NewReader(body), http.MethodPost, "/containers/clone", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	report := new(entities.ContainerCreateReport)
+	return report, response.Process(report)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -154,3 +155,53 @@ func ExecRemove(ctx context.Context, sessionID string, options *ExecRemoveOption
 
 	return resp.Process(nil)
 }
+
+// ExecList lists the exec sessions of the given container.
+func ExecList(ctx context.Context, nameOrID string, options *ExecListOptions) ([]*define.InspectExecSession, error) {
+	if options == nil {
+		options = new(ExecListOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("Listing exec sessions of container %s", nameOrID)
+
+	resp, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/exec", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reports []*define.InspectExecSession
+	return reports, resp.Process(&reports)
+}
+
+// ExecRecording writes the recording with the given ID of an exec or attach
+// session of the given container to w.
+func ExecRecording(ctx context.Context, nameOrID, recordingID string, w io.Writer, options *ExecRecordingOptions) error {
+	if options == nil {
+		options = new(ExecRecordingOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Retrieving recording %s of container %s", recordingID, nameOrID)
+
+	resp, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/recordings/%s", nil, nil, nameOrID, recordingID)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsSuccess() {
+		_, err = io.Copy(w, resp.Body)
+		return err
+	}
+	return resp.Process(nil)
+}
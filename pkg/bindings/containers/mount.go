@@ -34,12 +34,15 @@ func Unmount(ctx context.Context, nameOrID string, options *UnmountOptions) erro
 	if options == nil {
 		options = new(UnmountOptions)
 	}
-	_ = options
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return err
 	}
-	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/unmount", nil, nil, nameOrID)
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/unmount", params, nil, nameOrID)
 	if err != nil {
 		return err
 	}
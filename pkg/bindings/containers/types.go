@@ -13,9 +13,12 @@ import (
 //go:generate go run ../generator/generator.go LogOptions
 type LogOptions struct {
 	Follow     *bool
+	Grep       *string
+	JSONField  []string `schema:"jsonField"`
 	Since      *string
 	Stderr     *bool
 	Stdout     *bool
+	Stream     *string
 	Tail       *string
 	Timestamps *bool
 	Until      *string
@@ -46,6 +49,7 @@ type AttachOptions struct {
 	DetachKeys *string // Keys to detach from running container
 	Logs       *bool   // Flag to return all logs from container when true
 	Stream     *bool   // Flag only return container logs when false and Logs is true
+	Record     *bool   // Flag to record the attach session
 }
 
 // CheckpointOptions are optional options for checkpointing containers
@@ -79,7 +83,10 @@ type RestoreOptions struct {
 	ImportAchive *string
 	// ImportArchive is the path to an archive which contains the checkpoint data.
 	// ImportArchive is preferred over ImportAchive when both are set.
-	ImportArchive  *string
+	ImportArchive *string
+	// ImportPrevious is the path to an archive which contains the
+	// pre-checkpoint data the checkpoint is restored on top of.
+	ImportPrevious *string `schema:"-"`
 	Keep           *bool
 	Name           *string
 	TCPEstablished *bool
@@ -133,7 +140,19 @@ type MountOptions struct{}
 // containers
 //
 //go:generate go run ../generator/generator.go UnmountOptions
-type UnmountOptions struct{}
+type UnmountOptions struct {
+	// Force unmounts the container even if other processes have it mounted.
+	Force *bool
+}
+
+// WalkOptions are optional options for listing the files of
+// containers
+//
+//go:generate go run ../generator/generator.go WalkOptions
+type WalkOptions struct {
+	// Depth is the number of directory levels to list.
+	Depth *int
+}
 
 // MountedContainerPathsOptions are optional options for getting
 // container mount paths
@@ -315,6 +334,40 @@ type ExecStartAndAttachOptions struct {
 	AttachInput *bool
 }
 
+// ExecAttachOptions are optional options for attaching to a running exec
+// session
+//
+//go:generate go run ../generator/generator.go ExecAttachOptions
+type ExecAttachOptions struct {
+	// OutputStream will be attached to container's STDOUT
+	OutputStream *io.Writer `schema:"-"`
+	// ErrorStream will be attached to container's STDERR
+	ErrorStream *io.Writer `schema:"-"`
+	// InputStream will be attached to container's STDIN
+	InputStream *bufio.Reader `schema:"-"`
+	// AttachOutput is whether to attach to STDOUT
+	AttachOutput *bool `schema:"stdout"`
+	// AttachError is whether to attach to STDERR
+	AttachError *bool `schema:"stderr"`
+	// AttachInput is whether to attach to STDIN
+	AttachInput *bool `schema:"stdin"`
+	// DetachKeys overrides the key sequence for detaching from the exec
+	// session
+	DetachKeys *string `schema:"detachKeys"`
+}
+
+// ExecListOptions are optional options for listing the exec sessions of a
+// container
+//
+//go:generate go run ../generator/generator.go ExecListOptions
+type ExecListOptions struct{}
+
+// ExecRecordingOptions are optional options for retrieving a recording of an
+// exec or attach session of a container
+//
+//go:generate go run ../generator/generator.go ExecRecordingOptions
+type ExecRecordingOptions struct{}
+
 // ExistsOptions are optional options for checking if a container exists
 //
 //go:generate go run ../generator/generator.go ExistsOptions
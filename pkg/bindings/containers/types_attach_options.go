@@ -61,3 +61,18 @@ func (o *AttachOptions) GetStream() bool {
 	}
 	return *o.Stream
 }
+
+// WithRecord set flag to record the attach session
+func (o *AttachOptions) WithRecord(value bool) *AttachOptions {
+	o.Record = &value
+	return o
+}
+
+// GetRecord returns value of flag to record the attach session
+func (o *AttachOptions) GetRecord() bool {
+	if o.Record == nil {
+		var z bool
+		return z
+	}
+	return *o.Record
+}
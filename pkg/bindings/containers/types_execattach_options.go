@@ -0,0 +1,125 @@
+// Code generated by go generate; DO NOT EDIT.
+package containers
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+
+	"go.podman.io/podman/v6/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *ExecAttachOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *ExecAttachOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithOutputStream set field OutputStream to given value
+func (o *ExecAttachOptions) WithOutputStream(value io.Writer) *ExecAttachOptions {
+	o.OutputStream = &value
+	return o
+}
+
+// GetOutputStream returns value of field OutputStream
+func (o *ExecAttachOptions) GetOutputStream() io.Writer {
+	if o.OutputStream == nil {
+		var z io.Writer
+		return z
+	}
+	return *o.OutputStream
+}
+
+// WithErrorStream set field ErrorStream to given value
+func (o *ExecAttachOptions) WithErrorStream(value io.Writer) *ExecAttachOptions {
+	o.ErrorStream = &value
+	return o
+}
+
+// GetErrorStream returns value of field ErrorStream
+func (o *ExecAttachOptions) GetErrorStream() io.Writer {
+	if o.ErrorStream == nil {
+		var z io.Writer
+		return z
+	}
+	return *o.ErrorStream
+}
+
+// WithInputStream set field InputStream to given value
+func (o *ExecAttachOptions) WithInputStream(value bufio.Reader) *ExecAttachOptions {
+	o.InputStream = &value
+	return o
+}
+
+// GetInputStream returns value of field InputStream
+func (o *ExecAttachOptions) GetInputStream() bufio.Reader {
+	if o.InputStream == nil {
+		var z bufio.Reader
+		return z
+	}
+	return *o.InputStream
+}
+
+// WithAttachOutput set field AttachOutput to given value
+func (o *ExecAttachOptions) WithAttachOutput(value bool) *ExecAttachOptions {
+	o.AttachOutput = &value
+	return o
+}
+
+// GetAttachOutput returns value of field AttachOutput
+func (o *ExecAttachOptions) GetAttachOutput() bool {
+	if o.AttachOutput == nil {
+		var z bool
+		return z
+	}
+	return *o.AttachOutput
+}
+
+// WithAttachError set field AttachError to given value
+func (o *ExecAttachOptions) WithAttachError(value bool) *ExecAttachOptions {
+	o.AttachError = &value
+	return o
+}
+
+// GetAttachError returns value of field AttachError
+func (o *ExecAttachOptions) GetAttachError() bool {
+	if o.AttachError == nil {
+		var z bool
+		return z
+	}
+	return *o.AttachError
+}
+
+// WithAttachInput set field AttachInput to given value
+func (o *ExecAttachOptions) WithAttachInput(value bool) *ExecAttachOptions {
+	o.AttachInput = &value
+	return o
+}
+
+// GetAttachInput returns value of field AttachInput
+func (o *ExecAttachOptions) GetAttachInput() bool {
+	if o.AttachInput == nil {
+		var z bool
+		return z
+	}
+	return *o.AttachInput
+}
+
+// WithDetachKeys set field DetachKeys to given value
+func (o *ExecAttachOptions) WithDetachKeys(value string) *ExecAttachOptions {
+	o.DetachKeys = &value
+	return o
+}
+
+// GetDetachKeys returns value of field DetachKeys
+func (o *ExecAttachOptions) GetDetachKeys() string {
+	if o.DetachKeys == nil {
+		var z string
+		return z
+	}
+	return *o.DetachKeys
+}
@@ -32,6 +32,36 @@ func (o *LogOptions) GetFollow() bool {
 	return *o.Follow
 }
 
+// WithGrep set field Grep to given value
+func (o *LogOptions) WithGrep(value string) *LogOptions {
+	o.Grep = &value
+	return o
+}
+
+// GetGrep returns value of field Grep
+func (o *LogOptions) GetGrep() string {
+	if o.Grep == nil {
+		var z string
+		return z
+	}
+	return *o.Grep
+}
+
+// WithJSONField set field JSONField to given value
+func (o *LogOptions) WithJSONField(value []string) *LogOptions {
+	o.JSONField = value
+	return o
+}
+
+// GetJSONField returns value of field JSONField
+func (o *LogOptions) GetJSONField() []string {
+	if o.JSONField == nil {
+		var z []string
+		return z
+	}
+	return o.JSONField
+}
+
 // WithSince set field Since to given value
 func (o *LogOptions) WithSince(value string) *LogOptions {
 	o.Since = &value
@@ -77,6 +107,21 @@ func (o *LogOptions) GetStdout() bool {
 	return *o.Stdout
 }
 
+// WithStream set field Stream to given value
+func (o *LogOptions) WithStream(value string) *LogOptions {
+	o.Stream = &value
+	return o
+}
+
+// GetStream returns value of field Stream
+func (o *LogOptions) GetStream() string {
+	if o.Stream == nil {
+		var z string
+		return z
+	}
+	return *o.Stream
+}
+
 // WithTail set field Tail to given value
 func (o *LogOptions) WithTail(value string) *LogOptions {
 	o.Tail = &value
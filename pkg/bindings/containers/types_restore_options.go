@@ -107,6 +107,21 @@ func (o *RestoreOptions) GetImportArchive() string {
 	return *o.ImportArchive
 }
 
+// WithImportPrevious set field ImportPrevious to given value
+func (o *RestoreOptions) WithImportPrevious(value string) *RestoreOptions {
+	o.ImportPrevious = &value
+	return o
+}
+
+// GetImportPrevious returns value of field ImportPrevious
+func (o *RestoreOptions) GetImportPrevious() string {
+	if o.ImportPrevious == nil {
+		var z string
+		return z
+	}
+	return *o.ImportPrevious
+}
+
 // WithKeep set field Keep to given value
 func (o *RestoreOptions) WithKeep(value bool) *RestoreOptions {
 	o.Keep = &value
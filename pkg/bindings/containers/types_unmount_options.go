@@ -16,3 +16,18 @@ func (o *UnmountOptions) Changed(fieldName string) bool {
 func (o *UnmountOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
+
+// WithForce set field Force to given value
+func (o *UnmountOptions) WithForce(value bool) *UnmountOptions {
+	o.Force = &value
+	return o
+}
+
+// GetForce returns value of field Force
+func (o *UnmountOptions) GetForce() bool {
+	if o.Force == nil {
+		var z bool
+		return z
+	}
+	return *o.Force
+}
@@ -43,6 +43,9 @@ func Update(ctx context.Context, options *types.ContainerUpdateOptions) (string,
 	if options.Rlimits != nil {
 		updateEntities.Rlimits = options.Rlimits
 	}
+	if options.PressureMonitor != nil {
+		updateEntities.UpdatePressureMonitor = *options.PressureMonitor
+	}
 
 	requestData, err := jsoniter.MarshalToString(updateEntities)
 	if err != nil {
@@ -3,6 +3,7 @@ package generate
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -70,3 +71,35 @@ func Kube(ctx context.Context, nameOrIDs []string, options *KubeOptions) (*types
 	// Unpack the error.
 	return nil, response.Process(nil)
 }
+
+// Spec generates a JSON spec based on a container or pod which can be used
+// to create a new one.
+func Spec(ctx context.Context, nameOrID string, options *SpecOptions) (*types.GenerateSpecReport, error) {
+	if options == nil {
+		options = new(SpecOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/generate/%s/spec", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		// Unpack the error.
+		return nil, response.Process(nil)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &types.GenerateSpecReport{Data: data}, nil
+}
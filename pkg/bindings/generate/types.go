@@ -16,6 +16,16 @@ type KubeOptions struct {
 	NoTrunc *bool
 }
 
+// SpecOptions are optional options for generating specs
+//
+//go:generate go run ../generator/generator.go SpecOptions
+type SpecOptions struct {
+	// Compact - print the JSON in a compact format
+	Compact *bool
+	// Name - assign a new, unused name to the generated spec
+	Name *bool
+}
+
 // SystemdOptions are optional options for generating systemd files
 //
 //go:generate go run ../generator/generator.go SystemdOptions
@@ -0,0 +1,48 @@
+// Code generated by go generate; DO NOT EDIT.
+package generate
+
+import (
+	"net/url"
+
+	"go.podman.io/podman/v6/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *SpecOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *SpecOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithCompact set field Compact to given value
+func (o *SpecOptions) WithCompact(value bool) *SpecOptions {
+	o.Compact = &value
+	return o
+}
+
+// GetCompact returns value of field Compact
+func (o *SpecOptions) GetCompact() bool {
+	if o.Compact == nil {
+		var z bool
+		return z
+	}
+	return *o.Compact
+}
+
+// WithName set field Name to given value
+func (o *SpecOptions) WithName(value bool) *SpecOptions {
+	o.Name = &value
+	return o
+}
+
+// GetName returns value of field Name
+func (o *SpecOptions) GetName() bool {
+	if o.Name == nil {
+		var z bool
+		return z
+	}
+	return *o.Name
+}
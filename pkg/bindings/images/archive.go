@@ -0,0 +1,98 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.podman.io/podman/v6/pkg/bindings"
+	"go.podman.io/podman/v6/pkg/copy"
+)
+
+// Stat checks if the specified path is in the root filesystem of the image.
+// Note that the file info may be set even in case of an error.  This happens
+// when the path resolves to symlink pointing to a non-existent path.
+func Stat(ctx context.Context, nameOrID string, path string) (*copy.FileInfo, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("path", path)
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodHead, "/images/%s/archive", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var finalErr error
+	if response.StatusCode == http.StatusNotFound {
+		finalErr = copy.ErrENOENT
+	} else if response.StatusCode != http.StatusOK {
+		finalErr = errors.New(response.Status)
+	}
+
+	fileInfo, err := copy.ExtractFileInfoFromHeader(&response.Header)
+	if err != nil && finalErr == nil {
+		return nil, err
+	}
+
+	return fileInfo, finalErr
+}
+
+// CopyToArchive copies the files at path in the root filesystem of the image
+// as a tar archive to the writer.
+func CopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (func() error, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("path", path)
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/images/%s/archive", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, response.Process(nil)
+	}
+
+	return func() error {
+		defer response.Body.Close()
+		_, err := io.Copy(writer, response.Body)
+		return err
+	}, nil
+}
+
+// Walk lists the contents of the directory at path in the root filesystem of
+// the image, descending into subdirectories down to the depth set in the
+// options.  The name of each file is its path in the image.
+func Walk(ctx context.Context, nameOrID string, path string, options *WalkOptions) ([]*copy.FileInfo, error) {
+	if options == nil {
+		options = new(WalkOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("path", path)
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/images/%s/walk", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var infos []*copy.FileInfo
+	return infos, response.Process(&infos)
+}
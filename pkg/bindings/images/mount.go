@@ -0,0 +1,73 @@
+package images
+
+import (
+	"context"
+	"net/http"
+
+	"go.podman.io/podman/v6/pkg/bindings"
+	"go.podman.io/podman/v6/pkg/domain/entities/types"
+)
+
+// Mount mounts the root filesystem of an image.  The mount point in the
+// returned report is a path on the server.
+func Mount(ctx context.Context, nameOrID string, options *MountOptions) (*types.ImageMountReport, error) {
+	if options == nil {
+		options = new(MountOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/%s/mount", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	report := new(types.ImageMountReport)
+	return report, response.Process(report)
+}
+
+// Unmount unmounts the root filesystem of an image.  Nothing is done if the
+// image is not mounted.
+func Unmount(ctx context.Context, nameOrID string, options *UnmountOptions) error {
+	if options == nil {
+		options = new(UnmountOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/%s/unmount", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
+
+// GetMountedImages returns the mounted images and their mount points.
+func GetMountedImages(ctx context.Context, options *MountedImagesOptions) ([]*types.ImageMountReport, error) {
+	if options == nil {
+		options = new(MountedImagesOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/images/showmounted", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reports []*types.ImageMountReport
+	return reports, response.Process(&reports)
+}
@@ -46,7 +46,12 @@ func Pull(ctx context.Context, rawImage string, options *PullOptions) ([]string,
 		return nil, err
 	}
 
-	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/pull", params, header)
+	body, err := keyMaterialBody(options.KeyMaterial)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, body, http.MethodPost, "/images/pull", params, header)
 	if err != nil {
 		return nil, err
 	}
@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
+	jsoniter "github.com/json-iterator/go"
 	imageTypes "go.podman.io/image/v5/types"
 	"go.podman.io/podman/v6/pkg/auth"
 	"go.podman.io/podman/v6/pkg/bindings"
@@ -45,8 +47,13 @@ func Push(ctx context.Context, source string, destination string, options *PushO
 	}
 	params.Set("destination", destination)
 
+	body, err := keyMaterialBody(options.KeyMaterial)
+	if err != nil {
+		return err
+	}
+
 	path := fmt.Sprintf("/images/%s/push", source)
-	response, err := conn.DoRequest(ctx, nil, http.MethodPost, path, params, header)
+	response, err := conn.DoRequest(ctx, body, http.MethodPost, path, params, header)
 	if err != nil {
 		return err
 	}
@@ -99,3 +106,16 @@ LOOP:
 
 	return nil
 }
+
+// keyMaterialBody returns the request body to forward the key material to the
+// server, or nil if there is nothing to forward.
+func keyMaterialBody(keys *types.ImageKeyMaterial) (io.Reader, error) {
+	if keys == nil {
+		return nil, nil
+	}
+	body, err := jsoniter.MarshalToString(keys)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(body), nil
+}
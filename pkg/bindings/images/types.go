@@ -151,6 +151,8 @@ type PushOptions struct {
 	AddCompression []string
 	// Manifest type of the pushed image
 	Format *string
+	// KeyMaterial is forwarded to the server to encrypt or sign the image.
+	KeyMaterial *types.ImageKeyMaterial `schema:"-"`
 	// Password for authenticating against the registry.
 	Password *string `schema:"-"`
 	// ProgressWriter is a writer where push progress are sent.
@@ -207,6 +209,8 @@ type PullOptions struct {
 	// Authfile is the path to the authentication file. Ignored for remote
 	// calls.
 	Authfile *string
+	// KeyMaterial is forwarded to the server to decrypt the image.
+	KeyMaterial *types.ImageKeyMaterial `schema:"-"`
 	// OS will overwrite the local operating system (OS) for image
 	// pulls.
 	OS *string
@@ -240,6 +244,32 @@ type BuildOptions = types.BuildOptions
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct{}
 
+// MountOptions are optional options for mounting images
+//
+//go:generate go run ../generator/generator.go MountOptions
+type MountOptions struct{}
+
+// UnmountOptions are optional options for unmounting images
+//
+//go:generate go run ../generator/generator.go UnmountOptions
+type UnmountOptions struct {
+	// Force unmounts the image even if other processes have it mounted.
+	Force *bool
+}
+
+// MountedImagesOptions are optional options for listing mounted images
+//
+//go:generate go run ../generator/generator.go MountedImagesOptions
+type MountedImagesOptions struct{}
+
+// WalkOptions are optional options for listing the files of images
+//
+//go:generate go run ../generator/generator.go WalkOptions
+type WalkOptions struct {
+	// Depth is the number of directory levels to list.
+	Depth *int
+}
+
 type ScpOptions struct {
 	Quiet       *bool
 	Destination *string
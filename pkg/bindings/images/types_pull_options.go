@@ -6,6 +6,8 @@ import (
 	"net/url"
 
 	"go.podman.io/podman/v6/pkg/bindings/internal/util"
+
+	"go.podman.io/podman/v6/pkg/domain/entities/types"
 )
 
 // Changed returns true if named field has been set
@@ -63,6 +65,21 @@ func (o *PullOptions) GetAuthfile() string {
 	return *o.Authfile
 }
 
+// WithKeyMaterial set field KeyMaterial to given value
+func (o *PullOptions) WithKeyMaterial(value types.ImageKeyMaterial) *PullOptions {
+	o.KeyMaterial = &value
+	return o
+}
+
+// GetKeyMaterial returns value of field KeyMaterial
+func (o *PullOptions) GetKeyMaterial() types.ImageKeyMaterial {
+	if o.KeyMaterial == nil {
+		var z types.ImageKeyMaterial
+		return z
+	}
+	return *o.KeyMaterial
+}
+
 // WithOS set field OS to given value
 func (o *PullOptions) WithOS(value string) *PullOptions {
 	o.OS = &value
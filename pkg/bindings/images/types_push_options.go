@@ -6,6 +6,8 @@ import (
 	"net/url"
 
 	"go.podman.io/podman/v6/pkg/bindings/internal/util"
+
+	"go.podman.io/podman/v6/pkg/domain/entities/types"
 )
 
 // Changed returns true if named field has been set
@@ -138,6 +140,21 @@ func (o *PushOptions) GetFormat() string {
 	return *o.Format
 }
 
+// WithKeyMaterial set field KeyMaterial to given value
+func (o *PushOptions) WithKeyMaterial(value types.ImageKeyMaterial) *PushOptions {
+	o.KeyMaterial = &value
+	return o
+}
+
+// GetKeyMaterial returns value of field KeyMaterial
+func (o *PushOptions) GetKeyMaterial() types.ImageKeyMaterial {
+	if o.KeyMaterial == nil {
+		var z types.ImageKeyMaterial
+		return z
+	}
+	return *o.KeyMaterial
+}
+
 // WithPassword set field Password to given value
 func (o *PushOptions) WithPassword(value string) *PushOptions {
 	o.Password = &value
@@ -0,0 +1,33 @@
+// Code generated by go generate; DO NOT EDIT.
+package images
+
+import (
+	"net/url"
+
+	"go.podman.io/podman/v6/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *UnmountOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *UnmountOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithForce set field Force to given value
+func (o *UnmountOptions) WithForce(value bool) *UnmountOptions {
+	o.Force = &value
+	return o
+}
+
+// GetForce returns value of field Force
+func (o *UnmountOptions) GetForce() bool {
+	if o.Force == nil {
+		var z bool
+		return z
+	}
+	return *o.Force
+}
@@ -0,0 +1,33 @@
+// Code generated by go generate; DO NOT EDIT.
+package images
+
+import (
+	"net/url"
+
+	"go.podman.io/podman/v6/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *WalkOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *WalkOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithDepth set field Depth to given value
+func (o *WalkOptions) WithDepth(value int) *WalkOptions {
+	o.Depth = &value
+	return o
+}
+
+// GetDepth returns value of field Depth
+func (o *WalkOptions) GetDepth() int {
+	if o.Depth == nil {
+		var z int
+		return z
+	}
+	return *o.Depth
+}
@@ -46,6 +46,8 @@ type PlayOptions struct {
 	LogOptions *[]string
 	// Replace - replace existing pods and containers
 	Replace *bool
+	// Update - perform a rolling update of the pods of existing deployments
+	Update *bool
 	// Start - don't start the pod if false
 	Start *bool
 	// NoTrunc - use annotations that were not truncated to the
@@ -288,6 +288,21 @@ func (o *PlayOptions) GetReplace() bool {
 	return *o.Replace
 }
 
+// WithUpdate set field Update to given value
+func (o *PlayOptions) WithUpdate(value bool) *PlayOptions {
+	o.Update = &value
+	return o
+}
+
+// GetUpdate returns value of field Update
+func (o *PlayOptions) GetUpdate() bool {
+	if o.Update == nil {
+		var z bool
+		return z
+	}
+	return *o.Update
+}
+
 // WithStart set field Start to given value
 func (o *PlayOptions) WithStart(value bool) *PlayOptions {
 	o.Start = &value
@@ -264,7 +264,16 @@ func Push(ctx context.Context, name, destination string, options *images.PushOpt
 		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
 	}
 
-	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/manifests/%s/registry/%s", params, header, name, destination)
+	var body io.Reader
+	if options.KeyMaterial != nil {
+		keys, err := jsoniter.MarshalToString(options.KeyMaterial)
+		if err != nil {
+			return "", err
+		}
+		body = strings.NewReader(keys)
+	}
+
+	response, err := conn.DoRequest(ctx, body, http.MethodPost, "/manifests/%s/registry/%s", params, header, name, destination)
 	if err != nil {
 		return "", err
 	}
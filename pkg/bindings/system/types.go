@@ -4,10 +4,11 @@ package system
 //
 //go:generate go run ../generator/generator.go EventsOptions
 type EventsOptions struct {
-	Filters map[string][]string
-	Since   *string
-	Stream  *bool
-	Until   *string
+	Filters     map[string][]string
+	Since       *string
+	Stream      *bool
+	Until       *string
+	AfterCursor *string `schema:"afterCursor"`
 }
 
 // PruneOptions are optional options for pruning
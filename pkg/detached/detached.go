@@ -0,0 +1,343 @@
+//go:build linux || freebsd
+
+// Package detached implements helper processes which are spawned by Podman
+// and outlive it, such as the forwarders of kube Services or the schedulers
+// of kube CronJobs.  A detached process is a reexec of the Podman binary
+// running in its own session.  It reports over a status pipe once it is
+// ready, and is controlled over a unix socket afterwards.
+package detached
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/reexec"
+)
+
+const (
+	// readyMessage is sent over the status pipe once the process is ready.
+	readyMessage = "ready"
+	// Timeout is the time to wait for a detached process to handle a
+	// request or to exit.
+	Timeout = 10 * time.Second
+	// StopCommand is the command asking a detached process to exit.
+	StopCommand = "stop"
+)
+
+// ErrNotRunning is returned when sending a request to a process which is not
+// running.
+var ErrNotRunning = errors.New("detached process is not running")
+
+// Path returns the path of a file belonging to the detached process with the
+// given name, such as its control socket.  The name is hashed to stay within
+// the length limits of unix socket paths.
+func Path(dir, name, ext string) string {
+	return filepath.Join(dir, digest.FromString(name).Encoded()[:12]+ext)
+}
+
+// Register registers main as the entry point of the detached processes
+// spawned with the given key.  The process exits once main returns.  main
+// must call ready once it is able to serve, errors returned before are
+// reported to the caller of Spawn.
+func Register(key string, main func(ready func() error) error) {
+	reexec.Register(key, func() {
+		status := os.NewFile(3, "status")
+		reported := false
+		ready := func() error {
+			reported = true
+			if status == nil {
+				return errors.New("internal error: expected the status pipe as file descriptor 3")
+			}
+			if _, err := status.WriteString(readyMessage); err != nil {
+				return err
+			}
+			return status.Close()
+		}
+		if err := main(ready); err != nil {
+			if !reported && status != nil {
+				_, _ = status.WriteString(err.Error())
+			} else {
+				logrus.Error(err)
+			}
+			os.Exit(1)
+		}
+		os.Exit(0)
+	})
+}
+
+// Spawn starts the detached process registered with the given key and waits
+// until it is ready.  The process reads stdin on its standard input, the
+// extra files are passed starting with file descriptor 4.
+func Spawn(key string, stdin []byte, extraFiles []*os.File, args ...string) error {
+	return SpawnWithHook(key, stdin, extraFiles, nil, args...)
+}
+
+// SpawnWithHook is like Spawn, but calls started with the PID of the process
+// once it is started, for example to move it into another cgroup.  The
+// process is only passed its standard input once started returned, so it
+// cannot start children before.
+func SpawnWithHook(key string, stdin []byte, extraFiles []*os.File, started func(pid int), args ...string) error {
+	statusR, statusW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer statusR.Close()
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		statusW.Close()
+		return err
+	}
+	defer stdinW.Close()
+
+	cmd := reexec.Command(append([]string{key}, args...)...)
+	cmd.Stdin = stdinR
+	cmd.ExtraFiles = append([]*os.File{statusW}, extraFiles...)
+	// Detach from the session of the caller so the process does not
+	// receive signals meant for Podman.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	err = cmd.Start()
+	statusW.Close()
+	stdinR.Close()
+	if err != nil {
+		return err
+	}
+
+	if started != nil {
+		started(cmd.Process.Pid)
+	}
+	// A failed write shows up as the process not being ready.
+	_, _ = stdinW.Write(stdin)
+	stdinW.Close()
+
+	status, err := io.ReadAll(statusR)
+	if err != nil || string(status) != readyMessage {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		if err == nil {
+			err = errors.New(string(status))
+			if len(status) == 0 {
+				err = errors.New("process exited unexpectedly")
+			}
+		}
+		return err
+	}
+
+	// Reap the process in case we are a long-running service.
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
+
+// request is sent to a detached process over its control socket.
+type request struct {
+	Command string          `json:"command"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// response is the reply of a detached process to a request.
+type response struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Handler handles a request other than StopCommand sent to a detached
+// process.
+type Handler func(command string, data json.RawMessage) error
+
+// Server is the control socket of a detached process.
+type Server struct {
+	listener net.Listener
+	handler  Handler
+
+	mutex   sync.Mutex
+	stopped chan struct{}
+	stopper net.Conn
+}
+
+// Listen creates the control socket at path.  Requests are passed to handler
+// until a StopCommand is received.
+func Listen(path string, handler Handler) (*Server, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("creating control socket: %w", err)
+	}
+	s := &Server{
+		listener: l,
+		handler:  handler,
+		stopped:  make(chan struct{}),
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
+	signal.Ignore(syscall.SIGHUP)
+	go func() {
+		<-sigChan
+		s.stop(nil)
+	}()
+	go s.serve()
+	return s, nil
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logrus.Errorf("Accepting on control socket: %v", err)
+				s.stop(nil)
+			}
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	_ = conn.SetDeadline(time.Now().Add(Timeout))
+	var req request
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		logrus.Debugf("Decoding request on control socket: %v", err)
+		conn.Close()
+		return
+	}
+	if req.Command == StopCommand {
+		// The connection is closed once the process cleaned up, that
+		// is how the sender knows the process is done.
+		s.stop(conn)
+		return
+	}
+	defer conn.Close()
+
+	var resp response
+	if err := s.handler(req.Command, req.Data); err != nil {
+		resp.Error = err.Error()
+	}
+	if err := json.NewEncoder(conn).Encode(&resp); err != nil {
+		logrus.Debugf("Replying on control socket: %v", err)
+	}
+}
+
+func (s *Server) stop(conn net.Conn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	select {
+	case <-s.stopped:
+		if conn != nil {
+			conn.Close()
+		}
+		return
+	default:
+	}
+	s.stopper = conn
+	close(s.stopped)
+}
+
+// Stopped returns a channel which is closed once the process was asked to
+// stop, either by a StopCommand or by a signal.
+func (s *Server) Stopped() <-chan struct{} {
+	return s.stopped
+}
+
+// Close removes the control socket.  The sender of a StopCommand is notified
+// that the process is done, so it must be called after cleaning up.
+func (s *Server) Close() error {
+	// Closing the listener also removes the socket.
+	err := s.listener.Close()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopper != nil {
+		s.stopper.Close()
+		s.stopper = nil
+	}
+	return err
+}
+
+// Send sends a request to the detached process with the control socket at
+// path and waits for its reply.  ErrNotRunning is returned if the process is
+// not running.
+func Send(path, command string, data any) error {
+	req := request{Command: command}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		req.Data = raw
+	}
+
+	conn, err := dial(path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(Timeout)); err != nil {
+		return err
+	}
+	if err := json.NewEncoder(conn).Encode(&req); err != nil {
+		return err
+	}
+
+	if command == StopCommand {
+		// The process closes the connection once it is done.
+		_, err := io.Copy(io.Discard, conn)
+		return err
+	}
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("connection closed without a reply")
+		}
+		return err
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// Stop stops the detached process with the control socket at path and waits
+// until it is done.  It is not an error if the process is not running.
+func Stop(path string) error {
+	if err := Send(path, StopCommand, nil); err != nil && !errors.Is(err, ErrNotRunning) {
+		return err
+	}
+	return nil
+}
+
+// IsRunning returns whether the detached process with the control socket at
+// path is running.
+func IsRunning(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// dial connects to the control socket at path.  A socket left behind by a
+// process which is gone is removed.
+func dial(path string) (net.Conn, error) {
+	conn, err := net.DialTimeout("unix", path, Timeout)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			return nil, ErrNotRunning
+		}
+		return nil, err
+	}
+	return conn, nil
+}
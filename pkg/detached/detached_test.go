@@ -0,0 +1,65 @@
+//go:build linux || freebsd
+
+package detached
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	path := Path("/run/dir", "a-rather-long-name-of-a-kube-service", ".sock")
+	assert.Equal(t, "/run/dir", filepath.Dir(path))
+	assert.Len(t, filepath.Base(path), 12+len(".sock"))
+	assert.Equal(t, path, Path("/run/dir", "a-rather-long-name-of-a-kube-service", ".sock"))
+	assert.NotEqual(t, path, Path("/run/dir", "another-name", ".sock"))
+}
+
+func TestServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+
+	received := make(chan string, 2)
+	server, err := Listen(path, func(command string, data json.RawMessage) error {
+		received <- command + " " + string(data)
+		if command == "fail" {
+			return errors.New("failed on purpose")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, IsRunning(path))
+
+	require.NoError(t, Send(path, "update", map[string]int{"n": 1}))
+	err = Send(path, "fail", nil)
+	assert.EqualError(t, err, "failed on purpose")
+	assert.Equal(t, `update {"n":1}`, <-received)
+	assert.Equal(t, "fail ", <-received)
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- Stop(path)
+	}()
+	select {
+	case <-server.Stopped():
+	case <-time.After(Timeout):
+		t.Fatal("server was not asked to stop")
+	}
+	// Stop waits until the server is closed.
+	select {
+	case err := <-stopped:
+		t.Fatalf("Stop returned before the server was closed: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, server.Close())
+	require.NoError(t, <-stopped)
+
+	assert.False(t, IsRunning(path))
+	assert.ErrorIs(t, Send(path, "update", nil), ErrNotRunning)
+	assert.NoError(t, Stop(path))
+}
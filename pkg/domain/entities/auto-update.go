@@ -13,6 +13,10 @@ type AutoUpdateOptions struct {
 	// If restarting the service with the new image failed, restart it
 	// another time with the previous image.
 	Rollback bool
+	// Restart at most this many systemd units at once for a staged
+	// rollout.  The units are restarted in batches, and the rollout is
+	// halted once a unit fails to restart.  Zero means no limit.
+	MaxUnits int
 	// Allow contacting registries over HTTP, or HTTPS with failed TLS
 	// verification. Note that this does not affect other TLS connections.
 	InsecureSkipTLSVerify types.OptionalBool
@@ -31,6 +35,8 @@ type AutoUpdateReport struct {
 	// SystemdUnit running a container configured for auto updates.
 	SystemdUnit string
 	// Indicates the update status: true, false, failed, pending (see
-	// DryRun).
+	// DryRun), rolled back or skipped.
 	Updated string
+	// Explains the update status, e.g., why the update was skipped.
+	Reason string
 }
@@ -247,10 +247,12 @@ type AttachOptions struct {
 	DetachKeys string
 	Latest     bool
 	NoStdin    bool
-	SigProxy   bool
-	Stdin      *os.File
-	Stdout     *os.File
-	Stderr     *os.File
+	// Record records the attach session.
+	Record   bool
+	SigProxy bool
+	Stdin    *os.File
+	Stdout   *os.File
+	Stderr   *os.File
 }
 
 // ContainerLogsOptions describes the options to extract container logs.
@@ -273,6 +275,16 @@ type ContainerLogsOptions struct {
 	Timestamps bool
 	// Show different colors in the logs.
 	Colors bool
+	// Only show the lines matching this regular expression.
+	Grep string
+	// Only show the lines of this stream, stdout or stderr.
+	Stream string
+	// Only show the lines logged as JSON objects with these fields, as
+	// FIELD=VALUE.
+	JSONFields []string
+	// Output format, text or json.  The json format writes the lines as
+	// ContainerLogRecords to the StdoutWriter.
+	Output string
 	// Write the stdout to this Writer.
 	StdoutWriter io.Writer
 	// Write the stderr to this Writer.
@@ -287,12 +299,43 @@ type ExecOptions struct {
 	Envs        map[string]string
 	Interactive bool
 	Latest      bool
-	PreserveFDs uint
-	PreserveFD  []uint
-	Privileged  bool
-	Tty         bool
-	User        string
-	WorkDir     string
+	// OutputBuffer is the maximum size of the output kept to be replayed
+	// when attaching to the exec session again.
+	OutputBuffer int64
+	PreserveFDs  uint
+	PreserveFD   []uint
+	Privileged   bool
+	// Record records the exec session.
+	Record  bool
+	Tty     bool
+	User    string
+	WorkDir string
+}
+
+// ExecListOptions describes the cli values to list the exec sessions of a
+// container
+type ExecListOptions struct {
+	Latest bool
+}
+
+// ExecListReport describes an exec session of a container
+type ExecListReport = define.InspectExecSession
+
+// ExecRecordingOptions describes the cli values to retrieve a recording of an
+// exec or attach session of a container
+type ExecRecordingOptions struct {
+	Latest bool
+	// Recording is the ID of the recording, it may be truncated.
+	Recording string
+	// Output receives the recording.
+	Output io.Writer
+}
+
+// ExecAttachOptions describes the cli values to attach to a running exec
+// session
+type ExecAttachOptions struct {
+	DetachKeys string
+	NoStdin    bool
 }
 
 // ContainerExistsOptions describes the cli values to check if a container exists
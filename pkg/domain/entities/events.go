@@ -63,6 +63,7 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Type:              t,
 		HealthStatus:      e.HealthStatus,
 		Error:             errorString,
+		Cursor:            e.Cursor,
 		Details: libpodEvents.Details{
 			PodID:      podID,
 			Attributes: details,
@@ -110,6 +111,7 @@ func ConvertToEntitiesEvent(e libpodEvents.Event) *types.Event {
 	return &types.Event{
 		Message:      message,
 		HealthStatus: e.HealthStatus,
+		Cursor:       e.Cursor,
 		Status:       e.Status.String(),
 		ID:           e.ID,
 		From:         e.Image,
@@ -1,3 +1,7 @@
 package entities
 
-type HealthCheckOptions struct{}
+type HealthCheckOptions struct {
+	// Readiness runs the readiness check of the container instead of its
+	// healthcheck.
+	Readiness bool
+}
@@ -145,15 +145,20 @@ type ImagePushOptions struct {
 	// Rejected for remote calls.
 	Signers []*signer.Signer
 	// SignBy adds a signature at the destination using the specified key.
-	// Ignored for remote calls.
+	// Rejected for remote calls.
 	SignBy string
 	// SignPassphrase, if non-empty, specifies a passphrase to use when signing
 	// with the key ID from SignBy.
 	SignPassphrase string
 	// SignBySigstorePrivateKeyFile, if non-empty, asks for a signature to be added
 	// during the copy, using a sigstore private key file at the provided path.
-	// Ignored for remote calls.
+	// Remote clients forward the key to the server.
 	SignBySigstorePrivateKeyFile string
+	// SignBySigstoreParamFile, if non-empty, asks for a signature to be added
+	// during the copy, using the sigstore parameter file at the provided path.
+	// Only used by remote clients, which forward the key material referred to
+	// by the file to the server.  Local clients turn the file into Signers.
+	SignBySigstoreParamFile string
 	// SignSigstorePrivateKeyPassphrase is the passphrase to use when signing with
 	// SignBySigstorePrivateKeyFile.
 	SignSigstorePrivateKeyPassphrase []byte
@@ -195,6 +200,14 @@ type ImagePushReport struct {
 // remote API.
 type ImagePushStream = entitiesTypes.ImagePushStream
 
+// ImageKeyMaterial is the key material forwarded by remote clients to
+// decrypt, encrypt or sign images.
+type ImageKeyMaterial = entitiesTypes.ImageKeyMaterial
+
+// SigstoreKeyMaterial is the key material forwarded by remote clients to
+// create sigstore signatures.
+type SigstoreKeyMaterial = entitiesTypes.SigstoreKeyMaterial
+
 // ImageSearchOptions are the arguments for searching images.
 type ImageSearchOptions struct {
 	// Authfile is the path to the authentication file. Ignored for remote
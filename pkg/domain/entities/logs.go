@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Output formats of podman logs
+const (
+	LogsOutputText = "text"
+	LogsOutputJSON = "json"
+)
+
+// ContainerLogRecord is a line of the log of a container as printed by
+// podman logs --output json.  Partial lines are already reassembled.
+type ContainerLogRecord struct {
+	// Time is the time the line was logged
+	Time time.Time `json:"time"`
+	// Stream is stdout or stderr
+	Stream string `json:"stream"`
+	// ContainerID is the ID of the container
+	ContainerID string `json:"container_id"`
+	// ContainerName is the name of the container
+	ContainerName string `json:"container_name"`
+	// PodID is the ID of the pod of the container, if any
+	PodID string `json:"pod_id,omitempty"`
+	// PodName is the name of the pod of the container, if any
+	PodName string `json:"pod_name,omitempty"`
+	// Log is the logged line, without the trailing newline
+	Log string `json:"log"`
+}
+
+// MarshalJSON encodes the record with sorted keys.  If the logged line is a
+// JSON object, its fields are merged into the record, except for the fields
+// of the record itself, which are kept.
+func (r *ContainerLogRecord) MarshalJSON() ([]byte, error) {
+	type record ContainerLogRecord
+	data, err := json.Marshal((*record)(r))
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if trimmed := strings.TrimSpace(r.Log); strings.HasPrefix(trimmed, "{") {
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(trimmed), &payload); err == nil {
+			for key, value := range payload {
+				if _, ok := fields[key]; !ok {
+					fields[key] = value
+				}
+			}
+		}
+	}
+	// Maps are encoded with sorted keys, so the output is stable.
+	return json.Marshal(fields)
+}
+
+// ContainerLogRecordWriter writes the records of podman logs --output json,
+// one JSON object per line.  Unless following the logs, the records are held
+// back until Flush and then written in the order of their timestamps, which
+// interleaves the logs of several containers.
+type ContainerLogRecordWriter struct {
+	w       io.Writer
+	follow  bool
+	records []*ContainerLogRecord
+}
+
+// NewContainerLogRecordWriter creates a writer writing records to w
+func NewContainerLogRecordWriter(w io.Writer, follow bool) *ContainerLogRecordWriter {
+	return &ContainerLogRecordWriter{w: w, follow: follow}
+}
+
+// Write writes a record, or holds it back until Flush
+func (w *ContainerLogRecordWriter) Write(record *ContainerLogRecord) error {
+	if !w.follow {
+		w.records = append(w.records, record)
+		return nil
+	}
+	return w.write(record)
+}
+
+func (w *ContainerLogRecordWriter) write(record *ContainerLogRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(data, '\n'))
+	return err
+}
+
+// Flush writes the records held back, ordered by their timestamps.  Records
+// with the same timestamp are written in the order they were logged.
+func (w *ContainerLogRecordWriter) Flush() error {
+	sort.SliceStable(w.records, func(i, j int) bool {
+		return w.records[i].Time.Before(w.records[j].Time)
+	})
+	for _, record := range w.records {
+		if err := w.write(record); err != nil {
+			return err
+		}
+	}
+	w.records = nil
+	return nil
+}
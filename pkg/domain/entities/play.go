@@ -27,6 +27,9 @@ type PlayKubeOptions struct {
 	ExitCodePropagation string
 	// Replace indicates whether to delete and recreate a yaml file
 	Replace bool
+	// Update indicates whether to perform a rolling update of the pods
+	// of Deployments created by a previous run instead of failing
+	Update bool
 	// Do not create /etc/hostname within the pod's containers,
 	// instead use the version from the image
 	NoHostname bool
@@ -77,7 +80,9 @@ type PlayKubeOptions struct {
 	// PublishAllPorts - whether to publish all ports defined in the K8S YAML file
 	// (containerPort, hostPort) otherwise only hostPort will be published
 	PublishAllPorts bool
-	// Wait - indicates whether to return after having created the pods
+	// Wait - indicates whether to return after having created the pods.
+	// If set, the readiness probes of all containers must pass before
+	// returning.
 	Wait bool
 	// SystemContext - used when building the image
 	SystemContext *types.SystemContext
@@ -91,6 +96,12 @@ type PlayKubePod = entitiesTypes.PlayKubePod
 // PlayKubeVolume represents a single volume created by play kube.
 type PlayKubeVolume entitiesTypes.PlayKubeVolume
 
+// PlayKubeService represents a K8s Service forwarded by play kube.
+type PlayKubeService = entitiesTypes.PlayKubeService
+
+// PlayKubeCronJob represents a K8s CronJob scheduled by play kube.
+type PlayKubeCronJob = entitiesTypes.PlayKubeCronJob
+
 // PlayKubeReport contains the results of running play kube.
 type (
 	PlayKubeReport = entitiesTypes.PlayKubeReport
@@ -106,4 +117,12 @@ type PlayKubeDownOptions struct {
 // PlayKubeDownReport contains the results of tearing down play kube
 type PlayKubeTeardown = entitiesTypes.PlayKubeTeardown
 
+// PlayKubeServiceRmReport contains the result of stopping the forwarder of a
+// K8s Service.
+type PlayKubeServiceRmReport = entitiesTypes.PlayKubeServiceRmReport
+
+// PlayKubeCronJobRmReport contains the result of removing the schedule of a
+// K8s CronJob.
+type PlayKubeCronJobRmReport = entitiesTypes.PlayKubeCronJobRmReport
+
 type PlaySecret = entitiesTypes.PlaySecret
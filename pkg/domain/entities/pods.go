@@ -172,6 +172,9 @@ type ContainerCreateOptions struct {
 	GPUs                 []string
 	GroupAdd             []string
 	HealthCmd            string
+	HealthGRPC           string
+	HealthHTTPGet        string
+	HealthTCP            string
 	HealthInterval       string
 	HealthRetries        uint
 	HealthLogDestination string
@@ -213,6 +216,8 @@ type ContainerCreateOptions struct {
 	Personality          string
 	PreserveFDs          uint
 	PreserveFD           []uint
+	PressureInterval     string
+	PressureThreshold    []string
 	Privileged           bool
 	PublishAll           bool
 	Pull                 string
@@ -477,6 +482,10 @@ func PodLogsOptionsToContainerLogsOptions(options PodLogsOptions) ContainerLogsO
 		Tail:         options.Tail,
 		Timestamps:   options.Timestamps,
 		Colors:       options.Colors,
+		Grep:         options.Grep,
+		Stream:       options.Stream,
+		JSONFields:   options.JSONFields,
+		Output:       options.Output,
 		StdoutWriter: options.StdoutWriter,
 		StderrWriter: options.StderrWriter,
 	}
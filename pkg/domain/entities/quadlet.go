@@ -6,6 +6,9 @@ type QuadletInstallOptions struct {
 	ReloadSystemd bool
 	// Replace the installation even if the quadlet already exists
 	Replace bool
+	// RestartChanged restarts the running units whose generated systemd
+	// units were changed by the installation
+	RestartChanged bool
 }
 
 // QuadletInstallReport contains the output of the `quadlet install` command
@@ -18,6 +21,21 @@ type QuadletInstallReport struct {
 	// QuadletErrors is a map of the path of the quadlet file to be installed
 	// to the error that occurred attempting to install it
 	QuadletErrors map[string]error
+	// UnitChanges are the changes of the generated systemd units, set if
+	// RestartChanged was requested
+	UnitChanges []QuadletUnitChange
+}
+
+// QuadletUnitChange describes how a change of a systemd unit generated from
+// Quadlets was applied
+type QuadletUnitChange struct {
+	// Unit is the name of the systemd unit
+	Unit string
+	// Action is either "restarted", "stopped", or "none" if the unit was not
+	// running
+	Action string
+	// Error is the error that occurred applying the change, if any
+	Error string
 }
 
 // QuadletListOptions contains options to the `podman quadlet list` command.
@@ -41,9 +59,18 @@ type ListQuadlet struct {
 	// If multiple quadlets were installed together they will belong
 	// to common App.
 	App string
+	// Source is the reference of the OCI artifact the App was installed
+	// from. Empty if the Quadlet was not installed from an artifact.
+	Source string
 	// Pod is the pod Quadlet file referenced by Pod= in [Container]
 	// Empty for quadlet types that do not support Pod=
 	Pod string
+	// Timer is the name of the systemd timer unit created from the
+	// [Schedule] group of the Quadlet.
+	// Empty if the Quadlet is not scheduled
+	Timer string
+	// TimerStatus is the systemd status of the timer unit
+	TimerStatus string
 }
 
 // QuadletRemoveOptions contains parameters for removing Quadlets
@@ -56,6 +83,9 @@ type QuadletRemoveOptions struct {
 	Ignore bool
 	// ReloadSystemd determines whether systemd will be reloaded after the Quadlet is removed.
 	ReloadSystemd bool
+	// RestartChanged restarts the running units whose generated systemd
+	// units were changed by the removal and stops the ones which were removed
+	RestartChanged bool
 }
 
 // QuadletRemoveReport contains the results of an operation to remove obe or more quadlets
@@ -64,4 +94,32 @@ type QuadletRemoveReport struct {
 	Removed []string
 	// Errors is a map of Quadlet name to error that occurred during removal.
 	Errors map[string]error
+	// UnitChanges are the changes of the generated systemd units, set if
+	// RestartChanged was requested
+	UnitChanges []QuadletUnitChange
+}
+
+// QuadletDiagnostic is a problem found in a Quadlet file by
+// `podman quadlet validate`
+type QuadletDiagnostic struct {
+	// File is the path of the Quadlet file, or of its drop-in file if the
+	// problem is in one
+	File string
+	// Line is the number of the line with the problem, 0 if the problem is
+	// not tied to a line
+	Line int
+	// Group is the group of the key with the problem, if any
+	Group string
+	// Key is the key with the problem, if any
+	Key string
+	// Severity is either "error" or "warning"
+	Severity string
+	// Message describes the problem
+	Message string
+}
+
+// QuadletValidateReport contains the results of validating Quadlets
+type QuadletValidateReport struct {
+	// Diagnostics are the problems found, sorted by file and line
+	Diagnostics []QuadletDiagnostic
 }
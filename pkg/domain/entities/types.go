@@ -90,6 +90,8 @@ type EventsOptions struct {
 	Stream    bool
 	Since     string
 	Until     string
+	// AfterCursor resumes reading after the event with the given cursor
+	AfterCursor string
 }
 
 // ContainerCreateResponse is the response struct for creating a container
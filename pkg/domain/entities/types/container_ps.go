@@ -59,6 +59,9 @@ type ListContainer struct {
 	PodName string
 	// Port mappings
 	Ports []netTypes.PortMapping
+	// Readiness of the container.  Only set if the container has a
+	// readiness check.
+	Readiness string `json:",omitempty"`
 	// Restarts is how many times the container was restarted by its
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
@@ -54,6 +54,7 @@ type ContainerUpdateOptions struct {
 	// - Env to change the environment variables.
 	// - UntsetEnv to unset the environment variables.
 	// - Rlimits to change POSIX resource limits.
+	// - PressureMonitor to change the pressure monitoring.
 	Specgen                         *specgen.SpecGenerator
 	Resources                       *specs.LinuxResources
 	DevicesLimits                   *define.UpdateContainerDevicesLimits
@@ -64,6 +65,7 @@ type ContainerUpdateOptions struct {
 	UnsetEnv                        []string
 	Latest                          bool
 	Rlimits                         []specs.POSIXRlimit
+	PressureMonitor                 *define.UpdatePressureMonitor
 }
 
 func (u *ContainerUpdateOptions) ProcessSpecgen() {
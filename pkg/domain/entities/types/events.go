@@ -11,6 +11,9 @@ type Event struct {
 	// point and fork such Docker types.
 	dockerEvents.Message
 	HealthStatus string `json:",omitempty"`
+	// Cursor is the position of the event in the event log, reading events
+	// after it resumes right after the event.
+	Cursor string `json:"cursor,omitempty"`
 	// Deprecated: use Action instead.
 	// Information from JSONMessage.
 	// With data only in container events.
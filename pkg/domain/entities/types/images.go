@@ -3,6 +3,8 @@ package types
 import (
 	"time"
 
+	encconfig "github.com/containers/ocicrypt/config"
+
 	"go.podman.io/podman/v6/pkg/inspect"
 	"go.podman.io/podman/v6/pkg/trust"
 )
@@ -209,6 +211,39 @@ type ArtifactPullProgress struct {
 	ProgressComponentID string `json:"progressComponentID,omitempty"`
 }
 
+// ImageKeyMaterial is the key material a remote client forwards in the body
+// of a pull or push request to decrypt, encrypt or sign an image.  The server
+// only keeps it in memory for the duration of the request.
+type ImageKeyMaterial struct {
+	// DecryptConfig holds the private keys to decrypt the layers of a pulled
+	// image.
+	DecryptConfig *encconfig.DecryptConfig `json:"decryptConfig,omitempty"`
+	// EncryptConfig holds the public keys to encrypt the layers of a pushed
+	// image.
+	EncryptConfig *encconfig.EncryptConfig `json:"encryptConfig,omitempty"`
+	// EncryptLayers are the indices of the layers to encrypt.  An empty
+	// list denotes all layers.
+	EncryptLayers *[]int `json:"encryptLayers,omitempty"`
+	// Sigstore holds the key material to sign a pushed image.
+	Sigstore *SigstoreKeyMaterial `json:"sigstore,omitempty"`
+}
+
+// SigstoreKeyMaterial is the key material to create sigstore signatures.
+// Either PrivateKey or FulcioURL must be set.
+type SigstoreKeyMaterial struct {
+	// PrivateKey is the PEM-encoded private key to sign with.
+	PrivateKey []byte `json:"privateKey,omitempty"`
+	// PrivateKeyPassphrase is the passphrase of PrivateKey.
+	PrivateKeyPassphrase []byte `json:"privateKeyPassphrase,omitempty"`
+	// FulcioURL is the URL of the Fulcio server to obtain a short-lived
+	// certificate from.
+	FulcioURL string `json:"fulcioURL,omitempty"`
+	// OIDCIDToken is the OIDC ID token to present to FulcioURL.
+	OIDCIDToken string `json:"oidcIDToken,omitempty"`
+	// RekorURL is the URL of the Rekor server to upload signatures to.
+	RekorURL string `json:"rekorURL,omitempty"`
+}
+
 type ImagePushStream struct {
 	// ManifestDigest is the digest of the manifest of the pushed image.
 	ManifestDigest string `json:"manifestdigest,omitempty"`
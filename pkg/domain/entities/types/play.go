@@ -19,11 +19,33 @@ type PlayKubeVolume struct {
 	Name string
 }
 
+// PlayKubeService represents a K8s Service forwarded by play kube.
+type PlayKubeService struct {
+	// Name - Name of the Service.
+	Name string
+	// Ports - host ports forwarded to the pods selected by the Service.
+	Ports []string
+}
+
+// PlayKubeCronJob represents a K8s CronJob scheduled by play kube.
+type PlayKubeCronJob struct {
+	// Name - Name of the CronJob.
+	Name string
+	// Schedule - cron schedule of the CronJob.
+	Schedule string
+	// Suspended - the CronJob is suspended and has not been scheduled.
+	Suspended bool
+}
+
 type PlayKubeReport struct {
 	// Pods - pods created by play kube.
 	Pods []PlayKubePod
 	// Volumes - volumes created by play kube.
 	Volumes []PlayKubeVolume
+	// Services - services forwarded by play kube.
+	Services []PlayKubeService
+	// CronJobs - cron jobs scheduled by play kube.
+	CronJobs []PlayKubeCronJob
 	PlayKubeTeardown
 	// Secrets - secrets created by play kube
 	Secrets []PlaySecret
@@ -41,6 +63,24 @@ type PlayKubeTeardown struct {
 	RmReport       []*PodRmReport
 	VolumeRmReport []*VolumeRmReport
 	SecretRmReport []*SecretRmReport
+	// ServiceRmReport - forwarders of K8s Services which have been stopped.
+	ServiceRmReport []*PlayKubeServiceRmReport
+	// CronJobRmReport - schedules of K8s CronJobs which have been removed.
+	CronJobRmReport []*PlayKubeCronJobRmReport
+}
+
+// PlayKubeServiceRmReport contains the result of stopping the forwarder of a
+// K8s Service.
+type PlayKubeServiceRmReport struct {
+	Name string
+	Err  error
+}
+
+// PlayKubeCronJobRmReport contains the result of removing the schedule of a
+// K8s CronJob.
+type PlayKubeCronJobRmReport struct {
+	Name string
+	Err  error
 }
 
 type PlaySecret struct {
@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -869,7 +871,7 @@ func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string,
 	ctr := containers[0]
 
 	// If the container is in a pod, also set to recursively start dependencies
-	err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, false)
+	err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, false, options.Record)
 	if err != nil && !errors.Is(err, define.ErrDetach) {
 		return fmt.Errorf("attaching to container %s: %w", ctr.ID(), err)
 	}
@@ -889,6 +891,8 @@ func makeExecConfig(options entities.ExecOptions, rt *libpod.Runtime, noSession
 	execConfig.PreserveFDs = options.PreserveFDs
 	execConfig.PreserveFD = options.PreserveFD
 	execConfig.AttachStdin = options.Interactive
+	execConfig.OutputBuffer = options.OutputBuffer
+	execConfig.Record = options.Record
 
 	// Only set up exit command for regular exec sessions, not no-session mode
 	if !noSession {
@@ -1027,6 +1031,81 @@ func (ic *ContainerEngine) ContainerExecDetached(_ context.Context, nameOrID str
 	return id, nil
 }
 
+func (ic *ContainerEngine) ContainerExecList(_ context.Context, nameOrID string, options entities.ExecListOptions) ([]*entities.ExecListReport, error) {
+	containers, err := getContainers(ic.Libpod, getContainersOptions{latest: options.Latest, names: []string{nameOrID}})
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) != 1 {
+		return nil, fmt.Errorf("%w: expected to find exactly one container but got %d", define.ErrInternal, len(containers))
+	}
+	ctr := containers[0]
+
+	ids, err := ctr.ExecSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entities.ExecListReport, 0, len(ids))
+	for _, id := range ids {
+		session, err := ctr.ExecSession(id)
+		if err != nil {
+			// The session may have been removed in the meantime.
+			if errors.Is(err, define.ErrNoSuchExecSession) {
+				continue
+			}
+			return nil, err
+		}
+		report, err := session.Inspect()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) ContainerExecRecording(_ context.Context, nameOrID string, options entities.ExecRecordingOptions) error {
+	containers, err := getContainers(ic.Libpod, getContainersOptions{latest: options.Latest, names: []string{nameOrID}})
+	if err != nil {
+		return err
+	}
+	if len(containers) != 1 {
+		return fmt.Errorf("%w: expected to find exactly one container but got %d", define.ErrInternal, len(containers))
+	}
+
+	path, err := containers[0].RecordingPath(options.Recording)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(options.Output, file)
+	return err
+}
+
+func (ic *ContainerEngine) ContainerExecAttach(ctx context.Context, sessionID string, options entities.ExecAttachOptions, streams define.AttachStreams) (int, error) {
+	ec := define.ExecErrorCodeGeneric
+	ctr, err := ic.Libpod.GetExecSessionContainer(sessionID)
+	if err != nil {
+		return ec, err
+	}
+	session, err := ctr.ExecSession(sessionID)
+	if err != nil {
+		return ec, err
+	}
+
+	if options.NoStdin || !session.Config.AttachStdin {
+		streams.AttachInput = false
+	}
+
+	ec, err = terminal.ExecAttachSession(ctx, ctr, sessionID, session.Config.Terminal, &options.DetachKeys, &streams)
+	return define.TranslateExecErrorToExitCode(ec, err), err
+}
+
 func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []string, options entities.ContainerStartOptions) ([]*entities.ContainerStartReport, error) {
 	reports := []*entities.ContainerStartReport{}
 	exitCode := define.ExecErrorCodeGeneric
@@ -1045,7 +1124,7 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 		}
 
 		if options.Attach {
-			err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, true)
+			err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, true, false)
 			if errors.Is(err, define.ErrDetach) {
 				// User manually detached
 				// Exit cleanly immediately
@@ -1270,7 +1349,7 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 	}
 
 	// if the container was created as part of a pod, also start its dependencies, if any.
-	if err := terminal.StartAttachCtr(ctx, ctr, opts.OutputStream, opts.ErrorStream, opts.InputStream, opts.DetachKeys, opts.SigProxy, true); err != nil {
+	if err := terminal.StartAttachCtr(ctx, ctr, opts.OutputStream, opts.ErrorStream, opts.InputStream, opts.DetachKeys, opts.SigProxy, true, false); err != nil {
 		// We've manually detached from the container
 		// Do not perform cleanup, or wait for container exit code
 		// Just exit immediately
@@ -1336,6 +1415,11 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 		return err
 	}
 
+	filter, err := logs.NewLogFilter(options.Grep, options.Stream, options.JSONFields)
+	if err != nil {
+		return err
+	}
+
 	logOpts := &logs.LogOptions{
 		Multi:      len(containers) > 1,
 		Details:    options.Details,
@@ -1347,6 +1431,7 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 		Colors:     options.Colors,
 		UseName:    options.Names,
 		WaitGroup:  &wg,
+		Filter:     filter,
 	}
 
 	chSize := len(containers)
@@ -1366,6 +1451,10 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 		close(logChannel)
 	}()
 
+	if options.Output == entities.LogsOutputJSON {
+		return ic.writeContainerLogRecords(libpodContainers, logChannel, options)
+	}
+
 	for line := range logChannel {
 		line.Write(options.StdoutWriter, options.StderrWriter, logOpts)
 	}
@@ -1373,6 +1462,69 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 	return nil
 }
 
+// writeContainerLogRecords writes the log lines read from logChannel as the
+// records of podman logs --output json.  Partial lines are reassembled by
+// container and stream.
+func (ic *ContainerEngine) writeContainerLogRecords(containers []*libpod.Container, logChannel chan *logs.LogLine, options entities.ContainerLogsOptions) error {
+	out := options.StdoutWriter
+	if out == nil {
+		out = options.StderrWriter
+	}
+	writer := entities.NewContainerLogRecordWriter(out, options.Follow)
+
+	// The journald log driver only reports the short IDs of the containers.
+	templates := make(map[string]*entities.ContainerLogRecord)
+	for _, ctr := range containers {
+		template := &entities.ContainerLogRecord{
+			ContainerID:   ctr.ID(),
+			ContainerName: ctr.Name(),
+			PodID:         ctr.PodID(),
+		}
+		if template.PodID != "" {
+			if pod, err := ic.Libpod.LookupPod(template.PodID); err == nil {
+				template.PodName = pod.Name()
+			}
+		}
+		templates[ctr.ID()] = template
+		templates[ctr.ID()[:min(12, len(ctr.ID()))]] = template
+	}
+
+	var writeErr error
+	partial := make(map[string]*entities.ContainerLogRecord)
+	for line := range logChannel {
+		key := line.CID + "/" + line.Device
+		record, ok := partial[key]
+		if !ok {
+			record = &entities.ContainerLogRecord{ContainerID: line.CID, ContainerName: line.CName}
+			if template, ok := templates[line.CID]; ok {
+				*record = *template
+			}
+			record.Time = line.Time
+			record.Stream = line.Device
+		}
+		record.Log += line.Msg
+		if line.Partial() {
+			partial[key] = record
+			continue
+		}
+		delete(partial, key)
+		// Keep reading the logs on errors to not block the readers.
+		if err := writer.Write(record); err != nil && writeErr == nil {
+			writeErr = err
+		}
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	// Lines which were never completed are written as they are.
+	for _, key := range slices.Sorted(maps.Keys(partial)) {
+		if err := writer.Write(partial[key]); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
 func (ic *ContainerEngine) ContainerCleanup(ctx context.Context, namesOrIds []string, options entities.ContainerCleanupOptions) ([]*entities.ContainerCleanupReport, error) {
 	containers, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: namesOrIds})
 	if err != nil {
@@ -1799,7 +1951,7 @@ func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts enti
 		}
 	}
 
-	ctrCloneOpts.CreateOpts.HealthOnFailure = spec.HealthCheckOnFailureAction.String()
+	ctrCloneOpts.CreateOpts.HealthOnFailure = define.FormatHealthCheckOnFailure(spec.HealthCheckOnFailureAction, spec.HealthCheckOnFailureHook)
 	ctrCloneOpts.CreateOpts.HealthLogDestination = spec.HealthLogDestination
 	ctrCloneOpts.CreateOpts.HealthMaxLogCount = spec.HealthMaxLogCount
 	ctrCloneOpts.CreateOpts.HealthMaxLogSize = spec.HealthMaxLogSize
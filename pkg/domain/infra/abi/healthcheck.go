@@ -9,7 +9,14 @@ import (
 	"go.podman.io/podman/v6/pkg/domain/entities"
 )
 
-func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, _ entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+	if options.Readiness {
+		readiness, err := ic.Libpod.ReadinessCheck(ctx, nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		return &define.HealthCheckResults{Status: readiness}, nil
+	}
 	status, err := ic.Libpod.HealthCheck(ctx, nameOrID)
 	if err != nil {
 		return nil, err
@@ -19,3 +26,9 @@ func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string,
 	}
 	return &report, nil
 }
+
+// ContainerCheckPressure checks the memory events and resource pressure of a
+// container.  It is run by the timer of the pressure monitoring.
+func (ic *ContainerEngine) ContainerCheckPressure(ctx context.Context, nameOrID string) error {
+	return ic.Libpod.PressureCheck(ctx, nameOrID)
+}
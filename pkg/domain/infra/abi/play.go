@@ -11,6 +11,7 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -56,10 +57,34 @@ const sdNotifyAnnotation = "io.containers.sdnotify"
 // default network created/used by kube
 const kubeDefaultNetwork = "podman-default-kube-network"
 
+// namespace of pods which do not set one, exposed by downward API volumes
+const kubeDefaultNamespace = "default"
+
+// kubeProjectedVolumeLabel is set on the volumes of projected and downward API
+// volumes to the name of the pod they were rendered for
+const kubeProjectedVolumeLabel = "io.podman.kube.projected-for"
+
+// kubeProjectedVolumes returns the volumes holding the files of the projected
+// and downward API volumes of the named pods.
+func (ic *ContainerEngine) kubeProjectedVolumes(podNames []string) ([]*libpod.Volume, error) {
+	return ic.Libpod.Volumes(func(v *libpod.Volume) bool {
+		pod, ok := v.Labels()[kubeProjectedVolumeLabel]
+		return ok && slices.Contains(podNames, pod)
+	})
+}
+
 // createServiceContainer creates a container that can later on
 // be associated with the pods of a K8s yaml.  It will be started along with
 // the first pod.
 func (ic *ContainerEngine) createServiceContainer(ctx context.Context, name string, options entities.PlayKubeOptions) (*libpod.Container, error) {
+	// The pods of a previous run are bound to its service container, they
+	// cannot be kept or rolled over to a new one.
+	if options.Update {
+		if _, err := ic.Libpod.LookupContainer(name); err == nil {
+			return nil, fmt.Errorf("cannot update the pods of service container %s, remove them with podman kube down first", name)
+		}
+	}
+
 	// Make sure to replace the service container as well if requested by
 	// the user.
 	if options.Replace {
@@ -234,6 +259,15 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	if options.ServiceContainer && options.Start == types.OptionalBoolFalse { // Sanity check to be future proof
 		return nil, fmt.Errorf("running a service container requires starting the pod(s)")
 	}
+	if options.Update && (options.Replace || options.Wait) {
+		return nil, fmt.Errorf("updating deployments cannot be combined with replacing pods or waiting for the pods")
+	}
+	// Pods not created by a Deployment cannot be updated, they are
+	// replaced instead.
+	podOptions := options
+	if options.Update {
+		podOptions.Replace = true
+	}
 
 	report := &entities.PlayKubeReport{}
 	validKinds := 0
@@ -276,6 +310,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	ipIndex := 0
 
 	var configMaps []v1.ConfigMap
+	var services []*kubeService
 
 	ranContainers := false
 	// set the ranContainers bool to true if at least one container was successfully started.
@@ -372,7 +407,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, err
 			}
 
-			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, podOptions, &ipIndex, podYAML.Annotations, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -388,7 +423,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube DaemonSet: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDaemonSet(ctx, &daemonSetYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDaemonSet(ctx, &daemonSetYAML, podOptions, &ipIndex, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -404,7 +439,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Deployment: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -420,7 +455,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, podOptions, &ipIndex, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -429,6 +464,20 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			report.Pods = append(report.Pods, r.Pods...)
 			validKinds++
 			setRanContainers(r)
+		case "CronJob":
+			var cronJobYAML v1.CronJob
+
+			if err := yaml.Unmarshal(document, &cronJobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube CronJob: %w", err)
+			}
+
+			r, err := ic.playKubeCronJob(ctx, &cronJobYAML, podOptions, configMaps)
+			if err != nil {
+				return nil, err
+			}
+
+			report.CronJobs = append(report.CronJobs, *r)
+			validKinds++
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 
@@ -476,6 +525,21 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			}
 			report.Secrets = append(report.Secrets, entities.PlaySecret{CreateReport: r})
 			validKinds++
+		case "Service":
+			var serviceYAML v1.Service
+
+			if err := yaml.Unmarshal(document, &serviceYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Service: %w", err)
+			}
+
+			s, err := newKubeService(&serviceYAML)
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				services = append(services, s)
+			}
+			validKinds++
 		default:
 			logrus.Infof("Kube kind %s not supported", kind)
 			continue
@@ -489,6 +553,18 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		return nil, fmt.Errorf("YAML document does not contain any supported kube kind")
 	}
 
+	// All pods have been created, so the backends of the Services are
+	// known and their forwarders can be started.
+	for _, s := range services {
+		r, err := ic.startKubeService(s)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			report.Services = append(report.Services, *r)
+		}
+	}
+
 	if !options.ServiceContainer {
 		return report, nil
 	}
@@ -540,7 +616,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	return report, nil
 }
 
-func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services []*kubeService, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		daemonSetName string
 		podSpec       v1.PodTemplateSpec
@@ -554,7 +630,7 @@ func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML
 	podSpec = daemonSetYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", daemonSetName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, daemonSetYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, daemonSetYAML.Annotations, configMaps, services, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -563,12 +639,13 @@ func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services []*kubeService, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		deploymentName string
 		podSpec        v1.PodTemplateSpec
 		numReplicas    int32
 		report         entities.PlayKubeReport
+		proxies        []*notifyproxy.NotifyProxy
 	)
 
 	deploymentName = deploymentYAML.ObjectMeta.Name
@@ -579,22 +656,72 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	if deploymentYAML.Spec.Replicas != nil {
 		numReplicas = *deploymentYAML.Spec.Replicas
 	}
-	if numReplicas > 1 {
-		logrus.Warnf("Limiting replica count to 1, more than one replica is not supported by Podman")
-	}
 	podSpec = deploymentYAML.Spec.Template
+	if numReplicas > 1 && !canScaleDeployment(&podSpec, options, services) {
+		logrus.Warnf("Limiting replica count to 1, more than one replica is not supported by Podman unless the deployment is selected by a service and does not publish host ports")
+		numReplicas = 1
+	}
 
-	podName := fmt.Sprintf("%s-pod", deploymentName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, serviceContainer)
+	// Label the pods with the hash of their template to detect changes
+	// when updating the deployment.
+	templateHash, err := deploymentTemplateHash(&deploymentYAML.Spec.Template)
 	if err != nil {
-		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
+		return nil, nil, err
+	}
+	podSpec.Labels = maps.Clone(podSpec.Labels)
+	if podSpec.Labels == nil {
+		podSpec.Labels = make(map[string]string)
+	}
+	podSpec.Labels[v1apps.DefaultDeploymentUniqueLabelKey] = templateHash
+
+	if options.Update {
+		return ic.updateKubeDeployment(ctx, deploymentYAML, &podSpec, numReplicas, options, ipIndex, configMaps, services, serviceContainer)
+	}
+
+	for i := range max(numReplicas, 1) {
+		podName := deploymentPodName(deploymentName, i)
+		podReport, podProxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, services, serviceContainer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
+		}
+		report.Pods = append(report.Pods, podReport.Pods...)
+		proxies = append(proxies, podProxies...)
 	}
-	report.Pods = podReport.Pods
 
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+// deploymentPodName returns the name of the pod of the specified replica of a
+// deployment.  The first replica is named like the pod of a deployment
+// without replicas.
+func deploymentPodName(deploymentName string, replica int32) string {
+	if replica == 0 {
+		return fmt.Sprintf("%s-pod", deploymentName)
+	}
+	return fmt.Sprintf("%s-pod-%d", deploymentName, replica)
+}
+
+// canScaleDeployment returns whether more than one replica of a deployment can
+// be created.  Replicas are only reachable through a service load-balancing
+// between them, and they cannot share host ports or container names.
+func canScaleDeployment(podSpec *v1.PodTemplateSpec, options entities.PlayKubeOptions, services []*kubeService) bool {
+	if options.NoPodPrefix || len(options.PublishPorts) > 0 || options.PublishAllPorts {
+		return false
+	}
+	if len(selectingKubeServices(services, podSpec.Labels)) == 0 {
+		return false
+	}
+	for _, ctr := range podSpec.Spec.Containers {
+		for _, port := range ctr.Ports {
+			if port.HostPort != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services []*kubeService, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		jobName string
 		podSpec v1.PodTemplateSpec
@@ -608,7 +735,7 @@ func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, opt
 	podSpec = jobYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", jobName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, jobYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, jobYAML.Annotations, configMaps, services, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -617,7 +744,7 @@ func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, opt
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, services []*kubeService, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	cfg, err := ic.Libpod.GetConfigNoCopy()
 	if err != nil {
 		return nil, nil, err
@@ -741,6 +868,14 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		mergePublishPorts(&podOpt, publishPorts)
 	}
 
+	// Publish the ports of the pod serving K8s services on random loopback
+	// ports which the forwarders of the services connect to.
+	serviceBackends := kubeServiceBackends(services, podName, podYAML)
+	hostNetwork := podOpt.Net.Network.NSMode == specgen.Host
+	if !hostNetwork {
+		podOpt.Net.PublishPorts = append(podOpt.Net.PublishPorts, kubeServicePortMappings(serviceBackends)...)
+	}
+
 	p := specgen.NewPodSpecGenerator()
 
 	p, err = entities.ToPodSpecGen(*p, &podOpt)
@@ -779,19 +914,34 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		return nil, nil, err
 	}
 
-	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager, mountLabel)
+	podInfo := &kube.PodInfo{
+		Name:        podName,
+		Namespace:   podYAML.Namespace,
+		Labels:      podYAML.Labels,
+		Annotations: podYAML.Annotations,
+		Containers:  podYAML.Spec.Containers,
+	}
+	if podInfo.Namespace == "" {
+		podInfo.Namespace = kubeDefaultNamespace
+	}
+	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager, podInfo, mountLabel)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Go through the volumes and create a podman volume for all volumes that have been
-	// defined by a configmap or secret
+	// defined by a configmap, secret or projection
 	for _, v := range volumes {
-		if (v.Type == kube.KubeVolumeTypeConfigMap || v.Type == kube.KubeVolumeTypeSecret) && !v.Optional {
+		if (v.Type == kube.KubeVolumeTypeConfigMap || v.Type == kube.KubeVolumeTypeSecret || v.Type == kube.KubeVolumeTypeProjected) && !v.Optional {
 			volumeOptions := []libpod.VolumeCreateOption{
 				libpod.WithVolumeName(v.Source),
 				libpod.WithVolumeMountLabel(mountLabel),
 			}
+			if v.Type == kube.KubeVolumeTypeProjected {
+				// The files are rendered for this pod, so the
+				// volume goes away along with the pod.
+				volumeOptions = append(volumeOptions, libpod.WithVolumeLabels(map[string]string{kubeProjectedVolumeLabel: podName}))
+			}
 			vol, err := ic.Libpod.NewVolume(ctx, volumeOptions...)
 			if err != nil {
 				if errors.Is(err, define.ErrVolumeExists) {
@@ -810,6 +960,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 				return nil, nil, fmt.Errorf("unable to get mountpoint of volume %q: %w", vol.Name(), err)
 			}
 			defaultMode := v.DefaultMode
+			modes := v.Modes
 			// Create files and add data to the volume mountpoint based on the Items in the volume
 			for k, v := range v.Items {
 				f, err := openPathSafely(mountPoint, k)
@@ -821,8 +972,12 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 				if err != nil {
 					return nil, nil, err
 				}
+				mode := defaultMode
+				if m, ok := modes[k]; ok {
+					mode = m
+				}
 				// Set file permissions
-				if err := f.Chmod(os.FileMode(defaultMode)); err != nil {
+				if err := f.Chmod(os.FileMode(mode)); err != nil {
 					return nil, nil, err
 				}
 			}
@@ -920,6 +1075,20 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		return nil, nil, err
 	}
 
+	if len(serviceBackends) > 0 {
+		infraCtr, err := pod.InfraContainer()
+		if err != nil {
+			return nil, nil, err
+		}
+		portMappings, err := infraCtr.PortMappings()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := addKubeServiceBackends(serviceBackends, podName, portMappings, hostNetwork); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if !options.Quiet {
 		writer = os.Stderr
 	}
@@ -1194,6 +1363,16 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 				return nil, nil, err
 			}
 		}
+
+		// Do not move on to the next pod before this one is ready so
+		// that pods further down in the YAML can rely on it.  Like a
+		// replica of a deployment, the pod is given the default
+		// progress deadline to become ready.
+		if options.Wait && len(podStartErrors) == 0 {
+			if err := pod.WaitForReady(ctx, defaultProgressDeadline); err != nil {
+				return nil, nil, fmt.Errorf("waiting for pod %s to become ready: %w", podName, err)
+			}
+		}
 	}
 
 	playKubePod.ID = pod.ID()
@@ -1339,6 +1518,8 @@ func (ic *ContainerEngine) getImageAndLabelInfo(ctx context.Context, cwd string,
 
 	setLabel(define.AutoUpdateLabel)
 	setLabel(define.AutoUpdateAuthfileLabel)
+	setLabel(define.AutoUpdateWindowLabel)
+	setLabel(define.AutoUpdateSignatureLabel)
 
 	return pulledImage, labels, nil
 }
@@ -1620,7 +1801,7 @@ func sortKubeKinds(documentList [][]byte) ([][]byte, error) {
 		}
 
 		switch kind {
-		case "Pod", "Deployment", "DaemonSet", "Job":
+		case "Pod", "Deployment", "DaemonSet", "Job", "CronJob":
 			sortedDocumentList = append(sortedDocumentList, document)
 		default:
 			sortedDocumentList = append([][]byte{document}, sortedDocumentList...)
@@ -1678,9 +1859,11 @@ func getBuildFile(imageName string, cwd string) (string, error) {
 
 func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, options entities.PlayKubeDownOptions) (*entities.PlayKubeReport, error) {
 	var (
-		podNames    []string
-		volumeNames []string
-		secretNames []string
+		podNames     []string
+		volumeNames  []string
+		secretNames  []string
+		serviceNames []string
+		cronJobNames []string
 	)
 	reports := new(entities.PlayKubeReport)
 
@@ -1746,11 +1929,22 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			if deploymentYAML.Spec.Replicas != nil {
 				numReplicas = *deploymentYAML.Spec.Replicas
 			}
-			if numReplicas > 1 {
-				logrus.Warnf("Limiting replica count to 1, more than one replica is not supported by Podman")
+			// Pods of replicas which have not been created are
+			// ignored when removing them.
+			for i := range max(numReplicas, 1) {
+				podNames = append(podNames, deploymentPodName(deploymentName, i))
+			}
+			// Rolling updates may have created pods of other
+			// replicas.
+			pods, err := ic.deploymentPods(deploymentName)
+			if err != nil {
+				return nil, err
+			}
+			for _, pod := range pods {
+				if !slices.Contains(podNames, pod.Name()) {
+					podNames = append(podNames, pod.Name())
+				}
 			}
-			podName := fmt.Sprintf("%s-pod", deploymentName)
-			podNames = append(podNames, podName)
 		case "Job":
 			var jobYAML v1.Job
 
@@ -1760,6 +1954,13 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			jobName := jobYAML.ObjectMeta.Name
 			podName := fmt.Sprintf("%s-pod", jobName)
 			podNames = append(podNames, podName)
+		case "CronJob":
+			var cronJobYAML v1.CronJob
+
+			if err := yaml.Unmarshal(document, &cronJobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube CronJob: %w", err)
+			}
+			cronJobNames = append(cronJobNames, cronJobYAML.Name)
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 			if err := yaml.Unmarshal(document, &pvcYAML); err != nil {
@@ -1772,11 +1973,45 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 				return nil, fmt.Errorf("unable to read YAML as Kube Secret: %w", err)
 			}
 			secretNames = append(secretNames, secret.Name)
+		case "Service":
+			var serviceYAML v1.Service
+			if err := yaml.Unmarshal(document, &serviceYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Service: %w", err)
+			}
+			serviceNames = append(serviceNames, serviceYAML.Name)
 		default:
 			continue
 		}
 	}
 
+	// Stop forwarding to the pods before removing them
+	reports.ServiceRmReport, err = ic.stopKubeServices(serviceNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop scheduling new jobs before removing the existing ones
+	reports.CronJobRmReport, err = ic.removeKubeCronJobs(cronJobNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range cronJobNames {
+		pods, err := ic.cronJobPods(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			podNames = append(podNames, pod.Name())
+		}
+	}
+
+	// The projected and downward API volumes were rendered for the pods
+	// of all kinds, they are removed along with the pods.
+	projectedVolumes, err := ic.kubeProjectedVolumes(podNames)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the service containers associated with the pods if any
 	serviceCtrIDs := []string{}
 	for _, name := range podNames {
@@ -1816,7 +2051,15 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 		return nil, err
 	}
 
-	if options.Force {
+	if !options.Force {
+		volumeNames = nil
+	}
+	for _, vol := range projectedVolumes {
+		if !slices.Contains(volumeNames, vol.Name()) {
+			volumeNames = append(volumeNames, vol.Name())
+		}
+	}
+	if len(volumeNames) > 0 {
 		reports.VolumeRmReport, err = ic.VolumeRm(ctx, volumeNames, entities.VolumeRmOptions{Ignore: true})
 		if err != nil {
 			return nil, err
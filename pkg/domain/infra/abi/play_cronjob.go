@@ -0,0 +1,497 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	v1 "go.podman.io/podman/v6/pkg/k8s.io/api/core/v1"
+	"go.podman.io/podman/v6/pkg/kubecron"
+	"go.podman.io/podman/v6/pkg/specgenutil"
+)
+
+const (
+	// kubeCronJobLabel is set on the pods of the jobs of a CronJob to the
+	// name of the CronJob.
+	kubeCronJobLabel = "io.podman.kube.cronjob"
+	// defaultSuccessfulJobsHistoryLimit is the default number of
+	// successfully finished jobs of a CronJob to keep.
+	defaultSuccessfulJobsHistoryLimit = 3
+	// defaultFailedJobsHistoryLimit is the default number of failed jobs of
+	// a CronJob to keep.
+	defaultFailedJobsHistoryLimit = 1
+)
+
+// kubeCronJob is the state of a scheduled CronJob.  It is stored along with
+// the configuration of the scheduler of the CronJob.
+type kubeCronJob struct {
+	ConcurrencyPolicy          v1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	SuccessfulJobsHistoryLimit int                  `json:"successfulJobsHistoryLimit"`
+	FailedJobsHistoryLimit     int                  `json:"failedJobsHistoryLimit"`
+	// Job is the job created every time the schedule fires.
+	Job        v1.Job             `json:"job"`
+	ConfigMaps []v1.ConfigMap     `json:"configMaps,omitempty"`
+	Options    kubeCronJobOptions `json:"options"`
+}
+
+// kubeCronJobOptions are the options of kube play applied to the jobs of a
+// CronJob.
+type kubeCronJobOptions struct {
+	Networks           []string `json:"networks,omitempty"`
+	LogDriver          string   `json:"logDriver,omitempty"`
+	LogOptions         []string `json:"logOptions,omitempty"`
+	NoHosts            bool     `json:"noHosts,omitempty"`
+	NoHostname         bool     `json:"noHostname,omitempty"`
+	Userns             string   `json:"userns,omitempty"`
+	SeccompProfileRoot string   `json:"seccompProfileRoot,omitempty"`
+	UseLongAnnotations bool     `json:"useLongAnnotations,omitempty"`
+}
+
+// playOptions returns the kube play options used for creating a job.
+func (o *kubeCronJobOptions) playOptions() entities.PlayKubeOptions {
+	return entities.PlayKubeOptions{
+		Networks:           o.Networks,
+		LogDriver:          o.LogDriver,
+		LogOptions:         o.LogOptions,
+		NoHosts:            o.NoHosts,
+		NoHostname:         o.NoHostname,
+		Userns:             o.Userns,
+		SeccompProfileRoot: o.SeccompProfileRoot,
+		UseLongAnnotations: o.UseLongAnnotations,
+		// The images have been pulled or built by kube play.
+		Build: types.OptionalBoolFalse,
+		Quiet: true,
+	}
+}
+
+// jobsHistoryLimit returns the history limit or the default if unset.
+func jobsHistoryLimit(limit *int32, defaultLimit int) (int, error) {
+	if limit == nil {
+		return defaultLimit, nil
+	}
+	if *limit < 0 {
+		return 0, fmt.Errorf("invalid negative value %d", *limit)
+	}
+	return int(*limit), nil
+}
+
+// cronJobJobName returns the name of the job of the CronJob scheduled at the
+// specified time.  Like K8s, the scheduled time in minutes is used as suffix.
+func cronJobJobName(cronJobName string, scheduled time.Time) string {
+	return cronJobName + "-" + strconv.FormatInt(scheduled.Unix()/60, 10)
+}
+
+// kubeCronJobSchedulers returns the schedulers of the CronJobs.  Their
+// configurations are kept in the static directory, so that the schedules
+// survive a reboot.
+func kubeCronJobSchedulers(r *libpod.Runtime) (*kubecron.Schedulers, error) {
+	tmpDir, err := r.TmpDir()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := r.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	return &kubecron.Schedulers{
+		StateDir: filepath.Join(cfg.Engine.StaticDir, "kube-cronjobs"),
+		RunDir:   filepath.Join(tmpDir, "kube-cronjobs"),
+	}, nil
+}
+
+// ResumeKubeCronJobs restarts the schedulers of the CronJobs played by kube
+// play.  The schedulers do not survive a reboot, so this is run when the
+// runtime is refreshed after one.
+func ResumeKubeCronJobs(r *libpod.Runtime) error {
+	schedulers, err := kubeCronJobSchedulers(r)
+	if err != nil {
+		return err
+	}
+	if err := schedulers.Resume(); err != nil {
+		return fmt.Errorf("resuming kube cron jobs: %w", err)
+	}
+	return nil
+}
+
+// KubeResume restarts the schedulers of the CronJobs and the forwarders of the
+// Services played by kube play which are not running.  It is run at boot by
+// podman-kube-resume.service, so the schedules do not depend on another
+// Podman command refreshing the runtime.
+func (ic *ContainerEngine) KubeResume(_ context.Context) error {
+	return errors.Join(ResumeKubeCronJobs(ic.Libpod), ResumeKubeServices(ic.Libpod))
+}
+
+// playKubeCronJob schedules the CronJob.  The images of the job template are
+// pulled once by kube play, the pods of the jobs are created by
+// KubeCronJobRun every time the schedule fires.
+func (ic *ContainerEngine) playKubeCronJob(ctx context.Context, cronJobYAML *v1.CronJob, options entities.PlayKubeOptions, configMaps []v1.ConfigMap) (*entities.PlayKubeCronJob, error) {
+	name := cronJobYAML.Name
+	if name == "" {
+		return nil, errors.New("cron job does not have a name")
+	}
+	spec := &cronJobYAML.Spec
+	if _, err := kubecron.ParseSchedule(spec.Schedule); err != nil {
+		return nil, fmt.Errorf("cron job %s: %w", name, err)
+	}
+	switch spec.ConcurrencyPolicy {
+	case "", v1.AllowConcurrent, v1.ForbidConcurrent, v1.ReplaceConcurrent:
+	default:
+		return nil, fmt.Errorf("cron job %s: unsupported concurrency policy %q", name, spec.ConcurrencyPolicy)
+	}
+	successfulLimit, err := jobsHistoryLimit(spec.SuccessfulJobsHistoryLimit, defaultSuccessfulJobsHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("cron job %s: successfulJobsHistoryLimit: %w", name, err)
+	}
+	failedLimit, err := jobsHistoryLimit(spec.FailedJobsHistoryLimit, defaultFailedJobsHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("cron job %s: failedJobsHistoryLimit: %w", name, err)
+	}
+	var startingDeadline time.Duration
+	if spec.StartingDeadlineSeconds != nil {
+		if *spec.StartingDeadlineSeconds < 0 {
+			return nil, fmt.Errorf("cron job %s: invalid negative startingDeadlineSeconds %d", name, *spec.StartingDeadlineSeconds)
+		}
+		startingDeadline = time.Duration(*spec.StartingDeadlineSeconds) * time.Second
+	}
+	var timeZone string
+	if spec.TimeZone != nil {
+		timeZone = *spec.TimeZone
+	}
+
+	schedulers, err := kubeCronJobSchedulers(ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	if !options.Replace && schedulers.IsScheduled(name) {
+		return nil, fmt.Errorf("cron job %s is already scheduled, use --replace to reschedule it", name)
+	}
+
+	job := v1.Job{
+		ObjectMeta: spec.JobTemplate.ObjectMeta,
+		Spec:       spec.JobTemplate.Spec,
+	}
+	job.Annotations = maps.Clone(job.Annotations)
+	for k, v := range options.Annotations {
+		if job.Annotations == nil {
+			job.Annotations = make(map[string]string)
+		}
+		job.Annotations[k] = v
+	}
+
+	// Pull the images now, the jobs are created without credentials and
+	// should not fail on the registry.
+	var writer io.Writer
+	if !options.Quiet {
+		writer = os.Stderr
+	}
+	cwd := options.ContextDir
+	if cwd == "" {
+		if cwd, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+	}
+	podSpec := &job.Spec.Template.Spec
+	podSpec.InitContainers = slices.Clone(podSpec.InitContainers)
+	podSpec.Containers = slices.Clone(podSpec.Containers)
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			ctr := &containers[i]
+			if ctr.Image == "" {
+				continue
+			}
+			if _, err := ic.buildOrPullImage(ctx, cwd, writer, ctr.Image, ctr.ImagePullPolicy, options); err != nil {
+				return nil, fmt.Errorf("cron job %s: %w", name, err)
+			}
+			if ctr.ImagePullPolicy != v1.PullNever {
+				ctr.ImagePullPolicy = v1.PullIfNotPresent
+			}
+		}
+	}
+
+	report := &entities.PlayKubeCronJob{Name: name, Schedule: spec.Schedule}
+	if spec.Suspend != nil && *spec.Suspend {
+		logrus.Infof("Kube cron job %s is suspended and is not scheduled", name)
+		if err := schedulers.Stop(name); err != nil {
+			return nil, err
+		}
+		report.Suspended = true
+		return report, nil
+	}
+
+	data, err := json.Marshal(&kubeCronJob{
+		ConcurrencyPolicy:          spec.ConcurrencyPolicy,
+		SuccessfulJobsHistoryLimit: successfulLimit,
+		FailedJobsHistoryLimit:     failedLimit,
+		Job:                        job,
+		ConfigMaps:                 configMaps,
+		Options: kubeCronJobOptions{
+			Networks:           options.Networks,
+			LogDriver:          options.LogDriver,
+			LogOptions:         options.LogOptions,
+			NoHosts:            options.NoHosts,
+			NoHostname:         options.NoHostname,
+			Userns:             options.Userns,
+			SeccompProfileRoot: options.SeccompProfileRoot,
+			UseLongAnnotations: options.UseLongAnnotations,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	podman, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := ic.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	command := append([]string{podman}, specgenutil.GlobalPodmanArgs(ic.Libpod.StorageConfig(), cfg, logrus.IsLevelEnabled(logrus.DebugLevel))...)
+	command = append(command, "kube", "cronjob-run", name)
+
+	config := &kubecron.Config{
+		Name:             name,
+		Schedule:         spec.Schedule,
+		TimeZone:         timeZone,
+		StartingDeadline: startingDeadline,
+		Command:          command,
+		Data:             data,
+	}
+	if err := schedulers.Start(config); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// cronJobPods returns the pods of the jobs of the CronJob.
+func (ic *ContainerEngine) cronJobPods(cronJobName string) ([]*libpod.Pod, error) {
+	return ic.Libpod.Pods(func(p *libpod.Pod) bool {
+		return p.Labels()[kubeCronJobLabel] == cronJobName
+	})
+}
+
+// cronJobRunPhase is the phase of a job of a CronJob.
+type cronJobRunPhase int
+
+const (
+	cronJobRunActive cronJobRunPhase = iota
+	cronJobRunSucceeded
+	cronJobRunFailed
+)
+
+// cronJobRun is a job of a CronJob.
+type cronJobRun struct {
+	pod     *libpod.Pod
+	created time.Time
+	phase   cronJobRunPhase
+}
+
+// cronJobRunPhaseOf returns the phase of the job run by the pod.  The job
+// succeeded once all of its containers exited with 0.
+func cronJobRunPhaseOf(pod *libpod.Pod) (cronJobRunPhase, error) {
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return 0, err
+	}
+	phase := cronJobRunSucceeded
+	for _, ctr := range ctrs {
+		if ctr.IsInfra() {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return 0, err
+		}
+		exitCode, exited, err := ctr.ExitCode()
+		if err != nil {
+			return 0, err
+		}
+		switch cronJobContainerPhase(state, exitCode, exited) {
+		case cronJobRunActive:
+			return cronJobRunActive, nil
+		case cronJobRunFailed:
+			phase = cronJobRunFailed
+		}
+	}
+	return phase, nil
+}
+
+// cronJobContainerPhase returns the phase of a single container of a job.
+// A container that has not exited yet, including one that is still being
+// created or started, keeps the job active.
+func cronJobContainerPhase(state define.ContainerStatus, exitCode int32, exited bool) cronJobRunPhase {
+	switch state {
+	case define.ContainerStateExited, define.ContainerStateStopped, define.ContainerStateRemoving:
+	default:
+		return cronJobRunActive
+	}
+	if !exited || exitCode != 0 {
+		return cronJobRunFailed
+	}
+	return cronJobRunSucceeded
+}
+
+// cronJobRuns returns the jobs of the CronJob ordered by their creation.
+func (ic *ContainerEngine) cronJobRuns(cronJobName string) ([]cronJobRun, error) {
+	pods, err := ic.cronJobPods(cronJobName)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]cronJobRun, 0, len(pods))
+	for _, pod := range pods {
+		phase, err := cronJobRunPhaseOf(pod)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchPod) || errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return nil, err
+		}
+		runs = append(runs, cronJobRun{pod: pod, created: pod.CreatedTime(), phase: phase})
+	}
+	slices.SortFunc(runs, func(a, b cronJobRun) int {
+		return a.created.Compare(b.created)
+	})
+	return runs, nil
+}
+
+// expiredCronJobRuns returns the finished jobs exceeding the history limits.
+// The runs must be ordered by their creation, the oldest jobs expire first.
+func expiredCronJobRuns(runs []cronJobRun, successfulLimit, failedLimit int) []cronJobRun {
+	var expired []cronJobRun
+	succeeded, failed := 0, 0
+	for i := len(runs) - 1; i >= 0; i-- {
+		switch runs[i].phase {
+		case cronJobRunSucceeded:
+			succeeded++
+			if succeeded > successfulLimit {
+				expired = append(expired, runs[i])
+			}
+		case cronJobRunFailed:
+			failed++
+			if failed > failedLimit {
+				expired = append(expired, runs[i])
+			}
+		}
+	}
+	return expired
+}
+
+// KubeCronJobRun creates the job of a CronJob scheduled by kube play for the
+// given scheduled time.  It is run by the scheduler of the CronJob every time
+// the schedule fires.
+func (ic *ContainerEngine) KubeCronJobRun(ctx context.Context, name string, scheduled time.Time) error {
+	schedulers, err := kubeCronJobSchedulers(ic.Libpod)
+	if err != nil {
+		return err
+	}
+	config, err := schedulers.Config(name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("kube cron job %s is not scheduled", name)
+	}
+	var cronJob kubeCronJob
+	if err := json.Unmarshal(config.Data, &cronJob); err != nil {
+		return fmt.Errorf("decoding kube cron job %s: %w", name, err)
+	}
+
+	// Checking for active jobs and creating the new one must not race
+	// with another activation, e.g. a late one.
+	lock, err := schedulers.Lock(name)
+	if err != nil {
+		return fmt.Errorf("locking kube cron job %s: %w", name, err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	runs, err := ic.cronJobRuns(name)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if run.phase != cronJobRunActive {
+			continue
+		}
+		switch cronJob.ConcurrencyPolicy {
+		case v1.ForbidConcurrent:
+			logrus.Infof("Skipping run of kube cron job %s, job %s is still active", name, run.pod.Name())
+			return nil
+		case v1.ReplaceConcurrent:
+			logrus.Infof("Replacing active job %s of kube cron job %s", run.pod.Name(), name)
+			if _, err := ic.Libpod.RemovePod(ctx, run.pod, true, true, nil); err != nil && !errors.Is(err, define.ErrNoSuchPod) {
+				return fmt.Errorf("removing active job %s of kube cron job %s: %w", run.pod.Name(), name, err)
+			}
+		}
+	}
+
+	job := cronJob.Job
+	job.Name = cronJobJobName(name, scheduled)
+	if exists, err := ic.Libpod.HasPod(job.Name + "-pod"); err != nil || exists {
+		if exists {
+			logrus.Infof("Job %s of kube cron job %s has already been created", job.Name, name)
+		}
+		return err
+	}
+	job.Spec.Template.Labels = maps.Clone(job.Spec.Template.Labels)
+	if job.Spec.Template.Labels == nil {
+		job.Spec.Template.Labels = make(map[string]string)
+	}
+	job.Spec.Template.Labels[kubeCronJobLabel] = name
+	ipIndex := 0
+	_, proxies, err := ic.playKubeJob(ctx, &job, cronJob.Options.playOptions(), &ipIndex, cronJob.ConfigMaps, nil, nil)
+	for _, proxy := range proxies {
+		if err := proxy.Close(); err != nil {
+			logrus.Errorf("Closing notify proxy %q: %v", proxy.SocketPath(), err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("running kube cron job %s: %w", name, err)
+	}
+
+	runs, err = ic.cronJobRuns(name)
+	if err != nil {
+		return err
+	}
+	for _, run := range expiredCronJobRuns(runs, cronJob.SuccessfulJobsHistoryLimit, cronJob.FailedJobsHistoryLimit) {
+		if _, err := ic.Libpod.RemovePod(ctx, run.pod, true, true, nil); err != nil && !errors.Is(err, define.ErrNoSuchPod) {
+			logrus.Errorf("Removing finished job %s of kube cron job %s: %v", run.pod.Name(), name, err)
+		}
+	}
+	return nil
+}
+
+// removeKubeCronJobs removes the schedules of the named CronJobs.
+func (ic *ContainerEngine) removeKubeCronJobs(names []string) ([]*entities.PlayKubeCronJobRmReport, error) {
+	schedulers, err := kubeCronJobSchedulers(ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.PlayKubeCronJobRmReport, 0, len(names))
+	for _, name := range names {
+		if !schedulers.IsScheduled(name) {
+			continue
+		}
+		reports = append(reports, &entities.PlayKubeCronJobRmReport{
+			Name: name,
+			Err:  schedulers.Stop(name),
+		})
+	}
+	return reports, nil
+}
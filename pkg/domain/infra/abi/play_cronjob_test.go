@@ -0,0 +1,77 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/podman/v6/libpod/define"
+)
+
+func TestJobsHistoryLimit(t *testing.T) {
+	limit, err := jobsHistoryLimit(nil, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, limit)
+
+	zero := int32(0)
+	limit, err = jobsHistoryLimit(&zero, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, limit)
+
+	negative := int32(-1)
+	_, err = jobsHistoryLimit(&negative, 3)
+	assert.EqualError(t, err, "invalid negative value -1")
+}
+
+func TestCronJobJobName(t *testing.T) {
+	scheduled := time.Date(2026, time.March, 1, 12, 30, 45, 0, time.UTC)
+	assert.Equal(t, "backup-29539470", cronJobJobName("backup", scheduled))
+	assert.Equal(t, cronJobJobName("backup", scheduled), cronJobJobName("backup", scheduled.Truncate(time.Minute)))
+}
+
+func TestCronJobContainerPhase(t *testing.T) {
+	// A job whose containers are created but not started yet is active.
+	for _, state := range []define.ContainerStatus{
+		define.ContainerStateConfigured,
+		define.ContainerStateCreated,
+		define.ContainerStateRunning,
+		define.ContainerStatePaused,
+		define.ContainerStateStopping,
+	} {
+		assert.Equal(t, cronJobRunActive, cronJobContainerPhase(state, 0, false), state.String())
+	}
+	assert.Equal(t, cronJobRunSucceeded, cronJobContainerPhase(define.ContainerStateExited, 0, true))
+	assert.Equal(t, cronJobRunFailed, cronJobContainerPhase(define.ContainerStateExited, 1, true))
+	assert.Equal(t, cronJobRunFailed, cronJobContainerPhase(define.ContainerStateStopped, 0, false))
+}
+
+func TestExpiredCronJobRuns(t *testing.T) {
+	phases := []cronJobRunPhase{
+		cronJobRunSucceeded,
+		cronJobRunFailed,
+		cronJobRunSucceeded,
+		cronJobRunFailed,
+		cronJobRunSucceeded,
+		cronJobRunActive,
+		cronJobRunSucceeded,
+	}
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	runs := make([]cronJobRun, 0, len(phases))
+	for i, phase := range phases {
+		runs = append(runs, cronJobRun{created: start.Add(time.Duration(i) * time.Minute), phase: phase})
+	}
+	expiredIndexes := func(successfulLimit, failedLimit int) []int {
+		var indexes []int
+		for _, expired := range expiredCronJobRuns(runs, successfulLimit, failedLimit) {
+			indexes = append(indexes, int(expired.created.Sub(start)/time.Minute))
+		}
+		return indexes
+	}
+
+	// The newest runs are kept, active runs never expire.
+	assert.Equal(t, []int{1, 0}, expiredIndexes(3, 1))
+	assert.Equal(t, []int{6, 4, 3, 2, 1, 0}, expiredIndexes(0, 0))
+	assert.Empty(t, expiredIndexes(10, 10))
+}
@@ -0,0 +1,547 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	v1apps "go.podman.io/podman/v6/pkg/k8s.io/api/apps/v1"
+	v1 "go.podman.io/podman/v6/pkg/k8s.io/api/core/v1"
+	"go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"go.podman.io/podman/v6/pkg/kubeservice"
+	"go.podman.io/podman/v6/pkg/systemd/notifyproxy"
+)
+
+const (
+	// defaultProgressDeadline is the time a new replica of a deployment
+	// has to become available during a rolling update unless the
+	// deployment sets progressDeadlineSeconds.
+	defaultProgressDeadline = 600 * time.Second
+	// defaultRollingUpdateLimit is the default of maxSurge and
+	// maxUnavailable of a rolling update.
+	defaultRollingUpdateLimit = "25%"
+)
+
+// deploymentTemplateHash returns the hash of the pod template of a deployment.
+// It is stored in the pod-template-hash label of the pods of the deployment
+// to detect changes of the template.
+func deploymentTemplateHash(template *v1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", fmt.Errorf("hashing pod template: %w", err)
+	}
+	return digest.FromBytes(data).Encoded()[:10], nil
+}
+
+// deploymentPodReplica returns the replica of the deployment the pod with the
+// specified name was created for.  It is the reverse of deploymentPodName.
+func deploymentPodReplica(deploymentName, podName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(podName, deploymentPodName(deploymentName, 0))
+	if !ok {
+		return 0, false
+	}
+	if suffix == "" {
+		return 0, true
+	}
+	index, ok := strings.CutPrefix(suffix, "-")
+	if !ok {
+		return 0, false
+	}
+	replica, err := strconv.Atoi(index)
+	if err != nil || replica < 1 || strconv.Itoa(replica) != index {
+		return 0, false
+	}
+	return replica, true
+}
+
+// deploymentPods returns the existing pods of the deployment ordered by their
+// replica.
+func (ic *ContainerEngine) deploymentPods(deploymentName string) ([]*libpod.Pod, error) {
+	allPods, err := ic.Libpod.GetAllPods()
+	if err != nil {
+		return nil, err
+	}
+	var pods []*libpod.Pod
+	for _, pod := range allPods {
+		if _, ok := deploymentPodReplica(deploymentName, pod.Name()); ok {
+			pods = append(pods, pod)
+		}
+	}
+	slices.SortFunc(pods, func(a, b *libpod.Pod) int {
+		ra, _ := deploymentPodReplica(deploymentName, a.Name())
+		rb, _ := deploymentPodReplica(deploymentName, b.Name())
+		return ra - rb
+	})
+	return pods, nil
+}
+
+// scaledRollingUpdateLimit resolves maxSurge or maxUnavailable of a rolling
+// update against the number of replicas.  Percentages are rounded up or down.
+func scaledRollingUpdateLimit(value *intstr.IntOrString, replicas int, roundUp bool) (int, error) {
+	limit := intstr.FromString(defaultRollingUpdateLimit)
+	if value != nil {
+		limit = *value
+	}
+	if limit.Type == intstr.Int {
+		if limit.IntVal < 0 {
+			return 0, fmt.Errorf("invalid negative value %d", limit.IntVal)
+		}
+		return int(limit.IntVal), nil
+	}
+	percent, ok := strings.CutSuffix(limit.StrVal, "%")
+	if !ok {
+		return 0, fmt.Errorf("invalid value %q: must be an integer or a percentage", limit.StrVal)
+	}
+	p, err := strconv.Atoi(percent)
+	if err != nil || p < 0 {
+		return 0, fmt.Errorf("invalid value %q: must be an integer or a percentage", limit.StrVal)
+	}
+	scaled := float64(p) * float64(replicas) / 100
+	if roundUp {
+		return int(math.Ceil(scaled)), nil
+	}
+	return int(math.Floor(scaled)), nil
+}
+
+// rollingUpdateLimits returns how many pods may be created above the number of
+// replicas and how many replicas may be unavailable during an update of a
+// deployment.
+func rollingUpdateLimits(strategy *v1apps.DeploymentStrategy, replicas int) (surge, unavailable int, err error) {
+	switch strategy.Type {
+	case v1apps.RecreateDeploymentStrategyType:
+		// All old pods are stopped before new ones are created.
+		return 0, replicas, nil
+	case "", v1apps.RollingUpdateDeploymentStrategyType:
+	default:
+		return 0, 0, fmt.Errorf("unsupported deployment strategy %q", strategy.Type)
+	}
+
+	var maxSurge, maxUnavailable *intstr.IntOrString
+	if strategy.RollingUpdate != nil {
+		maxSurge = strategy.RollingUpdate.MaxSurge
+		maxUnavailable = strategy.RollingUpdate.MaxUnavailable
+	}
+	surge, err = scaledRollingUpdateLimit(maxSurge, replicas, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("maxSurge: %w", err)
+	}
+	unavailable, err = scaledRollingUpdateLimit(maxUnavailable, replicas, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("maxUnavailable: %w", err)
+	}
+	if surge == 0 && unavailable == 0 {
+		// Like K8s, make sure the update can progress.
+		unavailable = 1
+	}
+	return surge, min(unavailable, replicas), nil
+}
+
+// rolloutStep returns how many old pods can be retired and how many new pods
+// can be created in the next step of a rolling update without exceeding the
+// surge and unavailability limits.
+func rolloutStep(replicas, surge, unavailable, oldPods, newPods int) (retire, create int) {
+	retire = max(min(oldPods, oldPods+newPods-(replicas-unavailable)), 0)
+	create = max(min(replicas-newPods, replicas+surge-(oldPods-retire+newPods)), 0)
+	return retire, create
+}
+
+// waitForPodAvailable waits for all containers of the pod to become ready and
+// healthy and checks that they are still running after minReady.
+func waitForPodAvailable(ctx context.Context, pod *libpod.Pod, minReady time.Duration) error {
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		if ctr.IsInitCtr() {
+			continue
+		}
+		if err := ctr.WaitForHealthy(ctx); err != nil {
+			return err
+		}
+		if err := ctr.WaitForReady(ctx, 0); err != nil {
+			return err
+		}
+	}
+
+	if minReady > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for pod %s to become available: %w", pod.Name(), ctx.Err())
+		case <-time.After(minReady):
+		}
+	}
+
+	for _, ctr := range ctrs {
+		if ctr.IsInitCtr() {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		if state != define.ContainerStateRunning {
+			return fmt.Errorf("container %s is %s: %w", ctr.ID(), state, define.ErrCtrStateInvalid)
+		}
+		status, err := ctr.HealthCheckStatus()
+		if err != nil {
+			return err
+		}
+		if status == define.HealthCheckUnhealthy {
+			return fmt.Errorf("container %s is unhealthy", ctr.ID())
+		}
+	}
+	return nil
+}
+
+// kubeServiceAddresses returns the loopback addresses the ports of the pod
+// serving K8s Services are published on.
+func kubeServiceAddresses(pod *libpod.Pod) ([]string, error) {
+	infraCtr, err := pod.InfraContainer()
+	if err != nil {
+		return nil, err
+	}
+	portMappings, err := infraCtr.PortMappings()
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	for _, pm := range portMappings {
+		if pm.HostIP != kubeServiceLoopback {
+			continue
+		}
+		for i := range max(pm.Range, 1) {
+			addresses = append(addresses, net.JoinHostPort(kubeServiceLoopback, strconv.Itoa(int(pm.HostPort+i))))
+		}
+	}
+	return addresses, nil
+}
+
+// keepDeploymentPod registers the backends of a running pod of a deployment
+// which is up to date with its Services.  It returns false if the pod is not
+// running or does not publish the ports required by the Services, so it needs
+// to be replaced.
+func keepDeploymentPod(pod *libpod.Pod, podSpec *v1.PodTemplateSpec, services []*kubeService) (bool, error) {
+	status, err := pod.GetPodStatus()
+	if err != nil {
+		return false, err
+	}
+	if status != define.PodStateRunning {
+		return false, nil
+	}
+
+	backends := kubeServiceBackends(services, pod.Name(), podSpec)
+	if len(backends) == 0 {
+		return true, nil
+	}
+	infraCtr, err := pod.InfraContainer()
+	if err != nil {
+		return false, err
+	}
+	portMappings, err := infraCtr.PortMappings()
+	if err != nil {
+		return false, err
+	}
+	hostNetwork := infraCtr.NetworkMode() == "host"
+	if !hostNetwork {
+		for _, b := range backends {
+			if _, ok := loopbackHostPort(portMappings, b.containerPort, b.service.ports[b.index].Protocol); !ok {
+				return false, nil
+			}
+		}
+	}
+	return true, addKubeServiceBackends(backends, pod.Name(), portMappings, hostNetwork)
+}
+
+// runningKubeServices returns the configurations of the running forwarders of
+// the Services.
+func (ic *ContainerEngine) runningKubeServices(services []*kubeService) (map[string]*kubeservice.Config, error) {
+	forwarders, err := kubeServiceForwarders(ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]*kubeservice.Config, len(services))
+	for _, s := range services {
+		config, err := forwarders.RunningConfig(s.name)
+		if err != nil {
+			return nil, err
+		}
+		configs[s.name] = config
+	}
+	return configs, nil
+}
+
+// refreshKubeServices updates the backends of the forwarders of the Services
+// while a deployment is updated.  The forwarders keep running, so connections
+// to the pods which are kept are not interrupted.  The backends of the
+// forwarders running before the update are kept unless they belong to a
+// retired pod, and the backends of the pods created so far are added.
+func (ic *ContainerEngine) refreshKubeServices(services []*kubeService, running map[string]*kubeservice.Config, retired []string) error {
+	forwarders, err := kubeServiceForwarders(ic.Libpod)
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		config := &kubeservice.Config{Name: s.name, Pods: make(map[string]string)}
+		old := running[s.name]
+		for _, port := range s.ports {
+			backends := slices.Clone(port.Backends)
+			for _, b := range backends {
+				config.Pods[b] = s.pods[b]
+			}
+			if old != nil {
+				for _, oldPort := range old.Ports {
+					if oldPort.Protocol != port.Protocol || oldPort.HostIP != port.HostIP || oldPort.HostPort != port.HostPort {
+						continue
+					}
+					for _, b := range oldPort.Backends {
+						if !slices.Contains(retired, b) && !slices.Contains(backends, b) {
+							backends = append(backends, b)
+							if pod, ok := old.Pods[b]; ok {
+								config.Pods[b] = pod
+							}
+						}
+					}
+				}
+			}
+			if len(backends) == 0 {
+				continue
+			}
+			port.Backends = backends
+			config.Ports = append(config.Ports, port)
+		}
+		if len(config.Ports) == 0 {
+			if err := forwarders.Stop(s.name); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := setKubeServiceReadiness(ic.Libpod, config); err != nil {
+			return err
+		}
+		if err := forwarders.Update(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreKubeServices restores the backends of the forwarders of the Services
+// they were running with before a deployment was updated.
+func (ic *ContainerEngine) restoreKubeServices(services []*kubeService, running map[string]*kubeservice.Config) error {
+	forwarders, err := kubeServiceForwarders(ic.Libpod)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, s := range services {
+		if config := running[s.name]; config != nil {
+			if err := setKubeServiceReadiness(ic.Libpod, config); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			errs = append(errs, forwarders.Update(config))
+		} else {
+			errs = append(errs, forwarders.Stop(s.name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateKubeDeployment performs a rolling update of the pods of a deployment
+// created by a previous run.  Pods created from the same pod template are kept.
+// The others are replaced by new pods within the surge and unavailability
+// limits of the deployment strategy.  If a new pod does not become available,
+// the new pods are removed and the retired pods are started again.
+func (ic *ContainerEngine) updateKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, podSpec *v1.PodTemplateSpec, numReplicas int32, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services []*kubeService, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	var (
+		deploymentName = deploymentYAML.ObjectMeta.Name
+		replicas       = int(max(numReplicas, 1))
+		report         entities.PlayKubeReport
+		proxies        []*notifyproxy.NotifyProxy
+	)
+
+	surge, unavailable, err := rollingUpdateLimits(&deploymentYAML.Spec.Strategy, replicas)
+	if err != nil {
+		return nil, nil, fmt.Errorf("deployment %s: %w", deploymentName, err)
+	}
+	progressDeadline := defaultProgressDeadline
+	if deploymentYAML.Spec.ProgressDeadlineSeconds != nil {
+		progressDeadline = time.Duration(*deploymentYAML.Spec.ProgressDeadlineSeconds) * time.Second
+	}
+	minReady := time.Duration(deploymentYAML.Spec.MinReadySeconds) * time.Second
+
+	existing, err := ic.deploymentPods(deploymentName)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The pods of a previous run are bound to its service container, they
+	// cannot be kept or rolled over to the new one.
+	if serviceContainer != nil && len(existing) > 0 {
+		return nil, nil, fmt.Errorf("deployment %s: cannot update pods created by a previous run with a service container, remove them with podman kube down first", deploymentName)
+	}
+	takenNames := make(map[string]bool, len(existing))
+	var current, outdated []*libpod.Pod
+	for _, pod := range existing {
+		takenNames[pod.Name()] = true
+		if pod.Labels()[v1apps.DefaultDeploymentUniqueLabelKey] == podSpec.Labels[v1apps.DefaultDeploymentUniqueLabelKey] && len(current) < replicas {
+			keep, err := keepDeploymentPod(pod, podSpec, services)
+			if err != nil {
+				return nil, nil, err
+			}
+			if keep {
+				current = append(current, pod)
+				continue
+			}
+		}
+		outdated = append(outdated, pod)
+	}
+	if len(current) == replicas && len(outdated) == 0 {
+		logrus.Debugf("Deployment %s is up to date", deploymentName)
+		return &report, nil, nil
+	}
+
+	selecting := selectingKubeServices(services, podSpec.Labels)
+	running, err := ic.runningKubeServices(selecting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		created        []*libpod.Pod
+		retired        []*libpod.Pod
+		restart        []*libpod.Pod
+		retiredAddrs   []string
+		nextPodName    string
+		nextPodReplica int32
+	)
+	rollback := func(cause error) error {
+		var errs []error
+		if nextPodName != "" {
+			// The pod may have been created partially.
+			if _, err := ic.PodRm(ctx, []string{nextPodName}, entities.PodRmOptions{Force: true, Ignore: true}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, pod := range created {
+			if _, err := ic.Libpod.RemovePod(ctx, pod, true, true, nil); err != nil {
+				errs = append(errs, fmt.Errorf("removing pod %s: %w", pod.Name(), err))
+			}
+		}
+		for _, pod := range restart {
+			if _, err := pod.Start(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("restarting pod %s: %w", pod.Name(), err))
+			}
+		}
+		errs = append(errs, ic.restoreKubeServices(selecting, running))
+		if err := errors.Join(errs...); err != nil {
+			logrus.Errorf("Rolling back deployment %s: %v", deploymentName, err)
+		}
+		return fmt.Errorf("rolling update of deployment %s failed, rolled back: %w", deploymentName, cause)
+	}
+
+	for len(current)+len(created) < replicas || len(outdated) > 0 {
+		retire, create := rolloutStep(replicas, surge, unavailable, len(outdated), len(current)+len(created))
+		if retire == 0 && create == 0 {
+			return nil, nil, rollback(errors.New("internal error: rolling update does not progress"))
+		}
+
+		for _, pod := range outdated[:retire] {
+			status, err := pod.GetPodStatus()
+			if err != nil {
+				return nil, nil, rollback(err)
+			}
+			addresses, err := kubeServiceAddresses(pod)
+			if err != nil {
+				return nil, nil, rollback(err)
+			}
+			retiredAddrs = append(retiredAddrs, addresses...)
+			if status == define.PodStateRunning || status == define.PodStateDegraded {
+				restart = append(restart, pod)
+			}
+			logrus.Debugf("Stopping pod %s of deployment %s", pod.Name(), deploymentName)
+			if _, err := pod.Stop(ctx, true); err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+				return nil, nil, rollback(fmt.Errorf("stopping pod %s: %w", pod.Name(), err))
+			}
+			retired = append(retired, pod)
+		}
+		outdated = outdated[retire:]
+		if retire > 0 {
+			if err := ic.refreshKubeServices(selecting, running, retiredAddrs); err != nil {
+				return nil, nil, rollback(err)
+			}
+		}
+
+		for range create {
+			for takenNames[deploymentPodName(deploymentName, nextPodReplica)] {
+				nextPodReplica++
+			}
+			nextPodName = deploymentPodName(deploymentName, nextPodReplica)
+			takenNames[nextPodName] = true
+
+			podReport, podProxies, err := ic.playKubePod(ctx, nextPodName, podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, services, serviceContainer)
+			if err != nil {
+				return nil, nil, rollback(fmt.Errorf("encountered while bringing up pod %s: %w", nextPodName, err))
+			}
+			pod, err := ic.Libpod.LookupPod(nextPodName)
+			if err != nil {
+				return nil, nil, rollback(err)
+			}
+			created = append(created, pod)
+			nextPodName = ""
+			report.Pods = append(report.Pods, podReport.Pods...)
+			proxies = append(proxies, podProxies...)
+
+			if errs := podReport.Pods[0].ContainerErrors; len(errs) > 0 {
+				return nil, nil, rollback(fmt.Errorf("starting pod %s: %s", pod.Name(), strings.Join(errs, "; ")))
+			}
+			if options.Start != types.OptionalBoolFalse {
+				waitCtx, cancel := context.WithTimeout(ctx, progressDeadline)
+				err = waitForPodAvailable(waitCtx, pod, minReady)
+				cancel()
+				if err != nil {
+					return nil, nil, rollback(fmt.Errorf("pod %s did not become available: %w", pod.Name(), err))
+				}
+			}
+			if err := ic.refreshKubeServices(selecting, running, retiredAddrs); err != nil {
+				return nil, nil, rollback(err)
+			}
+		}
+	}
+
+	retiredNames := make([]string, 0, len(retired))
+	for _, pod := range retired {
+		logrus.Debugf("Removing pod %s of deployment %s", pod.Name(), deploymentName)
+		if _, err := ic.Libpod.RemovePod(ctx, pod, true, true, nil); err != nil {
+			return nil, nil, fmt.Errorf("removing retired pod %s of deployment %s: %w", pod.Name(), deploymentName, err)
+		}
+		retiredNames = append(retiredNames, pod.Name())
+	}
+	// The projected and downward API volumes were rendered for the retired
+	// pods and are of no use anymore.
+	projectedVolumes, err := ic.kubeProjectedVolumes(retiredNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, vol := range projectedVolumes {
+		if err := ic.Libpod.RemoveVolume(ctx, vol, false, nil); err != nil && !errors.Is(err, define.ErrNoSuchVolume) {
+			return nil, nil, fmt.Errorf("removing volume %s of retired pod of deployment %s: %w", vol.Name(), deploymentName, err)
+		}
+	}
+	return &report, proxies, nil
+}
@@ -0,0 +1,214 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	v1apps "go.podman.io/podman/v6/pkg/k8s.io/api/apps/v1"
+	v1 "go.podman.io/podman/v6/pkg/k8s.io/api/core/v1"
+	v12 "go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDeploymentPodReplica(t *testing.T) {
+	tests := []struct {
+		podName string
+		replica int
+		ok      bool
+	}{
+		{"web-pod", 0, true},
+		{"web-pod-1", 1, true},
+		{"web-pod-12", 12, true},
+		{"web-pod-0", 0, false},
+		{"web-pod-01", 0, false},
+		{"web-pod-x", 0, false},
+		{"web-pod-pod", 0, false},
+		{"web-pods", 0, false},
+		{"other-pod", 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.podName, func(t *testing.T) {
+			replica, ok := deploymentPodReplica("web", test.podName)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.replica, replica)
+		})
+	}
+}
+
+func TestDeploymentTemplateHash(t *testing.T) {
+	template := v1.PodTemplateSpec{
+		ObjectMeta: v12.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "ctr", Image: "alpine:3.20"}}},
+	}
+	hash, err := deploymentTemplateHash(&template)
+	assert.NoError(t, err)
+	assert.Len(t, hash, 10)
+
+	same, err := deploymentTemplateHash(&template)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	template.Spec.Containers[0].Image = "alpine:3.21"
+	changed, err := deploymentTemplateHash(&template)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
+
+func TestRollingUpdateLimits(t *testing.T) {
+	intOrStr := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+	tests := []struct {
+		name        string
+		strategy    v1apps.DeploymentStrategy
+		replicas    int
+		surge       int
+		unavailable int
+		err         string
+	}{
+		{
+			name:        "defaults",
+			replicas:    10,
+			surge:       3,
+			unavailable: 2,
+		},
+		{
+			name:        "defaults single replica",
+			replicas:    1,
+			surge:       1,
+			unavailable: 0,
+		},
+		{
+			name:        "recreate",
+			strategy:    v1apps.DeploymentStrategy{Type: v1apps.RecreateDeploymentStrategyType},
+			replicas:    3,
+			surge:       0,
+			unavailable: 3,
+		},
+		{
+			name: "absolute values",
+			strategy: v1apps.DeploymentStrategy{
+				Type: v1apps.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &v1apps.RollingUpdateDeployment{
+					MaxSurge:       intOrStr(intstr.FromInt(2)),
+					MaxUnavailable: intOrStr(intstr.FromInt(5)),
+				},
+			},
+			replicas:    3,
+			surge:       2,
+			unavailable: 3,
+		},
+		{
+			name: "both zero",
+			strategy: v1apps.DeploymentStrategy{
+				RollingUpdate: &v1apps.RollingUpdateDeployment{
+					MaxSurge:       intOrStr(intstr.FromInt(0)),
+					MaxUnavailable: intOrStr(intstr.FromString("0%")),
+				},
+			},
+			replicas:    3,
+			surge:       0,
+			unavailable: 1,
+		},
+		{
+			name: "invalid percentage",
+			strategy: v1apps.DeploymentStrategy{
+				RollingUpdate: &v1apps.RollingUpdateDeployment{MaxSurge: intOrStr(intstr.FromString("ten"))},
+			},
+			replicas: 3,
+			err:      `maxSurge: invalid value "ten": must be an integer or a percentage`,
+		},
+		{
+			name:     "unsupported strategy",
+			strategy: v1apps.DeploymentStrategy{Type: "Canary"},
+			replicas: 3,
+			err:      `unsupported deployment strategy "Canary"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			surge, unavailable, err := rollingUpdateLimits(&test.strategy, test.replicas)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.surge, surge)
+			assert.Equal(t, test.unavailable, unavailable)
+		})
+	}
+}
+
+func TestRolloutStep(t *testing.T) {
+	tests := []struct {
+		name                         string
+		replicas, surge, unavailable int
+		oldPods, newPods             int
+		// steps are the pods retired and created in each step.
+		steps [][2]int
+	}{
+		{
+			name:     "surge only",
+			replicas: 3, surge: 1, unavailable: 0,
+			oldPods: 3,
+			steps:   [][2]int{{0, 1}, {1, 1}, {1, 1}, {1, 0}},
+		},
+		{
+			name:     "unavailable only",
+			replicas: 3, surge: 0, unavailable: 1,
+			oldPods: 3,
+			steps:   [][2]int{{1, 1}, {1, 1}, {1, 1}},
+		},
+		{
+			name:     "recreate",
+			replicas: 3, surge: 0, unavailable: 3,
+			oldPods: 3,
+			steps:   [][2]int{{3, 3}},
+		},
+		{
+			name:     "scale up",
+			replicas: 4, surge: 1, unavailable: 1,
+			oldPods: 1, newPods: 2,
+			steps: [][2]int{{0, 2}, {1, 0}},
+		},
+		{
+			name:     "scale down",
+			replicas: 2, surge: 1, unavailable: 0,
+			oldPods: 2, newPods: 2,
+			steps: [][2]int{{2, 0}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldPods, newPods := test.oldPods, test.newPods
+			var steps [][2]int
+			for newPods < test.replicas || oldPods > 0 {
+				retire, create := rolloutStep(test.replicas, test.surge, test.unavailable, oldPods, newPods)
+				if retire == 0 && create == 0 {
+					t.Fatalf("rollout does not progress with %d old and %d new pods", oldPods, newPods)
+				}
+				assert.LessOrEqual(t, oldPods+newPods-retire+create, test.replicas+test.surge)
+				steps = append(steps, [2]int{retire, create})
+				oldPods -= retire
+				newPods += create
+			}
+			assert.Equal(t, test.steps, steps)
+		})
+	}
+}
+
+func TestPlayKubeUpdateConflicts(t *testing.T) {
+	// The options are checked before the runtime is used, so API clients
+	// get the same error as the CLI.
+	ic := &ContainerEngine{}
+	for _, options := range []entities.PlayKubeOptions{
+		{Update: true, Replace: true},
+		{Update: true, Wait: true},
+	} {
+		_, err := ic.PlayKube(context.Background(), strings.NewReader(""), options)
+		assert.ErrorContains(t, err, "updating deployments cannot be combined")
+	}
+}
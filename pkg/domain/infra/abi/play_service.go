@@ -0,0 +1,366 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	nettypes "go.podman.io/common/libnetwork/types"
+	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	v1 "go.podman.io/podman/v6/pkg/k8s.io/api/core/v1"
+	"go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"go.podman.io/podman/v6/pkg/kubeservice"
+)
+
+// kubeServiceLoopback is the address the ports of pods selected by a K8s
+// Service are published on.  The forwarder of the Service connects to them.
+const kubeServiceLoopback = "127.0.0.1"
+
+// kubeService is a K8s Service whose ports are published on the host and
+// forwarded to the pods selected by the Service.
+type kubeService struct {
+	name     string
+	selector map[string]string
+	// ports are the forwarded ports.  The backends are added while
+	// creating the selected pods.
+	ports []kubeservice.Port
+	// targetPorts are the target ports of ports on the selected pods.
+	targetPorts []intstr.IntOrString
+	// pods maps the backends to the pods serving them.
+	pods map[string]string
+}
+
+// newKubeService returns the kubeService for the specified K8s Service.  It
+// returns nil if the Service does not need to be forwarded.
+func newKubeService(service *v1.Service) (*kubeService, error) {
+	if service.Name == "" {
+		return nil, errors.New("service does not have a name")
+	}
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		logrus.Infof("Ignoring kube service %s of type %s", service.Name, service.Spec.Type)
+		return nil, nil
+	}
+	if len(service.Spec.Selector) == 0 {
+		logrus.Infof("Ignoring kube service %s without selector", service.Name)
+		return nil, nil
+	}
+
+	s := &kubeService{
+		name:     service.Name,
+		selector: service.Spec.Selector,
+		pods:     make(map[string]string),
+	}
+	// Node ports are only published on the loopback address unless the
+	// Service opts in to another address.  podman kube generate --service
+	// emits random node ports which must not become reachable from other
+	// hosts by replaying the YAML.
+	nodePortAddress := kubeServiceLoopback
+	if addr, ok := service.Annotations[define.KubeServiceNodePortAddressAnnotation]; ok {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("service %s: invalid address %q in annotation %s", service.Name, addr, define.KubeServiceNodePortAddressAnnotation)
+		}
+		nodePortAddress = ""
+		if !ip.IsUnspecified() {
+			nodePortAddress = ip.String()
+		}
+	}
+	for _, sp := range service.Spec.Ports {
+		protocol, err := kubeservice.ParseProtocol(string(sp.Protocol))
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", service.Name, err)
+		}
+		// NodePorts are published instead of the Service's port as
+		// that is where the Service is reachable from outside of a
+		// K8s cluster.
+		hostIP := ""
+		hostPort := sp.Port
+		if (service.Spec.Type == v1.ServiceTypeNodePort || service.Spec.Type == v1.ServiceTypeLoadBalancer) && sp.NodePort != 0 {
+			hostIP = nodePortAddress
+			hostPort = sp.NodePort
+		}
+		if hostPort < 1 || hostPort > 65535 {
+			return nil, fmt.Errorf("service %s: invalid port %d", service.Name, hostPort)
+		}
+		targetPort := sp.TargetPort
+		if (targetPort.Type == intstr.Int && targetPort.IntVal == 0) || (targetPort.Type == intstr.String && targetPort.StrVal == "") {
+			targetPort = intstr.FromInt(int(sp.Port))
+		}
+		s.ports = append(s.ports, kubeservice.Port{
+			Protocol: protocol,
+			HostIP:   hostIP,
+			HostPort: uint16(hostPort),
+		})
+		s.targetPorts = append(s.targetPorts, targetPort)
+	}
+	return s, nil
+}
+
+// selects returns whether the Service selects pods with the specified labels.
+func (s *kubeService) selects(labels map[string]string) bool {
+	for k, v := range s.selector {
+		if val, ok := labels[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
+// containerPort resolves the target port of the Service port at the specified
+// index against the container ports of the pod.
+func (s *kubeService) containerPort(index int, podSpec *v1.PodSpec) (uint16, error) {
+	targetPort := s.targetPorts[index]
+	if targetPort.Type == intstr.Int {
+		if targetPort.IntVal < 1 || targetPort.IntVal > 65535 {
+			return 0, fmt.Errorf("invalid target port %d", targetPort.IntVal)
+		}
+		return uint16(targetPort.IntVal), nil
+	}
+	for _, ctr := range podSpec.Containers {
+		for _, cp := range ctr.Ports {
+			if cp.Name != targetPort.StrVal {
+				continue
+			}
+			protocol, err := kubeservice.ParseProtocol(string(cp.Protocol))
+			if err != nil || protocol != s.ports[index].Protocol {
+				continue
+			}
+			return uint16(cp.ContainerPort), nil
+		}
+	}
+	return 0, fmt.Errorf("no container port named %q", targetPort.StrVal)
+}
+
+// kubeServiceBackend is a port of a pod which serves a port of a Service.
+type kubeServiceBackend struct {
+	service       *kubeService
+	index         int
+	containerPort uint16
+}
+
+// selectingKubeServices returns the Services selecting pods with the specified
+// labels.
+func selectingKubeServices(services []*kubeService, labels map[string]string) []*kubeService {
+	var selecting []*kubeService
+	for _, s := range services {
+		if s.selects(labels) {
+			selecting = append(selecting, s)
+		}
+	}
+	return selecting
+}
+
+// kubeServiceBackends returns the ports of the pod which serve the Services
+// selecting it.
+func kubeServiceBackends(services []*kubeService, podName string, podYAML *v1.PodTemplateSpec) []kubeServiceBackend {
+	var backends []kubeServiceBackend
+	for _, s := range selectingKubeServices(services, podYAML.Labels) {
+		for i := range s.ports {
+			containerPort, err := s.containerPort(i, &podYAML.Spec)
+			if err != nil {
+				logrus.Warnf("Pod %s is not a backend of port %s of kube service %s: %v", podName, s.ports[i].String(), s.name, err)
+				continue
+			}
+			backends = append(backends, kubeServiceBackend{
+				service:       s,
+				index:         i,
+				containerPort: containerPort,
+			})
+		}
+	}
+	return backends
+}
+
+// kubeServicePortMappings returns the port mappings publishing the backends on
+// random loopback ports.
+func kubeServicePortMappings(backends []kubeServiceBackend) []nettypes.PortMapping {
+	var mappings []nettypes.PortMapping
+	seen := make(map[string]bool)
+	for _, b := range backends {
+		protocol := b.service.ports[b.index].Protocol
+		key := fmt.Sprintf("%d/%s", b.containerPort, protocol)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		mappings = append(mappings, nettypes.PortMapping{
+			HostIP:        kubeServiceLoopback,
+			ContainerPort: b.containerPort,
+			Protocol:      protocol,
+		})
+	}
+	return mappings
+}
+
+// addKubeServiceBackends registers the backends of the pod with their
+// Services once the pod has been created and the host ports of its port
+// mappings are known.
+func addKubeServiceBackends(backends []kubeServiceBackend, podName string, portMappings []nettypes.PortMapping, hostNetwork bool) error {
+	for _, b := range backends {
+		port := &b.service.ports[b.index]
+		hostPort := b.containerPort
+		if !hostNetwork {
+			var ok bool
+			hostPort, ok = loopbackHostPort(portMappings, b.containerPort, port.Protocol)
+			if !ok {
+				return fmt.Errorf("internal error: container port %d/%s of kube service %s is not published", b.containerPort, port.Protocol, b.service.name)
+			}
+		}
+		addr := net.JoinHostPort(kubeServiceLoopback, strconv.Itoa(int(hostPort)))
+		port.Backends = append(port.Backends, addr)
+		b.service.pods[addr] = podName
+	}
+	return nil
+}
+
+// loopbackHostPort returns the loopback host port the container port is
+// published on.
+func loopbackHostPort(portMappings []nettypes.PortMapping, containerPort uint16, protocol string) (uint16, bool) {
+	for _, pm := range portMappings {
+		if pm.HostIP != kubeServiceLoopback || !isSamePortProtocol(pm.Protocol, protocol) {
+			continue
+		}
+		portRange := max(pm.Range, 1)
+		if containerPort >= pm.ContainerPort && uint32(containerPort) < uint32(pm.ContainerPort)+uint32(portRange) {
+			return pm.HostPort + (containerPort - pm.ContainerPort), true
+		}
+	}
+	return 0, false
+}
+
+// kubeServiceForwarders returns the forwarders of the Services played by kube
+// play.
+func kubeServiceForwarders(r *libpod.Runtime) (*kubeservice.Forwarders, error) {
+	tmpDir, err := r.TmpDir()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := r.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	return &kubeservice.Forwarders{
+		StateDir: filepath.Join(cfg.Engine.StaticDir, "kube-services"),
+		RunDir:   filepath.Join(tmpDir, "kube-services"),
+	}, nil
+}
+
+// ResumeKubeServices restarts the forwarders of the Services played by kube
+// play.  The forwarders do not survive a reboot, so this is run when the
+// runtime is refreshed after one.  They only forward to the pods once these
+// are started again and become ready.
+func ResumeKubeServices(r *libpod.Runtime) error {
+	forwarders, err := kubeServiceForwarders(r)
+	if err != nil {
+		return err
+	}
+	if err := forwarders.Resume(func(config *kubeservice.Config) error {
+		return setKubeServiceReadiness(r, config)
+	}); err != nil {
+		return fmt.Errorf("resuming kube services: %w", err)
+	}
+	return nil
+}
+
+// setKubeServiceReadiness marks the pods serving the backends of the Service
+// whose readiness checks did not pass yet as not ready, so that their
+// backends are not forwarded to.  The forwarder is told once they become
+// ready by UpdateKubeServiceReadiness.
+func setKubeServiceReadiness(r *libpod.Runtime, config *kubeservice.Config) error {
+	config.Unready = nil
+	for _, podName := range config.Pods {
+		if slices.Contains(config.Unready, podName) {
+			continue
+		}
+		pod, err := r.LookupPod(podName)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchPod) {
+				config.Unready = append(config.Unready, podName)
+				continue
+			}
+			return err
+		}
+		ready, err := pod.ReadinessChecksPassed()
+		if err != nil {
+			return err
+		}
+		if !ready {
+			config.Unready = append(config.Unready, podName)
+		}
+	}
+	slices.Sort(config.Unready)
+	return nil
+}
+
+// UpdateKubeServiceReadiness tells the forwarders of the Services selecting
+// the pod whether the pod is ready.  It is run by libpod whenever the
+// readiness of a pod changes.
+func UpdateKubeServiceReadiness(r *libpod.Runtime, pod *libpod.Pod, ready bool) error {
+	forwarders, err := kubeServiceForwarders(r)
+	if err != nil {
+		return err
+	}
+	return forwarders.SetPodReady(pod.Name(), ready)
+}
+
+// startKubeService starts the forwarder of the Service, or updates the
+// backends of an already running one.  Ports without any backend are not
+// forwarded.  Backends of pods which are not ready are only forwarded to once
+// they become ready.
+func (ic *ContainerEngine) startKubeService(s *kubeService) (*entities.PlayKubeService, error) {
+	config := &kubeservice.Config{Name: s.name, Pods: make(map[string]string)}
+	report := &entities.PlayKubeService{Name: s.name}
+	for _, port := range s.ports {
+		if len(port.Backends) == 0 {
+			logrus.Warnf("Kube service %s does not select any pod for port %s", s.name, port.String())
+			continue
+		}
+		config.Ports = append(config.Ports, port)
+		report.Ports = append(report.Ports, port.String())
+		for _, b := range port.Backends {
+			config.Pods[b] = s.pods[b]
+		}
+	}
+	if len(config.Ports) == 0 {
+		return nil, nil
+	}
+	if err := setKubeServiceReadiness(ic.Libpod, config); err != nil {
+		return nil, err
+	}
+
+	forwarders, err := kubeServiceForwarders(ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	if err := forwarders.Update(config); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// stopKubeServices stops the forwarders of the named Services.
+func (ic *ContainerEngine) stopKubeServices(names []string) ([]*entities.PlayKubeServiceRmReport, error) {
+	forwarders, err := kubeServiceForwarders(ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.PlayKubeServiceRmReport, 0, len(names))
+	for _, name := range names {
+		if !forwarders.Exists(name) && !forwarders.IsRunning(name) {
+			continue
+		}
+		reports = append(reports, &entities.PlayKubeServiceRmReport{
+			Name: name,
+			Err:  forwarders.Stop(name),
+		})
+	}
+	return reports, nil
+}
@@ -0,0 +1,255 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	nettypes "go.podman.io/common/libnetwork/types"
+	"go.podman.io/podman/v6/libpod/define"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	v1 "go.podman.io/podman/v6/pkg/k8s.io/api/core/v1"
+	v12 "go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewKubeService(t *testing.T) {
+	tests := []struct {
+		name          string
+		service       v1.Service
+		expectNil     bool
+		expectedError string
+		hostIPs       []string
+		hostPorts     []uint16
+		protocols     []string
+	}{
+		{
+			name:          "no name",
+			service:       v1.Service{},
+			expectedError: "service does not have a name",
+		},
+		{
+			name: "no selector",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{Name: "svc"},
+				Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+			},
+			expectNil: true,
+		},
+		{
+			name: "external name",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{Name: "svc"},
+				Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, Selector: map[string]string{"app": "web"}},
+			},
+			expectNil: true,
+		},
+		{
+			name: "cluster ip uses port",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{Name: "svc"},
+				Spec: v1.ServiceSpec{
+					Selector: map[string]string{"app": "web"},
+					Ports:    []v1.ServicePort{{Port: 8080, NodePort: 30080}, {Port: 53, Protocol: v1.ProtocolUDP}},
+				},
+			},
+			hostIPs:   []string{"", ""},
+			hostPorts: []uint16{8080, 53},
+			protocols: []string{"tcp", "udp"},
+		},
+		{
+			name: "node port uses node port",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{Name: "svc"},
+				Spec: v1.ServiceSpec{
+					Type:     v1.ServiceTypeNodePort,
+					Selector: map[string]string{"app": "web"},
+					Ports:    []v1.ServicePort{{Port: 8080, NodePort: 30080}, {Port: 8081}},
+				},
+			},
+			hostIPs:   []string{"127.0.0.1", ""},
+			hostPorts: []uint16{30080, 8081},
+			protocols: []string{"tcp", "tcp"},
+		},
+		{
+			name: "node port address",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{
+					Name:        "svc",
+					Annotations: map[string]string{define.KubeServiceNodePortAddressAnnotation: "192.168.1.2"},
+				},
+				Spec: v1.ServiceSpec{
+					Type:     v1.ServiceTypeLoadBalancer,
+					Selector: map[string]string{"app": "web"},
+					Ports:    []v1.ServicePort{{Port: 8080, NodePort: 30080}},
+				},
+			},
+			hostIPs:   []string{"192.168.1.2"},
+			hostPorts: []uint16{30080},
+			protocols: []string{"tcp"},
+		},
+		{
+			name: "node port on all addresses",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{
+					Name:        "svc",
+					Annotations: map[string]string{define.KubeServiceNodePortAddressAnnotation: "0.0.0.0"},
+				},
+				Spec: v1.ServiceSpec{
+					Type:     v1.ServiceTypeNodePort,
+					Selector: map[string]string{"app": "web"},
+					Ports:    []v1.ServicePort{{Port: 8080, NodePort: 30080}},
+				},
+			},
+			hostIPs:   []string{""},
+			hostPorts: []uint16{30080},
+			protocols: []string{"tcp"},
+		},
+		{
+			name: "invalid node port address",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{
+					Name:        "svc",
+					Annotations: map[string]string{define.KubeServiceNodePortAddressAnnotation: "localhost"},
+				},
+				Spec: v1.ServiceSpec{
+					Type:     v1.ServiceTypeNodePort,
+					Selector: map[string]string{"app": "web"},
+					Ports:    []v1.ServicePort{{Port: 8080, NodePort: 30080}},
+				},
+			},
+			expectedError: `service svc: invalid address "localhost" in annotation io.podman.annotations.kube.service.nodeport-address`,
+		},
+		{
+			name: "sctp",
+			service: v1.Service{
+				ObjectMeta: v12.ObjectMeta{Name: "svc"},
+				Spec: v1.ServiceSpec{
+					Selector: map[string]string{"app": "web"},
+					Ports:    []v1.ServicePort{{Port: 8080, Protocol: v1.ProtocolSCTP}},
+				},
+			},
+			expectedError: `service svc: protocol "SCTP" is not supported for kube services`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := newKubeService(&test.service)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			if test.expectNil {
+				assert.Nil(t, s)
+				return
+			}
+			var hostIPs []string
+			var hostPorts []uint16
+			var protocols []string
+			for _, p := range s.ports {
+				hostIPs = append(hostIPs, p.HostIP)
+				hostPorts = append(hostPorts, p.HostPort)
+				protocols = append(protocols, p.Protocol)
+			}
+			assert.Equal(t, test.hostIPs, hostIPs)
+			assert.Equal(t, test.hostPorts, hostPorts)
+			assert.Equal(t, test.protocols, protocols)
+		})
+	}
+}
+
+func TestKubeServiceBackends(t *testing.T) {
+	service := v1.Service{
+		ObjectMeta: v12.ObjectMeta{Name: "svc"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports: []v1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+				{Port: 81, TargetPort: intstr.FromInt(8081)},
+				{Port: 82},
+				{Port: 83, TargetPort: intstr.FromString("missing")},
+			},
+		},
+	}
+	s, err := newKubeService(&service)
+	assert.NoError(t, err)
+
+	pod := v1.PodTemplateSpec{
+		ObjectMeta: v12.ObjectMeta{Labels: map[string]string{"app": "web", "tier": "frontend"}},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "ctr",
+				Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}},
+		},
+	}
+	backends := kubeServiceBackends([]*kubeService{s}, "pod", &pod)
+	ports := make([]uint16, 0, len(backends))
+	for _, b := range backends {
+		ports = append(ports, b.containerPort)
+	}
+	assert.Equal(t, []uint16{8080, 8081, 82}, ports)
+
+	mappings := kubeServicePortMappings(append(backends, backends...))
+	assert.Len(t, mappings, 3)
+
+	other := v1.PodTemplateSpec{ObjectMeta: v12.ObjectMeta{Labels: map[string]string{"app": "db"}}}
+	assert.Empty(t, kubeServiceBackends([]*kubeService{s}, "other", &other))
+}
+
+func TestLoopbackHostPort(t *testing.T) {
+	mappings := []nettypes.PortMapping{
+		{HostIP: "", HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1},
+		{HostIP: kubeServiceLoopback, HostPort: 40000, ContainerPort: 80, Protocol: "tcp", Range: 3},
+		{HostIP: kubeServiceLoopback, HostPort: 41000, ContainerPort: 53, Protocol: "udp", Range: 1},
+	}
+
+	hostPort, ok := loopbackHostPort(mappings, 82, "tcp")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(40002), hostPort)
+
+	hostPort, ok = loopbackHostPort(mappings, 53, "udp")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(41000), hostPort)
+
+	_, ok = loopbackHostPort(mappings, 53, "tcp")
+	assert.False(t, ok)
+}
+
+func TestCanScaleDeployment(t *testing.T) {
+	service, err := newKubeService(&v1.Service{
+		ObjectMeta: v12.ObjectMeta{Name: "svc"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports:    []v1.ServicePort{{Port: 80}},
+		},
+	})
+	assert.NoError(t, err)
+	services := []*kubeService{service}
+
+	podSpec := func(hostPort int32) *v1.PodTemplateSpec {
+		return &v1.PodTemplateSpec{
+			ObjectMeta: v12.ObjectMeta{Labels: map[string]string{"app": "web"}},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:  "ctr",
+					Ports: []v1.ContainerPort{{ContainerPort: 80, HostPort: hostPort}},
+				}},
+			},
+		}
+	}
+
+	assert.True(t, canScaleDeployment(podSpec(0), entities.PlayKubeOptions{}, services))
+	assert.False(t, canScaleDeployment(podSpec(0), entities.PlayKubeOptions{}, nil))
+	assert.False(t, canScaleDeployment(podSpec(8080), entities.PlayKubeOptions{}, services))
+	assert.False(t, canScaleDeployment(podSpec(0), entities.PlayKubeOptions{PublishPorts: []string{"8080:80"}}, services))
+	assert.False(t, canScaleDeployment(podSpec(0), entities.PlayKubeOptions{NoPodPrefix: true}, services))
+}
+
+func TestDeploymentPodName(t *testing.T) {
+	assert.Equal(t, "web-pod", deploymentPodName("web", 0))
+	assert.Equal(t, "web-pod-2", deploymentPodName("web", 2))
+}
@@ -17,6 +17,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/coreos/go-systemd/v22/dbus"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/podman/v6/libpod/define"
@@ -95,6 +96,10 @@ func getAssetListFromFile(path string) ([]string, error) {
 
 // Install one or more Quadlet files
 func (ic *ContainerEngine) QuadletInstall(ctx context.Context, pathsOrURLs []string, options entities.QuadletInstallOptions) (*entities.QuadletInstallReport, error) {
+	if options.RestartChanged && !options.ReloadSystemd {
+		return nil, errors.New("restarting the changed units requires reloading systemd")
+	}
+
 	// Is systemd available to the current user?
 	// We cannot proceed if not.
 	conn, err := systemd.ConnectToDBUS()
@@ -136,9 +141,27 @@ func (ic *ContainerEngine) QuadletInstall(ctx context.Context, pathsOrURLs []str
 		QuadletErrors:     make(map[string]error),
 	}
 
+	var unitsBefore map[string]string
+	if options.RestartChanged {
+		unitsBefore = generateInstalledUnits()
+	}
+
 	assetFile := ""
 	paths := pathsOrURLs
-	if len(pathsOrURLs) > 0 && !strings.HasPrefix(pathsOrURLs[0], "http://") && !strings.HasPrefix(pathsOrURLs[0], "https://") {
+	if len(pathsOrURLs) > 0 && strings.HasPrefix(pathsOrURLs[0], ociArtifactPrefix) {
+		// An artifact is an APP on its own
+		if len(pathsOrURLs) > 1 {
+			return nil, fmt.Errorf("an OCI artifact must be installed on its own")
+		}
+		installed, err := ic.installQuadletArtifact(ctx, strings.TrimPrefix(pathsOrURLs[0], ociArtifactPrefix), installDir, options.Replace)
+		if err != nil {
+			installReport.QuadletErrors[pathsOrURLs[0]] = err
+		}
+		for name, installedPath := range installed {
+			installReport.InstalledQuadlets[fmt.Sprintf("%s#%s", pathsOrURLs[0], name)] = installedPath
+		}
+		paths = nil
+	} else if len(pathsOrURLs) > 0 && !strings.HasPrefix(pathsOrURLs[0], "http://") && !strings.HasPrefix(pathsOrURLs[0], "https://") {
 		// Check if first path is dir, this is an APP
 		info, err := os.Stat(pathsOrURLs[0])
 		if err != nil {
@@ -290,10 +313,88 @@ func (ic *ContainerEngine) QuadletInstall(ctx context.Context, pathsOrURLs []str
 			return &installReport, fmt.Errorf("reloading systemd: %w", err)
 		}
 	}
+	if options.RestartChanged {
+		installReport.UnitChanges = applyUnitChanges(ctx, conn, unitsBefore, generateInstalledUnits())
+	}
 
 	return &installReport, nil
 }
 
+// generateInstalledUnits returns the contents of the systemd units the
+// generator generates from the installed Quadlets by name.
+func generateInstalledUnits() map[string]string {
+	isUser := rootless.IsRootless()
+	return systemdquadlet.GenerateUnits(systemdquadlet.GetUnitDirs(isUser), isUser)
+}
+
+// applyUnitChanges compares the generated units before and after a change
+// of the installed Quadlets, restarts the running units which were changed
+// and stops the running units which were removed.  Systemd must have been
+// reloaded already.
+func applyUnitChanges(ctx context.Context, conn *dbus.Conn, before, after map[string]string) []entities.QuadletUnitChange {
+	changed, removed := systemdquadlet.ChangedUnits(before, after)
+	changes := []entities.QuadletUnitChange{}
+	if len(changed) == 0 && len(removed) == 0 {
+		return changes
+	}
+
+	// Template units are matched against their running instances
+	patterns := make([]string, 0, len(changed)+len(removed))
+	for _, name := range slices.Concat(changed, removed) {
+		patterns = append(patterns, strings.Replace(name, "@.", "@*.", 1))
+	}
+	statuses, err := conn.ListUnitsByPatternsContext(ctx, []string{"active", "activating", "reloading"}, patterns)
+	if err != nil {
+		for _, name := range slices.Concat(changed, removed) {
+			changes = append(changes, entities.QuadletUnitChange{Unit: name, Action: "none", Error: fmt.Sprintf("querying systemd for unit status: %v", err)})
+		}
+		return changes
+	}
+
+	apply := func(name, verb, action string, job func(string, chan<- string) (int, error)) {
+		pattern := strings.Replace(name, "@.", "@*.", 1)
+		found := false
+		for _, status := range statuses {
+			if matched, _ := path.Match(pattern, status.Name); !matched {
+				continue
+			}
+			found = true
+			change := entities.QuadletUnitChange{Unit: status.Name, Action: action}
+			logrus.Infof("Going to %s systemd unit %s", verb, status.Name)
+			if err := waitForUnitJob(status.Name, job); err != nil {
+				change.Error = err.Error()
+			}
+			changes = append(changes, change)
+		}
+		if !found {
+			changes = append(changes, entities.QuadletUnitChange{Unit: name, Action: "none"})
+		}
+	}
+	for _, name := range removed {
+		apply(name, "stop", "stopped", func(unit string, ch chan<- string) (int, error) {
+			return conn.StopUnitContext(ctx, unit, "replace", ch)
+		})
+	}
+	for _, name := range changed {
+		apply(name, "restart", "restarted", func(unit string, ch chan<- string) (int, error) {
+			return conn.TryRestartUnitContext(ctx, unit, "replace", ch)
+		})
+	}
+	return changes
+}
+
+// waitForUnitJob starts a systemd job for a unit and waits for it to finish
+func waitForUnitJob(unit string, job func(string, chan<- string) (int, error)) error {
+	ch := make(chan string)
+	if _, err := job(unit, ch); err != nil {
+		return err
+	}
+	if result := <-ch; result != "done" && result != "skipped" {
+		return fmt.Errorf("job for unit %s finished with result %q", unit, result)
+	}
+	return nil
+}
+
 // Extracts file name from Content-Disposition or URL
 func getFileName(resp *http.Response, fileURL string) (string, error) {
 	// Try to get filename from Content-Disposition header
@@ -639,12 +740,6 @@ func getQuadletServiceNameAndUnit(quadletPath string) (string, *parser.UnitFile,
 	return serviceName + ".service", unit, nil
 }
 
-// Generate systemd service name for a Quadlet from full path to the Quadlet file
-func getQuadletServiceName(quadletPath string) (string, error) {
-	name, _, err := getQuadletServiceNameAndUnit(quadletPath)
-	return name, err
-}
-
 type QuadletFilter func(q *entities.ListQuadlet) bool
 
 func generateQuadletFilter(filter string, filterValues []string) (func(q *entities.ListQuadlet) bool, error) {
@@ -704,6 +799,7 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 
 	reports := make([]*entities.ListQuadlet, 0, len(quadletPaths))
 	allServiceNames := make([]string, 0, len(quadletPaths))
+	allTimerNames := make([]string, 0)
 	partialReports := make(map[string]entities.ListQuadlet)
 
 	for _, path := range quadletPaths {
@@ -717,6 +813,9 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 			Path: path,
 			App:  appName,
 		}
+		if appName != "" {
+			report.Source = readArtifactSource(filepath.Dir(path), appName)
+		}
 
 		serviceName, unit, err := getQuadletServiceNameAndUnit(path)
 		if err != nil {
@@ -727,6 +826,10 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 		if pod, ok := unit.Lookup(systemdquadlet.ContainerGroup, systemdquadlet.KeyPod); ok {
 			report.Pod = pod
 		}
+		if unit.HasGroup(systemdquadlet.ScheduleGroup) {
+			report.Timer = strings.TrimSuffix(serviceName, ".service") + ".timer"
+			allTimerNames = append(allTimerNames, report.Timer)
+		}
 		partialReports[serviceName] = report
 		allServiceNames = append(allServiceNames, serviceName)
 	}
@@ -739,6 +842,29 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 	if len(statuses) != len(allServiceNames) {
 		logrus.Warnf("Queried for %d services but received %d responses", len(allServiceNames), len(statuses))
 	}
+
+	// Get status of the timers of scheduled Quadlets.
+	timerStatuses := make(map[string]string, len(allTimerNames))
+	if len(allTimerNames) > 0 {
+		timerUnits, err := conn.ListUnitsByNamesContext(ctx, allTimerNames)
+		if err != nil {
+			return nil, fmt.Errorf("querying systemd for timer status: %w", err)
+		}
+		for _, unitStatus := range timerUnits {
+			logrus.Debugf("Timer %s has status %s %s %s", unitStatus.Name, unitStatus.LoadState, unitStatus.ActiveState, unitStatus.SubState)
+			timerStatuses[unitStatus.Name] = formatUnitStatus(unitStatus)
+		}
+	}
+	timerStatus := func(report *entities.ListQuadlet) {
+		if report.Timer == "" {
+			return
+		}
+		report.TimerStatus = "Not loaded"
+		if status, ok := timerStatuses[report.Timer]; ok {
+			report.TimerStatus = status
+		}
+	}
+
 	for _, unitStatus := range statuses {
 		report, ok := partialReports[unitStatus.Name]
 		if !ok {
@@ -747,13 +873,8 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 
 		logrus.Debugf("Unit %s has status %s %s %s", unitStatus.Name, unitStatus.LoadState, unitStatus.ActiveState, unitStatus.SubState)
 		report.UnitName = unitStatus.Name
-
-		// Unit is not loaded
-		if unitStatus.LoadState != "loaded" {
-			report.Status = "Not loaded"
-		} else {
-			report.Status = fmt.Sprintf("%s/%s", unitStatus.ActiveState, unitStatus.SubState)
-		}
+		report.Status = formatUnitStatus(unitStatus)
+		timerStatus(&report)
 		reports = append(reports, &report)
 		delete(partialReports, unitStatus.Name)
 	}
@@ -764,6 +885,7 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 	// Handle it anyways because it's easy enough to do.
 	for _, report := range partialReports {
 		report.Status = "Not loaded"
+		timerStatus(&report)
 		reports = append(reports, &report)
 	}
 
@@ -784,6 +906,15 @@ func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.Qua
 	return finalReports, nil
 }
 
+// formatUnitStatus returns the status of a systemd unit as shown by quadlet list.
+func formatUnitStatus(unitStatus dbus.UnitStatus) string {
+	// Unit is not loaded
+	if unitStatus.LoadState != "loaded" {
+		return "Not loaded"
+	}
+	return fmt.Sprintf("%s/%s", unitStatus.ActiveState, unitStatus.SubState)
+}
+
 // QuadletExists checks whether a quadlet with the given name exists.
 func (ic *ContainerEngine) QuadletExists(_ context.Context, name string) (*entities.BoolReport, error) {
 	_, err := getQuadletPathByName(name)
@@ -825,7 +956,33 @@ func (ic *ContainerEngine) QuadletPrint(_ context.Context, quadlet string) (stri
 		return "", fmt.Errorf("reading quadlet %q contents: %w", quadletPath, err)
 	}
 
-	return string(contents), nil
+	// Show the timer generated from the [Schedule] group as well, it is
+	// not part of the Quadlet file.
+	unit, err := parser.ParseUnitFile(quadletPath)
+	if err != nil {
+		logrus.Warnf("Parsing Quadlet %s: %v", quadletPath, err)
+		return string(contents), nil
+	}
+	timer, err := systemdquadlet.ConvertSchedule(unit)
+	if err != nil {
+		logrus.Warnf("Generating timer of Quadlet %s: %v", quadletPath, err)
+		return string(contents), nil
+	}
+	if timer == nil {
+		return string(contents), nil
+	}
+	timerContents, err := timer.ToString()
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	out.Write(contents)
+	if len(contents) > 0 && contents[len(contents)-1] != '\n' {
+		out.WriteString("\n")
+	}
+	fmt.Fprintf(&out, "---%s---\n%s", timer.Filename, timerContents)
+	return out.String(), nil
 }
 
 // QuadletRemove removes one or more Quadlet files or applications and reloads systemd daemon as needed. The function returns a `QuadletRemoveReport`
@@ -872,11 +1029,15 @@ func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string,
 	allServiceNames := make([]string, 0, len(quadlets))
 	runningQuadlets := make([]string, 0, len(quadlets))
 	serviceNameToQuadletName := make(map[string]string)
+	timerNameToQuadletName := make(map[string]string)
 	needReload := options.ReloadSystemd
 
 	if len(quadlets) == 0 && !options.All {
 		return nil, errors.New("must provide at least 1 quadlet to remove")
 	}
+	if options.RestartChanged && !options.ReloadSystemd {
+		return nil, errors.New("restarting the changed units requires reloading systemd")
+	}
 
 	// Is systemd available to the current user?
 	// We cannot proceed if not.
@@ -886,6 +1047,12 @@ func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string,
 	}
 	defer conn.Close()
 
+	var unitsBefore map[string]string
+	if options.RestartChanged {
+		unitsBefore = generateInstalledUnits()
+		needReload = true
+	}
+
 	if options.All {
 		allQuadlets := getAllQuadletPaths()
 		quadlets = allQuadlets
@@ -943,7 +1110,7 @@ func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string,
 
 		allQuadletPaths = append(allQuadletPaths, quadletPath)
 
-		serviceName, err := getQuadletServiceName(quadletPath)
+		serviceName, unit, err := getQuadletServiceNameAndUnit(quadletPath)
 		if err != nil {
 			report.Errors[quadlet] = err
 			continue
@@ -951,6 +1118,10 @@ func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string,
 
 		allServiceNames = append(allServiceNames, serviceName)
 		serviceNameToQuadletName[serviceName] = quadlet
+		if unit.HasGroup(systemdquadlet.ScheduleGroup) {
+			timerName := strings.TrimSuffix(serviceName, ".service") + ".timer"
+			timerNameToQuadletName[timerName] = quadlet
+		}
 	}
 
 	if len(allServiceNames) != 0 {
@@ -993,6 +1164,22 @@ func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string,
 		}
 	}
 
+	// Stop the timers of scheduled quadlets, they would keep starting the
+	// services until the next reload otherwise.
+	for timerName, quadletName := range timerNameToQuadletName {
+		if slices.Contains(runningQuadlets, quadletName) {
+			continue
+		}
+		ch := make(chan string)
+		if _, err := conn.StopUnitContext(ctx, timerName, "replace", ch); err != nil {
+			logrus.Debugf("Stopping systemd timer %s (Quadlet %s): %v", timerName, quadletName, err)
+			continue
+		}
+		if stopResult := <-ch; stopResult != "done" && stopResult != "skipped" {
+			logrus.Warnf("Unable to stop systemd timer %s of quadlet %s: %s", timerName, quadletName, stopResult)
+		}
+	}
+
 	// Remove the actual files behind the quadlets
 	if len(allQuadletPaths) != 0 {
 		for _, path := range allQuadletPaths {
@@ -1025,6 +1212,44 @@ func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string,
 			return &report, fmt.Errorf("reloading systemd: %w", err)
 		}
 	}
+	if options.RestartChanged {
+		report.UnitChanges = applyUnitChanges(ctx, conn, unitsBefore, generateInstalledUnits())
+	}
 
 	return &report, nil
 }
+
+// QuadletValidate runs the conversion of the Quadlets at paths like the
+// generator does and reports the problems found in them.  If no paths are
+// given, all Quadlets of the current user are validated.
+func (ic *ContainerEngine) QuadletValidate(_ context.Context, paths []string) (*entities.QuadletValidateReport, error) {
+	isUser := rootless.IsRootless()
+
+	if len(paths) == 0 {
+		// Only the first Quadlet with a name is used by the generator
+		seen := make(map[string]bool)
+		for _, quadletPath := range getAllQuadletPaths() {
+			if name := filepath.Base(quadletPath); !seen[name] {
+				seen[name] = true
+				paths = append(paths, quadletPath)
+			}
+		}
+	}
+
+	diagnostics := systemdquadlet.Validate(paths, systemdquadlet.GetUnitDirs(isUser), isUser)
+
+	report := &entities.QuadletValidateReport{
+		Diagnostics: make([]entities.QuadletDiagnostic, 0, len(diagnostics)),
+	}
+	for _, d := range diagnostics {
+		report.Diagnostics = append(report.Diagnostics, entities.QuadletDiagnostic{
+			File:     d.File,
+			Line:     d.Line,
+			Group:    d.Group,
+			Key:      d.Key,
+			Severity: d.Severity,
+			Message:  d.Message,
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,227 @@
+//go:build !remote && (linux || freebsd)
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/libartifact"
+	"go.podman.io/common/pkg/libartifact/types"
+	"go.podman.io/podman/v6/pkg/domain/entities"
+	systemdquadlet "go.podman.io/podman/v6/pkg/systemd/quadlet"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+// ociArtifactPrefix is the prefix of the Quadlets to install from an OCI artifact
+const ociArtifactPrefix = "oci://"
+
+// artifactSourceFile returns the name of the file recording the artifact an
+// application was installed from.  Its extension must not be one of a Quadlet
+// unit, or the generator would try to convert it.
+func artifactSourceFile(appFile string) string {
+	return strings.TrimSuffix(appFile, ".app") + ".source"
+}
+
+// readArtifactSource returns the reference of the artifact an application
+// was installed from, or an empty string if it was not installed from one.
+func readArtifactSource(installDir, appFile string) string {
+	var source string
+	err := readLinesFromFile(filepath.Join(installDir, artifactSourceFile(appFile)), func(line string) error {
+		if source == "" {
+			source = line
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logrus.Debugf("Reading artifact source of application %s: %v", appFile, err)
+	}
+	return source
+}
+
+// installQuadletArtifact installs the files of an OCI artifact as an
+// application named after the repository of the artifact.  The artifact is
+// pulled unless it is in the local artifact store already.  If another
+// version of the artifact is installed, all files of the new version are
+// staged before they replace the files of the installed version.  It returns
+// the paths of the installed files by name.
+func (ic *ContainerEngine) installQuadletArtifact(ctx context.Context, ref, installDir string, replace bool) (map[string]string, error) {
+	artRef, err := libartifact.NewArtifactReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("parsing artifact reference %q: %w", ref, err)
+	}
+
+	imageEngine := ImageEngine{Libpod: ic.Libpod}
+	inspectReport, err := imageEngine.ArtifactInspect(ctx, ref, entities.ArtifactInspectOptions{})
+	if errors.Is(err, types.ErrArtifactNotExist) {
+		if _, err := imageEngine.ArtifactPull(ctx, ref, entities.ArtifactPullOptions{}); err != nil {
+			return nil, fmt.Errorf("pulling artifact %s: %w", ref, err)
+		}
+		inspectReport, err = imageEngine.ArtifactInspect(ctx, ref, entities.ArtifactInspectOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("inspecting artifact %s: %w", ref, err)
+	}
+
+	extractDir, err := os.MkdirTemp("", "quadlet-artifact")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(extractDir); err != nil {
+			logrus.Errorf("Unable to remove temporary directory %q: %v", extractDir, err)
+		}
+	}()
+	if err := imageEngine.ArtifactExtract(ctx, ref, extractDir, entities.ArtifactExtractOptions{}); err != nil {
+		return nil, fmt.Errorf("extracting artifact %s: %w", ref, err)
+	}
+
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading extracted artifact %s: %w", ref, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+	if !slices.ContainsFunc(names, systemdquadlet.IsExtSupported) {
+		return nil, fmt.Errorf("artifact %s does not contain any Quadlet files", ref)
+	}
+
+	appName := path.Base(artRef.RepoName())
+	appFile := "." + appName + ".app"
+	sourceFile := artifactSourceFile(appFile)
+	reverseMap, appMap, err := buildAppMap(installDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build app map: %w", err)
+	}
+	installedFiles := appMap[appFile]
+	if len(installedFiles) > 0 && !replace {
+		return nil, fmt.Errorf("application %s is already installed, use replace to upgrade it", appName)
+	}
+	for _, name := range names {
+		if app, ok := reverseMap[name]; ok && app != appFile {
+			return nil, fmt.Errorf("file %s of artifact %s is part of the application %s", name, ref, app)
+		}
+		if name == appFile || name == sourceFile {
+			return nil, fmt.Errorf("file %s of artifact %s conflicts with the application files", name, ref)
+		}
+		if slices.Contains(installedFiles, name) || replace {
+			continue
+		}
+		if err := fileutils.Exists(filepath.Join(installDir, name)); err == nil {
+			return nil, fmt.Errorf("a Quadlet with name %s already exists, refusing to overwrite", name)
+		}
+	}
+
+	// Stage all files first, so a failure leaves the installed version untouched
+	staged := make(map[string]string, len(names)+2)
+	defer func() {
+		for _, tempPath := range staged {
+			os.Remove(tempPath)
+		}
+	}()
+	for _, name := range names {
+		tempPath, err := stageQuadletFile(installDir, filepath.Join(extractDir, name))
+		if err != nil {
+			return nil, err
+		}
+		staged[name] = tempPath
+	}
+	source := fmt.Sprintf("%s\n%s\n", artRef.String(), inspectReport.Digest)
+	if staged[sourceFile], err = stageQuadletContent(installDir, source); err != nil {
+		return nil, err
+	}
+	appContent := strings.Join(append(slices.Clone(names), sourceFile), "\n") + "\n"
+	if staged[appFile], err = stageQuadletContent(installDir, appContent); err != nil {
+		return nil, err
+	}
+
+	// The application file is replaced last, it lists the files of the
+	// installed version until then
+	installed := make(map[string]string, len(names))
+	for _, name := range append(names, sourceFile) {
+		finalPath := filepath.Join(installDir, name)
+		if err := os.Rename(staged[name], finalPath); err != nil {
+			return installed, fmt.Errorf("unable to rename temp file to %s: %w", finalPath, err)
+		}
+		delete(staged, name)
+		if name != sourceFile {
+			installed[name] = finalPath
+		}
+	}
+	for _, name := range installedFiles {
+		if slices.Contains(names, name) || name == sourceFile {
+			continue
+		}
+		if err := os.Remove(filepath.Join(installDir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logrus.Warnf("Unable to remove file %s of the previous version of application %s: %v", name, appName, err)
+		}
+	}
+	if err := os.Rename(staged[appFile], filepath.Join(installDir, appFile)); err != nil {
+		return installed, fmt.Errorf("unable to rename temp file to %s: %w", appFile, err)
+	}
+	delete(staged, appFile)
+
+	return installed, nil
+}
+
+// stageQuadletFile copies a file to a temporary file in installDir and
+// returns its path
+func stageQuadletFile(installDir, srcPath string) (string, error) {
+	srcFile, err := os.Open(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to open file: %w", err)
+	}
+	defer srcFile.Close()
+
+	destFile, err := os.CreateTemp(installDir, ".quadlet-install-*")
+	if err != nil {
+		return "", fmt.Errorf("unable to create temp file: %w", err)
+	}
+	if err := fileutils.ReflinkOrCopy(srcFile, destFile); err != nil {
+		destFile.Close()
+		os.Remove(destFile.Name())
+		return "", fmt.Errorf("unable to copy file from %s to %s: %w", srcPath, destFile.Name(), err)
+	}
+	if err := destFile.Close(); err != nil {
+		os.Remove(destFile.Name())
+		return "", fmt.Errorf("unable to close file: %w", err)
+	}
+	if err := os.Chmod(destFile.Name(), 0o644); err != nil {
+		os.Remove(destFile.Name())
+		return "", fmt.Errorf("unable to set permissions on temp file: %w", err)
+	}
+	return destFile.Name(), nil
+}
+
+// stageQuadletContent writes content to a temporary file in installDir and
+// returns its path
+func stageQuadletContent(installDir, content string) (string, error) {
+	destFile, err := os.CreateTemp(installDir, ".quadlet-install-*")
+	if err != nil {
+		return "", fmt.Errorf("unable to create temp file: %w", err)
+	}
+	_, err = destFile.WriteString(content)
+	if closeErr := destFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(destFile.Name(), 0o644)
+	}
+	if err != nil {
+		os.Remove(destFile.Name())
+		return "", fmt.Errorf("unable to write temp file: %w", err)
+	}
+	return destFile.Name(), nil
+}
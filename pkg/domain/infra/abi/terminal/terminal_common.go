@@ -5,6 +5,7 @@ package terminal
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -38,10 +39,39 @@ func ExecAttachCtr(ctx context.Context, ctr *libpod.Container, execConfig *libpo
 	return ctr.Exec(execConfig, streams, resizechan)
 }
 
-// StartAttachCtr starts and (if required) attaches to a container
+// ExecAttachSession attaches to a running exec session of a container
+func ExecAttachSession(ctx context.Context, ctr *libpod.Container, sessionID string, isTerminal bool, detachKeys *string, streams *define.AttachStreams) (int, error) {
+	var resizechan chan resize.TerminalSize
+	haveTerminal := term.IsTerminal(int(os.Stdin.Fd()))
+
+	// Check if we are attached to a terminal. If we are, generate resize
+	// events, and set the terminal to raw mode
+	if haveTerminal && isTerminal {
+		resizechan = make(chan resize.TerminalSize)
+		cancel, oldTermState, err := handleTerminalAttach(ctx, resizechan)
+		if err != nil {
+			return -1, err
+		}
+		defer cancel()
+		defer func() {
+			if err := restoreTerminal(oldTermState); err != nil {
+				logrus.Errorf("Unable to restore terminal: %q", err)
+			}
+		}()
+	}
+	exitCode, err := ctr.ExecAttach(sessionID, streams, detachKeys, resizechan)
+	// user detached, the session keeps running
+	if errors.Is(err, define.ErrDetach) {
+		return 0, nil
+	}
+	return exitCode, err
+}
+
+// StartAttachCtr starts and (if required) attaches to a container, and records
+// the attach session if record is set.
 // if you change the signature of this function from os.File to io.Writer, it will trigger a downstream
 // error. we may need to just lint disable this one.
-func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer bool) error { //nolint: interfacer
+func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer, record bool) error { //nolint: interfacer
 	resize := make(chan resize.TerminalSize)
 
 	haveTerminal := term.IsTerminal(int(os.Stdin.Fd()))
@@ -89,7 +119,7 @@ func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr,
 		ProxySignals(ctr)
 	}
 
-	attachChan, err := ctr.Attach(ctx, streams, detachKeys, resize, startContainer)
+	attachChan, err := ctr.Attach(ctx, streams, detachKeys, resize, startContainer, record)
 	if err != nil {
 		return err
 	}
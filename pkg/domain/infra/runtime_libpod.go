@@ -15,7 +15,9 @@ import (
 	flag "github.com/spf13/pflag"
 	"go.podman.io/image/v5/pkg/cli/basetls/tlsdetails"
 	"go.podman.io/podman/v6/libpod"
+	"go.podman.io/podman/v6/pkg/autoupdate"
 	"go.podman.io/podman/v6/pkg/domain/entities"
+	"go.podman.io/podman/v6/pkg/domain/infra/abi"
 	"go.podman.io/podman/v6/pkg/namespaces"
 	"go.podman.io/podman/v6/pkg/rootless"
 	"go.podman.io/podman/v6/pkg/util"
@@ -213,6 +215,15 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 	if !opts.withFDS {
 		options = append(options, libpod.WithEnableSDNotify())
 	}
+
+	// The schedulers of kube CronJobs do not survive a reboot.
+	options = append(options, libpod.WithRefreshHook(abi.ResumeKubeCronJobs))
+	// Neither do the forwarders of kube Services.
+	options = append(options, libpod.WithRefreshHook(abi.ResumeKubeServices))
+	// The forwarders of kube Services only forward to ready pods.
+	options = append(options, libpod.WithPodReadinessHook(abi.UpdateKubeServiceReadiness))
+	// Rolling back unhealthy containers needs the auto-update logic.
+	options = append(options, libpod.WithHealthCheckRollbackHook(autoupdate.RollbackUnhealthy))
 	return libpod.NewRuntime(ctx, options...)
 }
 
@@ -69,6 +69,10 @@ func (ir *ImageEngine) ArtifactRm(_ context.Context, opts entities.ArtifactRemov
 }
 
 func (ir *ImageEngine) ArtifactPush(_ context.Context, name string, opts entities.ArtifactPushOptions) (*entities.ArtifactPushReport, error) {
+	if opts.Signers != nil || opts.SignBy != "" || opts.SignBySigstorePrivateKeyFile != "" || opts.SignBySigstoreParamFile != "" {
+		return nil, errors.New("signing artifacts is not supported for remote clients")
+	}
+
 	options := artifacts.PushOptions{
 		Username:   &opts.Username,
 		Password:   &opts.Password,
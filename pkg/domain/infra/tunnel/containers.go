@@ -20,6 +20,7 @@ import (
 	"go.podman.io/podman/v6/pkg/bindings"
 	"go.podman.io/podman/v6/pkg/bindings/containers"
 	"go.podman.io/podman/v6/pkg/bindings/images"
+	"go.podman.io/podman/v6/pkg/bindings/pods"
 	"go.podman.io/podman/v6/pkg/domain/entities"
 	"go.podman.io/podman/v6/pkg/domain/entities/reports"
 	"go.podman.io/podman/v6/pkg/errorhandling"
@@ -457,10 +458,6 @@ func (ic *ContainerEngine) ContainerCheckpoint(_ context.Context, namesOrIds []s
 }
 
 func (ic *ContainerEngine) ContainerRestore(_ context.Context, namesOrIds []string, opts entities.RestoreOptions) ([]*entities.RestoreReport, error) {
-	if opts.ImportPrevious != "" {
-		return nil, fmt.Errorf("--import-previous is not supported on the remote client")
-	}
-
 	var (
 		ids          []string
 		idToRawInput = map[string]string{}
@@ -478,6 +475,7 @@ func (ic *ContainerEngine) ContainerRestore(_ context.Context, namesOrIds []stri
 	options.WithPod(opts.Pod)
 	options.WithPrintStats(opts.PrintStats)
 	options.WithPublishPorts(opts.PublishPorts)
+	options.WithImportPrevious(opts.ImportPrevious)
 
 	if opts.Import != "" {
 		options.WithImportArchive(opts.Import)
@@ -555,6 +553,26 @@ func (ic *ContainerEngine) ContainerLogs(_ context.Context, nameOrIDs []string,
 	stderr := opts.StderrWriter != nil
 	options := new(containers.LogOptions).WithFollow(opts.Follow).WithSince(since).WithUntil(until).WithStderr(stderr)
 	options.WithStdout(stdout).WithTail(tail).WithTimestamps(opts.Timestamps)
+	if opts.Grep != "" {
+		options.WithGrep(opts.Grep)
+	}
+	if opts.Stream != "" {
+		options.WithStream(opts.Stream)
+	}
+	if len(opts.JSONFields) > 0 {
+		options.WithJSONField(opts.JSONFields)
+	}
+
+	var records *containerLogRecords
+	if opts.Output == entities.LogsOutputJSON {
+		var err error
+		records, err = ic.newContainerLogRecords(nameOrIDs[0], opts)
+		if err != nil {
+			return err
+		}
+		// The timestamps are parsed into the records.
+		options.WithTimestamps(true)
+	}
 
 	var err error
 	stdoutCh := make(chan string)
@@ -568,19 +586,104 @@ func (ic *ContainerEngine) ContainerLogs(_ context.Context, nameOrIDs []string,
 	for {
 		select {
 		case <-ctx.Done():
+			if records != nil {
+				if flushErr := records.flush(); err == nil {
+					err = flushErr
+				}
+			}
 			return err
 		case line := <-stdoutCh:
-			if opts.StdoutWriter != nil {
+			if records != nil {
+				records.add("stdout", line)
+			} else if opts.StdoutWriter != nil {
 				_, _ = io.WriteString(opts.StdoutWriter, line)
 			}
 		case line := <-stderrCh:
-			if opts.StderrWriter != nil {
+			if records != nil {
+				records.add("stderr", line)
+			} else if opts.StderrWriter != nil {
 				_, _ = io.WriteString(opts.StderrWriter, line)
 			}
 		}
 	}
 }
 
+// containerLogRecords reassembles the lines of the log of a container
+// received from the server into the records of podman logs --output json
+type containerLogRecords struct {
+	template entities.ContainerLogRecord
+	writer   *entities.ContainerLogRecordWriter
+	partial  map[string]*entities.ContainerLogRecord
+	err      error
+}
+
+func (ic *ContainerEngine) newContainerLogRecords(nameOrID string, opts entities.ContainerLogsOptions) (*containerLogRecords, error) {
+	data, err := containers.Inspect(ic.ClientCtx, nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	r := &containerLogRecords{
+		template: entities.ContainerLogRecord{
+			ContainerID:   data.ID,
+			ContainerName: data.Name,
+			PodID:         data.Pod,
+		},
+		partial: make(map[string]*entities.ContainerLogRecord),
+	}
+	if data.Pod != "" {
+		if pod, err := pods.Inspect(ic.ClientCtx, data.Pod, nil); err == nil {
+			r.template.PodName = pod.Name
+		}
+	}
+	out := opts.StdoutWriter
+	if out == nil {
+		out = opts.StderrWriter
+	}
+	r.writer = entities.NewContainerLogRecordWriter(out, opts.Follow)
+	return r, nil
+}
+
+// add adds a line received from the server, prefixed with its timestamp.
+// Lines without a trailing newline are partial lines.
+func (r *containerLogRecords) add(stream, line string) {
+	timestamp, msg, _ := strings.Cut(line, " ")
+	record, ok := r.partial[stream]
+	if !ok {
+		record = new(entities.ContainerLogRecord)
+		*record = r.template
+		record.Stream = stream
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			record.Time = t
+		}
+	}
+	msg, full := strings.CutSuffix(msg, "\n")
+	record.Log += msg
+	if !full {
+		r.partial[stream] = record
+		return
+	}
+	delete(r.partial, stream)
+	if err := r.writer.Write(record); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// flush writes the records held back, including lines which were never
+// completed
+func (r *containerLogRecords) flush() error {
+	if r.err != nil {
+		return r.err
+	}
+	for _, stream := range []string{"stdout", "stderr"} {
+		if record, ok := r.partial[stream]; ok {
+			if err := r.writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	return r.writer.Flush()
+}
+
 func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string, opts entities.AttachOptions) error {
 	ctrs, err := getContainersByContext(ic.ClientCtx, false, false, []string{nameOrID})
 	if err != nil {
@@ -590,7 +693,7 @@ func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string,
 	if ctr.State != define.ContainerStateRunning.String() {
 		return fmt.Errorf("you can only attach to running containers")
 	}
-	options := new(containers.AttachOptions).WithStream(true).WithDetachKeys(opts.DetachKeys)
+	options := new(containers.AttachOptions).WithStream(true).WithDetachKeys(opts.DetachKeys).WithRecord(opts.Record)
 	if opts.SigProxy {
 		remoteProxySignals(ctr.ID, func(signal string) error {
 			killOpts := entities.KillOptions{All: false, Latest: false, Signal: signal}
@@ -618,6 +721,8 @@ func makeExecConfig(options entities.ExecOptions) *handlers.ExecCreateConfig {
 	createConfig.Env = env
 	createConfig.WorkingDir = options.WorkDir
 	createConfig.Cmd = options.Cmd
+	createConfig.OutputBuffer = options.OutputBuffer
+	createConfig.Record = options.Record
 
 	return createConfig
 }
@@ -629,7 +734,12 @@ func (ic *ContainerEngine) ContainerExec(_ context.Context, nameOrID string, opt
 	if err != nil {
 		return 125, err
 	}
+	detached := false
 	defer func() {
+		// The user detached, the exec session keeps running.
+		if detached {
+			return
+		}
 		if err := containers.ExecRemove(ic.ClientCtx, sessionID, nil); err != nil {
 			apiErr := new(bindings.APIVersionError)
 			if errors.As(err, &apiErr) {
@@ -656,6 +766,51 @@ func (ic *ContainerEngine) ContainerExec(_ context.Context, nameOrID string, opt
 	if err != nil {
 		return 125, err
 	}
+	if inspectOut.Running {
+		detached = true
+		return 0, nil
+	}
+
+	return inspectOut.ExitCode, nil
+}
+
+func (ic *ContainerEngine) ContainerExecList(_ context.Context, nameOrID string, options entities.ExecListOptions) ([]*entities.ExecListReport, error) {
+	if options.Latest {
+		return nil, errors.New("--latest is not supported for the remote client")
+	}
+	return containers.ExecList(ic.ClientCtx, nameOrID, nil)
+}
+
+func (ic *ContainerEngine) ContainerExecRecording(_ context.Context, nameOrID string, options entities.ExecRecordingOptions) error {
+	if options.Latest {
+		return errors.New("--latest is not supported for the remote client")
+	}
+	return containers.ExecRecording(ic.ClientCtx, nameOrID, options.Recording, options.Output, nil)
+}
+
+func (ic *ContainerEngine) ContainerExecAttach(_ context.Context, sessionID string, options entities.ExecAttachOptions, streams define.AttachStreams) (int, error) {
+	attachOptions := new(containers.ExecAttachOptions)
+	attachOptions.WithOutputStream(streams.OutputStream).WithErrorStream(streams.ErrorStream)
+	if streams.InputStream != nil {
+		attachOptions.WithInputStream(*streams.InputStream)
+	}
+	attachOptions.WithAttachError(streams.AttachError).WithAttachOutput(streams.AttachOutput)
+	if options.NoStdin || !streams.AttachInput {
+		attachOptions.WithAttachInput(false)
+	}
+	attachOptions.WithDetachKeys(options.DetachKeys)
+	if err := containers.ExecAttach(ic.ClientCtx, sessionID, attachOptions); err != nil {
+		return 125, err
+	}
+
+	inspectOut, err := containers.ExecInspect(ic.ClientCtx, sessionID, nil)
+	if err != nil {
+		return 125, err
+	}
+	// The user detached, the exec session keeps running.
+	if inspectOut.Running {
+		return 0, nil
+	}
 
 	return inspectOut.ExitCode, nil
 }
@@ -1022,12 +1177,87 @@ func (ic *ContainerEngine) ContainerInit(_ context.Context, namesOrIds []string,
 	return reports, nil
 }
 
-func (ic *ContainerEngine) ContainerMount(_ context.Context, _ []string, _ entities.ContainerMountOptions) ([]*entities.ContainerMountReport, error) {
-	return nil, errors.New("mounting containers is not supported for remote clients")
+// ContainerMount mounts containers on the server.  The reported mount points
+// are paths on the server; the archive and walk endpoints of the API give
+// access to the content of the containers.
+func (ic *ContainerEngine) ContainerMount(_ context.Context, nameOrIDs []string, options entities.ContainerMountOptions) ([]*entities.ContainerMountReport, error) {
+	if options.Latest {
+		return nil, errors.New("latest is not supported for the remote client")
+	}
+
+	if !options.All && len(nameOrIDs) == 0 {
+		// No containers were passed, so we send back what is mounted
+		mounts, err := containers.GetMountedContainerPaths(ic.ClientCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+		ctrs, err := getContainersByContext(ic.ClientCtx, true, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		reports := []*entities.ContainerMountReport{}
+		for _, c := range ctrs {
+			path, mounted := mounts[c.ID]
+			if !mounted {
+				continue
+			}
+			report := entities.ContainerMountReport{Id: c.ID, Path: path}
+			if len(c.Names) > 0 {
+				report.Name = c.Names[0]
+			}
+			reports = append(reports, &report)
+		}
+		return reports, nil
+	}
+
+	ctrs, err := getContainersByContext(ic.ClientCtx, options.All, false, nameOrIDs)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ContainerMountReport, 0, len(ctrs))
+	for _, c := range ctrs {
+		report := entities.ContainerMountReport{Id: c.ID}
+		report.Path, report.Err = containers.Mount(ic.ClientCtx, c.ID, nil)
+		reports = append(reports, &report)
+	}
+	return reports, nil
 }
 
-func (ic *ContainerEngine) ContainerUnmount(_ context.Context, _ []string, _ entities.ContainerUnmountOptions) ([]*entities.ContainerUnmountReport, error) {
-	return nil, errors.New("unmounting containers is not supported for remote clients")
+func (ic *ContainerEngine) ContainerUnmount(_ context.Context, nameOrIDs []string, options entities.ContainerUnmountOptions) ([]*entities.ContainerUnmountReport, error) {
+	if options.Latest {
+		return nil, errors.New("latest is not supported for the remote client")
+	}
+
+	ctrs, err := getContainersByContext(ic.ClientCtx, options.All, false, nameOrIDs)
+	if err != nil {
+		return nil, err
+	}
+	var mounts map[string]string
+	if options.All {
+		mounts, err = containers.GetMountedContainerPaths(ic.ClientCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unmountOptions := new(containers.UnmountOptions).WithForce(options.Force)
+	reports := []*entities.ContainerUnmountReport{}
+	for _, c := range ctrs {
+		if options.All {
+			if _, mounted := mounts[c.ID]; !mounted {
+				continue
+			}
+		}
+		if c.State == define.ContainerStateRunning.String() {
+			logrus.Debugf("Error umounting container %s, is running", c.ID)
+			continue
+		}
+		reports = append(reports, &entities.ContainerUnmountReport{
+			Id:  c.ID,
+			Err: containers.Unmount(ic.ClientCtx, c.ID, unmountOptions),
+		})
+	}
+	return reports, nil
 }
 
 func (ic *ContainerEngine) Config(_ context.Context) (*config.Config, error) {
@@ -1089,8 +1319,8 @@ func (ic *ContainerEngine) ContainerRename(_ context.Context, nameOrID string, o
 	return containers.Rename(ic.ClientCtx, nameOrID, new(containers.RenameOptions).WithName(opts.NewName))
 }
 
-func (ic *ContainerEngine) ContainerClone(_ context.Context, _ entities.ContainerCloneOptions) (*entities.ContainerCreateReport, error) {
-	return nil, errors.New("cloning a container is not supported on the remote client")
+func (ic *ContainerEngine) ContainerClone(_ context.Context, ctrCloneOpts entities.ContainerCloneOptions) (*entities.ContainerCreateReport, error) {
+	return containers.Clone(ic.ClientCtx, ctrCloneOpts)
 }
 
 // ContainerUpdate finds and updates the given container's cgroup config with the specified options
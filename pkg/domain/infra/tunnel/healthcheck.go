@@ -2,12 +2,20 @@ package tunnel
 
 import (
 	"context"
+	"errors"
 
 	"go.podman.io/podman/v6/libpod/define"
 	"go.podman.io/podman/v6/pkg/bindings/containers"
 	"go.podman.io/podman/v6/pkg/domain/entities"
 )
 
-func (ic *ContainerEngine) HealthCheckRun(_ context.Context, nameOrID string, _ entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+func (ic *ContainerEngine) HealthCheckRun(_ context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+	if options.Readiness {
+		return nil, errors.New("running readiness checks is not supported on the remote client")
+	}
 	return containers.RunHealthCheck(ic.ClientCtx, nameOrID, nil)
 }
+
+func (ic *ContainerEngine) ContainerCheckPressure(_ context.Context, _ string) error {
+	return errors.New("checking the resource pressure of containers is not supported on the remote client")
+}
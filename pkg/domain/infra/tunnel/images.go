@@ -15,6 +15,8 @@ import (
 	"go.podman.io/common/libimage/filter"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/pkg/cli"
+	sigstoreParams "go.podman.io/image/v5/pkg/cli/sigstore/params"
 	"go.podman.io/image/v5/types"
 	"go.podman.io/podman/v6/internal/localapi"
 	"go.podman.io/podman/v6/libpod/define"
@@ -64,12 +66,70 @@ func (ir *ImageEngine) List(_ context.Context, opts entities.ImageListOptions) (
 	return is, nil
 }
 
-func (ir *ImageEngine) Mount(_ context.Context, _ []string, _ entities.ImageMountOptions) ([]*entities.ImageMountReport, error) {
-	return nil, errors.New("mounting images is not supported for remote clients")
+// Mount mounts images on the server.  The reported mount points are paths on
+// the server; the archive and walk endpoints of the API give access to the
+// content of the images.
+func (ir *ImageEngine) Mount(_ context.Context, nameOrIDs []string, opts entities.ImageMountOptions) ([]*entities.ImageMountReport, error) {
+	switch {
+	case opts.All && len(nameOrIDs) > 0:
+		return nil, errors.New("cannot mix --all with images")
+	case opts.All:
+		listOptions := new(images.ListOptions).WithFilters(map[string][]string{"readonly": {"false"}})
+		summaries, err := images.List(ir.ClientCtx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range summaries {
+			nameOrIDs = append(nameOrIDs, summary.ID)
+		}
+	case len(nameOrIDs) == 0:
+		// No images were passed, so we send back what is mounted.
+		return images.GetMountedImages(ir.ClientCtx, nil)
+	}
+
+	mountReports := make([]*entities.ImageMountReport, 0, len(nameOrIDs))
+	for _, nameOrID := range nameOrIDs {
+		report, err := images.Mount(ir.ClientCtx, nameOrID, nil)
+		if err != nil {
+			return nil, err
+		}
+		mountReports = append(mountReports, report)
+	}
+	return mountReports, nil
 }
 
-func (ir *ImageEngine) Unmount(_ context.Context, _ []string, _ entities.ImageUnmountOptions) ([]*entities.ImageUnmountReport, error) {
-	return nil, errors.New("unmounting images is not supported for remote clients")
+func (ir *ImageEngine) Unmount(_ context.Context, nameOrIDs []string, options entities.ImageUnmountOptions) ([]*entities.ImageUnmountReport, error) {
+	var ids []string
+	switch {
+	case options.All && len(nameOrIDs) > 0:
+		return nil, errors.New("cannot mix --all with images")
+	case len(nameOrIDs) > 0:
+		for _, nameOrID := range nameOrIDs {
+			data, err := images.GetImage(ir.ClientCtx, nameOrID, nil)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, data.ID)
+		}
+	default:
+		mounted, err := images.GetMountedImages(ir.ClientCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, report := range mounted {
+			ids = append(ids, report.Id)
+		}
+	}
+
+	unmountOptions := new(images.UnmountOptions).WithForce(options.Force)
+	unmountReports := make([]*entities.ImageUnmountReport, 0, len(ids))
+	for _, id := range ids {
+		unmountReports = append(unmountReports, &entities.ImageUnmountReport{
+			Id:  id,
+			Err: images.Unmount(ir.ClientCtx, id, unmountOptions),
+		})
+	}
+	return unmountReports, nil
 }
 
 func (ir *ImageEngine) History(_ context.Context, nameOrID string, _ entities.ImageHistoryOptions) (*entities.ImageHistoryReport, error) {
@@ -114,11 +174,10 @@ func (ir *ImageEngine) Prune(_ context.Context, opts entities.ImagePruneOptions)
 }
 
 func (ir *ImageEngine) Pull(_ context.Context, rawImage string, opts entities.ImagePullOptions) (*entities.ImagePullReport, error) {
+	options := new(images.PullOptions)
 	if opts.OciDecryptConfig != nil {
-		return nil, fmt.Errorf("decryption is not supported for remote clients")
+		options.WithKeyMaterial(entities.ImageKeyMaterial{DecryptConfig: opts.OciDecryptConfig})
 	}
-
-	options := new(images.PullOptions)
 	options.WithAllTags(opts.AllTags).WithAuthfile(opts.Authfile).WithArch(opts.Arch).WithOS(opts.OS)
 	options.WithVariant(opts.Variant).WithPassword(opts.Password)
 	options.WithQuiet(opts.Quiet).WithUsername(opts.Username).WithPolicy(opts.PullPolicy.String())
@@ -272,16 +331,22 @@ func (ir *ImageEngine) Import(_ context.Context, opts entities.ImageImportOption
 }
 
 func (ir *ImageEngine) Push(_ context.Context, source string, destination string, opts entities.ImagePushOptions) (*entities.ImagePushReport, error) {
-	if opts.Signers != nil {
-		return nil, fmt.Errorf("forwarding Signers is not supported for remote clients")
-	}
-	if opts.OciEncryptConfig != nil {
-		return nil, fmt.Errorf("encryption is not supported for remote clients")
+	sigstoreKeys, err := sigstoreKeyMaterial(opts)
+	if err != nil {
+		return nil, err
 	}
 
 	options := new(images.PushOptions)
 	options.WithAll(opts.All).WithCompress(opts.Compress).WithUsername(opts.Username).WithPassword(opts.Password).WithAuthfile(opts.Authfile).WithFormat(opts.Format).WithRemoveSignatures(opts.RemoveSignatures).WithQuiet(opts.Quiet).WithCompressionFormat(opts.CompressionFormat).WithProgressWriter(opts.Writer).WithForceCompressionFormat(opts.ForceCompressionFormat)
 
+	if sigstoreKeys != nil || opts.OciEncryptConfig != nil {
+		options.WithKeyMaterial(entities.ImageKeyMaterial{
+			EncryptConfig: opts.OciEncryptConfig,
+			EncryptLayers: opts.OciEncryptLayers,
+			Sigstore:      sigstoreKeys,
+		})
+	}
+
 	if opts.CompressionLevel != nil {
 		options.WithCompressionLevel(*opts.CompressionLevel)
 	}
@@ -305,6 +370,63 @@ func (ir *ImageEngine) Push(_ context.Context, source string, destination string
 	return &entities.ImagePushReport{ManifestDigest: options.GetManifestDigest()}, nil
 }
 
+// sigstoreKeyMaterial reads the sigstore key material to forward to the
+// server from the files referred to by opts.  It returns nil if the image is
+// not to be signed with sigstore.
+func sigstoreKeyMaterial(opts entities.ImagePushOptions) (*entities.SigstoreKeyMaterial, error) {
+	if opts.Signers != nil {
+		return nil, errors.New("forwarding Signers is not supported for remote clients")
+	}
+	if opts.SignBy != "" {
+		return nil, errors.New("signing with GPG keys is not supported for remote clients, use --sign-by-sigstore-private-key or --sign-by-sigstore instead")
+	}
+
+	switch {
+	case opts.SignBySigstorePrivateKeyFile != "" && opts.SignBySigstoreParamFile != "":
+		return nil, errors.New("only one of --sign-by-sigstore-private-key and --sign-by-sigstore can be used with remote clients")
+	case opts.SignBySigstorePrivateKeyFile != "":
+		privateKey, err := os.ReadFile(opts.SignBySigstorePrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading private key: %w", err)
+		}
+		return &entities.SigstoreKeyMaterial{
+			PrivateKey:           privateKey,
+			PrivateKeyPassphrase: opts.SignSigstorePrivateKeyPassphrase,
+		}, nil
+	case opts.SignBySigstoreParamFile != "":
+		params, err := sigstoreParams.ParseFile(opts.SignBySigstoreParamFile)
+		if err != nil {
+			return nil, err
+		}
+		keys := &entities.SigstoreKeyMaterial{RekorURL: params.RekorURL}
+		if params.PrivateKeyFile != "" {
+			keys.PrivateKey, err = os.ReadFile(params.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading private key: %w", err)
+			}
+			keys.PrivateKeyPassphrase = opts.SignSigstorePrivateKeyPassphrase
+			if params.PrivateKeyPassphraseFile != "" {
+				passphrase, err := cli.ReadPassphraseFile(params.PrivateKeyPassphraseFile)
+				if err != nil {
+					return nil, err
+				}
+				keys.PrivateKeyPassphrase = []byte(passphrase)
+			}
+		}
+		if params.Fulcio != nil {
+			// Obtaining an OIDC ID token requires user interaction,
+			// which cannot happen on the server.
+			if params.Fulcio.OIDCMode != sigstoreParams.OIDCModeStaticToken {
+				return nil, fmt.Errorf("OIDC mode %q is not supported for remote clients, use %q instead", params.Fulcio.OIDCMode, sigstoreParams.OIDCModeStaticToken)
+			}
+			keys.FulcioURL = params.Fulcio.FulcioURL
+			keys.OIDCIDToken = params.Fulcio.OIDCIDToken
+		}
+		return keys, nil
+	}
+	return nil, nil
+}
+
 func (ir *ImageEngine) Save(_ context.Context, nameOrID string, tags []string, opts entities.ImageSaveOptions) error {
 	var (
 		f   *os.File
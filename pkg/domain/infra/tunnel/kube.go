@@ -2,8 +2,9 @@ package tunnel
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"io"
+	"time"
 
 	"go.podman.io/image/v5/types"
 	"go.podman.io/podman/v6/pkg/bindings/generate"
@@ -50,15 +51,16 @@ func (ic *ContainerEngine) GenerateKube(_ context.Context, nameOrIDs []string, o
 	return generate.Kube(ic.ClientCtx, nameOrIDs, options)
 }
 
-func (ic *ContainerEngine) GenerateSpec(_ context.Context, _ *entities.GenerateSpecOptions) (*entities.GenerateSpecReport, error) {
-	return nil, fmt.Errorf("GenerateSpec is not supported on the remote API")
+func (ic *ContainerEngine) GenerateSpec(_ context.Context, opts *entities.GenerateSpecOptions) (*entities.GenerateSpecReport, error) {
+	options := new(generate.SpecOptions).WithCompact(opts.Compact).WithName(opts.Name)
+	return generate.Spec(ic.ClientCtx, opts.ID, options)
 }
 
 func (ic *ContainerEngine) PlayKube(_ context.Context, body io.Reader, opts entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
 	options := new(kube.PlayOptions).WithAuthfile(opts.Authfile).WithUsername(opts.Username).WithPassword(opts.Password)
 	options.WithCertDir(opts.CertDir).WithQuiet(opts.Quiet).WithSignaturePolicy(opts.SignaturePolicy).WithConfigMaps(opts.ConfigMaps)
 	options.WithLogDriver(opts.LogDriver).WithNetwork(opts.Networks).WithSeccompProfileRoot(opts.SeccompProfileRoot)
-	options.WithStaticIPs(opts.StaticIPs).WithStaticMACs(opts.StaticMACs).WithWait(opts.Wait).WithServiceContainer(opts.ServiceContainer).WithReplace(opts.Replace)
+	options.WithStaticIPs(opts.StaticIPs).WithStaticMACs(opts.StaticMACs).WithWait(opts.Wait).WithServiceContainer(opts.ServiceContainer).WithReplace(opts.Replace).WithUpdate(opts.Update)
 	if len(opts.LogOptions) > 0 {
 		options.WithLogOptions(opts.LogOptions)
 	}
@@ -87,3 +89,11 @@ func (ic *ContainerEngine) KubeApply(_ context.Context, body io.Reader, opts ent
 	options := new(kube.ApplyOptions).WithKubeconfig(opts.Kubeconfig).WithCACertFile(opts.CACertFile).WithNamespace(opts.Namespace)
 	return kube.ApplyWithBody(ic.ClientCtx, body, options)
 }
+
+func (ic *ContainerEngine) KubeCronJobRun(_ context.Context, _ string, _ time.Time) error {
+	return errors.New("running kube cron jobs is not supported on the remote client")
+}
+
+func (ic *ContainerEngine) KubeResume(_ context.Context) error {
+	return errors.New("resuming kube cron jobs and services is not supported on the remote client")
+}
@@ -184,12 +184,16 @@ func (ir *ImageEngine) ManifestRm(ctx context.Context, names []string, opts enti
 
 // ManifestPush pushes a manifest list or image index to the destination
 func (ir *ImageEngine) ManifestPush(ctx context.Context, name, destination string, opts entities.ImagePushOptions) (string, error) {
-	if opts.Signers != nil {
-		return "", fmt.Errorf("forwarding Signers is not supported for remote clients")
+	sigstoreKeys, err := sigstoreKeyMaterial(opts)
+	if err != nil {
+		return "", err
 	}
 
 	options := new(images.PushOptions)
 	options.WithUsername(opts.Username).WithPassword(opts.Password).WithAuthfile(opts.Authfile).WithRemoveSignatures(opts.RemoveSignatures).WithAll(opts.All).WithFormat(opts.Format).WithCompressionFormat(opts.CompressionFormat).WithQuiet(opts.Quiet).WithProgressWriter(opts.Writer).WithAddCompression(opts.AddCompression).WithForceCompressionFormat(opts.ForceCompressionFormat)
+	if sigstoreKeys != nil {
+		options.WithKeyMaterial(entities.ImageKeyMaterial{Sigstore: sigstoreKeys})
+	}
 
 	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
 		if s == types.OptionalBoolTrue {
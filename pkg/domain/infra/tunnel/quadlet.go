@@ -28,3 +28,7 @@ func (ic *ContainerEngine) QuadletPrint(_ context.Context, _ string) (string, er
 func (ic *ContainerEngine) QuadletRemove(_ context.Context, _ []string, _ entities.QuadletRemoveOptions) (*entities.QuadletRemoveReport, error) {
 	return nil, errNotImplemented
 }
+
+func (ic *ContainerEngine) QuadletValidate(_ context.Context, _ []string) (*entities.QuadletValidateReport, error) {
+	return nil, errNotImplemented
+}
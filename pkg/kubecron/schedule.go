@@ -0,0 +1,207 @@
+// Package kubecron implements the scheduling of K8s CronJobs for
+// `podman kube play`.  A CronJob is realized as a small scheduler process
+// which runs a command every time the cron schedule of the CronJob fires.
+package kubecron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLookahead is the time span searched for the next activation of a
+// schedule.  Schedules like "0 0 30 2 *" never fire.
+const maxLookahead = 5 * 366 * 24 * time.Hour
+
+// macros are the predefined schedules supported by K8s.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes the range and the names of the values of a schedule field.
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// 7 is an alias of Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// Schedule is a parsed cron schedule in the standard five field format.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are set if the day of month or the day of week
+	// field is unrestricted.  Like cron, a day matches if either field
+	// matches when both are restricted.
+	domAny, dowAny bool
+}
+
+// ParseSchedule parses a cron schedule as accepted by the schedule field of
+// a K8s CronJob.
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return nil, fmt.Errorf("invalid schedule %q: time zones must be set with the timeZone field", spec)
+	}
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := macros[spec]
+		if !ok {
+			return nil, fmt.Errorf("invalid schedule %q: unsupported descriptor", spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, found %d", spec, len(fields))
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+	if s.minute, _, err = parseField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	if s.hour, _, err = parseField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	if s.dom, s.domAny, err = parseField(fields[2], domField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	if s.month, _, err = parseField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	if s.dow, s.dowAny, err = parseField(fields[4], dowField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return &s, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps into
+// a bit set.  It also returns whether the field is unrestricted.
+func parseField(value string, f field) (uint64, bool, error) {
+	var bits uint64
+	all := false
+	for part := range strings.SplitSeq(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, false, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			if rangePart == "?" && f.name != domField.name && f.name != dowField.name {
+				return 0, false, fmt.Errorf("%q is not allowed in %s field", rangePart, f.name)
+			}
+			start, end = f.min, f.max
+			if f.name == dowField.name {
+				// Do not count Sunday twice.
+				end = 6
+			}
+			if !hasStep {
+				all = true
+			}
+		default:
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = f.parseValue(first); err != nil {
+				return 0, false, err
+			}
+			end = start
+			if isRange {
+				if end, err = f.parseValue(last); err != nil {
+					return 0, false, err
+				}
+				if end < start {
+					return 0, false, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+				}
+			} else if hasStep {
+				end = f.max
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, all, nil
+}
+
+// parseValue parses a single number or name of the field.
+func (f field) parseValue(value string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			return i + f.min, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, f.name)
+	}
+	return n, nil
+}
+
+// Next returns the first activation of the schedule after t in the location
+// of t.  It returns an error if the schedule does not fire within the next
+// five years.
+func (s *Schedule) Next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxLookahead)
+
+	for t.Before(limit) {
+		if s.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, nil
+	}
+	return time.Time{}, errors.New("schedule does not fire within the next five years")
+}
+
+// matchesDay returns whether the schedule fires on the day of t.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
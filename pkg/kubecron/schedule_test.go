@@ -0,0 +1,86 @@
+package kubecron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	tests := []struct {
+		spec string
+		err  string
+	}{
+		{"* * * *", `invalid schedule "* * * *": expected 5 fields, found 4`},
+		{"60 * * * *", `invalid schedule "60 * * * *": invalid value "60" in minute field`},
+		{"* * 0 * *", `invalid schedule "* * 0 * *": invalid value "0" in day of month field`},
+		{"* * * foo *", `invalid schedule "* * * foo *": invalid value "foo" in month field`},
+		{"*/0 * * * *", `invalid schedule "*/0 * * * *": invalid step "0" in minute field`},
+		{"5-1 * * * *", `invalid schedule "5-1 * * * *": invalid range "5-1" in minute field`},
+		{"? * * * *", `invalid schedule "? * * * *": "?" is not allowed in minute field`},
+		{"@every 5m", `invalid schedule "@every 5m": unsupported descriptor`},
+		{"TZ=UTC 0 * * * *", `invalid schedule "TZ=UTC 0 * * * *": time zones must be set with the timeZone field`},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			_, err := ParseSchedule(test.spec)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Saturday
+	start := time.Date(2026, time.January, 31, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next []string
+	}{
+		{"* * * * *", []string{"2026-01-31 10:18", "2026-01-31 10:19"}},
+		{"*/15 * * * *", []string{"2026-01-31 10:30", "2026-01-31 10:45", "2026-01-31 11:00"}},
+		{"5,40 9-10 * * *", []string{"2026-01-31 10:40", "2026-02-01 09:05", "2026-02-01 09:40"}},
+		{"0 12 * * mon-fri", []string{"2026-02-02 12:00", "2026-02-03 12:00"}},
+		{"0 0 * * 7", []string{"2026-02-01 00:00", "2026-02-08 00:00"}},
+		{"0 0 29 feb *", []string{"2028-02-29 00:00"}},
+		// Either the day of month or the day of week has to match.
+		{"0 0 13 * fri", []string{"2026-02-06 00:00", "2026-02-13 00:00", "2026-02-20 00:00"}},
+		{"30 4 1/10 * *", []string{"2026-02-01 04:30", "2026-02-11 04:30", "2026-02-21 04:30"}},
+		{"@hourly", []string{"2026-01-31 11:00", "2026-01-31 12:00"}},
+		{"@monthly", []string{"2026-02-01 00:00", "2026-03-01 00:00"}},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			s, err := ParseSchedule(test.spec)
+			require.NoError(t, err)
+			next := start
+			for _, expected := range test.next {
+				next, err = s.Next(next)
+				require.NoError(t, err)
+				assert.Equal(t, expected, next.Format("2006-01-02 15:04"))
+			}
+		})
+	}
+}
+
+func TestScheduleNextNever(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	_, err = s.Next(time.Now())
+	assert.Error(t, err)
+}
+
+func TestScheduleNextTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data is not available")
+	}
+	s, err := ParseSchedule("30 2 * * *")
+	require.NoError(t, err)
+
+	// 02:30 does not exist on the day DST starts.
+	next, err := s.Next(time.Date(2026, time.March, 28, 12, 0, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-30 02:30 CEST", next.Format("2006-01-02 15:04 MST"))
+}
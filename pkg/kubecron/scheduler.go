@@ -0,0 +1,302 @@
+//go:build linux || freebsd
+
+package kubecron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/pkg/detached"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+const (
+	// reexecKey is the reexec key of the scheduler process.
+	reexecKey = "podman-kube-cronjob"
+	// defaultDeadline is the default starting deadline of an activation.
+	defaultDeadline = time.Minute
+)
+
+func init() {
+	detached.Register(reexecKey, runScheduler)
+}
+
+// Config is the configuration of the scheduler of a CronJob.
+type Config struct {
+	// Name is the name of the CronJob.
+	Name string `json:"name"`
+	// Schedule is the cron schedule of the CronJob.
+	Schedule string `json:"schedule"`
+	// TimeZone is the name of the time zone the schedule is interpreted
+	// in.  The local time zone is used if empty.
+	TimeZone string `json:"timeZone,omitempty"`
+	// StartingDeadline is the time after an activation within which the
+	// command is still run if the activation was missed, for example
+	// because the host was suspended.  It defaults to defaultDeadline.
+	StartingDeadline time.Duration `json:"startingDeadline,omitempty"`
+	// Command is run on every activation of the schedule.  The time
+	// of the activation is appended as Unix time in seconds.
+	Command []string `json:"command"`
+	// Data is opaque data of the caller stored along with the
+	// configuration.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Validate checks the configuration for errors.
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("cron job does not have a name")
+	}
+	if _, err := ParseSchedule(c.Schedule); err != nil {
+		return fmt.Errorf("cron job %s: %w", c.Name, err)
+	}
+	if _, err := c.location(); err != nil {
+		return fmt.Errorf("cron job %s: %w", c.Name, err)
+	}
+	if len(c.Command) == 0 {
+		return fmt.Errorf("cron job %s: no command", c.Name)
+	}
+	return nil
+}
+
+// location returns the location the schedule is interpreted in.
+func (c *Config) location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", c.TimeZone, err)
+	}
+	return loc, nil
+}
+
+// Schedulers manages the detached scheduler processes of CronJobs.
+type Schedulers struct {
+	// StateDir is the directory the configurations of the schedulers are
+	// stored in.  It must persist across reboots so the schedulers can
+	// be resumed.
+	StateDir string
+	// RunDir is the directory of the control sockets of the schedulers.
+	RunDir string
+}
+
+// controlSocket returns the path of the socket used to control the scheduler
+// of the named CronJob.
+func (s *Schedulers) controlSocket(name string) string {
+	return detached.Path(s.RunDir, name, ".sock")
+}
+
+// configFile returns the path of the file the configuration of the scheduler
+// of the named CronJob is stored in.
+func (s *Schedulers) configFile(name string) string {
+	return detached.Path(s.StateDir, name, ".json")
+}
+
+// Lock returns the lock of the named CronJob.  It is held while a job of the
+// CronJob is created, so that the concurrency policy is honored when
+// activations overlap.
+func (s *Schedulers) Lock(name string) (*lockfile.LockFile, error) {
+	if err := os.MkdirAll(s.RunDir, 0o700); err != nil {
+		return nil, err
+	}
+	return lockfile.GetLockFile(detached.Path(s.RunDir, name, ".lock"))
+}
+
+// Start spawns a detached scheduler process for the CronJob.  The process
+// outlives the caller and keeps running until Stop is called.  An already
+// running scheduler for a CronJob with the same name is stopped first.
+func (s *Schedulers) Start(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := s.Stop(config.Name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.StateDir, 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	// Store the configuration first, the scheduler may fire right away
+	// and the command is likely to look it up.
+	if err := os.WriteFile(s.configFile(config.Name), data, 0o600); err != nil {
+		return fmt.Errorf("storing configuration of cron job %s: %w", config.Name, err)
+	}
+	if err := s.spawn(config.Name, data); err != nil {
+		_ = os.Remove(s.configFile(config.Name))
+		return err
+	}
+	return nil
+}
+
+func (s *Schedulers) spawn(name string, data []byte) error {
+	if err := os.MkdirAll(s.RunDir, 0o700); err != nil {
+		return err
+	}
+	if err := detached.Spawn(reexecKey, data, nil, s.controlSocket(name)); err != nil {
+		return fmt.Errorf("starting scheduler of cron job %s: %w", name, err)
+	}
+	return nil
+}
+
+// Stop stops the scheduler of the named CronJob and removes its
+// configuration.  It is not an error if no such scheduler is running.
+func (s *Schedulers) Stop(name string) error {
+	if err := os.Remove(s.configFile(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := detached.Stop(s.controlSocket(name)); err != nil {
+		return fmt.Errorf("stopping scheduler of cron job %s: %w", name, err)
+	}
+	return nil
+}
+
+// IsScheduled returns whether the named CronJob is scheduled.  A CronJob
+// stays scheduled across reboots even if its scheduler has not been resumed
+// yet.
+func (s *Schedulers) IsScheduled(name string) bool {
+	_, err := os.Stat(s.configFile(name))
+	return err == nil
+}
+
+// Config returns the configuration of the scheduler of the named CronJob.  It
+// returns nil if the CronJob is not scheduled.
+func (s *Schedulers) Config(name string) (*Config, error) {
+	data, err := os.ReadFile(s.configFile(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("decoding configuration of cron job %s: %w", name, err)
+	}
+	return &config, nil
+}
+
+// Resume starts the schedulers of all scheduled CronJobs which are not
+// running, for example because the host was rebooted.
+func (s *Schedulers) Resume() error {
+	entries, err := os.ReadDir(s.StateDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.StateDir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var config Config
+		if err := json.Unmarshal(data, &config); err != nil {
+			errs = append(errs, fmt.Errorf("decoding configuration %s: %w", entry.Name(), err))
+			continue
+		}
+		if detached.IsRunning(s.controlSocket(config.Name)) {
+			continue
+		}
+		logrus.Debugf("Resuming scheduler of cron job %s", config.Name)
+		if err := s.spawn(config.Name, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func runScheduler(ready func() error) error {
+	if len(os.Args) != 2 {
+		return errors.New("internal error: expected the control socket as argument")
+	}
+	socketPath := os.Args[1]
+
+	var config Config
+	if err := json.NewDecoder(os.Stdin).Decode(&config); err != nil {
+		return fmt.Errorf("decoding scheduler configuration: %w", err)
+	}
+	schedule, err := ParseSchedule(config.Schedule)
+	if err != nil {
+		return err
+	}
+	loc, err := config.location()
+	if err != nil {
+		return err
+	}
+
+	control, err := detached.Listen(socketPath, func(command string, _ json.RawMessage) error {
+		return fmt.Errorf("unknown command %q", command)
+	})
+	if err != nil {
+		return err
+	}
+	if err := ready(); err != nil {
+		_ = control.Close()
+		return err
+	}
+
+	schedule.loop(&config, loc, control.Stopped())
+	return control.Close()
+}
+
+// loop runs the command of the CronJob on every activation of the schedule
+// until the scheduler is stopped.
+func (s *Schedule) loop(config *Config, loc *time.Location, stopped <-chan struct{}) {
+	for {
+		next, err := s.Next(time.Now().In(loc))
+		if err != nil {
+			logrus.Errorf("Cron job %s: %v", config.Name, err)
+			<-stopped
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-stopped:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// The timer is based on the monotonic clock, so it fires late
+		// if the host was suspended.
+		deadline := config.StartingDeadline
+		if deadline <= 0 {
+			deadline = defaultDeadline
+		}
+		if late := time.Since(next); late > deadline {
+			logrus.Warnf("Cron job %s: skipping activation at %s missed by %s", config.Name, next, late.Round(time.Second))
+			continue
+		}
+		// Do not block stopping the scheduler on a slow run, the
+		// command decides what to do about runs which overlap.
+		// The scheduled time is passed to the command, it identifies
+		// the activation even if the command runs late.
+		go func() {
+			args := append(slices.Clone(config.Command[1:]), strconv.FormatInt(next.Unix(), 10))
+			cmd := exec.Command(config.Command[0], args...)
+			if output, err := cmd.CombinedOutput(); err != nil {
+				logrus.Errorf("Cron job %s: running %v: %v: %s", config.Name, config.Command, err, output)
+			}
+		}()
+	}
+}
@@ -0,0 +1,319 @@
+//go:build linux || freebsd
+
+package kubeservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"go.podman.io/podman/v6/pkg/detached"
+)
+
+const (
+	// reexecKey is the reexec key of the forwarder process.
+	reexecKey = "podman-kube-service"
+	// updateCommand asks the forwarder to replace its backends.
+	updateCommand = "update"
+	// readyCommand tells the forwarder whether a pod is ready.
+	readyCommand = "ready"
+)
+
+// podReadiness is the data of readyCommand.
+type podReadiness struct {
+	Pod   string `json:"pod"`
+	Ready bool   `json:"ready"`
+}
+
+func init() {
+	detached.Register(reexecKey, runForwarder)
+}
+
+// Forwarders manages the detached forwarder processes of Services.
+type Forwarders struct {
+	// StateDir is the directory the configurations of the forwarders are
+	// stored in.  It must persist across reboots so the forwarders can be
+	// resumed.
+	StateDir string
+	// RunDir is the directory of the control sockets of the forwarders.
+	RunDir string
+}
+
+// controlSocket returns the path of the socket used to control the forwarder
+// of the named Service.
+func (f *Forwarders) controlSocket(name string) string {
+	return detached.Path(f.RunDir, name, ".sock")
+}
+
+// configFile returns the path of the file the configuration of the forwarder
+// of the named Service is stored in.
+func (f *Forwarders) configFile(name string) string {
+	return detached.Path(f.StateDir, name, ".json")
+}
+
+// Start spawns a detached forwarder process for the Service.  The process
+// outlives the caller and keeps running until Stop is called.  An already
+// running forwarder for a Service with the same name is stopped first.
+func (f *Forwarders) Start(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := f.Stop(config.Name); err != nil {
+		return err
+	}
+	if err := f.spawn(config); err != nil {
+		return err
+	}
+	return f.writeConfig(config)
+}
+
+func (f *Forwarders) spawn(config *Config) error {
+	if err := os.MkdirAll(f.RunDir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := detached.Spawn(reexecKey, data, nil, f.controlSocket(config.Name)); err != nil {
+		return fmt.Errorf("starting forwarder of kube service %s: %w", config.Name, err)
+	}
+	return nil
+}
+
+// Update changes the backends of the running forwarder of the Service.  The
+// forwarder keeps listening, so established connections are not interrupted.
+// A forwarder is started if none is running, and restarted if the ports of
+// the Service changed.
+func (f *Forwarders) Update(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	running, err := f.RunningConfig(config.Name)
+	if err != nil {
+		return err
+	}
+	if running == nil || !samePorts(running, config) {
+		return f.Start(config)
+	}
+
+	if err := detached.Send(f.controlSocket(config.Name), updateCommand, config); err != nil {
+		if errors.Is(err, detached.ErrNotRunning) {
+			return f.Start(config)
+		}
+		return fmt.Errorf("updating forwarder of kube service %s: %w", config.Name, err)
+	}
+	return f.writeConfig(config)
+}
+
+// SetPodReady tells the running forwarders with backends served by the pod
+// whether the pod is ready.  The forwarders only forward to the backends of
+// ready pods.
+func (f *Forwarders) SetPodReady(pod string, ready bool) error {
+	var errs []error
+	configs, err := f.configs()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, config := range configs {
+		if !config.setPodReady(pod, ready) {
+			continue
+		}
+		err = detached.Send(f.controlSocket(config.Name), readyCommand, &podReadiness{Pod: pod, Ready: ready})
+		if err != nil && !errors.Is(err, detached.ErrNotRunning) {
+			errs = append(errs, fmt.Errorf("updating readiness of pod %s in forwarder of kube service %s: %w", pod, config.Name, err))
+			continue
+		}
+		// The readiness of a forwarder which is not running yet is
+		// stored for when it is resumed.
+		if err := f.writeConfig(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stop stops the forwarder of the named Service and removes its
+// configuration.  It is not an error if no such forwarder is running.
+func (f *Forwarders) Stop(name string) error {
+	if err := os.Remove(f.configFile(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := detached.Stop(f.controlSocket(name)); err != nil {
+		return fmt.Errorf("stopping forwarder of kube service %s: %w", name, err)
+	}
+	return nil
+}
+
+// Exists returns whether a forwarder for the named Service was started.  It
+// keeps existing across reboots even if it has not been resumed yet.
+func (f *Forwarders) Exists(name string) bool {
+	_, err := os.Stat(f.configFile(name))
+	return err == nil
+}
+
+// IsRunning returns whether a forwarder for the named Service is running.
+func (f *Forwarders) IsRunning(name string) bool {
+	return detached.IsRunning(f.controlSocket(name))
+}
+
+// RunningConfig returns the configuration of the running forwarder of the
+// named Service.  It returns nil if no such forwarder is running.
+func (f *Forwarders) RunningConfig(name string) (*Config, error) {
+	if !f.IsRunning(name) {
+		return nil, nil
+	}
+	config, err := readConfig(f.configFile(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("kube service %s: %w", name, err)
+	}
+	return config, nil
+}
+
+// Resume starts the forwarders of all Services whose forwarder is not
+// running, for example because the host was rebooted.  prepare is called
+// with the stored configuration before the forwarder is started, e.g. to
+// update the readiness of the pods.
+func (f *Forwarders) Resume(prepare func(*Config) error) error {
+	var errs []error
+	configs, err := f.configs()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, config := range configs {
+		if f.IsRunning(config.Name) {
+			continue
+		}
+		if err := prepare(config); err != nil {
+			errs = append(errs, fmt.Errorf("kube service %s: %w", config.Name, err))
+			continue
+		}
+		logrus.Debugf("Resuming forwarder of kube service %s", config.Name)
+		if err := f.spawn(config); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := f.writeConfig(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configs returns the stored configurations of all forwarders.
+func (f *Forwarders) configs() ([]*Config, error) {
+	paths, err := filepath.Glob(filepath.Join(f.StateDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var configs []*Config
+	var errs []error
+	for _, path := range paths {
+		config, err := readConfig(path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		configs = append(configs, config)
+	}
+	return configs, errors.Join(errs...)
+}
+
+// readConfig reads the stored configuration of a forwarder.
+func readConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("decoding forwarder configuration %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// writeConfig stores the configuration of the forwarder of a Service.
+func (f *Forwarders) writeConfig(config *Config) error {
+	if err := os.MkdirAll(f.StateDir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.configFile(config.Name), data, 0o600); err != nil {
+		return fmt.Errorf("storing configuration of kube service %s: %w", config.Name, err)
+	}
+	return nil
+}
+
+// runForwarder is the entry point of the forwarder process.  It expects the
+// path of the control socket as argument and the configuration on stdin.
+func runForwarder(ready func() error) error {
+	if len(os.Args) != 2 {
+		return errors.New("internal error: expected the control socket as argument")
+	}
+	socketPath := os.Args[1]
+
+	var config Config
+	if err := json.NewDecoder(os.Stdin).Decode(&config); err != nil {
+		return fmt.Errorf("decoding forwarder configuration: %w", err)
+	}
+
+	proxy, err := Listen(&config)
+	if err != nil {
+		return err
+	}
+	control, err := detached.Listen(socketPath, func(command string, data json.RawMessage) error {
+		switch command {
+		case updateCommand:
+			var update Config
+			if err := json.Unmarshal(data, &update); err != nil {
+				return fmt.Errorf("decoding forwarder configuration: %w", err)
+			}
+			logrus.Debugf("Updating backends of kube service %s", config.Name)
+			return proxy.Update(&update)
+		case readyCommand:
+			var readiness podReadiness
+			if err := json.Unmarshal(data, &readiness); err != nil {
+				return fmt.Errorf("decoding pod readiness: %w", err)
+			}
+			logrus.Debugf("Kube service %s: pod %s ready: %t", config.Name, readiness.Pod, readiness.Ready)
+			proxy.SetPodReady(readiness.Pod, readiness.Ready)
+			return nil
+		default:
+			return fmt.Errorf("unknown command %q", command)
+		}
+	})
+	if err != nil {
+		_ = proxy.Close()
+		return err
+	}
+	if err := ready(); err != nil {
+		_ = proxy.Close()
+		_ = control.Close()
+		return err
+	}
+
+	go proxy.Serve()
+
+	<-control.Stopped()
+	if err := proxy.Close(); err != nil {
+		logrus.Errorf("Closing kube service %s: %v", config.Name, err)
+	}
+	// The ports are closed, so a new forwarder can take over while the
+	// established connections are drained.
+	err = control.Close()
+	if !proxy.Drain(drainTimeout) {
+		logrus.Warnf("Kube service %s: closing connections still established after %s", config.Name, drainTimeout)
+	}
+	return err
+}
@@ -0,0 +1,485 @@
+// Package kubeservice implements the host side of K8s Services for
+// `podman kube play`.  A Service is realized as a small userspace forwarder
+// which listens on the Service's ports and distributes the incoming traffic
+// round-robin over the pods selected by the Service.
+package kubeservice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dialTimeout is the time to wait for a backend to accept a connection
+	// before moving on to the next one.
+	dialTimeout = 5 * time.Second
+	// udpSessionTimeout is the time after which an idle UDP session to a
+	// backend is closed.
+	udpSessionTimeout = 60 * time.Second
+	// maxUDPPacketSize is the largest UDP payload that can be forwarded.
+	maxUDPPacketSize = 65535
+	// drainTimeout is the time established TCP connections are given to
+	// finish once the forwarder is stopped.
+	drainTimeout = 30 * time.Second
+)
+
+// Port is a single port of a Service published on the host.
+type Port struct {
+	// Protocol is either "tcp" or "udp".
+	Protocol string `json:"protocol"`
+	// HostIP is the address to listen on.  All addresses are used if empty.
+	HostIP string `json:"hostIP,omitempty"`
+	// HostPort is the port to listen on.
+	HostPort uint16 `json:"hostPort"`
+	// Backends are the addresses (host:port) traffic is forwarded to.
+	Backends []string `json:"backends"`
+}
+
+// Address returns the address the port listens on.
+func (p *Port) Address() string {
+	return net.JoinHostPort(p.HostIP, strconv.Itoa(int(p.HostPort)))
+}
+
+// String returns a human-readable representation of the port.
+func (p *Port) String() string {
+	hostIP := p.HostIP
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+	return fmt.Sprintf("%s/%s", net.JoinHostPort(hostIP, strconv.Itoa(int(p.HostPort))), p.Protocol)
+}
+
+// Config is the configuration of the forwarder of a Service.
+type Config struct {
+	// Name of the Service.
+	Name string `json:"name"`
+	// Ports to forward.
+	Ports []Port `json:"ports"`
+	// Pods maps the backends to the pods serving them.
+	Pods map[string]string `json:"pods,omitempty"`
+	// Unready are the pods which are not ready.  Their backends are not
+	// forwarded to until they become ready.
+	Unready []string `json:"unready,omitempty"`
+}
+
+// readyBackends returns the backends of the port whose pods are ready.
+func (c *Config) readyBackends(port *Port) []string {
+	backends := make([]string, 0, len(port.Backends))
+	for _, b := range port.Backends {
+		if pod, ok := c.Pods[b]; ok && slices.Contains(c.Unready, pod) {
+			continue
+		}
+		backends = append(backends, b)
+	}
+	return backends
+}
+
+// setPodReady marks the pod as ready or not ready.  It returns whether the
+// pod serves any backend.
+func (c *Config) setPodReady(pod string, ready bool) bool {
+	found := false
+	for _, p := range c.Pods {
+		if p == pod {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	c.Unready = slices.DeleteFunc(c.Unready, func(p string) bool { return p == pod })
+	if !ready {
+		c.Unready = append(c.Unready, pod)
+	}
+	return true
+}
+
+// Validate makes sure the configuration can be served.
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("kube service does not have a name")
+	}
+	for _, port := range c.Ports {
+		switch port.Protocol {
+		case "tcp", "udp":
+		default:
+			return fmt.Errorf("kube service %s: protocol %q is not supported", c.Name, port.Protocol)
+		}
+		if port.HostPort == 0 {
+			return fmt.Errorf("kube service %s: host port must be non-0", c.Name)
+		}
+		if len(port.Backends) == 0 {
+			return fmt.Errorf("kube service %s: port %s has no backends", c.Name, port.String())
+		}
+	}
+	return nil
+}
+
+// samePorts returns whether both configurations listen on the same ports.
+func samePorts(a, b *Config) bool {
+	return slices.EqualFunc(a.Ports, b.Ports, func(x, y Port) bool {
+		return x.Protocol == y.Protocol && x.HostIP == y.HostIP && x.HostPort == y.HostPort
+	})
+}
+
+// roundRobin hands out backends in round-robin order.  The backends can be
+// replaced while traffic is forwarded.
+type roundRobin struct {
+	backends atomic.Pointer[[]string]
+	next     atomic.Uint64
+}
+
+func newRoundRobin(backends []string) *roundRobin {
+	r := &roundRobin{}
+	r.set(backends)
+	return r
+}
+
+// set replaces the backends.
+func (r *roundRobin) set(backends []string) {
+	backends = slices.Clone(backends)
+	r.backends.Store(&backends)
+}
+
+// order returns all backends starting with the one whose turn it is.  The
+// remaining backends may be used as fallback if the first one fails.
+func (r *roundRobin) order() []string {
+	backends := *r.backends.Load()
+	if len(backends) == 0 {
+		return nil
+	}
+	start := int((r.next.Add(1) - 1) % uint64(len(backends)))
+	ordered := make([]string, 0, len(backends))
+	ordered = append(ordered, backends[start:]...)
+	return append(ordered, backends[:start]...)
+}
+
+// Proxy forwards the traffic of all ports of a Service.
+type Proxy struct {
+	name string
+	// mutex protects config while it is updated.
+	mutex  sync.Mutex
+	config *Config
+
+	tcpListeners []net.Listener
+	udpConns     []net.PacketConn
+	// balancers hold the backends of the ports in the order of the
+	// configuration.
+	balancers []*roundRobin
+
+	wg     sync.WaitGroup
+	conns  sync.WaitGroup
+	closed atomic.Bool
+}
+
+// Listen opens all ports of the Service.  Call Serve to start forwarding.
+func Listen(config *Config) (*Proxy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	p := &Proxy{name: config.Name, config: config}
+	for _, port := range config.Ports {
+		p.balancers = append(p.balancers, newRoundRobin(config.readyBackends(&port)))
+		var err error
+		switch port.Protocol {
+		case "tcp":
+			var l net.Listener
+			l, err = net.Listen("tcp", port.Address())
+			if err == nil {
+				p.tcpListeners = append(p.tcpListeners, l)
+			}
+		case "udp":
+			var c net.PacketConn
+			c, err = net.ListenPacket("udp", port.Address())
+			if err == nil {
+				p.udpConns = append(p.udpConns, c)
+			}
+		}
+		if err != nil {
+			_ = p.Close()
+			return nil, fmt.Errorf("kube service %s: listening on %s: %w", config.Name, port.String(), err)
+		}
+	}
+	return p, nil
+}
+
+// Serve forwards traffic until Close is called.
+func (p *Proxy) Serve() {
+	p.mutex.Lock()
+	ports := p.config.Ports
+	p.mutex.Unlock()
+
+	var tcpIndex, udpIndex int
+	for i, port := range ports {
+		rr := p.balancers[i]
+		p.wg.Add(1)
+		switch port.Protocol {
+		case "tcp":
+			go p.serveTCP(p.tcpListeners[tcpIndex], rr)
+			tcpIndex++
+		case "udp":
+			go p.serveUDP(p.udpConns[udpIndex], rr)
+			udpIndex++
+		}
+	}
+	p.wg.Wait()
+}
+
+// Update replaces the backends of the ports.  Established connections are not
+// affected, new ones are forwarded to the new backends.  The ports themselves
+// cannot be changed without listening anew.
+func (p *Proxy) Update(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !samePorts(p.config, config) {
+		return fmt.Errorf("kube service %s: ports cannot be changed while forwarding", p.name)
+	}
+	p.config = config
+	p.setBackends()
+	return nil
+}
+
+// SetPodReady marks the pod as ready or not ready.  The backends of pods
+// which are not ready are not forwarded to.  Established connections are
+// not affected.
+func (p *Proxy) SetPodReady(pod string, ready bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.config.setPodReady(pod, ready) {
+		p.setBackends()
+	}
+}
+
+// setBackends hands the ready backends of the configuration to the ports.
+// NOTE: The caller must hold the mutex.
+func (p *Proxy) setBackends() {
+	for i := range p.config.Ports {
+		p.balancers[i].set(p.config.readyBackends(&p.config.Ports[i]))
+	}
+}
+
+// Close stops listening on all ports.  Established TCP connections are kept
+// until either side closes them, see Drain.
+func (p *Proxy) Close() error {
+	p.closed.Store(true)
+	var errs []error
+	for _, l := range p.tcpListeners {
+		if err := l.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+	for _, c := range p.udpConns {
+		if err := c.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Proxy) serveTCP(l net.Listener, rr *roundRobin) {
+	defer p.wg.Done()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if p.closed.Load() || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			logrus.Errorf("Accepting connection for kube service %s: %v", p.name, err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		p.conns.Add(1)
+		go func() {
+			defer p.conns.Done()
+			p.forwardTCP(conn, rr)
+		}()
+	}
+}
+
+// Drain waits until all established TCP connections are closed or the
+// timeout expired.  It returns whether all connections have been closed.
+func (p *Proxy) Drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		p.conns.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (p *Proxy) forwardTCP(client net.Conn, rr *roundRobin) {
+	defer client.Close()
+
+	var backend net.Conn
+	for _, addr := range rr.order() {
+		conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+		if err != nil {
+			logrus.Debugf("Kube service %s: backend %s is not available: %v", p.name, addr, err)
+			continue
+		}
+		backend = conn
+		break
+	}
+	if backend == nil {
+		logrus.Errorf("Kube service %s: no backend available for connection from %s", p.name, client.RemoteAddr())
+		return
+	}
+	defer backend.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	pipe := func(dst, src net.Conn) {
+		defer wg.Done()
+		_, _ = io.Copy(dst, src)
+		// Propagate the half-close so that the other direction can
+		// drain properly.
+		if tcp, ok := dst.(*net.TCPConn); ok {
+			_ = tcp.CloseWrite()
+		} else {
+			_ = dst.Close()
+		}
+	}
+	go pipe(backend, client)
+	go pipe(client, backend)
+	wg.Wait()
+}
+
+// udpSession is the connection of a single UDP client to a backend.
+type udpSession struct {
+	backend  net.Conn
+	lastSeen atomic.Int64
+}
+
+func (p *Proxy) serveUDP(conn net.PacketConn, rr *roundRobin) {
+	defer p.wg.Done()
+
+	var (
+		mutex    sync.Mutex
+		sessions = make(map[string]*udpSession)
+	)
+	defer func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, s := range sessions {
+			s.backend.Close()
+		}
+	}()
+
+	buf := make([]byte, maxUDPPacketSize)
+	for {
+		n, client, err := conn.ReadFrom(buf)
+		if err != nil {
+			if p.closed.Load() || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			logrus.Errorf("Reading packet for kube service %s: %v", p.name, err)
+			continue
+		}
+
+		key := client.String()
+		mutex.Lock()
+		session, ok := sessions[key]
+		if !ok {
+			// UDP gives no indication whether a backend is alive,
+			// so new clients are simply assigned the next backend.
+			backends := rr.order()
+			if len(backends) == 0 {
+				mutex.Unlock()
+				logrus.Debugf("Kube service %s: no backend ready for packet from %s", p.name, client)
+				continue
+			}
+			addr := backends[0]
+			backend, err := net.Dial("udp", addr)
+			if err != nil {
+				mutex.Unlock()
+				logrus.Errorf("Kube service %s: connecting to backend %s: %v", p.name, addr, err)
+				continue
+			}
+			session = &udpSession{backend: backend}
+			sessions[key] = session
+			go func() {
+				p.replyUDP(conn, client, session)
+				mutex.Lock()
+				delete(sessions, key)
+				mutex.Unlock()
+			}()
+		}
+		mutex.Unlock()
+
+		session.lastSeen.Store(time.Now().UnixNano())
+		if _, err := session.backend.Write(buf[:n]); err != nil {
+			logrus.Debugf("Kube service %s: forwarding packet to %s: %v", p.name, session.backend.RemoteAddr(), err)
+		}
+	}
+}
+
+// replyUDP forwards the replies of a backend to the client until the session
+// has been idle for udpSessionTimeout.
+func (p *Proxy) replyUDP(conn net.PacketConn, client net.Addr, session *udpSession) {
+	defer session.backend.Close()
+	buf := make([]byte, maxUDPPacketSize)
+	for {
+		if err := session.backend.SetReadDeadline(time.Now().Add(udpSessionTimeout)); err != nil {
+			return
+		}
+		n, err := session.backend.Read(buf)
+		if err != nil {
+			if isTimeout(err) {
+				// The client may still be sending packets even
+				// if the backend did not reply in time.
+				if time.Since(time.Unix(0, session.lastSeen.Load())) < udpSessionTimeout {
+					continue
+				}
+				return
+			}
+			if !p.closed.Load() && !errors.Is(err, net.ErrClosed) {
+				logrus.Debugf("Kube service %s: reading from %s: %v", p.name, session.backend.RemoteAddr(), err)
+			}
+			return
+		}
+		if _, err := conn.WriteTo(buf[:n], client); err != nil {
+			if p.closed.Load() || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			logrus.Debugf("Kube service %s: replying to %s: %v", p.name, client, err)
+		}
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// ParseProtocol converts a K8s protocol name into the one used by the
+// forwarder.  An empty protocol defaults to TCP.
+func ParseProtocol(protocol string) (string, error) {
+	switch p := strings.ToLower(protocol); p {
+	case "":
+		return "tcp", nil
+	case "tcp", "udp":
+		return p, nil
+	default:
+		return "", fmt.Errorf("protocol %q is not supported for kube services", protocol)
+	}
+}
@@ -0,0 +1,279 @@
+package kubeservice
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTCPBackend starts a TCP server replying with its name to every
+// connection and returns its address.
+func startTCPBackend(t *testing.T, name string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(name))
+			conn.Close()
+		}
+	}()
+	return l.Addr().String()
+}
+
+// freePort returns a port which is very likely unused.
+func freePort(t *testing.T, network string) uint16 {
+	var addr string
+	switch network {
+	case "tcp":
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr = l.Addr().String()
+		l.Close()
+	case "udp":
+		c, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr = c.LocalAddr().String()
+		c.Close()
+	}
+	_, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return uint16(p)
+}
+
+func startProxy(t *testing.T, config *Config) *Proxy {
+	proxy, err := Listen(config)
+	require.NoError(t, err)
+	go proxy.Serve()
+	t.Cleanup(func() { proxy.Close() })
+	return proxy
+}
+
+// dialTCPBackend connects to the address and returns the reply.
+func dialTCPBackend(t *testing.T, address string) string {
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+	data, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestProxyTCPRoundRobin(t *testing.T) {
+	port := Port{
+		Protocol: "tcp",
+		HostIP:   "127.0.0.1",
+		HostPort: freePort(t, "tcp"),
+		Backends: []string{startTCPBackend(t, "a"), startTCPBackend(t, "b")},
+	}
+	startProxy(t, &Config{Name: "test", Ports: []Port{port}})
+
+	var replies []string
+	for range 4 {
+		conn, err := net.Dial("tcp", port.Address())
+		require.NoError(t, err)
+		data, err := io.ReadAll(conn)
+		require.NoError(t, err)
+		conn.Close()
+		replies = append(replies, string(data))
+	}
+	assert.Equal(t, []string{"a", "b", "a", "b"}, replies)
+}
+
+func TestProxyTCPSkipsUnavailableBackend(t *testing.T) {
+	port := Port{
+		Protocol: "tcp",
+		HostIP:   "127.0.0.1",
+		HostPort: freePort(t, "tcp"),
+		// Nothing is listening on the first backend.
+		Backends: []string{net.JoinHostPort("127.0.0.1", strconv.Itoa(int(freePort(t, "tcp")))), startTCPBackend(t, "b")},
+	}
+	startProxy(t, &Config{Name: "test", Ports: []Port{port}})
+
+	for range 2 {
+		conn, err := net.Dial("tcp", port.Address())
+		require.NoError(t, err)
+		data, err := io.ReadAll(conn)
+		require.NoError(t, err)
+		conn.Close()
+		assert.Equal(t, "b", string(data))
+	}
+}
+
+func TestProxyUpdate(t *testing.T) {
+	port := Port{
+		Protocol: "tcp",
+		HostIP:   "127.0.0.1",
+		HostPort: freePort(t, "tcp"),
+		Backends: []string{startTCPBackend(t, "a")},
+	}
+	proxy := startProxy(t, &Config{Name: "test", Ports: []Port{port}})
+	assert.Equal(t, "a", dialTCPBackend(t, port.Address()))
+
+	port.Backends = []string{startTCPBackend(t, "b")}
+	require.NoError(t, proxy.Update(&Config{Name: "test", Ports: []Port{port}}))
+	assert.Equal(t, "b", dialTCPBackend(t, port.Address()))
+
+	moved := port
+	moved.HostPort = freePort(t, "tcp")
+	err := proxy.Update(&Config{Name: "test", Ports: []Port{moved}})
+	assert.EqualError(t, err, "kube service test: ports cannot be changed while forwarding")
+	assert.Equal(t, "b", dialTCPBackend(t, port.Address()))
+}
+
+func TestProxyPodReadiness(t *testing.T) {
+	a, b := startTCPBackend(t, "a"), startTCPBackend(t, "b")
+	port := Port{
+		Protocol: "tcp",
+		HostIP:   "127.0.0.1",
+		HostPort: freePort(t, "tcp"),
+		Backends: []string{a, b},
+	}
+	proxy := startProxy(t, &Config{
+		Name:    "test",
+		Ports:   []Port{port},
+		Pods:    map[string]string{a: "pod-a", b: "pod-b"},
+		Unready: []string{"pod-b"},
+	})
+	for range 2 {
+		assert.Equal(t, "a", dialTCPBackend(t, port.Address()))
+	}
+
+	proxy.SetPodReady("pod-a", false)
+	// Without any ready backend, connections are closed right away.
+	assert.Equal(t, "", dialTCPBackend(t, port.Address()))
+
+	proxy.SetPodReady("pod-b", true)
+	proxy.SetPodReady("other-pod", false)
+	for range 2 {
+		assert.Equal(t, "b", dialTCPBackend(t, port.Address()))
+	}
+
+	proxy.SetPodReady("pod-a", true)
+	replies := []string{dialTCPBackend(t, port.Address()), dialTCPBackend(t, port.Address())}
+	assert.ElementsMatch(t, []string{"a", "b"}, replies)
+}
+
+func TestProxyDrain(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer backend.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := backend.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	port := Port{
+		Protocol: "tcp",
+		HostIP:   "127.0.0.1",
+		HostPort: freePort(t, "tcp"),
+		Backends: []string{backend.Addr().String()},
+	}
+	proxy := startProxy(t, &Config{Name: "test", Ports: []Port{port}})
+
+	client, err := net.Dial("tcp", port.Address())
+	require.NoError(t, err)
+	defer client.Close()
+	server := <-accepted
+
+	// Closing the proxy keeps the established connection.
+	require.NoError(t, proxy.Close())
+	assert.False(t, proxy.Drain(100*time.Millisecond))
+	_, err = server.Write([]byte("still there"))
+	require.NoError(t, err)
+	buf := make([]byte, len("still there"))
+	_, err = io.ReadFull(client, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "still there", string(buf))
+
+	server.Close()
+	client.Close()
+	assert.True(t, proxy.Drain(5*time.Second))
+}
+
+func TestProxyUDP(t *testing.T) {
+	backend, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer backend.Close()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := backend.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = backend.WriteTo(append([]byte("echo:"), buf[:n]...), addr)
+		}
+	}()
+
+	port := Port{
+		Protocol: "udp",
+		HostIP:   "127.0.0.1",
+		HostPort: freePort(t, "udp"),
+		Backends: []string{backend.LocalAddr().String()},
+	}
+	startProxy(t, &Config{Name: "test", Ports: []Port{port}})
+
+	conn, err := net.Dial("udp", port.Address())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "echo:hello", string(buf[:n]))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "no name",
+			config: Config{},
+			err:    "kube service does not have a name",
+		},
+		{
+			name:   "sctp",
+			config: Config{Name: "svc", Ports: []Port{{Protocol: "sctp", HostPort: 80, Backends: []string{"127.0.0.1:80"}}}},
+			err:    `kube service svc: protocol "sctp" is not supported`,
+		},
+		{
+			name:   "no backends",
+			config: Config{Name: "svc", Ports: []Port{{Protocol: "tcp", HostPort: 80}}},
+			err:    "kube service svc: port 0.0.0.0:80/tcp has no backends",
+		},
+		{
+			name:   "valid",
+			config: Config{Name: "svc", Ports: []Port{{Protocol: "udp", HostPort: 53, Backends: []string{"127.0.0.1:5353"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -182,6 +182,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		portMappings                            []libnetworkTypes.PortMapping
 		networks                                []string
 		healthStatus                            string
+		readiness                               string
 		restartCount                            uint
 		podName                                 string
 	)
@@ -232,6 +233,11 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 			return err
 		}
 
+		readiness, err = c.ReadinessStatus()
+		if err != nil {
+			return err
+		}
+
 		restartCount, err = c.RestartCount()
 		if err != nil {
 			return err
@@ -298,6 +304,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		Pod:          conConfig.Pod,
 		PodName:      podName,
 		Ports:        portMappings,
+		Readiness:    readiness,
 		Restarts:     restartCount,
 		Size:         size,
 		StartedAt:    startedTime.Unix(),
@@ -496,6 +496,7 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 	specg.HealthConfig = conf.HealthCheckConfig
 	specg.StartupHealthConfig = conf.StartupHealthCheckConfig
 	specg.HealthCheckOnFailureAction = conf.HealthCheckOnFailureAction
+	specg.HealthCheckOnFailureHook = conf.HealthCheckOnFailureHook
 
 	if len(tmpEnvSecrets) > 0 {
 		envSecrets := make(map[string]string, len(tmpEnvSecrets))
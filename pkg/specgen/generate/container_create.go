@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/jinzhu/copier"
@@ -568,6 +569,25 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		if len(s.LogConfiguration.Labels) > 0 {
 			options = append(options, libpod.WithLogLabels(s.LogConfiguration.Labels))
 		}
+		for _, key := range []string{"max-file", "compress"} {
+			if _, ok := s.LogConfiguration.Options[key]; ok && s.LogConfiguration.Driver != define.JSONLogging {
+				return nil, fmt.Errorf("log option %s is only supported by the %s log driver: %w", key, define.JSONLogging, define.ErrInvalidArg)
+			}
+		}
+		if maxFile, ok := s.LogConfiguration.Options["max-file"]; ok {
+			maxFiles, err := strconv.Atoi(maxFile)
+			if err != nil {
+				return nil, fmt.Errorf("invalid log option max-file %q: %w", maxFile, err)
+			}
+			options = append(options, libpod.WithLogMaxFiles(maxFiles))
+		}
+		if compress, ok := s.LogConfiguration.Options["compress"]; ok {
+			logCompress, err := strconv.ParseBool(compress)
+			if err != nil {
+				return nil, fmt.Errorf("invalid log option compress %q: %w", compress, err)
+			}
+			options = append(options, libpod.WithLogCompress(logCompress))
+		}
 		if len(s.LogConfiguration.Driver) > 0 {
 			options = append(options, libpod.WithLogDriver(s.LogConfiguration.Driver))
 		}
@@ -657,10 +677,19 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithStartupHealthcheck(s.ContainerHealthCheckConfig.StartupHealthConfig))
 		healthCheckSet = true
 	}
+	if s.ContainerHealthCheckConfig.ReadinessConfig != nil {
+		options = append(options, libpod.WithReadinessCheck(s.ContainerHealthCheckConfig.ReadinessConfig))
+	}
+	if s.PressureMonitor != nil {
+		options = append(options, libpod.WithPressureMonitor(s.PressureMonitor))
+	}
 
 	if s.ContainerHealthCheckConfig.HealthCheckOnFailureAction != define.HealthCheckOnFailureActionNone {
 		options = append(options, libpod.WithHealthCheckOnFailureAction(s.ContainerHealthCheckConfig.HealthCheckOnFailureAction))
 	}
+	if s.ContainerHealthCheckConfig.HealthCheckOnFailureHook != "" {
+		options = append(options, libpod.WithHealthCheckOnFailureHook(s.ContainerHealthCheckConfig.HealthCheckOnFailureHook))
+	}
 
 	options = append(options, libpod.WithHealthCheckLogDestination(s.ContainerHealthCheckConfig.HealthLogDestination))
 	options = append(options, libpod.WithHealthCheckMaxLogCount(s.ContainerHealthCheckConfig.HealthMaxLogCount))
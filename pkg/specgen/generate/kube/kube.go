@@ -289,6 +289,10 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure startupProbe: %w", err)
 	}
+	err = setupReadinessProbe(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readinessProbe: %w", err)
+	}
 
 	// Since we prefix the container name with pod name to work-around the uniqueness requirement,
 	// the seccomp profile should reference the actual container name from the YAML
@@ -556,7 +560,7 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				SubPath: volume.SubPath,
 			}
 			s.Volumes = append(s.Volumes, &namedVolume)
-		case KubeVolumeTypeConfigMap:
+		case KubeVolumeTypeConfigMap, KubeVolumeTypeProjected:
 			cmVolume := specgen.NamedVolume{
 				Dest:    volume.MountPath,
 				Name:    volumeSource.Source,
@@ -717,10 +721,11 @@ func parseMountPath(mountPath string, readOnly bool, propagationMode *v1.MountPr
 }
 
 func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*manifest.Schema2HealthConfig, error) {
-	var commandString string
-	failureCmd := "exit 1"
+	var healthCheckProbe *define.HealthCheckProbe
 	probeHandler := probe.Handler
-	host := "localhost" // Kubernetes default is host IP, but with Podman currently we run inside the container
+	// Kubernetes default is the pod IP, but Podman runs the probes in the
+	// network namespace of the container, so the default host is localhost
+	host := ""
 
 	// configure healthcheck on the basis of Handler Actions.
 	switch {
@@ -730,7 +735,7 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		if err != nil {
 			return nil, err
 		}
-		commandString = string(cmd)
+		return makeHealthCheck(string(cmd), probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
 	case probeHandler.HTTPGet != nil:
 		// set defaults as in https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#http-probes
 		uriScheme := v1.URISchemeHTTP
@@ -740,15 +745,24 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		if probeHandler.HTTPGet.Host != "" {
 			host = probeHandler.HTTPGet.Host
 		}
-		path := "/"
-		if probeHandler.HTTPGet.Path != "" {
-			path = probeHandler.HTTPGet.Path
+		path := probeHandler.HTTPGet.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
 		}
 		portNum, err := getPortNumber(probeHandler.HTTPGet.Port, containerPorts)
 		if err != nil {
 			return nil, err
 		}
-		commandString = fmt.Sprintf("curl -f %s://%s:%d%s || %s", uriScheme, host, portNum, path, failureCmd)
+		target := fmt.Sprintf("%s://%s%s", strings.ToLower(string(uriScheme)), net.JoinHostPort(host, strconv.Itoa(portNum)), path)
+		healthCheckProbe, err = define.NewHTTPGetHealthCheckProbe(target)
+		if err != nil {
+			return nil, err
+		}
+		for _, header := range probeHandler.HTTPGet.HTTPHeaders {
+			if err := healthCheckProbe.AddHeader(header.Name + ": " + header.Value); err != nil {
+				return nil, err
+			}
+		}
 	case probeHandler.TCPSocket != nil:
 		portNum, err := getPortNumber(probeHandler.TCPSocket.Port, containerPorts)
 		if err != nil {
@@ -757,9 +771,23 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		if probeHandler.TCPSocket.Host != "" {
 			host = probeHandler.TCPSocket.Host
 		}
-		commandString = fmt.Sprintf("nc -z -v %s %d || %s", host, portNum, failureCmd)
+		healthCheckProbe, err = define.NewTCPSocketHealthCheckProbe(net.JoinHostPort(host, strconv.Itoa(portNum)))
+		if err != nil {
+			return nil, err
+		}
+	case probeHandler.GRPC != nil:
+		var err error
+		healthCheckProbe, err = define.NewGRPCHealthCheckProbe(strconv.Itoa(int(probeHandler.GRPC.Port)))
+		if err != nil {
+			return nil, err
+		}
+		if probeHandler.GRPC.Service != nil {
+			healthCheckProbe.Service = *probeHandler.GRPC.Service
+		}
+	default:
+		return nil, errors.New("must define a healthcheck command for all healthchecks")
 	}
-	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+	return makeHealthCheckFromTest(healthCheckProbe.Test(), probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
 }
 
 func getPortNumber(port intstr.IntOrString, containerPorts []v1.ContainerPort) (int, error) {
@@ -828,6 +856,26 @@ func setupStartupProbe(s *specgen.SpecGenerator, containerYAML v1.Container, res
 	return nil
 }
 
+func setupReadinessProbe(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.ReadinessProbe == nil {
+		return nil
+	}
+	emptyHandler := v1.Handler{}
+	if containerYAML.ReadinessProbe.Handler != emptyHandler {
+		healthConfig, err := probeToHealthConfig(containerYAML.ReadinessProbe, containerYAML.Ports)
+		if err != nil {
+			return err
+		}
+		// A failing readiness probe only marks the container as not
+		// ready, so there is no on-failure action to set up.
+		s.ReadinessConfig = &define.ReadinessCheck{
+			Schema2HealthConfig: *healthConfig,
+			Successes:           int(containerYAML.ReadinessProbe.SuccessThreshold),
+		}
+	}
+	return nil
+}
+
 func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32, startPeriod int32) (*manifest.Schema2HealthConfig, error) {
 	// Every healthcheck requires a command
 	if len(inCmd) == 0 {
@@ -849,8 +897,14 @@ func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32,
 			cmd = append([]string{define.HealthConfigTestCmd}, cmd...)
 		}
 	}
+	return makeHealthCheckFromTest(cmd, interval, retries, timeout, startPeriod)
+}
+
+// makeHealthCheckFromTest creates a healthcheck running the given test with
+// the timing of a Kubernetes probe
+func makeHealthCheckFromTest(test []string, interval int32, retries int32, timeout int32, startPeriod int32) (*manifest.Schema2HealthConfig, error) {
 	hc := manifest.Schema2HealthConfig{
-		Test: cmd,
+		Test: test,
 	}
 
 	if interval < 1 {
@@ -1162,14 +1216,14 @@ func envVarValue(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
 	return &env.Value, nil
 }
 
+var (
+	fieldPathLabelRegex      = regexp.MustCompile(`^metadata.labels\['(.+)'\]$`)
+	fieldPathAnnotationRegex = regexp.MustCompile(`^metadata.annotations\['(.+)'\]$`)
+)
+
 func envVarValueFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
 	fieldRef := env.ValueFrom.FieldRef
 
-	fieldPathLabelPattern := `^metadata.labels\['(.+)'\]$`
-	fieldPathLabelRegex := regexp.MustCompile(fieldPathLabelPattern)
-	fieldPathAnnotationPattern := `^metadata.annotations\['(.+)'\]$`
-	fieldPathAnnotationRegex := regexp.MustCompile(fieldPathAnnotationPattern)
-
 	fieldPath := fieldRef.FieldPath
 
 	if fieldPath == "metadata.name" {
@@ -1196,18 +1250,28 @@ func envVarValueFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error
 }
 
 func envVarValueResourceFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
-	divisor := env.ValueFrom.ResourceFieldRef.Divisor
+	value, err := resourceFieldRefValue(env.ValueFrom.ResourceFieldRef, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("can not set env %v. Reason: %w", env.Name, err)
+	}
+	return &value, nil
+}
+
+// resourceFieldRefValue returns the value of the resource of the container
+// selected by the resourceFieldRef.
+func resourceFieldRefValue(resourceFieldRef *v1.ResourceFieldSelector, container v1.Container) (string, error) {
+	divisor := resourceFieldRef.Divisor
 	if divisor.IsZero() { // divisor not set, use default
 		divisor.Set(1)
 	}
 
-	resources, err := getContainerResources(opts.Container)
+	resources, err := getContainerResources(container)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	var value *resource.Quantity
-	resourceName := env.ValueFrom.ResourceFieldRef.Resource
+	resourceName := resourceFieldRef.Resource
 	var isValidDivisor bool
 
 	switch resourceName {
@@ -1224,24 +1288,16 @@ func envVarValueResourceFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*strin
 		value = resources.Requests.Cpu()
 		isValidDivisor = isCPUDivisor(divisor)
 	default:
-		return nil, fmt.Errorf(
-			"can not set env %v. Reason: resource %v is either not valid or not supported",
-			env.Name, resourceName,
-		)
+		return "", fmt.Errorf("resource %v is either not valid or not supported", resourceName)
 	}
 
 	if !isValidDivisor {
-		return nil, fmt.Errorf(
-			"can not set env %s. Reason: divisor value %s is not valid",
-			env.Name, divisor.String(),
-		)
+		return "", fmt.Errorf("divisor value %s is not valid", divisor.String())
 	}
 
 	// k8s rounds up the result to the nearest integer
 	intValue := int64(math.Ceil(value.AsApproximateFloat64() / divisor.AsApproximateFloat64()))
-	stringValue := strconv.FormatInt(intValue, 10)
-
-	return &stringValue, nil
+	return strconv.FormatInt(intValue, 10), nil
 }
 
 func isMemoryDivisor(divisor resource.Quantity) bool {
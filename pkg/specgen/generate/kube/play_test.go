@@ -4,6 +4,7 @@ package kube
 
 import (
 	"math"
+	"net"
 	"runtime"
 	"strconv"
 	"testing"
@@ -1395,13 +1396,140 @@ func TestTCPLivenessProbe(t *testing.T) {
 			assert.Equal(t, err == nil, test.succeed)
 			if err == nil {
 				assert.Equal(t, int(test.specGenerator.ContainerHealthCheckConfig.HealthCheckOnFailureAction), define.HealthCheckOnFailureActionRestart)
-				assert.Contains(t, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test, test.expectedHost)
-				assert.Contains(t, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test, test.expectedPort)
+				assert.Equal(t, []string{define.HealthConfigTestTCPSocket, net.JoinHostPort(test.expectedHost, test.expectedPort)}, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test)
 			}
 		})
 	}
 }
 
+func TestNativeLivenessProbe(t *testing.T) {
+	service := "db"
+	tests := []struct {
+		name         string
+		handler      v1.Handler
+		expectedTest []string
+	}{
+		{
+			name: "HTTPGetWithHeaders",
+			handler: v1.Handler{
+				HTTPGet: &v1.HTTPGetAction{
+					Scheme:      v1.URISchemeHTTPS,
+					Port:        intstr.FromInt(8443),
+					Path:        "healthz",
+					HTTPHeaders: []v1.HTTPHeader{{Name: "X-Probe", Value: "liveness"}},
+				},
+			},
+			expectedTest: []string{define.HealthConfigTestHTTPGet, "https://localhost:8443/healthz", "X-Probe: liveness"},
+		},
+		{
+			name: "GRPC",
+			handler: v1.Handler{
+				GRPC: &v1.GRPCAction{Port: 9000},
+			},
+			expectedTest: []string{define.HealthConfigTestGRPC, "localhost:9000"},
+		},
+		{
+			name: "GRPCWithService",
+			handler: v1.Handler{
+				GRPC: &v1.GRPCAction{Port: 9000, Service: &service},
+			},
+			expectedTest: []string{define.HealthConfigTestGRPC, "localhost:9000", "db"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupLivenessProbe(&s, v1.Container{LivenessProbe: &v1.Probe{Handler: test.handler}}, "")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedTest, s.ContainerHealthCheckConfig.HealthConfig.Test)
+		})
+	}
+}
+
+func TestReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name              string
+		container         v1.Container
+		succeed           bool
+		expectedTest      []string
+		expectedSuccesses int
+		expectedRetries   int
+	}{
+		{
+			name:      "NoReadinessProbe",
+			container: v1.Container{},
+			succeed:   true,
+		},
+		{
+			name: "ExecReadinessProbe",
+			container: v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{Command: []string{"cat", "/ready"}},
+					},
+					SuccessThreshold: 2,
+					FailureThreshold: 5,
+				},
+			},
+			succeed:           true,
+			expectedTest:      []string{define.HealthConfigTestCmd, "cat", "/ready"},
+			expectedSuccesses: 2,
+			expectedRetries:   5,
+		},
+		{
+			name: "TCPReadinessProbeUseNamedPort",
+			container: v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						TCPSocket: &v1.TCPSocketAction{
+							Port: intstr.FromString("http"),
+						},
+					},
+				},
+				Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			},
+			succeed:         true,
+			expectedTest:    []string{define.HealthConfigTestTCPSocket, "localhost:8080"},
+			expectedRetries: 3,
+		},
+		{
+			name: "TCPReadinessProbeInvalidPortName",
+			container: v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						TCPSocket: &v1.TCPSocketAction{
+							Port: intstr.FromString("http"),
+						},
+					},
+				},
+			},
+			succeed: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupReadinessProbe(&s, test.container)
+			assert.Equal(t, err == nil, test.succeed)
+			if err != nil {
+				return
+			}
+			// A readiness probe must never configure a healthcheck.
+			assert.Nil(t, s.ContainerHealthCheckConfig.HealthConfig)
+			assert.Equal(t, define.HealthCheckOnFailureActionNone, int(s.ContainerHealthCheckConfig.HealthCheckOnFailureAction))
+			if test.expectedTest == nil {
+				assert.Nil(t, s.ContainerHealthCheckConfig.ReadinessConfig)
+				return
+			}
+			assert.Equal(t, test.expectedTest, s.ContainerHealthCheckConfig.ReadinessConfig.Test)
+			assert.Equal(t, test.expectedSuccesses, s.ContainerHealthCheckConfig.ReadinessConfig.Successes)
+			assert.Equal(t, test.expectedRetries, s.ContainerHealthCheckConfig.ReadinessConfig.Retries)
+		})
+	}
+}
+
 func TestDeviceResource(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -8,6 +8,8 @@ import (
 	"io/fs"
 	"maps"
 	"os"
+	"slices"
+	"strings"
 
 	"go.podman.io/common/pkg/parse"
 	"go.podman.io/common/pkg/secrets"
@@ -38,6 +40,7 @@ const (
 	KubeVolumeTypeEmptyDir
 	KubeVolumeTypeEmptyDirTmpfs
 	KubeVolumeTypeImage
+	KubeVolumeTypeProjected
 )
 
 type KubeVolume struct {
@@ -56,6 +59,8 @@ type KubeVolume struct {
 	// DefaultMode sets the permissions on files created for the volume
 	// This is optional and defaults to 0644
 	DefaultMode int32
+	// Modes overrides DefaultMode for individual Items
+	Modes map[string]int32
 	// Used for volumes of type Image. Ignored for other volumes types.
 	ImagePullPolicy v1.PullPolicy
 }
@@ -284,8 +289,191 @@ func VolumeFromImage(imageVolumeSource *v1.ImageVolumeSource, _ string) (*KubeVo
 	}, nil
 }
 
+// PodInfo is the information about a pod exposed by downward API volumes.
+type PodInfo struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	Containers  []v1.Container
+}
+
+// ProjectedVolumeName returns the name of the volume holding the files of a
+// projected or downward API volume of a pod.  The files depend on the pod, so
+// every pod gets its own volume.  The names are joined with an underscore,
+// which cannot appear in the name of a K8s pod or volume, so that the names
+// of different pods and volumes cannot result in the same volume.
+func ProjectedVolumeName(podName, volName string) string {
+	return podName + "_" + volName
+}
+
+// formatDownwardAPIMap formats labels or annotations like K8s does for
+// downward API volumes: one key="value" pair per line, sorted by key.
+func formatDownwardAPIMap(m map[string]string) string {
+	lines := make([]string, 0, len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		lines = append(lines, fmt.Sprintf("%s=%q", k, m[k]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// downwardAPIFieldValue returns the value of the pod field selected by a
+// fieldRef of a downward API volume.
+func downwardAPIFieldValue(fieldPath string, pod *PodInfo) (string, error) {
+	switch fieldPath {
+	case "metadata.name":
+		return pod.Name, nil
+	case "metadata.namespace":
+		return pod.Namespace, nil
+	case "metadata.labels":
+		return formatDownwardAPIMap(pod.Labels), nil
+	case "metadata.annotations":
+		return formatDownwardAPIMap(pod.Annotations), nil
+	}
+	if matches := fieldPathLabelRegex.FindStringSubmatch(fieldPath); len(matches) == 2 {
+		return pod.Labels[matches[1]], nil
+	}
+	if matches := fieldPathAnnotationRegex.FindStringSubmatch(fieldPath); len(matches) == 2 {
+		return pod.Annotations[matches[1]], nil
+	}
+	return "", fmt.Errorf("fieldPath %v is either not valid or not supported", fieldPath)
+}
+
+// addDownwardAPIItems renders the files of downward API items into the volume.
+func addDownwardAPIItems(kv *KubeVolume, items []v1.DownwardAPIVolumeFile, pod *PodInfo) error {
+	for _, item := range items {
+		var (
+			value string
+			err   error
+		)
+		switch {
+		case item.FieldRef != nil:
+			value, err = downwardAPIFieldValue(item.FieldRef.FieldPath, pod)
+		case item.ResourceFieldRef != nil:
+			idx := slices.IndexFunc(pod.Containers, func(c v1.Container) bool {
+				return c.Name == item.ResourceFieldRef.ContainerName
+			})
+			if idx < 0 {
+				return fmt.Errorf("item %q: no such container %q", item.Path, item.ResourceFieldRef.ContainerName)
+			}
+			value, err = resourceFieldRefValue(item.ResourceFieldRef, pod.Containers[idx])
+		default:
+			return fmt.Errorf("item %q: either fieldRef or resourceFieldRef must be set", item.Path)
+		}
+		if err != nil {
+			return fmt.Errorf("item %q: %w", item.Path, err)
+		}
+		kv.Items[item.Path] = []byte(value)
+		if err := setItemMode(kv, item.Path, item.Mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setItemMode sets the permissions of a file of the volume if specified.
+func setItemMode(kv *KubeVolume, path string, mode *int32) error {
+	validMode, err := isValidDefaultMode(mode)
+	if err != nil {
+		return fmt.Errorf("invalid mode for item %q: %w", path, err)
+	}
+	if validMode {
+		kv.Modes[path] = *mode
+	}
+	return nil
+}
+
+// newProjectedVolume returns an empty KubeVolume of a projected or downward
+// API volume of the pod.
+func newProjectedVolume(defaultMode *int32, pod *PodInfo, volName string) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:        KubeVolumeTypeProjected,
+		Source:      ProjectedVolumeName(pod.Name, volName),
+		Items:       map[string][]byte{},
+		Modes:       map[string]int32{},
+		DefaultMode: v1.ProjectedVolumeSourceDefaultMode,
+	}
+	validMode, err := isValidDefaultMode(defaultMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DefaultMode: %w", err)
+	}
+	if validMode {
+		kv.DefaultMode = *defaultMode
+	}
+	return kv, nil
+}
+
+// VolumeFromDownwardAPI creates a KubeVolume from a DownwardAPIVolumeSource
+// by rendering the selected fields of the pod into files.
+func VolumeFromDownwardAPI(downwardAPI *v1.DownwardAPIVolumeSource, pod *PodInfo, volName string) (*KubeVolume, error) {
+	kv, err := newProjectedVolume(downwardAPI.DefaultMode, pod, volName)
+	if err != nil {
+		return nil, err
+	}
+	if err := addDownwardAPIItems(kv, downwardAPI.Items, pod); err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
+// VolumeFromProjected creates a KubeVolume from a ProjectedVolumeSource by
+// rendering the files of all its ConfigMap, Secret and downward API sources.
+func VolumeFromProjected(projected *v1.ProjectedVolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, pod *PodInfo, volName string) (*KubeVolume, error) {
+	kv, err := newProjectedVolume(projected.DefaultMode, pod, volName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range projected.Sources {
+		var (
+			items []v1.KeyToPath
+			sv    *KubeVolume
+		)
+		switch {
+		case source.ConfigMap != nil:
+			items = source.ConfigMap.Items
+			sv, err = VolumeFromConfigMap(&v1.ConfigMapVolumeSource{
+				LocalObjectReference: source.ConfigMap.LocalObjectReference,
+				Items:                source.ConfigMap.Items,
+				Optional:             source.ConfigMap.Optional,
+			}, configMaps)
+		case source.Secret != nil:
+			items = source.Secret.Items
+			sv, err = VolumeFromSecret(&v1.SecretVolumeSource{
+				SecretName: source.Secret.Name,
+				Items:      source.Secret.Items,
+				Optional:   source.Secret.Optional,
+			}, secretsManager)
+		case source.DownwardAPI != nil:
+			err = addDownwardAPIItems(kv, source.DownwardAPI.Items, pod)
+		case source.ServiceAccountToken != nil:
+			err = errors.New("serviceAccountToken projections are not supported")
+		default:
+			err = errors.New("empty projection")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if sv == nil || sv.Optional {
+			continue
+		}
+		for path, data := range sv.Items {
+			if _, ok := kv.Items[path]; ok {
+				return nil, fmt.Errorf("conflicting duplicate path %q", path)
+			}
+			kv.Items[path] = data
+		}
+		for _, item := range items {
+			if err := setItemMode(kv, item.Path, item.Mode); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return kv, nil
+}
+
 // Create a KubeVolume from one of the supported VolumeSource
-func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, volName, mountLabel string) (*KubeVolume, error) {
+func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, pod *PodInfo, volName, mountLabel string) (*KubeVolume, error) {
 	switch {
 	case volumeSource.HostPath != nil:
 		return VolumeFromHostPath(volumeSource.HostPath, mountLabel)
@@ -299,17 +487,21 @@ func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, s
 		return VolumeFromEmptyDir(volumeSource.EmptyDir, volName)
 	case volumeSource.Image != nil:
 		return VolumeFromImage(volumeSource.Image, volName)
+	case volumeSource.DownwardAPI != nil:
+		return VolumeFromDownwardAPI(volumeSource.DownwardAPI, pod, volName)
+	case volumeSource.Projected != nil:
+		return VolumeFromProjected(volumeSource.Projected, configMaps, secretsManager, pod, volName)
 	default:
-		return nil, errors.New("HostPath, ConfigMap, EmptyDir, Secret, and PersistentVolumeClaim are currently the only supported VolumeSource")
+		return nil, errors.New("HostPath, ConfigMap, EmptyDir, Secret, PersistentVolumeClaim, Image, DownwardAPI and Projected are currently the only supported VolumeSource")
 	}
 }
 
 // Create a map of volume name to KubeVolume
-func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, mountLabel string) (map[string]*KubeVolume, error) {
+func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, pod *PodInfo, mountLabel string) (map[string]*KubeVolume, error) {
 	volumes := make(map[string]*KubeVolume)
 
 	for _, specVolume := range specVolumes {
-		volume, err := VolumeFromSource(specVolume.VolumeSource, configMaps, secretsManager, specVolume.Name, mountLabel)
+		volume, err := VolumeFromSource(specVolume.VolumeSource, configMaps, secretsManager, pod, specVolume.Name, mountLabel)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create volume %q: %w", specVolume.Name, err)
 		}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "go.podman.io/podman/v6/pkg/k8s.io/api/core/v1"
+	"go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/api/resource"
+	metav1 "go.podman.io/podman/v6/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestVolumeFromEmptyDir(t *testing.T) {
@@ -22,3 +24,107 @@ func TestVolumeFromEmptyDir(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, memEmptyDirVol.Type, KubeVolumeTypeEmptyDirTmpfs)
 }
+
+func TestVolumeFromDownwardAPI(t *testing.T) {
+	mode := int32(0o600)
+	pod := &PodInfo{
+		Name:        "web",
+		Namespace:   "default",
+		Labels:      map[string]string{"tier": "frontend", "app": "web"},
+		Annotations: map[string]string{"build": `v1 "beta"`},
+		Containers: []v1.Container{{
+			Name: "ctr",
+			Resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+			},
+		}},
+	}
+	source := &v1.DownwardAPIVolumeSource{
+		Items: []v1.DownwardAPIVolumeFile{
+			{Path: "name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			{Path: "namespace", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			{Path: "labels", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels"}, Mode: &mode},
+			{Path: "annotations", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations"}},
+			{Path: "app", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"}},
+			{Path: "memory", ResourceFieldRef: &v1.ResourceFieldSelector{ContainerName: "ctr", Resource: "limits.memory", Divisor: resource.MustParse("1Mi")}},
+		},
+	}
+	kv, err := VolumeFromDownwardAPI(source, pod, "podinfo")
+	assert.NoError(t, err)
+	assert.Equal(t, KubeVolumeTypeProjected, kv.Type)
+	assert.Equal(t, "web_podinfo", kv.Source)
+	assert.Equal(t, v1.DownwardAPIVolumeSourceDefaultMode, kv.DefaultMode)
+	assert.Equal(t, map[string][]byte{
+		"name":        []byte("web"),
+		"namespace":   []byte("default"),
+		"labels":      []byte("app=\"web\"\ntier=\"frontend\""),
+		"annotations": []byte(`build="v1 \"beta\""`),
+		"app":         []byte("web"),
+		"memory":      []byte("64"),
+	}, kv.Items)
+	assert.Equal(t, map[string]int32{"labels": 0o600}, kv.Modes)
+
+	_, err = VolumeFromDownwardAPI(&v1.DownwardAPIVolumeSource{
+		Items: []v1.DownwardAPIVolumeFile{{Path: "uid", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.uid"}}},
+	}, pod, "podinfo")
+	assert.EqualError(t, err, `item "uid": fieldPath metadata.uid is either not valid or not supported`)
+
+	_, err = VolumeFromDownwardAPI(&v1.DownwardAPIVolumeSource{
+		Items: []v1.DownwardAPIVolumeFile{{Path: "cpu", ResourceFieldRef: &v1.ResourceFieldSelector{ContainerName: "missing", Resource: "limits.cpu"}}},
+	}, pod, "podinfo")
+	assert.EqualError(t, err, `item "cpu": no such container "missing"`)
+}
+
+func TestProjectedVolumeName(t *testing.T) {
+	assert.NotEqual(t, ProjectedVolumeName("a-b", "c"), ProjectedVolumeName("a", "b-c"))
+}
+
+func TestVolumeFromProjected(t *testing.T) {
+	mode := int32(0o400)
+	defaultMode := int32(0o640)
+	optional := true
+	pod := &PodInfo{Name: "web", Namespace: "default"}
+	configMaps := []v1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+		Data:       map[string]string{"app.conf": "debug=true", "other": "ignored"},
+	}}
+
+	projected := &v1.ProjectedVolumeSource{
+		DefaultMode: &defaultMode,
+		Sources: []v1.VolumeProjection{
+			{ConfigMap: &v1.ConfigMapProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: "config"},
+				Items:                []v1.KeyToPath{{Key: "app.conf", Path: "app.conf", Mode: &mode}},
+			}},
+			{ConfigMap: &v1.ConfigMapProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: "missing"},
+				Optional:             &optional,
+			}},
+			{DownwardAPI: &v1.DownwardAPIProjection{
+				Items: []v1.DownwardAPIVolumeFile{{Path: "name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+			}},
+		},
+	}
+	kv, err := VolumeFromProjected(projected, configMaps, nil, pod, "all")
+	assert.NoError(t, err)
+	assert.Equal(t, "web_all", kv.Source)
+	assert.Equal(t, defaultMode, kv.DefaultMode)
+	assert.Equal(t, map[string][]byte{
+		"app.conf": []byte("debug=true"),
+		"name":     []byte("web"),
+	}, kv.Items)
+	assert.Equal(t, map[string]int32{"app.conf": 0o400}, kv.Modes)
+
+	_, err = VolumeFromProjected(&v1.ProjectedVolumeSource{
+		Sources: []v1.VolumeProjection{{ServiceAccountToken: &v1.ServiceAccountTokenProjection{Path: "token"}}},
+	}, configMaps, nil, pod, "all")
+	assert.EqualError(t, err, "serviceAccountToken projections are not supported")
+
+	_, err = VolumeFromProjected(&v1.ProjectedVolumeSource{
+		Sources: []v1.VolumeProjection{
+			{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: v1.LocalObjectReference{Name: "config"}}},
+			{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: v1.LocalObjectReference{Name: "config"}}},
+		},
+	}, configMaps, nil, pod, "all")
+	assert.ErrorContains(t, err, "conflicting duplicate path")
+}
@@ -598,6 +598,10 @@ type ContainerResourceConfig struct {
 	// that are used to configure cgroup v2.
 	// Optional.
 	CgroupConf map[string]string `json:"unified,omitempty"`
+	// PressureMonitor enables the monitoring of the cgroup v2 memory events
+	// and resource pressure of the container.
+	// Optional.
+	PressureMonitor *define.PressureMonitor `json:"pressure_monitor,omitempty"`
 }
 
 // ContainerHealthCheckConfig describes a container healthcheck with attributes
@@ -605,10 +609,18 @@ type ContainerResourceConfig struct {
 type ContainerHealthCheckConfig struct {
 	HealthConfig               *manifest.Schema2HealthConfig     `json:"healthconfig,omitempty"`
 	HealthCheckOnFailureAction define.HealthCheckOnFailureAction `json:"health_check_on_failure_action,omitempty"`
+	// HealthCheckOnFailureHook is the absolute path of the hook run on
+	// the host by the exec on-failure action.
+	// Optional.
+	HealthCheckOnFailureHook string `json:"health_check_on_failure_hook,omitempty"`
 	// Startup healthcheck for a container.
 	// Requires that HealthConfig be set.
 	// Optional.
 	StartupHealthConfig *define.StartupHealthCheck `json:"startupHealthConfig,omitempty"`
+	// Readiness check for a container.
+	// Runs independently of HealthConfig.
+	// Optional.
+	ReadinessConfig *define.ReadinessCheck `json:"readinessConfig,omitempty"`
 	// HealthLogDestination defines the destination where the log is stored.
 	// TODO (6.0): In next major release convert it to pointer and use omitempty
 	HealthLogDestination string `json:"healthLogDestination"`
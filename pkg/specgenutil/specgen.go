@@ -349,11 +349,15 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		}
 	}
 
-	if len(c.HealthCmd) > 0 {
+	healthCmd, err := healthCheckProbeFromCli(c)
+	if err != nil {
+		return err
+	}
+	if len(healthCmd) > 0 {
 		if c.NoHealthCheck {
 			return errors.New("cannot specify both --no-healthcheck and --health-cmd")
 		}
-		s.HealthConfig, err = MakeHealthCheckFromCli(c.HealthCmd, c.HealthInterval, c.HealthRetries, c.HealthTimeout, c.HealthStartPeriod, false)
+		s.HealthConfig, err = MakeHealthCheckFromCli(healthCmd, c.HealthInterval, c.HealthRetries, c.HealthTimeout, c.HealthStartPeriod, false)
 		if err != nil {
 			return err
 		}
@@ -363,11 +367,12 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		}
 	}
 
-	onFailureAction, err := define.ParseHealthCheckOnFailureAction(c.HealthOnFailure)
+	onFailureAction, onFailureHook, err := define.ParseHealthCheckOnFailure(c.HealthOnFailure)
 	if err != nil {
 		return err
 	}
 	s.HealthCheckOnFailureAction = onFailureAction
+	s.HealthCheckOnFailureHook = onFailureHook
 
 	s.HealthLogDestination = c.HealthLogDestination
 
@@ -375,6 +380,14 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 
 	s.HealthMaxLogSize = c.HealthMaxLogSize
 
+	pressureMonitor, err := define.NewPressureMonitor(c.PressureInterval, c.PressureThreshold)
+	if err != nil {
+		return err
+	}
+	if pressureMonitor != nil {
+		s.PressureMonitor = pressureMonitor
+	}
+
 	if c.StartupHCCmd != "" {
 		if c.NoHealthCheck {
 			return errors.New("cannot specify both --no-healthcheck and --health-startup-cmd")
@@ -978,6 +991,46 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	return nil
 }
 
+// healthCheckProbeFromCli returns the healthcheck command of the container,
+// the probe set with --health-http-get, --health-tcp or --health-grpc as
+// JSON array or else --health-cmd.
+func healthCheckProbeFromCli(c *entities.ContainerCreateOptions) (string, error) {
+	var probe *define.HealthCheckProbe
+	for _, opt := range []struct {
+		flag   string
+		target string
+		parse  func(string) (*define.HealthCheckProbe, error)
+	}{
+		{"health-http-get", c.HealthHTTPGet, define.NewHTTPGetHealthCheckProbe},
+		{"health-tcp", c.HealthTCP, define.NewTCPSocketHealthCheckProbe},
+		{"health-grpc", c.HealthGRPC, define.NewGRPCHealthCheckProbe},
+	} {
+		if opt.target == "" {
+			continue
+		}
+		switch {
+		case len(c.HealthCmd) > 0:
+			return "", fmt.Errorf("cannot specify both --health-cmd and --%s", opt.flag)
+		case c.NoHealthCheck:
+			return "", fmt.Errorf("cannot specify both --no-healthcheck and --%s", opt.flag)
+		case probe != nil:
+			return "", errors.New("only one of --health-http-get, --health-tcp and --health-grpc can be specified")
+		}
+		var err error
+		if probe, err = opt.parse(opt.target); err != nil {
+			return "", err
+		}
+	}
+	if probe == nil {
+		return c.HealthCmd, nil
+	}
+	test, err := json.Marshal(probe.Test())
+	if err != nil {
+		return "", err
+	}
+	return string(test), nil
+}
+
 func MakeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, startPeriod string, isStartup bool) (*manifest.Schema2HealthConfig, error) {
 	cmdArr := []string{}
 	isArr := true
@@ -992,7 +1045,7 @@ func MakeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, start
 	}
 
 	var concat string
-	if strings.ToUpper(cmdArr[0]) == define.HealthConfigTestCmd || strings.ToUpper(cmdArr[0]) == define.HealthConfigTestNone { // this is for compat, we are already split properly for most compat cases
+	if strings.ToUpper(cmdArr[0]) == define.HealthConfigTestCmd || strings.ToUpper(cmdArr[0]) == define.HealthConfigTestNone || define.IsHealthCheckProbe(cmdArr) { // this is for compat, we are already split properly for most compat cases
 		// Only re-split if the input was not already a JSON array (isArr == false); otherwise preserve the unmarshaled array structure
 		if !isArr {
 			cmdArr = strings.Fields(inCmd)
@@ -1013,6 +1066,10 @@ func MakeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, start
 	if strings.ToUpper(cmdArr[0]) == define.HealthConfigTestNone { // if specified to remove healtcheck
 		cmdArr = []string{define.HealthConfigTestNone}
 	}
+	// probes run by Podman are validated when created, not when run
+	if _, err := define.ParseHealthCheckProbe(cmdArr); err != nil {
+		return nil, err
+	}
 
 	// healthcheck is by default an array, so we simply pass the user input
 	hc := manifest.Schema2HealthConfig{
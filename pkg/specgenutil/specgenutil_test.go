@@ -231,3 +231,49 @@ func TestFillOutSpecGenRecorsUserNs(t *testing.T) {
 	assert.True(t, ok, "UserNsAnnotation is set")
 	assert.Equal(t, "keep-id", v, "UserNsAnnotation is keep-id")
 }
+
+func TestFillOutSpecGenHealthCheckProbe(t *testing.T) {
+	newOptions := func() *entities.ContainerCreateOptions {
+		return &entities.ContainerCreateOptions{
+			ImageVolume:       "ignore",
+			HealthInterval:    define.DefaultHealthCheckInterval,
+			HealthRetries:     define.DefaultHealthCheckRetries,
+			HealthTimeout:     define.DefaultHealthCheckTimeout,
+			HealthStartPeriod: define.DefaultHealthCheckStartPeriod,
+		}
+	}
+
+	opts := newOptions()
+	opts.HealthHTTPGet = ":8080/healthz"
+	sg := specgen.NewSpecGenerator("nothing", false)
+	err := FillOutSpecGen(sg, opts, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{define.HealthConfigTestHTTPGet, "http://localhost:8080/healthz"}, sg.HealthConfig.Test)
+
+	opts = newOptions()
+	opts.HealthGRPC = "9000/db"
+	sg = specgen.NewSpecGenerator("nothing", false)
+	err = FillOutSpecGen(sg, opts, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{define.HealthConfigTestGRPC, "localhost:9000", "db"}, sg.HealthConfig.Test)
+
+	// A probe given as JSON array to --health-cmd is kept as it is
+	opts = newOptions()
+	opts.HealthCmd = `["TCP-SOCKET", "localhost:5432"]`
+	sg = specgen.NewSpecGenerator("nothing", false)
+	err = FillOutSpecGen(sg, opts, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{define.HealthConfigTestTCPSocket, "localhost:5432"}, sg.HealthConfig.Test)
+
+	for _, modify := range []func(*entities.ContainerCreateOptions){
+		func(o *entities.ContainerCreateOptions) { o.HealthTCP = "5432"; o.HealthCmd = "true" },
+		func(o *entities.ContainerCreateOptions) { o.HealthTCP = "5432"; o.NoHealthCheck = true },
+		func(o *entities.ContainerCreateOptions) { o.HealthTCP = "5432"; o.HealthGRPC = "9000" },
+		func(o *entities.ContainerCreateOptions) { o.HealthTCP = "db" },
+	} {
+		opts = newOptions()
+		modify(opts)
+		err = FillOutSpecGen(specgen.NewSpecGenerator("nothing", false), opts, []string{})
+		assert.Error(t, err, "%+v", opts)
+	}
+}
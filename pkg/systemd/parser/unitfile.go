@@ -28,6 +28,11 @@ type unitLine struct {
 	key       string
 	value     string
 	isComment bool
+	// Path of the parsed file and line number in it, empty and 0 if the
+	// line was not parsed.  Lines merged from drop-in files keep the path
+	// of the drop-in file.
+	path   string
+	lineNr int
 }
 
 type unitGroup struct {
@@ -44,6 +49,21 @@ type UnitFile struct {
 	Path     string
 }
 
+// ParseError is returned when a unit file can't be parsed
+type ParseError struct {
+	// Line is the number of the line in the unit file with the error
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
 type UnitFileParser struct {
 	file *UnitFile
 
@@ -65,7 +85,10 @@ func (l *unitLine) set(value string) {
 }
 
 func (l *unitLine) dup() *unitLine {
-	return newUnitLine(l.key, l.value, l.isComment)
+	d := newUnitLine(l.key, l.value, l.isComment)
+	d.path = l.path
+	d.lineNr = l.lineNr
+	return d
 }
 
 func (l *unitLine) isKey(key string) bool {
@@ -317,7 +340,7 @@ func (p *UnitFileParser) parseGroup(line string) error {
 	return nil
 }
 
-func (p *UnitFileParser) parseKeyValuePair(line string) error {
+func (p *UnitFileParser) parseKeyValuePair(line string, lineNr int) error {
 	if p.currentGroup == nil {
 		return fmt.Errorf("key file does not start with a group")
 	}
@@ -344,7 +367,10 @@ func (p *UnitFileParser) parseKeyValuePair(line string) error {
 
 	p.flushPendingComments(false)
 
-	p.currentGroup.add(key, value)
+	l := newUnitLine(key, value, false)
+	l.path = p.file.Path
+	l.lineNr = lineNr
+	p.currentGroup.addLine(l)
 
 	return nil
 }
@@ -356,7 +382,7 @@ func (p *UnitFileParser) parseLine(line string, lineNr int) error {
 	case lineIsGroup(line):
 		return p.parseGroup(line)
 	case lineIsKeyValuePair(line):
-		return p.parseKeyValuePair(line)
+		return p.parseKeyValuePair(line, lineNr)
 	default:
 		return fmt.Errorf("file contains line %d: “%s” which is not a key-value pair, group, or comment", lineNr, line)
 	}
@@ -386,8 +412,13 @@ func (f *UnitFile) Parse(data string) error {
 
 	lines := strings.Split(strings.TrimSuffix(data, "\n"), "\n")
 	remaining := ""
+	// First line of a line with continuations
+	startLineNr := 0
 
 	for lineNr, line := range lines {
+		if remaining == "" {
+			startLineNr = lineNr
+		}
 		line = strings.TrimSpace(line)
 		if lineIsComment(line) {
 			// ignore the comment is inside a continuation line.
@@ -408,8 +439,8 @@ func (f *UnitFile) Parse(data string) error {
 				remaining = ""
 			}
 		}
-		if err := p.parseLine(line, lineNr+1); err != nil {
-			return err
+		if err := p.parseLine(line, startLineNr+1); err != nil {
+			return &ParseError{Line: startLineNr + 1, Err: err}
 		}
 	}
 
@@ -627,6 +658,55 @@ func (f *UnitFile) Lookup(groupName string, key string) (string, bool) {
 	return v, true
 }
 
+// Location is the location of a line parsed from a unit file
+type Location struct {
+	// Path is the path of the file the line was parsed from, which is the
+	// path of a drop-in file for lines merged from one
+	Path string
+	// Line is the number of the line in the file
+	Line int
+}
+
+// LookupLocation returns the location of the last instance of the named key
+// in the group.  The zero Location is returned if the key was not parsed
+// from a file.
+func (f *UnitFile) LookupLocation(groupName string, key string) Location {
+	g, ok := f.groupByName[groupName]
+	if !ok {
+		return Location{}
+	}
+
+	line := g.findLast(key)
+	if line == nil {
+		return Location{}
+	}
+
+	return Location{Path: line.path, Line: line.lineNr}
+}
+
+// LookupAllLocations returns the locations of the instances of the named key
+// in the group, in the order of LookupAll
+func (f *UnitFile) LookupAllLocations(groupName string, key string) []Location {
+	g, ok := f.groupByName[groupName]
+	if !ok {
+		return make([]Location, 0)
+	}
+
+	locations := make([]Location, 0)
+	for _, line := range g.lines {
+		if line.isKey(key) {
+			if len(line.value) == 0 {
+				// Empty value clears all before
+				locations = make([]Location, 0)
+			} else {
+				locations = append(locations, Location{Path: line.path, Line: line.lineNr})
+			}
+		}
+	}
+
+	return locations
+}
+
 // Lookup the last instance of a key and convert the value to a bool
 func (f *UnitFile) LookupBoolean(groupName string, key string) (bool, bool) {
 	v, ok := f.Lookup(groupName, key)
@@ -371,6 +371,48 @@ func TestLookupQuoteStripping(t *testing.T) {
 	}
 }
 
+func TestLookupLocation(t *testing.T) {
+	unitData := `# comment
+[Container]
+Image=quay.io/example
+
+Exec=/bin/sh \\
+  -c true
+Label=a=b
+Label=c=d
+`
+	f := NewUnitFile()
+	f.Path = "/etc/containers/systemd/test.container"
+	assert.NoError(t, f.Parse(unitData))
+	assert.Equal(t, Location{Path: f.Path, Line: 3}, f.LookupLocation("Container", "Image"))
+	assert.Equal(t, Location{Path: f.Path, Line: 5}, f.LookupLocation("Container", "Exec"))
+	assert.Equal(t, Location{Path: f.Path, Line: 8}, f.LookupLocation("Container", "Label"))
+	assert.Equal(t, []Location{{Path: f.Path, Line: 7}, {Path: f.Path, Line: 8}}, f.LookupAllLocations("Container", "Label"))
+	assert.Equal(t, Location{}, f.LookupLocation("Container", "Missing"))
+	assert.Equal(t, Location{}, f.LookupLocation("Missing", "Image"))
+
+	d := f.Dup()
+	assert.Equal(t, Location{Path: f.Path, Line: 3}, d.LookupLocation("Container", "Image"))
+	d.Add("Container", "Added", "value")
+	assert.Equal(t, Location{}, d.LookupLocation("Container", "Added"))
+
+	dropin := NewUnitFile()
+	dropin.Path = "/etc/containers/systemd/test.container.d/10-label.conf"
+	assert.NoError(t, dropin.Parse("[Container]\nLabel=e=f\n"))
+	f.Merge(dropin)
+	assert.Equal(t, Location{Path: dropin.Path, Line: 2}, f.LookupLocation("Container", "Label"))
+	assert.Equal(t, Location{Path: f.Path, Line: 8}, f.LookupAllLocations("Container", "Label")[1])
+}
+
+func TestParseErrorLine(t *testing.T) {
+	f := NewUnitFile()
+	err := f.Parse("[Container]\nImage=quay.io/example\nnot a key\n")
+	var parseErr *ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 3, parseErr.Line)
+	assert.EqualError(t, err, "file contains line 3: “not a key” which is not a key-value pair, group, or comment")
+}
+
 func FuzzParser(f *testing.F) {
 	for _, sample := range samples {
 		f.Add([]byte(sample))
@@ -0,0 +1,989 @@
+package quadlet
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-shellwords"
+	"go.podman.io/podman/v6/pkg/systemd/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// A compose project name, as accepted by docker compose
+var composeProjectNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// composeProject is the subset of the compose specification supported by
+// .compose units
+type composeProject struct {
+	// The name of the project is set with the ProjectName key instead
+	Name       string                     `yaml:"name"`
+	Version    string                     `yaml:"version"`
+	Services   map[string]*composeService `yaml:"services"`
+	Volumes    map[string]*composeVolume  `yaml:"volumes"`
+	Networks   map[string]*composeNetwork `yaml:"networks"`
+	Extensions map[string]any             `yaml:",inline"`
+}
+
+type composeService struct {
+	Image           string                 `yaml:"image"`
+	ContainerName   string                 `yaml:"container_name"`
+	Command         composeCommand         `yaml:"command"`
+	Entrypoint      composeCommand         `yaml:"entrypoint"`
+	Environment     composeKeyVals         `yaml:"environment"`
+	EnvFile         composeStrings         `yaml:"env_file"`
+	Labels          composeKeyVals         `yaml:"labels"`
+	Ports           []composePort          `yaml:"ports"`
+	Volumes         []composeServiceVolume `yaml:"volumes"`
+	Tmpfs           composeStrings         `yaml:"tmpfs"`
+	Networks        composeServiceNetworks `yaml:"networks"`
+	DependsOn       composeDependsOn       `yaml:"depends_on"`
+	Restart         string                 `yaml:"restart"`
+	User            string                 `yaml:"user"`
+	WorkingDir      string                 `yaml:"working_dir"`
+	Healthcheck     *composeHealthcheck    `yaml:"healthcheck"`
+	CapAdd          []string               `yaml:"cap_add"`
+	CapDrop         []string               `yaml:"cap_drop"`
+	Devices         []string               `yaml:"devices"`
+	DNS             composeStrings         `yaml:"dns"`
+	ExtraHosts      composeStrings         `yaml:"extra_hosts"`
+	Init            bool                   `yaml:"init"`
+	Privileged      bool                   `yaml:"privileged"`
+	ReadOnly        bool                   `yaml:"read_only"`
+	StopSignal      string                 `yaml:"stop_signal"`
+	StopGracePeriod string                 `yaml:"stop_grace_period"`
+	Extensions      map[string]any         `yaml:",inline"`
+}
+
+type composeHealthcheck struct {
+	Test        composeStrings `yaml:"test"`
+	Interval    string         `yaml:"interval"`
+	Timeout     string         `yaml:"timeout"`
+	StartPeriod string         `yaml:"start_period"`
+	Retries     *uint          `yaml:"retries"`
+	Disable     bool           `yaml:"disable"`
+	Extensions  map[string]any `yaml:",inline"`
+}
+
+type composeVolume struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	Labels     composeKeyVals    `yaml:"labels"`
+	External   bool              `yaml:"external"`
+	Extensions map[string]any    `yaml:",inline"`
+}
+
+type composeNetwork struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	Labels     composeKeyVals    `yaml:"labels"`
+	External   bool              `yaml:"external"`
+	Internal   bool              `yaml:"internal"`
+	EnableIPv6 bool              `yaml:"enable_ipv6"`
+	IPAM       *composeIPAM      `yaml:"ipam"`
+	Extensions map[string]any    `yaml:",inline"`
+}
+
+type composeIPAM struct {
+	Config []struct {
+		Subnet     string         `yaml:"subnet"`
+		Gateway    string         `yaml:"gateway"`
+		IPRange    string         `yaml:"ip_range"`
+		Extensions map[string]any `yaml:",inline"`
+	} `yaml:"config"`
+	Extensions map[string]any `yaml:",inline"`
+}
+
+// composeStrings is a single string or a list of strings
+type composeStrings []string
+
+func (s *composeStrings) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = composeStrings{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// composeCommand is a command given either as a list of arguments or as a
+// string which is split like a shell would do it
+type composeCommand []string
+
+func (c *composeCommand) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		args, err := shellwords.Parse(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: parsing command %q: %w", value.Line, value.Value, err)
+		}
+		*c = args
+		return nil
+	}
+	var args []string
+	if err := value.Decode(&args); err != nil {
+		return err
+	}
+	*c = args
+	return nil
+}
+
+// composeKeyVals is a mapping or a list of KEY=VALUE strings, it is stored
+// as KEY=VALUE strings sorted by key
+type composeKeyVals []string
+
+func (kv *composeKeyVals) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var list []string
+		if err := value.Decode(&list); err != nil {
+			return err
+		}
+		*kv = list
+		return nil
+	}
+	var values map[string]*string
+	if err := value.Decode(&values); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	list := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if values[key] == nil {
+			// Passed on from the environment of podman
+			list = append(list, key)
+		} else {
+			list = append(list, key+"="+*values[key])
+		}
+	}
+	*kv = list
+	return nil
+}
+
+// composePort is a published port in the short or in the long syntax, it is
+// stored in the format of PublishPort
+type composePort string
+
+func (p *composePort) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*p = composePort(value.Value)
+		return nil
+	}
+	var long struct {
+		Target     uint16         `yaml:"target"`
+		Published  string         `yaml:"published"`
+		HostIP     string         `yaml:"host_ip"`
+		Protocol   string         `yaml:"protocol"`
+		Mode       string         `yaml:"mode"`
+		Extensions map[string]any `yaml:",inline"`
+	}
+	if err := value.Decode(&long); err != nil {
+		return err
+	}
+	if err := checkComposeExtensions(fmt.Sprintf("port on line %d", value.Line), long.Extensions); err != nil {
+		return err
+	}
+	if long.Target == 0 {
+		return fmt.Errorf("port on line %d: no target specified", value.Line)
+	}
+	port := strconv.FormatUint(uint64(long.Target), 10)
+	switch {
+	case long.HostIP != "":
+		port = long.HostIP + ":" + long.Published + ":" + port
+	case long.Published != "":
+		port = long.Published + ":" + port
+	}
+	if long.Protocol != "" {
+		port += "/" + long.Protocol
+	}
+	*p = composePort(port)
+	return nil
+}
+
+// composeServiceVolume is a volume of a service in the short or in the long
+// syntax
+type composeServiceVolume struct {
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+	// Options of the short syntax
+	Options string
+}
+
+func (v *composeServiceVolume) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		parts := strings.SplitN(value.Value, ":", 3)
+		if len(parts) == 1 {
+			v.Type = "volume"
+			v.Target = parts[0]
+			return nil
+		}
+		v.Source = parts[0]
+		v.Target = parts[1]
+		if len(parts) == 3 {
+			v.Options = parts[2]
+		}
+		v.Type = "volume"
+		if isComposeBindSource(v.Source) {
+			v.Type = "bind"
+		}
+		return nil
+	}
+	var long struct {
+		Type       string         `yaml:"type"`
+		Source     string         `yaml:"source"`
+		Target     string         `yaml:"target"`
+		ReadOnly   bool           `yaml:"read_only"`
+		Extensions map[string]any `yaml:",inline"`
+	}
+	if err := value.Decode(&long); err != nil {
+		return err
+	}
+	if err := checkComposeExtensions(fmt.Sprintf("volume on line %d", value.Line), long.Extensions); err != nil {
+		return err
+	}
+	switch long.Type {
+	case "volume", "bind", "tmpfs":
+	default:
+		return fmt.Errorf("volume on line %d: unsupported type %q", value.Line, long.Type)
+	}
+	if long.Target == "" {
+		return fmt.Errorf("volume on line %d: no target specified", value.Line)
+	}
+	v.Type = long.Type
+	v.Source = long.Source
+	v.Target = long.Target
+	v.ReadOnly = long.ReadOnly
+	return nil
+}
+
+// composeServiceNetworks are the networks of a service, given as a list of
+// names or as a mapping of names to their configuration
+type composeServiceNetworks struct {
+	Names   []string
+	Aliases []string
+}
+
+func (n *composeServiceNetworks) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode(&n.Names)
+	}
+	var networks map[string]*struct {
+		Aliases    []string       `yaml:"aliases"`
+		Extensions map[string]any `yaml:",inline"`
+	}
+	if err := value.Decode(&networks); err != nil {
+		return err
+	}
+	for name, network := range networks {
+		n.Names = append(n.Names, name)
+		if network == nil {
+			continue
+		}
+		if err := checkComposeExtensions(fmt.Sprintf("network %s on line %d", name, value.Line), network.Extensions); err != nil {
+			return err
+		}
+		n.Aliases = append(n.Aliases, network.Aliases...)
+	}
+	sort.Strings(n.Names)
+	return nil
+}
+
+type composeDependency struct {
+	Condition string
+	Required  bool
+}
+
+// composeDependsOn are the dependencies of a service, given as a list of
+// names or as a mapping of names to the conditions
+type composeDependsOn map[string]composeDependency
+
+func (d *composeDependsOn) UnmarshalYAML(value *yaml.Node) error {
+	*d = make(composeDependsOn)
+	if value.Kind == yaml.SequenceNode {
+		var names []string
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+		for _, name := range names {
+			(*d)[name] = composeDependency{Condition: "service_started", Required: true}
+		}
+		return nil
+	}
+	var dependencies map[string]struct {
+		Condition  string         `yaml:"condition"`
+		Required   *bool          `yaml:"required"`
+		Restart    bool           `yaml:"restart"`
+		Extensions map[string]any `yaml:",inline"`
+	}
+	if err := value.Decode(&dependencies); err != nil {
+		return err
+	}
+	for name, dependency := range dependencies {
+		if err := checkComposeExtensions(fmt.Sprintf("dependency %s on line %d", name, value.Line), dependency.Extensions); err != nil {
+			return err
+		}
+		condition := dependency.Condition
+		switch condition {
+		case "":
+			condition = "service_started"
+		case "service_started", "service_healthy", "service_completed_successfully":
+		default:
+			return fmt.Errorf("dependency %s on line %d: unsupported condition %q", name, value.Line, condition)
+		}
+		(*d)[name] = composeDependency{
+			Condition: condition,
+			Required:  dependency.Required == nil || *dependency.Required,
+		}
+	}
+	return nil
+}
+
+// checkComposeExtensions returns an error for the first key of a compose
+// element not known to quadlet, extension keys starting with x- are ignored
+func checkComposeExtensions(what string, extensions map[string]any) error {
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if !strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return fmt.Errorf("%s: unsupported key '%s'", what, keys[0])
+}
+
+func isComposeBindSource(source string) bool {
+	return strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~")
+}
+
+// getComposeProjectName returns the name of the compose project of a .compose
+// unit, it prefixes the names of all units and resources generated from it
+func getComposeProjectName(compose *parser.UnitFile) string {
+	if name, ok := compose.Lookup(ComposeGroup, KeyProjectName); ok && len(name) > 0 {
+		return name
+	}
+	return removeExtension(compose.Filename, "", "")
+}
+
+// GetComposeServiceName returns the name of the service of the pod generated
+// from a .compose unit, it starts all containers of the project
+func GetComposeServiceName(compose *parser.UnitFile) string {
+	return getComposeProjectName(compose) + "-pod"
+}
+
+// composeConverter keeps the state of the conversion of a compose file
+type composeConverter struct {
+	compose *parser.UnitFile
+	project *composeProject
+	name    string
+	// Directory of the compose file, relative paths are relative to it
+	dir string
+	// Name of the generated .pod unit
+	podFileName string
+	// References to the volumes and networks of the project, either the
+	// names of the generated units or the names of external resources
+	volumeRefs  map[string]string
+	networkRefs map[string]string
+}
+
+// ConvertCompose converts a .compose unit to the .pod, .container, .volume
+// and .network units equivalent to its compose file.  All services of the
+// project are run in a pod which is returned first, the .compose unit stands
+// for it.
+func ConvertCompose(compose *parser.UnitFile) ([]*parser.UnitFile, error) {
+	if err := checkForUnknownKeys(compose, ComposeGroup, groupsInfo[ComposeGroup].SupportedKeys); err != nil {
+		return nil, err
+	}
+
+	if _, _, isTemplate := compose.GetTemplateParts(); isTemplate {
+		return nil, fmt.Errorf("templates are not supported for .compose units")
+	}
+
+	yamlPath, ok := compose.Lookup(ComposeGroup, KeyYaml)
+	if !ok || len(yamlPath) == 0 {
+		return nil, fmt.Errorf("no Yaml key specified")
+	}
+	yamlPath, err := getAbsolutePath(compose, yamlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	name := getComposeProjectName(compose)
+	if !composeProjectNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid project name %q, use the %s key to set a valid one", name, KeyProjectName)
+	}
+
+	data, err := os.ReadFile(yamlPath)
+	if err != nil {
+		return nil, err
+	}
+	var project composeProject
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", yamlPath, err)
+	}
+
+	c := &composeConverter{
+		compose:     compose,
+		project:     &project,
+		name:        name,
+		dir:         filepath.Dir(yamlPath),
+		podFileName: name + ".pod",
+		volumeRefs:  make(map[string]string),
+		networkRefs: make(map[string]string),
+	}
+	units, err := c.convert()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", yamlPath, err)
+	}
+	return units, nil
+}
+
+// ExpandComposeUnits replaces the .compose units by the units generated from
+// their compose files.  It returns the map from the names of the .compose
+// units to the names of the pods standing for them, and the errors of the
+// .compose units which could not be expanded.
+func ExpandComposeUnits(units []*parser.UnitFile) ([]*parser.UnitFile, map[string]string, map[string]error) {
+	expanded := make([]*parser.UnitFile, 0, len(units))
+	unitNames := make(map[string]bool, len(units))
+	for _, unit := range units {
+		if !strings.HasSuffix(unit.Filename, ".compose") {
+			expanded = append(expanded, unit)
+			unitNames[unit.Filename] = true
+		}
+	}
+
+	aliases := make(map[string]string)
+	errs := make(map[string]error)
+	for _, unit := range units {
+		if !strings.HasSuffix(unit.Filename, ".compose") {
+			continue
+		}
+		generated, err := ConvertCompose(unit)
+		if err == nil {
+			for _, file := range generated {
+				if unitNames[file.Filename] {
+					err = fmt.Errorf("generated unit %s conflicts with an existing unit", file.Filename)
+					break
+				}
+			}
+		}
+		if err != nil {
+			errs[unit.Filename] = err
+			continue
+		}
+		for _, file := range generated {
+			unitNames[file.Filename] = true
+		}
+		expanded = append(expanded, generated...)
+		aliases[unit.Filename] = generated[0].Filename
+	}
+	return expanded, aliases, errs
+}
+
+func (c *composeConverter) newUnit(fileName, group string) *parser.UnitFile {
+	unit := parser.NewUnitFile()
+	unit.Filename = fileName
+	// Points to the .compose unit, it shows up as SourcePath and relative
+	// paths in it are resolved relative to it
+	unit.Path = c.compose.Path
+	// Settings of the [Quadlet] group apply to all units of the project
+	c.copyGroup(unit, QuadletGroup)
+	unit.AddComment(group, fmt.Sprintf("Generated from %s", c.compose.Filename))
+	return unit
+}
+
+func (c *composeConverter) convert() ([]*parser.UnitFile, error) {
+	if err := checkComposeExtensions("top level", c.project.Extensions); err != nil {
+		return nil, err
+	}
+	if len(c.project.Services) == 0 {
+		return nil, fmt.Errorf("no services defined")
+	}
+
+	for name, service := range c.project.Services {
+		if service == nil {
+			c.project.Services[name] = &composeService{}
+		}
+	}
+
+	var units []*parser.UnitFile
+
+	volumes, err := c.convertVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceNames := sortedKeys(c.project.Services)
+
+	// The default network is only created if it is used
+	usesDefaultNetwork := false
+	for _, serviceName := range serviceNames {
+		if len(c.project.Services[serviceName].Networks.Names) == 0 {
+			usesDefaultNetwork = true
+		}
+	}
+	if _, ok := c.project.Networks["default"]; !ok && usesDefaultNetwork {
+		if c.project.Networks == nil {
+			c.project.Networks = make(map[string]*composeNetwork)
+		}
+		c.project.Networks["default"] = nil
+	}
+	networks, err := c.convertNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	units = append(units, c.convertPod())
+	units = append(units, volumes...)
+	units = append(units, networks...)
+
+	containers, err := c.convertServices(serviceNames)
+	if err != nil {
+		return nil, err
+	}
+	units = append(units, containers...)
+
+	return units, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// copyGroup copies a group of the .compose unit to a generated unit
+func (c *composeConverter) copyGroup(unit *parser.UnitFile, group string) {
+	for _, key := range c.compose.ListKeys(group) {
+		for _, value := range c.compose.LookupAllRaw(group, key) {
+			unit.Add(group, key, value)
+		}
+	}
+}
+
+func (c *composeConverter) convertVolumes() ([]*parser.UnitFile, error) {
+	var units []*parser.UnitFile
+	for _, name := range sortedKeys(c.project.Volumes) {
+		volume := c.project.Volumes[name]
+		if volume == nil {
+			volume = &composeVolume{}
+		}
+		if err := checkComposeExtensions("volume "+name, volume.Extensions); err != nil {
+			return nil, err
+		}
+
+		volumeName := volume.Name
+		if volume.External {
+			if volumeName == "" {
+				volumeName = name
+			}
+			c.volumeRefs[name] = volumeName
+			continue
+		}
+		if volumeName == "" {
+			volumeName = c.name + "_" + name
+		}
+
+		fileName := c.name + "-" + name + ".volume"
+		unit := c.newUnit(fileName, VolumeGroup)
+		unit.Add(VolumeGroup, KeyVolumeName, volumeName)
+		if volume.Driver != "" {
+			unit.Add(VolumeGroup, KeyDriver, volume.Driver)
+		}
+		for _, key := range sortedKeys(volume.DriverOpts) {
+			unit.AddCmdline(VolumeGroup, KeyPodmanArgs, []string{"--opt", key + "=" + volume.DriverOpts[key]})
+		}
+		for _, label := range volume.Labels {
+			unit.AddEscaped(VolumeGroup, KeyLabel, label)
+		}
+		c.volumeRefs[name] = fileName
+		units = append(units, unit)
+	}
+	return units, nil
+}
+
+func (c *composeConverter) convertNetworks() ([]*parser.UnitFile, error) {
+	var units []*parser.UnitFile
+	for _, name := range sortedKeys(c.project.Networks) {
+		network := c.project.Networks[name]
+		if network == nil {
+			network = &composeNetwork{}
+		}
+		if err := checkComposeExtensions("network "+name, network.Extensions); err != nil {
+			return nil, err
+		}
+
+		networkName := network.Name
+		if network.External {
+			if networkName == "" {
+				networkName = name
+			}
+			c.networkRefs[name] = networkName
+			continue
+		}
+		if networkName == "" {
+			networkName = c.name + "_" + name
+		}
+
+		fileName := c.name + "-" + name + ".network"
+		unit := c.newUnit(fileName, NetworkGroup)
+		unit.Add(NetworkGroup, KeyNetworkName, networkName)
+		if network.Driver != "" {
+			unit.Add(NetworkGroup, KeyDriver, network.Driver)
+		}
+		if network.Internal {
+			unit.Add(NetworkGroup, KeyInternal, "true")
+		}
+		if network.EnableIPv6 {
+			unit.Add(NetworkGroup, KeyIPv6, "true")
+		}
+		if network.IPAM != nil {
+			if err := checkComposeExtensions(fmt.Sprintf("network %s: ipam", name), network.IPAM.Extensions); err != nil {
+				return nil, err
+			}
+			for _, config := range network.IPAM.Config {
+				if err := checkComposeExtensions(fmt.Sprintf("network %s: ipam config", name), config.Extensions); err != nil {
+					return nil, err
+				}
+				if config.Subnet == "" {
+					return nil, fmt.Errorf("network %s: ipam config without subnet", name)
+				}
+				unit.Add(NetworkGroup, KeySubnet, config.Subnet)
+				if config.Gateway != "" {
+					unit.Add(NetworkGroup, KeyGateway, config.Gateway)
+				}
+				if config.IPRange != "" {
+					unit.Add(NetworkGroup, KeyIPRange, config.IPRange)
+				}
+			}
+		}
+		for _, key := range sortedKeys(network.DriverOpts) {
+			unit.AddCmdline(NetworkGroup, KeyPodmanArgs, []string{"--opt", key + "=" + network.DriverOpts[key]})
+		}
+		for _, label := range network.Labels {
+			unit.AddEscaped(NetworkGroup, KeyLabel, label)
+		}
+		c.networkRefs[name] = fileName
+		units = append(units, unit)
+	}
+	return units, nil
+}
+
+// convertPod generates the pod running all services.  It only ties the
+// lifecycle of the containers together, the pod does not share any namespace
+// so every service has its own network namespace on the networks it is
+// attached to, like with docker compose.
+func (c *composeConverter) convertPod() *parser.UnitFile {
+	pod := c.newUnit(c.podFileName, PodGroup)
+	// The pod stands for the .compose unit
+	for _, group := range []string{UnitGroup, ServiceGroup, InstallGroup, ScheduleGroup} {
+		c.copyGroup(pod, group)
+	}
+	pod.Add(PodGroup, KeyPodName, c.name)
+	// The infra container is kept, it is the main process of the
+	// service of the pod
+	pod.Add(PodGroup, KeyNetwork, "none")
+	pod.AddCmdline(PodGroup, KeyPodmanArgs, []string{"--infra=true", "--share=none"})
+	return pod
+}
+
+// convertServiceNetworks attaches the container of the service to its
+// networks, the service can be reached with its name and its aliases on them.
+func (c *composeConverter) convertServiceNetworks(unit *parser.UnitFile, what, serviceName string, service *composeService) error {
+	networks := slices.Clone(service.Networks.Names)
+	if len(networks) == 0 {
+		networks = []string{"default"}
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		ref, ok := c.networkRefs[network]
+		if !ok {
+			return fmt.Errorf("%s: undefined network %s", what, network)
+		}
+		unit.Add(ContainerGroup, KeyNetwork, ref)
+	}
+	aliases := append([]string{serviceName}, service.Networks.Aliases...)
+	slices.Sort(aliases)
+	for _, alias := range slices.Compact(aliases) {
+		unit.Add(ContainerGroup, KeyNetworkAlias, alias)
+	}
+
+	for _, port := range service.Ports {
+		unit.Add(ContainerGroup, KeyPublishPort, string(port))
+	}
+	for _, dns := range service.DNS {
+		unit.Add(ContainerGroup, KeyDNS, dns)
+	}
+	for _, host := range service.ExtraHosts {
+		// Both host:ip and host=ip are valid in compose files
+		unit.Add(ContainerGroup, KeyAddHost, strings.Replace(host, "=", ":", 1))
+	}
+	return nil
+}
+
+func (c *composeConverter) serviceFileName(serviceName string) string {
+	return c.name + "-" + serviceName + ".container"
+}
+
+func (c *composeConverter) convertServices(serviceNames []string) ([]*parser.UnitFile, error) {
+	units := make(map[string]*parser.UnitFile, len(serviceNames))
+	healthyDependencies := make(map[string]bool)
+	completedDependencies := make(map[string]bool)
+
+	for _, serviceName := range serviceNames {
+		service := c.project.Services[serviceName]
+		what := "service " + serviceName
+		if err := checkComposeExtensions(what, service.Extensions); err != nil {
+			return nil, err
+		}
+		if service.Image == "" {
+			return nil, fmt.Errorf("%s: no image specified", what)
+		}
+
+		unit := c.newUnit(c.serviceFileName(serviceName), ContainerGroup)
+		unit.Add(ContainerGroup, KeyImage, service.Image)
+		unit.Add(ContainerGroup, KeyPod, c.podFileName)
+
+		containerName := service.ContainerName
+		if containerName == "" {
+			containerName = c.name + "-" + serviceName
+		}
+		unit.Add(ContainerGroup, KeyContainerName, containerName)
+
+		if len(service.Entrypoint) > 0 {
+			entrypoint, err := json.Marshal([]string(service.Entrypoint))
+			if err != nil {
+				return nil, err
+			}
+			unit.Add(ContainerGroup, KeyEntrypoint, string(entrypoint))
+		}
+		if len(service.Command) > 0 {
+			unit.AddCmdline(ContainerGroup, KeyExec, service.Command)
+		}
+
+		for _, env := range service.Environment {
+			unit.AddEscaped(ContainerGroup, KeyEnvironment, env)
+		}
+		for _, envFile := range service.EnvFile {
+			envFile, err := c.hostPath(envFile)
+			if err != nil {
+				return nil, fmt.Errorf("%s: env_file: %w", what, err)
+			}
+			unit.AddCmdline(ContainerGroup, KeyEnvironmentFile, []string{envFile})
+		}
+		for _, label := range service.Labels {
+			unit.AddEscaped(ContainerGroup, KeyLabel, label)
+		}
+
+		if err := c.convertServiceNetworks(unit, what, serviceName, service); err != nil {
+			return nil, err
+		}
+		if err := c.convertServiceVolumes(unit, what, service); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case service.Restart == "" || service.Restart == "no":
+		case service.Restart == "always" || service.Restart == "unless-stopped":
+			unit.Add(ServiceGroup, "Restart", "always")
+		case service.Restart == "on-failure" || strings.HasPrefix(service.Restart, "on-failure:"):
+			unit.Add(ServiceGroup, "Restart", "on-failure")
+		default:
+			return nil, fmt.Errorf("%s: unsupported restart policy %q", what, service.Restart)
+		}
+
+		if service.User != "" {
+			unit.Add(ContainerGroup, KeyUser, service.User)
+		}
+		if service.WorkingDir != "" {
+			unit.Add(ContainerGroup, KeyWorkingDir, service.WorkingDir)
+		}
+		if err := convertComposeHealthcheck(unit, what, service.Healthcheck); err != nil {
+			return nil, err
+		}
+		for _, capability := range service.CapAdd {
+			unit.Add(ContainerGroup, KeyAddCapability, capability)
+		}
+		for _, capability := range service.CapDrop {
+			unit.Add(ContainerGroup, KeyDropCapability, capability)
+		}
+		for _, device := range service.Devices {
+			unit.Add(ContainerGroup, KeyAddDevice, device)
+		}
+		if service.Init {
+			unit.Add(ContainerGroup, KeyRunInit, "true")
+		}
+		if service.Privileged {
+			unit.Add(ContainerGroup, KeyPodmanArgs, "--privileged")
+		}
+		if service.ReadOnly {
+			unit.Add(ContainerGroup, KeyReadOnly, "true")
+		}
+		if service.StopSignal != "" {
+			unit.Add(ContainerGroup, KeyStopSignal, service.StopSignal)
+		}
+		if service.StopGracePeriod != "" {
+			period, err := time.ParseDuration(service.StopGracePeriod)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid stop_grace_period: %w", what, err)
+			}
+			seconds := int64((period + time.Second - 1) / time.Second)
+			unit.Add(ContainerGroup, KeyStopTimeout, strconv.FormatInt(seconds, 10))
+		}
+
+		for _, dependencyName := range sortedKeys(service.DependsOn) {
+			dependency := service.DependsOn[dependencyName]
+			if _, ok := c.project.Services[dependencyName]; !ok {
+				return nil, fmt.Errorf("%s: depends on undefined service %s", what, dependencyName)
+			}
+			dependencyFileName := c.serviceFileName(dependencyName)
+			if dependency.Required {
+				unit.Add(UnitGroup, "Requires", dependencyFileName)
+			} else {
+				unit.Add(UnitGroup, "Wants", dependencyFileName)
+			}
+			unit.Add(UnitGroup, "After", dependencyFileName)
+
+			switch dependency.Condition {
+			case "service_healthy":
+				healthyDependencies[dependencyName] = true
+			case "service_completed_successfully":
+				completedDependencies[dependencyName] = true
+			}
+		}
+
+		units[serviceName] = unit
+	}
+
+	// Delay the start of the services depending on these until they are
+	// healthy or exited
+	for _, serviceName := range sortedKeys(healthyDependencies) {
+		healthcheck := c.project.Services[serviceName].Healthcheck
+		if healthcheck == nil || healthcheck.Disable || len(healthcheck.Test) == 0 {
+			return nil, fmt.Errorf("service %s: other services depend on it being healthy, but it has no healthcheck", serviceName)
+		}
+		units[serviceName].Add(ContainerGroup, KeyNotify, "healthy")
+	}
+	for _, serviceName := range sortedKeys(completedDependencies) {
+		units[serviceName].Add(ServiceGroup, "Type", "oneshot")
+	}
+
+	result := make([]*parser.UnitFile, 0, len(units))
+	for _, serviceName := range serviceNames {
+		result = append(result, units[serviceName])
+	}
+	return result, nil
+}
+
+// hostPath returns the absolute path of a path in the compose file
+func (c *composeConverter) hostPath(path string) (string, error) {
+	if strings.HasPrefix(path, "~") {
+		return "", fmt.Errorf("paths relative to the home directory are not supported: %s", path)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.dir, path)
+	}
+	return path, nil
+}
+
+func (c *composeConverter) convertServiceVolumes(unit *parser.UnitFile, what string, service *composeService) error {
+	for _, volume := range service.Volumes {
+		options := volume.Options
+		if volume.ReadOnly {
+			options = "ro"
+		}
+		if options != "" {
+			options = ":" + options
+		}
+
+		switch volume.Type {
+		case "tmpfs":
+			unit.Add(ContainerGroup, KeyTmpfs, volume.Target)
+		case "bind":
+			source, err := c.hostPath(volume.Source)
+			if err != nil {
+				return fmt.Errorf("%s: %w", what, err)
+			}
+			unit.Add(ContainerGroup, KeyVolume, source+":"+volume.Target+options)
+		default:
+			if volume.Source == "" {
+				// Anonymous volume
+				unit.Add(ContainerGroup, KeyVolume, volume.Target)
+				continue
+			}
+			ref, ok := c.volumeRefs[volume.Source]
+			if !ok {
+				return fmt.Errorf("%s: undefined volume %s", what, volume.Source)
+			}
+			unit.Add(ContainerGroup, KeyVolume, ref+":"+volume.Target+options)
+		}
+	}
+	for _, tmpfs := range service.Tmpfs {
+		unit.Add(ContainerGroup, KeyTmpfs, tmpfs)
+	}
+	return nil
+}
+
+func convertComposeHealthcheck(unit *parser.UnitFile, what string, healthcheck *composeHealthcheck) error {
+	if healthcheck == nil {
+		return nil
+	}
+	if err := checkComposeExtensions(what+": healthcheck", healthcheck.Extensions); err != nil {
+		return err
+	}
+	test := healthcheck.Test
+	if healthcheck.Disable || (len(test) > 0 && test[0] == "NONE") {
+		unit.Add(ContainerGroup, KeyHealthCmd, "none")
+		return nil
+	}
+
+	switch {
+	case len(test) == 0:
+		// Use the healthcheck of the image
+	case test[0] == "CMD":
+		cmd, err := json.Marshal(test[1:])
+		if err != nil {
+			return err
+		}
+		unit.Add(ContainerGroup, KeyHealthCmd, string(cmd))
+	case test[0] == "CMD-SHELL":
+		unit.Add(ContainerGroup, KeyHealthCmd, strings.Join(test[1:], " "))
+	case len(test) == 1:
+		// A plain string is run by a shell
+		unit.Add(ContainerGroup, KeyHealthCmd, test[0])
+	default:
+		return fmt.Errorf("%s: healthcheck test must start with CMD, CMD-SHELL or NONE", what)
+	}
+
+	if healthcheck.Interval != "" {
+		unit.Add(ContainerGroup, KeyHealthInterval, healthcheck.Interval)
+	}
+	if healthcheck.Timeout != "" {
+		unit.Add(ContainerGroup, KeyHealthTimeout, healthcheck.Timeout)
+	}
+	if healthcheck.StartPeriod != "" {
+		unit.Add(ContainerGroup, KeyHealthStartPeriod, healthcheck.StartPeriod)
+	}
+	if healthcheck.Retries != nil {
+		unit.Add(ContainerGroup, KeyHealthRetries, strconv.FormatUint(uint64(*healthcheck.Retries), 10))
+	}
+	return nil
+}
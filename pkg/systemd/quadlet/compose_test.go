@@ -0,0 +1,239 @@
+package quadlet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/podman/v6/pkg/systemd/parser"
+)
+
+func convertTestCompose(t *testing.T, unitData, yamlData string) (map[string]*parser.UnitFile, error) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(yamlData), 0o644))
+
+	unit := parser.NewUnitFile()
+	require.NoError(t, unit.Parse(unitData))
+	unit.Filename = "app.compose"
+	unit.Path = filepath.Join(dir, unit.Filename)
+
+	units, err := ConvertCompose(unit)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*parser.UnitFile, len(units))
+	for _, u := range units {
+		byName[u.Filename] = u
+	}
+	// The pod comes first
+	assert.Equal(t, PodGroup, units[0].ListGroups()[0])
+	return byName, nil
+}
+
+const testComposeUnit = "[Compose]\nYaml=compose.yaml\n"
+
+func TestConvertCompose(t *testing.T) {
+	units, err := convertTestCompose(t, "[Unit]\nDescription=App\n"+testComposeUnit, `
+services:
+  web:
+    image: quay.io/example/web
+    command: serve --port "8080"
+    ports:
+      - "8080:8080"
+      - target: 53
+        host_ip: 127.0.0.1
+        published: "5353"
+        protocol: udp
+    environment:
+      - A=1
+    labels:
+      b: "2"
+    volumes:
+      - ./html:/srv:ro
+      - data:/data
+      - /cache
+      - type: tmpfs
+        target: /tmp
+    restart: on-failure:3
+    depends_on:
+      db:
+        condition: service_healthy
+      init:
+        condition: service_completed_successfully
+        required: false
+  db:
+    image: quay.io/example/db
+    container_name: database
+    healthcheck:
+      test: pg_isready
+      interval: 5s
+    stop_grace_period: 1500ms
+    networks:
+      - back
+  init:
+    image: quay.io/example/init
+volumes:
+  data:
+    driver_opts:
+      type: tmpfs
+  shared:
+    external: true
+networks:
+  back:
+    internal: true
+`)
+	require.NoError(t, err)
+	assert.Len(t, units, 7)
+
+	pod := units["app.pod"]
+	require.NotNil(t, pod)
+	assert.Equal(t, []string{"App"}, pod.LookupAll(UnitGroup, "Description"))
+	assert.Equal(t, []string{"app"}, pod.LookupAll(PodGroup, KeyPodName))
+	// The services do not share the network namespace of the pod
+	assert.Equal(t, []string{"none"}, pod.LookupAll(PodGroup, KeyNetwork))
+	assert.Equal(t, []string{"--infra=true", "--share=none"}, pod.LookupAllArgs(PodGroup, KeyPodmanArgs))
+	assert.Empty(t, pod.LookupAll(PodGroup, KeyPublishPort))
+	assert.Empty(t, pod.LookupAll(PodGroup, KeyNetworkAlias))
+
+	volume := units["app-data.volume"]
+	require.NotNil(t, volume)
+	assert.Equal(t, []string{"app_data"}, volume.LookupAll(VolumeGroup, KeyVolumeName))
+	assert.Equal(t, []string{"--opt", "type=tmpfs"}, volume.LookupAllArgs(VolumeGroup, KeyPodmanArgs))
+	assert.Nil(t, units["app-shared.volume"])
+
+	network := units["app-back.network"]
+	require.NotNil(t, network)
+	assert.Equal(t, []string{"app_back"}, network.LookupAll(NetworkGroup, KeyNetworkName))
+	assert.Equal(t, []string{"true"}, network.LookupAll(NetworkGroup, KeyInternal))
+
+	web := units["app-web.container"]
+	require.NotNil(t, web)
+	assert.Equal(t, []string{"quay.io/example/web"}, web.LookupAll(ContainerGroup, KeyImage))
+	assert.Equal(t, []string{"app.pod"}, web.LookupAll(ContainerGroup, KeyPod))
+	assert.Equal(t, []string{"app-web"}, web.LookupAll(ContainerGroup, KeyContainerName))
+	assert.Equal(t, []string{"app-default.network"}, web.LookupAll(ContainerGroup, KeyNetwork))
+	assert.Equal(t, []string{"web"}, web.LookupAll(ContainerGroup, KeyNetworkAlias))
+	assert.Equal(t, []string{"8080:8080", "127.0.0.1:5353:53/udp"}, web.LookupAll(ContainerGroup, KeyPublishPort))
+	assert.Equal(t, []string{"serve", "--port", "8080"}, web.LookupAllArgs(ContainerGroup, KeyExec))
+	assert.Equal(t, []string{"A=1"}, web.LookupAll(ContainerGroup, KeyEnvironment))
+	assert.Equal(t, []string{"b=2"}, web.LookupAll(ContainerGroup, KeyLabel))
+	dir := filepath.Dir(web.Path)
+	assert.Equal(t, []string{filepath.Join(dir, "html") + ":/srv:ro", "app-data.volume:/data", "/cache"}, web.LookupAll(ContainerGroup, KeyVolume))
+	assert.Equal(t, []string{"/tmp"}, web.LookupAll(ContainerGroup, KeyTmpfs))
+	assert.Equal(t, []string{"on-failure"}, web.LookupAll(ServiceGroup, "Restart"))
+	assert.Equal(t, []string{"app-db.container", "app-init.container"}, web.LookupAll(UnitGroup, "After"))
+	assert.Equal(t, []string{"app-db.container"}, web.LookupAll(UnitGroup, "Requires"))
+	assert.Equal(t, []string{"app-init.container"}, web.LookupAll(UnitGroup, "Wants"))
+
+	db := units["app-db.container"]
+	require.NotNil(t, db)
+	assert.Equal(t, []string{"database"}, db.LookupAll(ContainerGroup, KeyContainerName))
+	assert.Equal(t, []string{"app-back.network"}, db.LookupAll(ContainerGroup, KeyNetwork))
+	assert.Equal(t, []string{"db"}, db.LookupAll(ContainerGroup, KeyNetworkAlias))
+	assert.Empty(t, db.LookupAll(ContainerGroup, KeyPublishPort))
+	assert.Equal(t, []string{"pg_isready"}, db.LookupAll(ContainerGroup, KeyHealthCmd))
+	assert.Equal(t, []string{"5s"}, db.LookupAll(ContainerGroup, KeyHealthInterval))
+	assert.Equal(t, []string{"2"}, db.LookupAll(ContainerGroup, KeyStopTimeout))
+	assert.Equal(t, []string{"healthy"}, db.LookupAll(ContainerGroup, KeyNotify))
+
+	initUnit := units["app-init.container"]
+	require.NotNil(t, initUnit)
+	assert.Equal(t, []string{"oneshot"}, initUnit.LookupAll(ServiceGroup, "Type"))
+}
+
+func TestConvertComposeProjectName(t *testing.T) {
+	units, err := convertTestCompose(t, testComposeUnit+"ProjectName=other\n", `
+services:
+  web:
+    image: quay.io/example/web
+    networks: [front]
+networks:
+  front:
+    external: true
+    name: shared
+`)
+	require.NoError(t, err)
+	// No default network is needed
+	assert.Len(t, units, 2)
+	assert.Equal(t, []string{"other"}, units["other.pod"].LookupAll(PodGroup, KeyPodName))
+	assert.Equal(t, []string{"shared"}, units["other-web.container"].LookupAll(ContainerGroup, KeyNetwork))
+	assert.Equal(t, []string{"other.pod"}, units["other-web.container"].LookupAll(ContainerGroup, KeyPod))
+}
+
+func TestConvertComposeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		unit string
+		yaml string
+		err  string
+	}{
+		{
+			name: "no yaml",
+			unit: "[Compose]\n",
+			err:  "no Yaml key specified",
+		},
+		{
+			name: "invalid project name",
+			unit: testComposeUnit + "ProjectName=My App\n",
+			err:  `invalid project name "My App", use the ProjectName key to set a valid one`,
+		},
+		{
+			name: "no services",
+			yaml: "volumes:\n  data:\n",
+			err:  "no services defined",
+		},
+		{
+			name: "no image",
+			yaml: "services:\n  web:\n    command: serve\n",
+			err:  "service web: no image specified",
+		},
+		{
+			name: "unsupported key",
+			yaml: "services:\n  web:\n    image: web\n    build: .\n    x-ignored: true\n",
+			err:  "service web: unsupported key 'build'",
+		},
+		{
+			name: "undefined volume",
+			yaml: "services:\n  web:\n    image: web\n    volumes: [data:/data]\n",
+			err:  "service web: undefined volume data",
+		},
+		{
+			name: "undefined network",
+			yaml: "services:\n  web:\n    image: web\n    networks: [back]\n",
+			err:  "service web: undefined network back",
+		},
+		{
+			name: "undefined dependency",
+			yaml: "services:\n  web:\n    image: web\n    depends_on: [db]\n",
+			err:  "service web: depends on undefined service db",
+		},
+		{
+			name: "healthy dependency without healthcheck",
+			yaml: "services:\n  web:\n    image: web\n    depends_on:\n      db:\n        condition: service_healthy\n  db:\n    image: db\n",
+			err:  "service db: other services depend on it being healthy, but it has no healthcheck",
+		},
+		{
+			name: "unsupported restart policy",
+			yaml: "services:\n  web:\n    image: web\n    restart: sometimes\n",
+			err:  `service web: unsupported restart policy "sometimes"`,
+		},
+		{
+			name: "home directory",
+			yaml: "services:\n  web:\n    image: web\n    volumes: [~/html:/srv]\n",
+			err:  "service web: paths relative to the home directory are not supported: ~/html",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unit := test.unit
+			if unit == "" {
+				unit = testComposeUnit
+			}
+			_, err := convertTestCompose(t, unit, test.yaml)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package quadlet
+
+import (
+	"maps"
+	"slices"
+)
+
+// GenerateUnits converts the Quadlet units in unitDirs like the generator
+// does and returns the contents of the generated systemd units by name.
+// Units which fail to convert are skipped and drop-in files are not applied,
+// so the result is only meant to be compared with another result of
+// GenerateUnits.
+func GenerateUnits(unitDirs []string, isUser bool) map[string]string {
+	units, composeAliases, _ := ExpandComposeUnits(loadInstalledUnits(unitDirs))
+	SortUnits(units)
+	unitsInfoMap, _ := GenerateUnitsInfoMap(units)
+	for composeFile, podFile := range composeAliases {
+		if info, ok := unitsInfoMap[podFile]; ok {
+			unitsInfoMap[composeFile] = info
+		}
+	}
+
+	generated := make(map[string]string)
+	for _, unit := range units {
+		service, _, err := ConvertUnit(unit, unitsInfoMap, isUser)
+		if err != nil {
+			continue
+		}
+		timer, err := ConvertSchedule(unit)
+		if err != nil {
+			continue
+		}
+		if contents, err := service.ToString(); err == nil {
+			generated[service.Filename] = contents
+		}
+		if timer != nil {
+			if contents, err := timer.ToString(); err == nil {
+				generated[timer.Filename] = contents
+			}
+		}
+	}
+	return generated
+}
+
+// ChangedUnits compares two results of GenerateUnits and returns the names
+// of the units whose contents changed or which were added, and the names of
+// the units which were removed, both sorted.
+func ChangedUnits(before, after map[string]string) (changed []string, removed []string) {
+	for _, name := range slices.Sorted(maps.Keys(after)) {
+		if contents, ok := before[name]; !ok || contents != after[name] {
+			changed = append(changed, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(before)) {
+		if _, ok := after[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	return changed, removed
+}
@@ -0,0 +1,41 @@
+package quadlet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateUnitsChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeTestUnits(t, dir, map[string]string{
+		"web.container":    "[Container]\nImage=quay.io/example\nNetwork=app.network\n",
+		"db.container":     "[Container]\nImage=quay.io/example\n",
+		"app.network":      "[Network]\n",
+		"backup.container": "[Container]\nImage=quay.io/example\n[Schedule]\nOnCalendar=daily\n",
+		"broken.network":   "[Network]\nFoo=bar\n",
+	})
+
+	before := GenerateUnits([]string{dir}, false)
+	assert.Contains(t, before, "web.service")
+	assert.Contains(t, before, "app-network.service")
+	assert.Contains(t, before, "backup.timer")
+	assert.NotContains(t, before, "broken-network.service")
+
+	changed, removed := ChangedUnits(before, GenerateUnits([]string{dir}, false))
+	assert.Empty(t, changed)
+	assert.Empty(t, removed)
+
+	writeTestUnits(t, dir, map[string]string{
+		"db.container":  "[Container]\nImage=quay.io/example:2\n",
+		"new.container": "[Container]\nImage=quay.io/example\n",
+	})
+	require.NoError(t, os.Remove(filepath.Join(dir, "backup.container")))
+
+	changed, removed = ChangedUnits(before, GenerateUnits([]string{dir}, false))
+	assert.Equal(t, []string{"db.service", "new.service"}, changed)
+	assert.Equal(t, []string{"backup.service", "backup.timer"}, removed)
+}
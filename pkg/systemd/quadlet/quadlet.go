@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"go.podman.io/podman/v6/pkg/specgenutilexternal"
@@ -28,6 +29,7 @@ const (
 
 	// Names of commonly used systemd/quadlet group names
 	ArtifactGroup   = "Artifact"
+	ComposeGroup    = "Compose"
 	ContainerGroup  = "Container"
 	InstallGroup    = "Install"
 	KubeGroup       = "Kube"
@@ -39,7 +41,10 @@ const (
 	ImageGroup      = "Image"
 	BuildGroup      = "Build"
 	QuadletGroup    = "Quadlet"
+	ScheduleGroup   = "Schedule"
+	TimerGroup      = "Timer"
 	XArtifactGroup  = "X-Artifact"
+	XComposeGroup   = "X-Compose"
 	XContainerGroup = "X-Container"
 	XKubeGroup      = "X-Kube"
 	XNetworkGroup   = "X-Network"
@@ -48,6 +53,7 @@ const (
 	XImageGroup     = "X-Image"
 	XBuildGroup     = "X-Build"
 	XQuadletGroup   = "X-Quadlet"
+	XScheduleGroup  = "X-Schedule"
 )
 
 // Systemd Unit file keys
@@ -146,6 +152,7 @@ const (
 	KeyPodmanArgs            = "PodmanArgs"
 	KeyPodName               = "PodName"
 	KeyPolicy                = "Policy"
+	KeyProjectName           = "ProjectName"
 	KeyPublishPort           = "PublishPort"
 	KeyPull                  = "Pull"
 	KeyQuiet                 = "Quiet"
@@ -389,6 +396,14 @@ var (
 				KeyPodmanArgs:           true,
 			},
 		},
+		ComposeGroup: {
+			GroupName:  ComposeGroup,
+			XGroupName: XComposeGroup,
+			SupportedKeys: map[string]bool{
+				KeyProjectName: true,
+				KeyYaml:        true,
+			},
+		},
 		KubeGroup: {
 			GroupName:  KubeGroup,
 			XGroupName: XKubeGroup,
@@ -532,6 +547,32 @@ var (
 	supportedQuadletKeys = map[string]bool{
 		KeyDefaultDependencies: true,
 	}
+
+	// Supported keys in the "Schedule" group, they are passed on to the
+	// "Timer" group of the generated timer unit as-is
+	supportedScheduleKeys = map[string]bool{
+		"AccuracySec":        true,
+		"FixedRandomDelay":   true,
+		"OnActiveSec":        true,
+		"OnBootSec":          true,
+		"OnCalendar":         true,
+		"OnClockChange":      true,
+		"OnStartupSec":       true,
+		"OnTimezoneChange":   true,
+		"OnUnitActiveSec":    true,
+		"OnUnitInactiveSec":  true,
+		"Persistent":         true,
+		"RandomizedDelaySec": true,
+		"RemainAfterElapse":  true,
+		"WakeSystem":         true,
+	}
+
+	// Groups of the Quadlet units which may be started by a timer
+	schedulableGroups = map[string]bool{
+		ContainerGroup: true,
+		KubeGroup:      true,
+		PodGroup:       true,
+	}
 )
 
 func (u *UnitInfo) ServiceFileName() string {
@@ -557,24 +598,34 @@ func isPortRange(port string) bool {
 	return validPortRange.MatchString(port)
 }
 
+// UnsupportedKeyError is returned when a unit uses a key not supported in a group
+type UnsupportedKeyError struct {
+	Group string
+	Key   string
+	Path  string
+}
+
+func (e *UnsupportedKeyError) Error() string {
+	return fmt.Sprintf("unsupported key '%s' in group '%s' in %s", e.Key, e.Group, e.Path)
+}
+
 func checkForUnknownKeysInSpecificGroup(unit *parser.UnitFile, groupName string, supportedKeys map[string]bool) error {
+	var errs []error
 	keys := unit.ListKeys(groupName)
 	for _, key := range keys {
 		if !supportedKeys[key] {
-			return fmt.Errorf("unsupported key '%s' in group '%s' in %s", key, groupName, unit.Path)
+			errs = append(errs, &UnsupportedKeyError{Group: groupName, Key: key, Path: unit.Path})
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func checkForUnknownKeys(unit *parser.UnitFile, groupName string, supportedKeys map[string]bool) error {
-	err := checkForUnknownKeysInSpecificGroup(unit, groupName, supportedKeys)
-	if err == nil {
-		return checkForUnknownKeysInSpecificGroup(unit, QuadletGroup, supportedQuadletKeys)
-	}
-
-	return err
+	return errors.Join(
+		checkForUnknownKeysInSpecificGroup(unit, groupName, supportedKeys),
+		checkForUnknownKeysInSpecificGroup(unit, QuadletGroup, supportedQuadletKeys),
+	)
 }
 
 func usernsOpts(kind string, opts []string) string {
@@ -727,6 +778,11 @@ func ConvertContainer(container *parser.UnitFile, unitsInfoMap map[string]*UnitI
 		return nil, warnings, err
 	}
 
+	// Containers started by a timer run until they exit by default
+	if container.HasGroup(ScheduleGroup) && !service.HasKey(ServiceGroup, "Type") {
+		service.Set(ServiceGroup, "Type", "oneshot")
+	}
+
 	serviceType, ok := service.Lookup(ServiceGroup, "Type")
 	if ok && serviceType != "notify" && serviceType != "oneshot" {
 		return nil, warnings, fmt.Errorf("invalid service Type '%s'", serviceType)
@@ -1258,6 +1314,12 @@ func ConvertKube(kube *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUse
 	// Set PODMAN_SYSTEMD_UNIT so that podman auto-update can restart the service.
 	service.Add(ServiceGroup, "Environment", "PODMAN_SYSTEMD_UNIT=%n")
 
+	// Kube units started by a timer run until the pods exit by default
+	scheduled := kube.HasGroup(ScheduleGroup)
+	if scheduled && !service.HasKey(ServiceGroup, "Type") {
+		service.Set(ServiceGroup, "Type", "oneshot")
+	}
+
 	// Allow users to set the Service Type to oneshot to allow resources only kube yaml
 	serviceType, ok := service.Lookup(ServiceGroup, "Type")
 	if ok && serviceType != "notify" && serviceType != "oneshot" {
@@ -1286,6 +1348,12 @@ func ConvertKube(kube *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUse
 		"--service-container=true",
 	)
 
+	if scheduled && serviceType == "oneshot" {
+		// Wait for the pods to exit, ExecStopPost would remove them
+		// right away otherwise
+		execStart.add("--wait")
+	}
+
 	if ecp, ok := kube.Lookup(KubeGroup, KeyExitCodePropagation); ok && len(ecp) > 0 {
 		execStart.addf("--service-exit-code-propagation=%s", ecp)
 	}
@@ -1540,11 +1608,111 @@ func GetUnitServiceName(unit *parser.UnitFile) (string, error) {
 		return GetArtifactServiceName(unit), nil
 	case strings.HasSuffix(unit.Filename, ".pod"):
 		return GetPodServiceName(unit), nil
+	case strings.HasSuffix(unit.Filename, ".compose"):
+		return GetComposeServiceName(unit), nil
 	default:
 		return "", fmt.Errorf("unsupported file type %q", unit.Filename)
 	}
 }
 
+// SortUnits sorts units according to potential inter-dependencies, with Volume
+// and Network units taking precedence over all others.
+func SortUnits(units []*parser.UnitFile) {
+	sort.SliceStable(units, func(i, j int) bool {
+		getOrder := func(i int) int {
+			ext := filepath.Ext(units[i].Filename)
+			order, ok := SupportedExtensions[ext]
+			if !ok {
+				return 0
+			}
+			return order
+		}
+		return getOrder(i) < getOrder(j)
+	})
+}
+
+// GenerateUnitsInfoMap generates the information needed to link the units to
+// each other, e.g. to allow containers to link to their pods and add
+// themselves to the pod's containers list.  Units of unsupported types are
+// skipped and returned as an error.
+func GenerateUnitsInfoMap(units []*parser.UnitFile) (map[string]*UnitInfo, error) {
+	var errs []error
+	unitsInfoMap := make(map[string]*UnitInfo)
+	for _, unit := range units {
+		var serviceName string
+		var containers []string
+		var resourceName string
+		var err error
+
+		serviceName, err = GetUnitServiceName(unit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("obtaining service name: %w", err))
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(unit.Filename, ".container"):
+			// Prefill resourceNames for .container files. This solves network reusing.
+			resourceName = GetContainerResourceName(unit)
+		case strings.HasSuffix(unit.Filename, ".build"):
+			// Prefill resourceNames for .build files. This is significantly less complex than
+			// pre-computing all resourceNames for all Quadlet types (which is rather complex for a few
+			// types), but still breaks the dependency cycle between .volume and .build ([Volume] can
+			// have Image=some.build, and [Build] can have Volume=some.volume:/some-volume)
+			resourceName = GetBuiltImageName(unit)
+		case strings.HasSuffix(unit.Filename, ".artifact"):
+			serviceName = GetArtifactServiceName(unit)
+		case strings.HasSuffix(unit.Filename, ".pod"):
+			containers = make([]string, 0)
+			// Prefill resourceNames for .pod files.
+			// This is requires for referencing the pod from .container files
+			resourceName = GetPodResourceName(unit)
+		case strings.HasSuffix(unit.Filename, ".volume"), strings.HasSuffix(unit.Filename, ".kube"), strings.HasSuffix(unit.Filename, ".network"), strings.HasSuffix(unit.Filename, ".image"):
+			// Do nothing for these case.
+		default:
+			errs = append(errs, fmt.Errorf("unsupported file type %q", unit.Filename))
+			continue
+		}
+
+		unitsInfoMap[unit.Filename] = &UnitInfo{
+			ServiceName:       serviceName,
+			ContainersToStart: containers,
+			ResourceName:      resourceName,
+		}
+	}
+
+	return unitsInfoMap, errors.Join(errs...)
+}
+
+// ConvertUnit converts a Quadlet unit to a systemd service with the converter
+// of its type.  The units it depends on must have been converted before, see
+// SortUnits.
+func ConvertUnit(unit *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUser bool) (*parser.UnitFile, error, error) {
+	switch {
+	case strings.HasSuffix(unit.Filename, ".container"):
+		return ConvertContainer(unit, unitsInfoMap, isUser)
+	case strings.HasSuffix(unit.Filename, ".volume"):
+		return ConvertVolume(unit, unitsInfoMap, isUser)
+	case strings.HasSuffix(unit.Filename, ".kube"):
+		service, err := ConvertKube(unit, unitsInfoMap, isUser)
+		return service, nil, err
+	case strings.HasSuffix(unit.Filename, ".network"):
+		return ConvertNetwork(unit, unitsInfoMap, isUser)
+	case strings.HasSuffix(unit.Filename, ".image"):
+		service, err := ConvertImage(unit, unitsInfoMap, isUser)
+		return service, nil, err
+	case strings.HasSuffix(unit.Filename, ".build"):
+		return ConvertBuild(unit, unitsInfoMap, isUser)
+	case strings.HasSuffix(unit.Filename, ".artifact"):
+		service, err := ConvertArtifact(unit, unitsInfoMap, isUser)
+		return service, nil, err
+	case strings.HasSuffix(unit.Filename, ".pod"):
+		return ConvertPod(unit, unitsInfoMap, isUser)
+	default:
+		return nil, nil, fmt.Errorf("unsupported file type %q", unit.Filename)
+	}
+}
+
 func GetContainerServiceName(podUnit *parser.UnitFile) string {
 	return getServiceName(podUnit, ContainerGroup, "")
 }
@@ -1711,6 +1879,59 @@ func ConvertPod(podUnit *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isU
 	return service, warnings, nil
 }
 
+// ConvertSchedule converts the Schedule group of a quadlet unit file to a
+// systemd timer unit which starts the service generated from the file.
+// The Install group of the file is moved to the timer as well.  It returns
+// nil if the file has no Schedule group.
+func ConvertSchedule(unit *parser.UnitFile) (*parser.UnitFile, error) {
+	if !unit.HasGroup(ScheduleGroup) {
+		return nil, nil
+	}
+
+	if err := checkForUnknownKeysInSpecificGroup(unit, ScheduleGroup, supportedScheduleKeys); err != nil {
+		return nil, err
+	}
+
+	serviceName, err := GetUnitServiceName(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	timer := parser.NewUnitFile()
+	// Use the name of the service, so the timer starts it without an
+	// explicit Unit key, this keeps working for templates too
+	timer.Filename = serviceName + ".timer"
+
+	if description, ok := unit.LookupLastRaw(UnitGroup, "Description"); ok {
+		timer.Set(UnitGroup, "Description", description)
+	}
+	if unit.Path != "" {
+		timer.Add(UnitGroup, "SourcePath", unit.Path)
+	}
+
+	// systemd refuses to load timers without any On* key
+	hasTrigger := false
+	for _, key := range unit.ListKeys(ScheduleGroup) {
+		if strings.HasPrefix(key, "On") {
+			hasTrigger = true
+		}
+		for _, value := range unit.LookupAllRaw(ScheduleGroup, key) {
+			timer.Add(TimerGroup, key, value)
+		}
+	}
+	if !hasTrigger {
+		return nil, fmt.Errorf("no OnCalendar or other On* key specified in group '%s'", ScheduleGroup)
+	}
+
+	for _, key := range unit.ListKeys(InstallGroup) {
+		for _, value := range unit.LookupAllRaw(InstallGroup, key) {
+			timer.Add(InstallGroup, key, value)
+		}
+	}
+
+	return timer, nil
+}
+
 func handleUser(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) error {
 	user, hasUser := unitFile.Lookup(groupName, KeyUser)
 	okUser := hasUser && len(user) > 0
@@ -2373,6 +2594,16 @@ func initServiceUnitFile(quadletUnitFile *parser.UnitFile, isUser bool, unitsInf
 		return nil, nil, err
 	}
 
+	scheduled := quadletUnitFile.HasGroup(ScheduleGroup)
+	if scheduled {
+		if !schedulableGroups[group] {
+			return nil, nil, fmt.Errorf("group '%s' is not supported in %s", ScheduleGroup, quadletUnitFile.Path)
+		}
+		if err := checkForUnknownKeysInSpecificGroup(quadletUnitFile, ScheduleGroup, supportedScheduleKeys); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	service := quadletUnitFile.Dup()
 	service.Filename = unitInfo.ServiceFileName()
 
@@ -2395,6 +2626,12 @@ func initServiceUnitFile(quadletUnitFile *parser.UnitFile, isUser bool, unitsInf
 	// Rename common quadlet group
 	service.RenameGroup(QuadletGroup, XQuadletGroup)
 
+	if scheduled {
+		service.RenameGroup(ScheduleGroup, XScheduleGroup)
+		// The service is started by the timer, which is installed instead
+		service.RemoveGroup(InstallGroup)
+	}
+
 	return service, unitInfo, nil
 }
 
@@ -11,4 +11,5 @@ var SupportedExtensions = map[string]int{
 	".image":     1,
 	".build":     3,
 	".pod":       5,
+	".compose":   0, // Expanded into the other units before processing
 }
@@ -2,15 +2,18 @@ package quadlet
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"os/user"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"go.podman.io/podman/v6/pkg/logiface"
+	"go.podman.io/podman/v6/pkg/systemd/parser"
 )
 
 // This returns whether a file has an extension recognized as a valid Quadlet unit type.
@@ -250,3 +253,81 @@ func getRootDirs(paths *searchPaths, userLevelFilter func(string, bool) bool) {
 	AppendSubPaths(paths, UnitDirAdmin, false, userLevelFilter)
 	AppendSubPaths(paths, UnitDirDistro, false, userLevelFilter)
 }
+
+// DropinError is returned by LoadUnitDropins for a drop-in file which cannot
+// be loaded
+type DropinError struct {
+	Path string
+	Err  error
+}
+
+func (e *DropinError) Error() string {
+	return fmt.Sprintf("error loading %q, %v", e.Path, e.Err)
+}
+
+func (e *DropinError) Unwrap() error {
+	return e.Err
+}
+
+// LoadUnitDropins merges the drop-in files of the unit found in sourcePaths
+// into it.  For each drop-in name, the first file found is used, and the
+// drop-in files are merged in alpha-numerical order of their names.  All
+// drop-in files which can be loaded are merged even if others cannot.
+func LoadUnitDropins(unit *parser.UnitFile, sourcePaths []string) error {
+	var errs []error
+
+	unitDropinPaths := unit.GetUnitDropinPaths()
+	dropinDirs := make([]string, 0, len(unitDropinPaths))
+	for _, dropinPath := range unitDropinPaths {
+		for _, sourcePath := range sourcePaths {
+			dropinDirs = append(dropinDirs, path.Join(sourcePath, dropinPath))
+		}
+	}
+
+	dropinPaths := make(map[string]string)
+	for _, dropinDir := range dropinDirs {
+		dropinFiles, err := os.ReadDir(dropinDir)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("error reading directory %q, %w", dropinDir, err))
+			}
+
+			continue
+		}
+
+		for _, dropinFile := range dropinFiles {
+			dropinName := dropinFile.Name()
+			if filepath.Ext(dropinName) != ".conf" {
+				continue // Only *.conf supported
+			}
+
+			if _, ok := dropinPaths[dropinName]; ok {
+				continue // We already saw this name
+			}
+
+			dropinPaths[dropinName] = path.Join(dropinDir, dropinName)
+		}
+	}
+
+	dropinFiles := make([]string, 0, len(dropinPaths))
+	for k := range dropinPaths {
+		dropinFiles = append(dropinFiles, k)
+	}
+
+	// Merge in alpha-numerical order
+	sort.Strings(dropinFiles)
+
+	for _, dropinFile := range dropinFiles {
+		dropinPath := dropinPaths[dropinFile]
+
+		logiface.Debugf("Loading source drop-in file %s", dropinPath)
+
+		if f, err := parser.ParseUnitFile(dropinPath); err != nil {
+			errs = append(errs, &DropinError{Path: dropinPath, Err: err})
+		} else {
+			unit.Merge(f)
+		}
+	}
+
+	return errors.Join(errs...)
+}
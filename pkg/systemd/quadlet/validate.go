@@ -0,0 +1,373 @@
+package quadlet
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.podman.io/podman/v6/pkg/specgenutilexternal"
+	"go.podman.io/podman/v6/pkg/systemd/parser"
+)
+
+const (
+	// SeverityError is the severity of problems which make the generator fail
+	SeverityError = "error"
+	// SeverityWarning is the severity of problems the generator only logs
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is a problem found in a Quadlet file by Validate
+type Diagnostic struct {
+	// File is the path of the Quadlet file, or of its drop-in file if the
+	// problem is in one
+	File string
+	// Line is the number of the line with the problem, 0 if the problem is
+	// not tied to a line
+	Line int
+	// Group and Key are the group and the key with the problem, if any
+	Group string
+	Key   string
+	// Severity is either SeverityError or SeverityWarning
+	Severity string
+	Message  string
+}
+
+// Keys of the Quadlet groups which may reference other Quadlet units
+var referenceKeys = []string{KeyImage, KeyMount, KeyNetwork, KeyPod, KeyVolume}
+
+// Validate runs the conversion of the Quadlet files at paths like the
+// generator does and returns the problems found in them.  The units in
+// unitDirs are used to resolve references to units which are not validated,
+// but their problems are not reported.  The drop-in files of all units are
+// merged from unitDirs, and for the validated units also from their own
+// directories.
+func Validate(paths []string, unitDirs []string, isUser bool) []Diagnostic {
+	v := &validator{
+		validated: make(map[string]bool),
+		failed:    make(map[string]bool),
+	}
+	v.run(paths, unitDirs, isUser)
+
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		a, b := v.diagnostics[i], v.diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return v.diagnostics
+}
+
+type validator struct {
+	diagnostics []Diagnostic
+	// Paths of the validated units, including the units generated from
+	// validated .compose units
+	validated map[string]bool
+	// Paths of the validated units with errors, they are not converted
+	failed map[string]bool
+}
+
+func (v *validator) report(path string, line int, group, key, severity, message string) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		File:     path,
+		Line:     line,
+		Group:    group,
+		Key:      key,
+		Severity: severity,
+		Message:  message,
+	})
+	if severity == SeverityError {
+		v.failed[path] = true
+	}
+}
+
+// reportAt reports an error of a unit at a location, which is in one of
+// its drop-in files for keys set in one
+func (v *validator) reportAt(unit *parser.UnitFile, loc parser.Location, group, key, message string) {
+	path := loc.Path
+	if path == "" {
+		path = unit.Path
+	}
+	v.report(path, loc.Line, group, key, SeverityError, message)
+	v.failed[unit.Path] = true
+}
+
+func (v *validator) reportKey(unit *parser.UnitFile, group, key, message string) {
+	v.reportAt(unit, unit.LookupLocation(group, key), group, key, message)
+}
+
+func (v *validator) reportError(unit *parser.UnitFile, err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			v.reportError(unit, e)
+		}
+		return
+	}
+	var keyErr *UnsupportedKeyError
+	if errors.As(err, &keyErr) {
+		v.reportKey(unit, keyErr.Group, keyErr.Key, err.Error())
+		return
+	}
+	var dropinErr *DropinError
+	if errors.As(err, &dropinErr) {
+		line := 0
+		var parseErr *parser.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		}
+		// Like the generator, convert the unit without the drop-in file
+		v.report(dropinErr.Path, line, "", "", SeverityError, err.Error())
+		return
+	}
+	v.report(unit.Path, 0, "", "", SeverityError, err.Error())
+}
+
+func (v *validator) run(paths []string, unitDirs []string, isUser bool) {
+	units := make([]*parser.UnitFile, 0, len(paths))
+	// Names of all units, the validated units by name
+	names := make(map[string]bool, len(paths))
+	validatedUnits := make(map[string]*parser.UnitFile, len(paths))
+	for _, path := range paths {
+		if !IsExtSupported(path) {
+			v.report(path, 0, "", "", SeverityError, fmt.Sprintf("unsupported file type %q", filepath.Base(path)))
+			continue
+		}
+		unit, err := parser.ParseUnitFile(path)
+		if err != nil {
+			line := 0
+			var parseErr *parser.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.Line
+			}
+			v.report(path, line, "", "", SeverityError, err.Error())
+			continue
+		}
+		if names[unit.Filename] {
+			v.report(path, 0, "", "", SeverityError, fmt.Sprintf("another unit named %s is validated", unit.Filename))
+			continue
+		}
+		names[unit.Filename] = true
+		validatedUnits[unit.Filename] = unit
+		v.validated[unit.Path] = true
+		units = append(units, unit)
+
+		sourcePaths := unitDirs
+		if dir := filepath.Dir(unit.Path); !slices.Contains(unitDirs, dir) {
+			sourcePaths = append([]string{dir}, unitDirs...)
+		}
+		if err := LoadUnitDropins(unit, sourcePaths); err != nil {
+			v.reportError(unit, err)
+		}
+	}
+
+	// The validated units shadow the installed units with the same names
+	for _, unit := range loadInstalledUnits(unitDirs) {
+		if !names[unit.Filename] {
+			names[unit.Filename] = true
+			_ = LoadUnitDropins(unit, unitDirs)
+			units = append(units, unit)
+		}
+	}
+
+	// The units generated from a .compose unit have its path, they are
+	// validated along with it
+	units, composeAliases, composeErrors := ExpandComposeUnits(units)
+	for _, unit := range units {
+		names[unit.Filename] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(composeErrors)) {
+		if unit, ok := validatedUnits[name]; ok {
+			v.reportError(unit, composeErrors[name])
+		}
+	}
+
+	for _, unit := range units {
+		if v.validated[unit.Path] {
+			v.checkReferences(unit, names)
+		}
+	}
+
+	SortUnits(units)
+	unitsInfoMap, _ := GenerateUnitsInfoMap(units)
+	for composeFile, podFile := range composeAliases {
+		if info, ok := unitsInfoMap[podFile]; ok {
+			unitsInfoMap[composeFile] = info
+		}
+	}
+
+	services := make(map[string]*parser.UnitFile)
+	owners := make(map[string]*parser.UnitFile)
+	for _, unit := range units {
+		validated := v.validated[unit.Path]
+		if v.failed[unit.Path] {
+			continue
+		}
+		service, warnings, err := ConvertUnit(unit, unitsInfoMap, isUser)
+		if validated && warnings != nil {
+			v.report(unit.Path, 0, "", "", SeverityWarning, warnings.Error())
+		}
+		if err != nil {
+			if validated {
+				v.reportError(unit, err)
+			}
+			continue
+		}
+		if _, err := ConvertSchedule(unit); err != nil {
+			if validated {
+				v.reportError(unit, err)
+			}
+			continue
+		}
+		services[service.Filename] = service
+		owners[service.Filename] = unit
+	}
+
+	v.checkCycles(services, owners)
+}
+
+// loadInstalledUnits loads the units in dirs, the first unit found with a
+// name shadows the others
+func loadInstalledUnits(dirs []string) []*parser.UnitFile {
+	var units []*parser.UnitFile
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !IsExtSupported(name) || seen[name] {
+				continue
+			}
+			unit, err := parser.ParseUnitFile(filepath.Join(dir, name))
+			if err != nil {
+				continue
+			}
+			seen[name] = true
+			units = append(units, unit)
+		}
+	}
+	return units
+}
+
+// isUnitReference returns whether a value references a Quadlet unit
+func isUnitReference(value string) bool {
+	if value == "" || value[0] == '/' || value[0] == '.' {
+		return false
+	}
+	_, ok := SupportedExtensions[filepath.Ext(value)]
+	return ok
+}
+
+// checkReferences reports the references of a unit to Quadlet units which
+// do not exist
+func (v *validator) checkReferences(unit *parser.UnitFile, names map[string]bool) {
+	check := func(group, key string, loc parser.Location, ref string) {
+		if isUnitReference(ref) && !names[ref] {
+			v.reportAt(unit, loc, group, key, fmt.Sprintf("%s references the Quadlet unit %s which does not exist", key, ref))
+		}
+	}
+
+	for _, key := range unitDependencyKeys {
+		locations := unit.LookupAllLocations(UnitGroup, key)
+		for i, value := range unit.LookupAll(UnitGroup, key) {
+			for _, dep := range strings.Fields(value) {
+				check(UnitGroup, key, locations[i], dep)
+			}
+		}
+	}
+
+	for _, group := range []string{ContainerGroup, PodGroup, KubeGroup, BuildGroup, VolumeGroup} {
+		for _, key := range referenceKeys {
+			locations := unit.LookupAllLocations(group, key)
+			for i, value := range unit.LookupAll(group, key) {
+				if key != KeyMount {
+					ref, _, _ := strings.Cut(value, ":")
+					check(group, key, locations[i], ref)
+					continue
+				}
+				_, tokens, err := specgenutilexternal.FindMountType(value)
+				if err != nil {
+					continue
+				}
+				for _, token := range tokens {
+					if name, ref, ok := strings.Cut(token, "="); ok && (name == "source" || name == "src") {
+						check(group, key, locations[i], ref)
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkCycles reports the cycles in the ordering dependencies of the
+// services generated from the validated units
+func (v *validator) checkCycles(services, owners map[string]*parser.UnitFile) {
+	// The services each service must be started before
+	before := make(map[string][]string)
+	for name, service := range services {
+		for _, dep := range service.LookupAllStrv(UnitGroup, "After") {
+			if _, ok := services[dep]; ok && dep != name {
+				before[dep] = append(before[dep], name)
+			}
+		}
+		for _, dep := range service.LookupAllStrv(UnitGroup, "Before") {
+			if _, ok := services[dep]; ok && dep != name {
+				before[name] = append(before[name], dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		next := before[name]
+		sort.Strings(next)
+		for _, n := range slices.Compact(next) {
+			switch state[n] {
+			case unvisited:
+				visit(n)
+			case visiting:
+				v.reportCycle(stack[slices.Index(stack, n):], owners)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+	for _, name := range slices.Sorted(maps.Keys(services)) {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+}
+
+func (v *validator) reportCycle(cycle []string, owners map[string]*parser.UnitFile) {
+	var owner *parser.UnitFile
+	files := make([]string, 0, len(cycle)+1)
+	for _, name := range cycle {
+		unit := owners[name]
+		if owner == nil && v.validated[unit.Path] {
+			owner = unit
+		}
+		files = append(files, unit.Filename)
+	}
+	if owner == nil {
+		return
+	}
+	files = append(files, files[0])
+	v.report(owner.Path, 0, "", "", SeverityError, "ordering dependency cycle: "+strings.Join(files, " -> "))
+}
@@ -0,0 +1,125 @@
+package quadlet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestUnits(t *testing.T, dir string, units map[string]string) []string {
+	paths := make([]string, 0, len(units))
+	for name, data := range units {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	installedDir := t.TempDir()
+	writeTestUnits(t, installedDir, map[string]string{
+		"installed.network": "[Network]\n",
+	})
+	paths := writeTestUnits(t, dir, map[string]string{
+		"ok.container":          "[Container]\nImage=quay.io/example\nNetwork=installed.network\n",
+		"unknown.container":     "[Container]\nImage=quay.io/example\n\nFoo=bar\nBaz=qux\n[Quadlet]\nUnknown=true\n",
+		"reference.container":   "[Unit]\nRequires=ok.container\nAfter=missing.volume\n[Container]\nImage=quay.io/example\nNetwork=missing.network\n",
+		"broken.pod":            "[Pod]\nnot a key\n",
+		"noimage.container":     "[Container]\nExec=true\n",
+		"unsupported.conf":      "[Container]\n",
+		"compose-error.compose": "[Compose]\n",
+	})
+
+	diagnostics := Validate(paths, []string{installedDir}, false)
+	assert.Equal(t, []Diagnostic{
+		{File: filepath.Join(dir, "broken.pod"), Line: 2, Severity: SeverityError, Message: "file contains line 2: “not a key” which is not a key-value pair, group, or comment"},
+		{File: filepath.Join(dir, "compose-error.compose"), Severity: SeverityError, Message: "no Yaml key specified"},
+		{File: filepath.Join(dir, "noimage.container"), Severity: SeverityError, Message: "no Image or Rootfs key specified"},
+		{File: filepath.Join(dir, "reference.container"), Line: 3, Group: UnitGroup, Key: "After", Severity: SeverityError, Message: "After references the Quadlet unit missing.volume which does not exist"},
+		{File: filepath.Join(dir, "reference.container"), Line: 6, Group: ContainerGroup, Key: KeyNetwork, Severity: SeverityError, Message: "Network references the Quadlet unit missing.network which does not exist"},
+		{File: filepath.Join(dir, "unknown.container"), Line: 4, Group: ContainerGroup, Key: "Foo", Severity: SeverityError, Message: "unsupported key 'Foo' in group 'Container' in " + filepath.Join(dir, "unknown.container")},
+		{File: filepath.Join(dir, "unknown.container"), Line: 5, Group: ContainerGroup, Key: "Baz", Severity: SeverityError, Message: "unsupported key 'Baz' in group 'Container' in " + filepath.Join(dir, "unknown.container")},
+		{File: filepath.Join(dir, "unknown.container"), Line: 7, Group: QuadletGroup, Key: "Unknown", Severity: SeverityError, Message: "unsupported key 'Unknown' in group 'Quadlet' in " + filepath.Join(dir, "unknown.container")},
+		{File: filepath.Join(dir, "unsupported.conf"), Severity: SeverityError, Message: `unsupported file type "unsupported.conf"`},
+	}, diagnostics)
+}
+
+func TestValidateCycle(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeTestUnits(t, dir, map[string]string{
+		"a.container": "[Unit]\nAfter=b.container\n[Container]\nImage=quay.io/example\n",
+		"b.container": "[Unit]\nAfter=c.container\n[Container]\nImage=quay.io/example\n",
+		"c.container": "[Unit]\nBefore=b.container\n[Container]\nImage=quay.io/example\nNetwork=a.container\n",
+	})
+
+	diagnostics := Validate(paths, nil, false)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, Diagnostic{
+		File:     filepath.Join(dir, "a.container"),
+		Severity: SeverityError,
+		Message:  "ordering dependency cycle: a.container -> c.container -> b.container -> a.container",
+	}, diagnostics[0])
+}
+
+func TestValidateDropins(t *testing.T) {
+	dir := t.TempDir()
+	installedDir := t.TempDir()
+	paths := writeTestUnits(t, dir, map[string]string{
+		"web.container": "[Container]\nImage=quay.io/example\n",
+	})
+	dropinDir := filepath.Join(dir, "web.container.d")
+	require.NoError(t, os.Mkdir(dropinDir, 0o755))
+	writeTestUnits(t, dropinDir, map[string]string{
+		"10-network.conf": "[Container]\nNetwork=installed.network\n",
+		"20-unknown.conf": "[Container]\n\nFoo=bar\n",
+		"30-broken.conf":  "not a key\n",
+	})
+	writeTestUnits(t, installedDir, map[string]string{
+		"installed.network": "[Network]\n",
+	})
+
+	diagnostics := Validate(paths, []string{installedDir}, false)
+	brokenPath := filepath.Join(dropinDir, "30-broken.conf")
+	assert.Equal(t, []Diagnostic{
+		{File: filepath.Join(dropinDir, "20-unknown.conf"), Line: 3, Group: ContainerGroup, Key: "Foo", Severity: SeverityError, Message: "unsupported key 'Foo' in group 'Container' in " + filepath.Join(dir, "web.container")},
+		{File: brokenPath, Line: 1, Severity: SeverityError, Message: fmt.Sprintf("error loading %q, file contains line 1: “not a key” which is not a key-value pair, group, or comment", brokenPath)},
+	}, diagnostics)
+}
+
+func TestValidateInstalledDropins(t *testing.T) {
+	dir := t.TempDir()
+	installedDir := t.TempDir()
+	paths := writeTestUnits(t, dir, map[string]string{
+		"web.container": "[Unit]\nAfter=db.container\n[Container]\nImage=quay.io/example\n",
+	})
+	writeTestUnits(t, installedDir, map[string]string{
+		"db.container": "[Container]\nImage=quay.io/example\n",
+	})
+	dropinDir := filepath.Join(installedDir, "db.container.d")
+	require.NoError(t, os.Mkdir(dropinDir, 0o755))
+	writeTestUnits(t, dropinDir, map[string]string{
+		"10-after.conf": "[Unit]\nAfter=web.container\n",
+	})
+
+	diagnostics := Validate(paths, []string{installedDir}, false)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "ordering dependency cycle: db.container -> web.container -> db.container", diagnostics[0].Message)
+}
+
+func TestValidateNoDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeTestUnits(t, dir, map[string]string{
+		"app.pod":       "[Pod]\nNetwork=app.network\n",
+		"app.network":   "[Network]\n",
+		"data.volume":   "[Volume]\n",
+		"web.container": "[Unit]\nRequires=app.network\n[Container]\nImage=quay.io/example\nPod=app.pod\nVolume=data.volume:/data\nMount=type=volume,source=data.volume,destination=/other\n",
+	})
+
+	assert.Empty(t, Validate(paths, nil, false))
+}
@@ -73,6 +73,22 @@ var _ = Describe("Podman attach", func() {
 		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
 	})
 
+	It("podman attach --record", func() {
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "test", CITEST_IMAGE, "/bin/sh", "-c", "for i in 1 2 3 4 5; do echo test; sleep 1; done")
+
+		results := podmanTest.Podman([]string{"attach", "--no-stdin", "--record", "test"})
+		results.WaitWithDefaultTimeout()
+		Expect(results).Should(ExitCleanly())
+		Expect(results.OutputToString()).To(ContainSubstring("test"))
+
+		events := podmanTest.PodmanExitCleanly("events", "--stream=false", "--filter", "event=record", "--filter", "container=test", "--format", "{{.Attributes.recording}}")
+		recordingID := events.OutputToString()
+		Expect(recordingID).ToNot(BeEmpty())
+
+		replay := podmanTest.PodmanExitCleanly("exec", "replay", "--speed", "0", "test", recordingID)
+		Expect(replay.OutputToString()).To(ContainSubstring("test"))
+	})
+
 	It("podman attach to a container with --sig-proxy set to false", func() {
 		podmanTest.PodmanExitCleanly("run", "-d", "--name", "test", CITEST_IMAGE, "/bin/sh", "-c", "while true; do echo test; sleep 1; done")
 
@@ -1022,7 +1022,6 @@ var _ = Describe("Podman checkpoint", func() {
 			Skip("skip on arm64/aarch64, https://github.com/checkpoint-restore/criu/issues/2676")
 		}
 		SkipIfContainerized("FIXME: #24230 - no longer works in container testing")
-		SkipIfRemote("--import-previous is not yet supported on the remote client")
 		if !criu.MemTrack() {
 			Skip("system (architecture/kernel/CRIU) does not support memory tracking")
 		}
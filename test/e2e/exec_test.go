@@ -567,6 +567,139 @@ RUN useradd -u 1000 auser`, FEDORA_MINIMAL)
 		podmanTest.StopContainer(ctrName)
 	})
 
+	It("podman exec ls and attach with --output-buffer", func() {
+		ctrName := "testctr"
+		ctr := podmanTest.RunTopContainer(ctrName)
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+
+		list := podmanTest.Podman([]string{"exec", "ls", "--noheading", ctrName})
+		list.WaitWithDefaultTimeout()
+		Expect(list).Should(ExitCleanly())
+		Expect(list.OutputToString()).To(BeEmpty())
+
+		exec1 := podmanTest.Podman([]string{"exec", "-d", "--output-buffer", "1m", ctrName, "sh", "-c", "echo hello; sleep 5"})
+		exec1.WaitWithDefaultTimeout()
+		Expect(exec1).Should(ExitCleanly())
+		sessionID := exec1.OutputToString()
+
+		list = podmanTest.Podman([]string{"exec", "ls", "--format", "{{range .}}{{.ID}} {{.Status}}\n{{end}}", ctrName})
+		list.WaitWithDefaultTimeout()
+		Expect(list).Should(ExitCleanly())
+		Expect(list.OutputToString()).To(Equal(sessionID + " running"))
+
+		attach := podmanTest.Podman([]string{"exec", "attach", "--no-stdin", sessionID})
+		attach.WaitWithDefaultTimeout()
+		Expect(attach).Should(ExitCleanly())
+		Expect(attach.OutputToString()).To(Equal("hello"))
+
+		attach = podmanTest.Podman([]string{"exec", "attach", sessionID})
+		attach.WaitWithDefaultTimeout()
+		Expect(attach).Should(ExitWithError(125, "can only attach to running exec sessions"))
+	})
+
+	It("podman exec attach replays the last output with --output-buffer", func() {
+		ctrName := "testctr"
+		ctr := podmanTest.RunTopContainer(ctrName)
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+
+		exec := podmanTest.Podman([]string{"exec", "-d", "--output-buffer", "10", ctrName, "sh", "-c", "seq 1 100; sleep 5"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(ExitCleanly())
+		time.Sleep(2 * time.Second) // Give time for the output to be written (CI is slow)
+
+		attach := podmanTest.Podman([]string{"exec", "attach", "--no-stdin", exec.OutputToString()})
+		attach.WaitWithDefaultTimeout()
+		Expect(attach).Should(ExitCleanly())
+		Expect(attach.OutputToStringArray()).To(Equal([]string{"98", "99", "100"}))
+	})
+
+	It("podman exec --record and exec replay", func() {
+		ctrName := "testctr"
+		ctr := podmanTest.RunTopContainer(ctrName)
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"exec", "--record", ctrName, "echo", "hello"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("hello"))
+
+		events := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=record", "--filter", "container=" + ctrName, "--format", "{{.Attributes.recording}} {{.Attributes.execID}}"})
+		events.WaitWithDefaultTimeout()
+		Expect(events).Should(ExitCleanly())
+		ids := strings.Fields(events.OutputToString())
+		Expect(ids).To(HaveLen(2))
+		Expect(ids[0]).To(Equal(ids[1]))
+		recordingID := ids[0]
+
+		replay := podmanTest.Podman([]string{"exec", "replay", "--speed", "0", ctrName, recordingID[:12]})
+		replay.WaitWithDefaultTimeout()
+		Expect(replay).Should(ExitCleanly())
+		Expect(replay.OutputToString()).To(Equal("hello"))
+
+		replay = podmanTest.Podman([]string{"exec", "replay", "--raw", ctrName, recordingID})
+		replay.WaitWithDefaultTimeout()
+		Expect(replay).Should(ExitCleanly())
+		lines := replay.OutputToStringArray()
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(ContainSubstring(`"version":2`))
+		Expect(lines[1]).To(HaveSuffix(`"o","hello\n"]`))
+
+		replay = podmanTest.Podman([]string{"exec", "replay", ctrName, "0123456789ab"})
+		replay.WaitWithDefaultTimeout()
+		Expect(replay).Should(ExitWithError(125, "no such recording"))
+	})
+
+	It("podman exec --record records detached sessions", func() {
+		ctrName := "testctr"
+		ctr := podmanTest.RunTopContainer(ctrName)
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+
+		session := podmanTest.PodmanExitCleanly("exec", "-d", "--record", ctrName, "sh", "-c", "echo hello; echo world")
+		execID := session.OutputToString()
+
+		// The recording is referenced once the session exited.
+		Eventually(func() string {
+			events := podmanTest.PodmanExitCleanly("events", "--stream=false", "--filter", "event=record", "--filter", "container="+ctrName, "--format", "{{.Attributes.recording}}")
+			return events.OutputToString()
+		}, defaultWaitTimeout, 1).Should(Equal(execID))
+
+		replay := podmanTest.PodmanExitCleanly("exec", "replay", "--speed", "0", ctrName, execID)
+		Expect(replay.OutputToStringArray()).To(Equal([]string{"hello", "world"}))
+	})
+
+	It("podman exec --record with --no-session", func() {
+		SkipIfRemote("The --no-session flag is not supported for remote clients")
+		session := podmanTest.Podman([]string{"exec", "--no-session", "--record", "foobar", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--no-session cannot be used with --detach, --detach-keys, --output-buffer or --record"))
+	})
+
+	It("podman exec ls still executes a command named ls", func() {
+		ctrName := "testctr"
+		ctr := podmanTest.RunTopContainer(ctrName)
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"exec", "-w", "/etc", "--latest", "ls", "hostname"})
+		if IsRemote() {
+			session = podmanTest.Podman([]string{"exec", "-w", "/etc", ctrName, "ls", "hostname"})
+		}
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("hostname"))
+	})
+
+	It("podman exec --output-buffer with --no-session", func() {
+		SkipIfRemote("The --no-session flag is not supported for remote clients")
+		session := podmanTest.Podman([]string{"exec", "--no-session", "--output-buffer", "1m", "foobar", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--no-session cannot be used with --detach, --detach-keys, --output-buffer or --record"))
+	})
+
 	It("podman exec with env var secret", func() {
 		secretsString := "somesecretdata"
 		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
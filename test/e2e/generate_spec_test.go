@@ -11,10 +11,6 @@ import (
 )
 
 var _ = Describe("Podman generate spec", func() {
-	BeforeEach(func() {
-		SkipIfRemote("podman generate spec is not supported on the remote client")
-	})
-
 	It("podman generate spec bogus should fail", func() {
 		session := podmanTest.Podman([]string{"generate", "spec", "foobar"})
 		session.WaitWithDefaultTimeout()
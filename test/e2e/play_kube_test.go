@@ -523,6 +523,49 @@ spec:
           periodSeconds: 1
 `
 
+var readinessProbePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: readiness-probe
+spec:
+  restartPolicy: Never
+  containers:
+  - command:
+    - top
+    - -d
+    - "1.5"
+    name: testimage
+    image: ` + CITEST_IMAGE + `
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /testfile
+      periodSeconds: 1
+`
+
+var readinessProbeNeverReadyPodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: readiness-never-ready
+spec:
+  restartPolicy: Never
+  containers:
+  - command:
+    - sleep
+    - "3"
+    name: testimage
+    image: ` + CITEST_IMAGE + `
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /testfile
+      periodSeconds: 1
+`
+
 var selinuxLabelPodYaml = `
 apiVersion: v1
 kind: Pod
@@ -583,6 +626,78 @@ spec:
         claimName: testvol
 `
 
+var downwardAPIPodYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: projected-config
+data:
+  app.conf: debug=true
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: infopod
+  labels:
+    app: web
+    tier: frontend
+  annotations:
+    build: v1
+spec:
+  containers:
+  - name: ctr
+    image: ` + CITEST_IMAGE + `
+    command:
+    - sleep
+    - inf
+    volumeMounts:
+    - mountPath: /etc/podinfo
+      name: podinfo
+    - mountPath: /etc/all
+      name: all
+  volumes:
+  - name: podinfo
+    downwardAPI:
+      items:
+      - path: name
+        fieldRef:
+          fieldPath: metadata.name
+      - path: labels
+        fieldRef:
+          fieldPath: metadata.labels
+  - name: all
+    projected:
+      sources:
+      - configMap:
+          name: projected-config
+      - downwardAPI:
+          items:
+          - path: build
+            fieldRef:
+              fieldPath: metadata.annotations['build']
+`
+
+var cronJobYaml = `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: Never
+          containers:
+          - name: ctr
+            image: ` + CITEST_IMAGE + `
+            command:
+            - sleep
+            - inf
+`
+
 var configMapYamlTemplate = `
 apiVersion: v1
 kind: ConfigMap
@@ -1315,6 +1430,75 @@ spec:
     - containerPort: 80
 `
 
+var publishPortsDeploymentWithService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: nginx
+spec:
+  type: NodePort
+  selector:
+    app: nginx
+  ports:
+  - port: 80
+    nodePort: 19010
+    targetPort: http
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels:
+        app: nginx
+    spec:
+      containers:
+      - name: nginx
+        image: ` + NGINX_IMAGE + `
+        imagePullPolicy: missing
+        ports:
+        - name: http
+          containerPort: 80
+`
+
+var readinessProbePodWithService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: nginx
+spec:
+  type: NodePort
+  selector:
+    app: nginx
+  ports:
+  - port: 80
+    nodePort: 19011
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  labels:
+    app: nginx
+spec:
+  containers:
+  - name: nginx
+    image: ` + NGINX_IMAGE + `
+    imagePullPolicy: missing
+    ports:
+    - containerPort: 80
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /testfile
+      periodSeconds: 1
+      failureThreshold: 1
+`
+
 var publishPortsPodWithContainerHostPort = `
 apiVersion: v1
 kind: Pod
@@ -2719,6 +2903,67 @@ var _ = Describe("Podman kube play", func() {
 		Expect(inspect[0].State.Health).To(HaveField("Status", define.HealthCheckHealthy))
 	})
 
+	It("support container readiness probe", func() {
+		// Running readiness checks is not supported remotely.
+		SkipIfRemote("podman healthcheck run --readiness is not supported remotely")
+		ctrName := "readiness-probe-testimage"
+		err := writeYaml(readinessProbePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].Config.ReadinessCheck).ToNot(BeNil())
+		Expect(inspect[0].Config.ReadinessCheck.Test).To(Equal([]string{"CMD", "cat", "/testfile"}))
+		// A readiness probe must not create a healthcheck.
+		Expect(inspect[0].Config.Healthcheck).To(BeNil())
+		Expect(inspect[0].State).To(HaveField("Readiness", define.ReadinessCheckNotReady))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "--readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(ExitWithError(1, ""))
+		Expect(hc.OutputToString()).To(Equal(define.ReadinessCheckNotReady))
+
+		podInspect := podmanTest.Podman([]string{"pod", "inspect", "readiness-probe", "--format", "{{.Ready}}"})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(ExitCleanly())
+		Expect(podInspect.OutputToString()).To(Equal("false"))
+
+		exec := podmanTest.Podman([]string{"exec", ctrName, "touch", "/testfile"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(ExitCleanly())
+
+		hc = podmanTest.Podman([]string{"healthcheck", "run", "--readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(ExitCleanly())
+
+		inspect = podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].State).To(HaveField("Readiness", define.ReadinessCheckReady))
+
+		ps := podmanTest.Podman([]string{"ps", "--filter", "name=" + ctrName, "--format", "{{.Readiness}} {{.Status}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps).Should(ExitCleanly())
+		Expect(ps.OutputToString()).To(HavePrefix(define.ReadinessCheckReady + " Up "))
+		Expect(ps.OutputToString()).To(HaveSuffix("(" + define.ReadinessCheckReady + ")"))
+
+		podInspect = podmanTest.Podman([]string{"pod", "inspect", "readiness-probe", "--format", "{{.Ready}}"})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(ExitCleanly())
+		Expect(podInspect.OutputToString()).To(Equal("true"))
+	})
+
+	It("with --wait should fail if a container never becomes ready", func() {
+		err := writeYaml(readinessProbeNeverReadyPodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, "waiting for pod readiness-never-ready to become ready: container "))
+		Expect(kube.ErrorToString()).To(ContainSubstring("will not become ready"))
+	})
+
 	It("fail with nonexistent authfile", func() {
 		err := generateKubeYaml("pod", getPod(), kubeYaml)
 		Expect(err).ToNot(HaveOccurred())
@@ -3709,6 +3954,47 @@ spec:
 		Expect(inspect.OutputToString()).To(ContainSubstring(strings.Join(defaultCtrCmd, " ")))
 	})
 
+	It("with CronJob", func() {
+		SkipIfRemote("podman kube cronjob-run is not supported remotely")
+		err := writeYaml(cronJobYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		play := podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		Expect(play.OutputToString()).To(ContainSubstring("CronJobs: backup 0 3 * * *"))
+		// No job is created before the schedule fires.
+		pods := podmanTest.PodmanExitCleanly("pod", "ps", "-q", "--filter", "label=io.podman.kube.cronjob=backup")
+		Expect(pods.OutputToString()).To(BeEmpty())
+
+		// A second kube play must not schedule the CronJob twice.
+		session := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "cron job backup is already scheduled"))
+
+		// Resuming leaves the running scheduler alone.
+		podmanTest.PodmanExitCleanly("kube", "resume")
+
+		// Run the job the way the scheduler does, the job is named
+		// after the scheduled time in minutes.
+		podmanTest.PodmanExitCleanly("kube", "cronjob-run", "backup", "1760666400")
+		pods = podmanTest.PodmanExitCleanly("pod", "ps", "--format", "{{.Name}} {{.Status}}", "--filter", "label=io.podman.kube.cronjob=backup")
+		Expect(pods.OutputToStringArray()).To(HaveLen(1))
+		Expect(pods.OutputToString()).To(Equal("backup-29344440-pod Running"))
+
+		// A late run of the same activation does not create a second job.
+		podmanTest.PodmanExitCleanly("kube", "cronjob-run", "backup", "1760666430")
+		pods = podmanTest.PodmanExitCleanly("pod", "ps", "-q", "--filter", "label=io.podman.kube.cronjob=backup")
+		Expect(pods.OutputToStringArray()).To(HaveLen(1))
+
+		down := podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		Expect(down.OutputToString()).To(ContainSubstring("CronJobs removed: backup"))
+		pods = podmanTest.PodmanExitCleanly("pod", "ps", "-q", "--filter", "label=io.podman.kube.cronjob=backup")
+		Expect(pods.OutputToString()).To(BeEmpty())
+
+		session = podmanTest.Podman([]string{"kube", "cronjob-run", "backup"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "kube cron job backup is not scheduled"))
+	})
+
 	It("--ip and --mac-address", func() {
 		var i, numReplicas int32
 		numReplicas = 3
@@ -4180,6 +4466,75 @@ spec:
 		Expect(permData.OutputToString()).To(Equal("644"))
 	})
 
+	It("with downwardAPI and projected volumes", func() {
+		err := writeYaml(downwardAPIPodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+
+		name := podmanTest.PodmanExitCleanly("exec", "infopod-ctr", "cat", "/etc/podinfo/name")
+		Expect(name.OutputToString()).To(Equal("infopod"))
+		labels := podmanTest.PodmanExitCleanly("exec", "infopod-ctr", "cat", "/etc/podinfo/labels")
+		Expect(labels.OutputToStringArray()).To(Equal([]string{`app="web"`, `tier="frontend"`}))
+		conf := podmanTest.PodmanExitCleanly("exec", "infopod-ctr", "cat", "/etc/all/app.conf")
+		Expect(conf.OutputToString()).To(Equal("debug=true"))
+		build := podmanTest.PodmanExitCleanly("exec", "infopod-ctr", "cat", "/etc/all/build")
+		Expect(build.OutputToString()).To(Equal("v1"))
+
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		volumes := podmanTest.PodmanExitCleanly("volume", "ls", "-q")
+		Expect(volumes.OutputToString()).To(BeEmpty())
+	})
+
+	It("with downwardAPI volume in a Deployment", func() {
+		deploymentYaml := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: info
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        app: info
+    spec:
+      containers:
+      - name: ctr
+        image: ` + CITEST_IMAGE + `
+        command:
+        - sleep
+        - inf
+        volumeMounts:
+        - mountPath: /etc/podinfo
+          name: podinfo
+      volumes:
+      - name: podinfo
+        downwardAPI:
+          items:
+          - path: name
+            fieldRef:
+              fieldPath: metadata.name
+`
+		err := writeYaml(deploymentYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		name := podmanTest.PodmanExitCleanly("exec", "info-pod-ctr", "cat", "/etc/podinfo/name")
+		Expect(name.OutputToString()).To(Equal("info-pod"))
+		volumes := podmanTest.PodmanExitCleanly("volume", "ls", "-q")
+		Expect(volumes.OutputToString()).To(Equal("info-pod_podinfo"))
+
+		// A rolling update removes the volume of the replaced pod.
+		err = writeYaml(strings.Replace(deploymentYaml, "- inf", "- \"3600\"", 1), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("kube", "play", "--update", kubeYaml)
+		volumes = podmanTest.PodmanExitCleanly("volume", "ls", "-q")
+		Expect(volumes.OutputToString()).To(Equal("info-pod-1_podinfo"))
+
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		volumes = podmanTest.PodmanExitCleanly("volume", "ls", "-q")
+		Expect(volumes.OutputToString()).To(BeEmpty())
+	})
+
 	It("with emptyDir volume", func() {
 		podName := "test-pod"
 		ctrName1 := "vol-test-ctr"
@@ -5820,6 +6175,120 @@ spec:
 		testHTTPServer("19005", false, "podman rulez")
 	})
 
+	It("with Service - curl should succeed on all replicas", func() {
+		SkipIfNotAMD64() // https://github.com/containers/podman/issues/28272
+		err := writeYaml(publishPortsDeploymentWithService, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		Expect(kube.OutputToString()).To(ContainSubstring("nginx 127.0.0.1:19010/tcp"))
+		Expect(strings.Count(kube.OutputToString(), "Pod:")).To(Equal(2))
+		podmanTest.PodmanExitCleanly("pod", "exists", "nginx-pod")
+		podmanTest.PodmanExitCleanly("pod", "exists", "nginx-pod-1")
+
+		// Round-robin across both replicas
+		for range 4 {
+			testHTTPServer("19010", false, "podman rulez")
+		}
+
+		down := podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		Expect(down.OutputToString()).To(ContainSubstring("Services stopped: nginx"))
+		testHTTPServer("19010", true, "connection refused")
+	})
+
+	It("with Service should only forward to ready pods", func() {
+		// Running readiness checks is not supported remotely.
+		SkipIfRemote("podman healthcheck run --readiness is not supported remotely")
+		SkipIfNotAMD64() // https://github.com/containers/podman/issues/28272
+		err := writeYaml(readinessProbePodWithService, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		Expect(kube.OutputToString()).To(ContainSubstring("nginx 127.0.0.1:19011/tcp"))
+		// The pod is not ready, so the connection is closed right away.
+		testHTTPServer("19011", true, "EOF")
+
+		podmanTest.PodmanExitCleanly("exec", "nginx-nginx", "touch", "/testfile")
+		podmanTest.PodmanExitCleanly("healthcheck", "run", "--readiness", "nginx-nginx")
+		testHTTPServer("19011", false, "podman rulez")
+
+		podmanTest.PodmanExitCleanly("exec", "nginx-nginx", "rm", "/testfile")
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "--readiness", "nginx-nginx"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(ExitWithError(1, ""))
+		testHTTPServer("19011", true, "EOF")
+
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+	})
+
+	It("with only a Service", func() {
+		serviceOnly := strings.SplitN(publishPortsDeploymentWithService, "---", 2)[0]
+		err := writeYaml(serviceOnly, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+		Expect(kube.ErrorToString()).To(ContainSubstring("does not select any pod"))
+	})
+
+	It("with --update should roll out a changed Deployment", func() {
+		SkipIfNotAMD64() // https://github.com/containers/podman/issues/28272
+		err := writeYaml(publishPortsDeploymentWithService, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		podID := podmanTest.PodmanExitCleanly("pod", "inspect", "nginx-pod", "--format", "{{.ID}}").OutputToString()
+
+		// Pods of an unchanged Deployment are kept.
+		kube := podmanTest.PodmanExitCleanly("kube", "play", "--update", kubeYaml)
+		Expect(kube.OutputToString()).ToNot(ContainSubstring("Pod:"))
+		Expect(podmanTest.PodmanExitCleanly("pod", "inspect", "nginx-pod", "--format", "{{.ID}}").OutputToString()).To(Equal(podID))
+		testHTTPServer("19010", false, "podman rulez")
+
+		// Pods of a changed Deployment are replaced.
+		changed := strings.Replace(publishPortsDeploymentWithService, "        imagePullPolicy: missing\n", "        imagePullPolicy: missing\n        env:\n        - name: VERSION\n          value: \"2\"\n", 1)
+		err = writeYaml(changed, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		kube = podmanTest.PodmanExitCleanly("kube", "play", "--update", kubeYaml)
+		Expect(strings.Count(kube.OutputToString(), "Pod:")).To(Equal(2))
+		for _, pod := range []string{"nginx-pod", "nginx-pod-1"} {
+			exists := podmanTest.Podman([]string{"pod", "exists", pod})
+			exists.WaitWithDefaultTimeout()
+			Expect(exists).Should(ExitWithError(1, ""))
+		}
+		podmanTest.PodmanExitCleanly("pod", "exists", "nginx-pod-2")
+		podmanTest.PodmanExitCleanly("pod", "exists", "nginx-pod-3")
+		labels := podmanTest.PodmanExitCleanly("pod", "inspect", "nginx-pod-2", "--format", "{{.Labels}}")
+		Expect(labels.OutputToString()).To(ContainSubstring("pod-template-hash:"))
+		testHTTPServer("19010", false, "podman rulez")
+
+		// New pods failing their liveness probe are rolled back.
+		failing := strings.Replace(changed, "  replicas: 2\n", "  replicas: 2\n  progressDeadlineSeconds: 30\n", 1)
+		failing = strings.Replace(failing, "        ports:\n", "        livenessProbe:\n          exec:\n            command: [\"false\"]\n          periodSeconds: 1\n          failureThreshold: 1\n        ports:\n", 1)
+		err = writeYaml(failing, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		kube = podmanTest.Podman([]string{"kube", "play", "--update", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, "rolling update of deployment nginx failed, rolled back: "))
+		for _, pod := range []string{"nginx-pod-2", "nginx-pod-3"} {
+			state := podmanTest.PodmanExitCleanly("pod", "inspect", pod, "--format", "{{.State}}")
+			Expect(state.OutputToString()).To(Equal("Running"))
+		}
+		exists := podmanTest.Podman([]string{"pod", "exists", "nginx-pod"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(ExitWithError(1, ""))
+		testHTTPServer("19010", false, "podman rulez")
+
+		// Pods of all replicas are removed even if their names changed.
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		for _, pod := range []string{"nginx-pod-2", "nginx-pod-3"} {
+			exists := podmanTest.Podman([]string{"pod", "exists", pod})
+			exists.WaitWithDefaultTimeout()
+			Expect(exists).Should(ExitWithError(1, ""))
+		}
+		testHTTPServer("19010", true, "connection refused")
+	})
+
 	It("multiple publish ports", func() {
 		SkipIfNotAMD64() // https://github.com/containers/podman/issues/28272
 		err := writeYaml(publishPortsPodWithoutPorts, kubeYaml)
@@ -226,12 +226,17 @@ var _ = Describe("Podman push", func() {
 		publicKeyFileName, _, err := WriteRSAKeyPair(keyFileName, bitSize)
 		Expect(err).ToNot(HaveOccurred())
 
-		if !IsRemote() { // Remote does not support --encryption-key
-			// Explicitly specify compression-format because encryption and zstd:chunked together triggers a warning:
-			//	Compression using zstd:chunked is not beneficial for encrypted layers, using plain zstd instead
-			push = podmanTest.Podman([]string{"push", "-q", "--encryption-key", "jwe:" + publicKeyFileName, "--tls-verify=false", "--remove-signatures", "--compression-format=zstd", ALPINE, "localhost:5003/my-alpine"})
+		// Explicitly specify compression-format because encryption and zstd:chunked together triggers a warning:
+		//	Compression using zstd:chunked is not beneficial for encrypted layers, using plain zstd instead
+		push = podmanTest.Podman([]string{"push", "-q", "--encryption-key", "jwe:" + publicKeyFileName, "--tls-verify=false", "--remove-signatures", "--compression-format=zstd", ALPINE, "localhost:5003/my-alpine"})
+		push.WaitWithDefaultTimeout()
+		Expect(push).Should(ExitCleanly())
+
+		if IsRemote() {
+			// Keys in the local GPG keyring cannot be forwarded to the server.
+			push = podmanTest.Podman([]string{"push", "-q", "--tls-verify=false", "--sign-by", "foo@example.com", ALPINE, "localhost:5003/my-alpine"})
 			push.WaitWithDefaultTimeout()
-			Expect(push).Should(ExitCleanly())
+			Expect(push).Should(ExitWithError(125, "signing with GPG keys is not supported for remote clients"))
 		}
 
 		// Test --digestfile option
@@ -54,7 +54,7 @@ func calcServiceName(path string) string {
 		service += "-build"
 	case ".artifact":
 		service += "-artifact"
-	case ".pod":
+	case ".pod", ".compose":
 		service += "-pod"
 	}
 	return service
@@ -546,6 +546,33 @@ func (t *quadletTestcase) assertSymlink(args []string, unit *parser.UnitFile) bo
 	return expectedTarget == target
 }
 
+// assertTimerKeyIs checks a key of the timer unit generated next to the service
+func (t *quadletTestcase) assertTimerKeyIs(args []string, unit *parser.UnitFile) bool {
+	timer, err := parser.ParseUnitFile(strings.TrimSuffix(unit.Path, ".service") + ".timer")
+	if err != nil {
+		return false
+	}
+	return t.assertKeyIs(args, timer)
+}
+
+// loadGeneratedUnit parses another unit generated next to the service
+func loadGeneratedUnit(name string, unit *parser.UnitFile) *parser.UnitFile {
+	other, err := parser.ParseUnitFile(filepath.Join(filepath.Dir(unit.Path), name))
+	Expect(err).ToNot(HaveOccurred())
+	return other
+}
+
+// assertUnitKeyIs checks a key of another unit generated next to the service
+func (t *quadletTestcase) assertUnitKeyIs(args []string, unit *parser.UnitFile) bool {
+	Expect(len(args)).To(BeNumerically(">=", 4))
+	return t.assertKeyIs(args[1:], loadGeneratedUnit(args[0], unit))
+}
+
+func (t *quadletTestcase) assertUnitKeyIsRegex(args []string, unit *parser.UnitFile) bool {
+	Expect(len(args)).To(BeNumerically(">=", 4))
+	return t.assertKeyIsRegex(args[1:], loadGeneratedUnit(args[0], unit))
+}
+
 func (t *quadletTestcase) doAssert(check []string, unit *parser.UnitFile, session *PodmanSessionIntegration) error {
 	Expect(check).ToNot(BeEmpty())
 	op := check[0]
@@ -628,6 +655,12 @@ func (t *quadletTestcase) doAssert(check []string, unit *parser.UnitFile, sessio
 		ok = t.assertStartPrePodmanFinalArgsRegex(args, unit)
 	case "assert-symlink":
 		ok = t.assertSymlink(args, unit)
+	case "assert-timer-key-is":
+		ok = t.assertTimerKeyIs(args, unit)
+	case "assert-unit-key-is":
+		ok = t.assertUnitKeyIs(args, unit)
+	case "assert-unit-key-is-regex":
+		ok = t.assertUnitKeyIsRegex(args, unit)
 	case "assert-podman-stop-args":
 		ok = t.assertStopPodmanArgs(args, unit)
 	case "assert-podman-stop-global-args":
@@ -961,6 +994,7 @@ BOGUS=foo
 		Entry("notify.container", "notify.container"),
 		Entry("notify-healthy.container", "notify-healthy.container"),
 		Entry("oneshot.container", "oneshot.container"),
+		Entry("schedule.container", "schedule.container"),
 		Entry("other-sections.container", "other-sections.container"),
 		Entry("podmanargs.container", "podmanargs.container"),
 		Entry("ports.container", "ports.container"),
@@ -1043,6 +1077,7 @@ BOGUS=foo
 		Entry("Kube - Containers Conf Modules", "containersconfmodule.kube"),
 		Entry("Kube - Service Type=oneshot", "oneshot.kube"),
 		Entry("Kube - Down force", "downforce.kube"),
+		Entry("Kube - Schedule", "schedule.kube"),
 
 		Entry("Network - Basic", "basic.network"),
 		Entry("Network - Disable DNS", "disable-dns.network"),
@@ -1145,6 +1180,7 @@ BOGUS=foo
 		Entry("Pod - StopTimeout", "stoptimeout.pod"),
 		Entry("Pod - Service Environment", "service-environment.pod"),
 		Entry("Pod - Restart policy override", "restart.pod"),
+		Entry("Pod - Schedule", "schedule.pod"),
 	)
 
 	DescribeTable("Running expected warning quadlet test case",
@@ -1176,6 +1212,12 @@ BOGUS=foo
 		Entry("userns-with-remap.container", "userns-with-remap.container", "converting \"userns-with-remap.container\": deprecated Remap keys are set along with explicit mapping keys"),
 		Entry("reloadboth.container", "reloadboth.container", "converting \"reloadboth.container\": ReloadCmd and ReloadSignal are mutually exclusive but both are set"),
 		Entry("dependent.error.container", "dependent.error.container", "converting \"dependent.error.container\": unable to translate dependency for basic.container"),
+		Entry("schedule-no-trigger.container", "schedule-no-trigger.container", "converting \"schedule-no-trigger.container\": no OnCalendar or other On* key specified in group 'Schedule'"),
+		Entry("schedule-unknown-key.container", "schedule-unknown-key.container", "converting \"schedule-unknown-key.container\": unsupported key 'Unit' in group 'Schedule'"),
+
+		Entry("schedule.volume", "schedule.volume", "converting \"schedule.volume\": group 'Schedule' is not supported"),
+		Entry("Compose - No Yaml", "no-yaml.compose", "converting \"no-yaml.compose\": no Yaml key specified"),
+		Entry("Compose - Template", "template@.compose", "converting \"template@.compose\": templates are not supported for .compose units"),
 
 		Entry("image-no-image.volume", "image-no-image.volume", "converting \"image-no-image.volume\": the key Image is mandatory when using the image driver"),
 		Entry("Volume - Quadlet image (.build) not found", "build-not-found.quadlet.volume", "converting \"build-not-found.quadlet.volume\": requested Quadlet image not-found.build was not found"),
@@ -1258,6 +1300,9 @@ BOGUS=foo
 			},
 		),
 
+		Entry("Compose - Basic", "basic.compose", []string{"compose.yaml"}),
+		Entry("Compose - Dependency on a compose unit", "compose.container", []string{"basic.compose", "compose.yaml"}),
+
 		Entry("Volume - Quadlet image (.build)", "build.quadlet.volume", []string{"basic.build"}),
 		Entry("Volume - Quadlet image (.image)", "image.quadlet.volume", []string{"basic.image"}),
 		Entry("Volume - Quadlet image (.build) overriding service name", "build.quadlet.servicename.volume", []string{"service-name.build"}),
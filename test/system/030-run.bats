@@ -475,9 +475,15 @@ json-file | f
             if ! test -e "$output"; then
                 die "LogPath (driver=$driver) does not exist: $output"
             fi
-            # eg 2020-09-23T13:34:58.644824420-06:00 stdout F 7aiYtvrqFGJWpak
-            is "$(< $output)" "[0-9T:.+-]\+ stdout F $msg" \
-               "LogPath contents (driver=$driver)"
+            if [[ $driver = 'json-file' ]]; then
+                # eg {"log":"7aiYtvrqFGJWpak\n","stream":"stdout","time":"2020-09-23T13:34:58.64482442-06:00"}
+                is "$(< $output)" "{\"log\":\"$msg\\\\n\",\"stream\":\"stdout\",\"time\":\"[0-9T:.Z+-]\+\"}" \
+                   "LogPath contents (driver=$driver)"
+            else
+                # eg 2020-09-23T13:34:58.644824420-06:00 stdout F 7aiYtvrqFGJWpak
+                is "$(< $output)" "[0-9T:.+-]\+ stdout F $msg" \
+                   "LogPath contents (driver=$driver)"
+            fi
         else
             is "$output" "" "LogPath (driver=$driver)"
         fi
@@ -61,6 +61,11 @@ function _log_test_tail() {
     _log_test_tail k8s-file
 }
 
+# bats test_tags=ci:parallel
+@test "podman logs - tail test, json-file" {
+    _log_test_tail json-file
+}
+
 # bats test_tags=ci:parallel
 @test "podman logs - tail test, journald" {
     # We can't use journald on RHEL as rootless: rhbz#1895105
@@ -446,4 +451,132 @@ EOF
     run_podman rm $cname
 }
 
+function _log_test_filter() {
+    local driver=$1
+    cname="c-$(safename)"
+
+    run_podman run --name $cname --log-driver=$driver $IMAGE sh -c '
+echo "{\"level\":\"info\",\"msg\":\"started\"}"
+echo "{\"level\":\"error\",\"msg\":\"failed 1\"}"
+echo "warning: low disk" >&2
+echo "{\"level\":\"error\",\"msg\":\"failed 2\"}"
+echo "error: retrying" >&2'
+
+    run_podman logs --grep '^error' $cname
+    is "$output" "error: retrying" "--grep"
+
+    run_podman logs --stream stderr $cname
+    assert "$output" = "warning: low disk
+error: retrying" "--stream stderr"
+
+    run_podman logs --stream stdout --grep failed $cname
+    assert "$output" = '{"level":"error","msg":"failed 1"}
+{"level":"error","msg":"failed 2"}' "--stream stdout --grep"
+
+    run_podman logs --json-field level=error --tail 1 $cname
+    is "$output" '{"level":"error","msg":"failed 2"}' "--json-field with --tail"
+
+    run_podman logs --json-field level=error --json-field msg="failed 1" $cname
+    is "$output" '{"level":"error","msg":"failed 1"}' "multiple --json-field"
+
+    run_podman 125 logs --stream stdin $cname
+    is "$output" 'Error: invalid log stream "stdin", must be stdout or stderr' "invalid --stream"
+
+    run_podman 125 logs --grep '(' $cname
+    assert "$output" =~ "invalid log filter regular expression" "invalid --grep"
+
+    run_podman rm $cname
+}
+
+# bats test_tags=ci:parallel
+@test "podman logs - filter k8s-file" {
+    _log_test_filter k8s-file
+}
+
+# bats test_tags=ci:parallel
+@test "podman logs - filter journald" {
+    # We can't use journald on RHEL as rootless: rhbz#1895105
+    skip_if_journald_unavailable
+
+    _log_test_filter journald
+}
+
+# bats test_tags=ci:parallel
+@test "podman logs --output json" {
+    podname="p-$(safename)"
+    c1="c1-$(safename)"
+    c2="c2-$(safename)"
+
+    run_podman pod create --name $podname
+    podid="$output"
+    # printf without a newline and a long line are split into partial lines
+    run_podman run --pod $podname --name $c1 --log-driver k8s-file $IMAGE sh -c '
+echo "{\"level\":\"error\",\"log\":\"kept\",\"code\":500}"; sleep 0.1
+printf "first "; sleep 0.1; echo "half" >&2; sleep 0.1; echo "second half"'
+    c1id=$(podman container inspect --format '{{.ID}}' $c1)
+    run_podman run --pod $podname --name $c2 --log-driver k8s-file $IMAGE echo from-c2
+
+    run_podman logs --output json $c1
+    assert "${#lines[@]}" = 3 "partial lines are reassembled"
+    assert "$(jq -r .container_id <<<"${lines[0]}")" = "$c1id" "container_id"
+    assert "$(jq -r .container_name <<<"${lines[0]}")" = "$c1" "container_name"
+    assert "$(jq -r .pod_id <<<"${lines[0]}")" = "$podid" "pod_id"
+    assert "$(jq -r .pod_name <<<"${lines[0]}")" = "$podname" "pod_name"
+    assert "$(jq -r .stream <<<"${lines[0]}")" = "stdout" "stream"
+    assert "$(jq -r .time <<<"${lines[0]}")" =~ "^[0-9-]+T[0-9:.]+([\+-][0-9:]+|Z)$" "time"
+    # The payload is merged in, without overwriting the fields of the record
+    assert "$(jq -r .level <<<"${lines[0]}")" = "error" "merged field"
+    assert "$(jq -r .code <<<"${lines[0]}")" = "500" "merged number"
+    assert "$(jq -r .log <<<"${lines[0]}")" = '{"level":"error","log":"kept","code":500}' "log is kept"
+    assert "$(jq -r '.stream + " " + .log' <<<"${lines[1]}")" = "stderr half" "stderr line"
+    assert "$(jq -r .log <<<"${lines[2]}")" = "first second half" "reassembled line"
+
+    # Records of several containers are ordered by time.  The remote client
+    # reads the logs of a single container only.
+    if ! is_remote; then
+        run_podman pod logs --output json $podname
+        run jq -r .log <<<"$output"
+        assert "${lines[-1]}" = "from-c2" "last record is from the last container"
+    fi
+
+    run_podman 125 logs --output yaml $c1
+    is "$output" 'Error: invalid output format "yaml", must be text or json' "invalid --output"
+
+    run_podman pod rm -f -t0 $podname
+}
+
+# bats test_tags=ci:parallel
+@test "podman logs - json-file rotation" {
+    cname="c-$(safename)"
+
+    # Every record is about 80 bytes, so a few lines fill a log file
+    run_podman run --name $cname --log-driver json-file \
+               --log-opt max-size=1k --log-opt max-file=3 --log-opt compress=true \
+               $IMAGE seq 1 100
+
+    run_podman inspect --format '{{.HostConfig.LogConfig.Path}}' $cname
+    logpath="$output"
+    run_podman inspect --format '{{index .HostConfig.LogConfig.Config "max-file"}} {{index .HostConfig.LogConfig.Config "compress"}}' $cname
+    is "$output" "3 true" "podman inspect: log options"
+
+    # Only the current log file and two compressed rotated files are kept
+    test -e "$logpath.1.gz" || die "rotated log file not found: $logpath.1.gz"
+    test -e "$logpath.2.gz" || die "rotated log file not found: $logpath.2.gz"
+    test ! -e "$logpath.3.gz" || die "log file exceeding max-file kept: $logpath.3.gz"
+    assert "$(wc -c < $logpath)" -le 1024 "size of the log file"
+
+    # Logs are read back across the rotated files, oldest lines are gone
+    run_podman logs $cname
+    assert "${lines[-1]}" = "100" "last line of the logs"
+    assert "${#lines[@]}" -lt 100 "oldest lines were rotated away"
+    run_podman logs --tail 20 $cname
+    assert "$output" = "$(seq 81 100)" "podman logs --tail 20"
+
+    run_podman 125 run --rm --log-driver k8s-file --log-opt max-file=2 $IMAGE true
+    is "$output" "Error: log option max-file is only supported by the json-file log driver: invalid argument" \
+       "max-file requires the json-file driver"
+
+    run_podman rm $cname
+}
+
 # vim: filetype=sh
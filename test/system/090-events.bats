@@ -461,3 +461,29 @@ EOF
     run_podman 125 events --since="the dawn of time...ish"
     assert "$output" =~ "failed to parse event filters"
 }
+
+# bats test_tags=ci:parallel
+@test "events - resume after cursor" {
+    local vname=v-$(safename)
+    run_podman volume create $vname-1
+    run_podman volume create $vname-2
+    run_podman volume create $vname-3
+
+    run_podman events --since=1m --stream=false --filter type=volume --filter event=create \
+               --format '{{.Name}} {{.Cursor}}'
+    local cursor=$(awk -v name=$vname-1 '$1 == name {print $2}' <<<"$output")
+    assert "$cursor" != "" "create event of $vname-1 has a cursor"
+
+    run_podman events --stream=false --after-cursor "$cursor" --filter type=volume --filter event=create \
+               --format '{{.Name}}'
+    assert "$output" !~ "$vname-1" "events after the cursor do not include the event at the cursor"
+    assert "$output" =~ "$vname-2.*$vname-3" "events after the cursor"
+
+    run_podman events --stream=false --after-cursor "$cursor" --filter type=volume --format json
+    assert "$output" =~ "\"cursor\":" "JSON events include their cursor"
+
+    run_podman 125 events --stream=false --after-cursor nope
+    assert "$output" =~ "invalid event cursor \"nope\"" "invalid cursor"
+
+    run_podman volume rm $vname-1 $vname-2 $vname-3
+}
@@ -487,6 +487,110 @@ function _check_health_log {
     run_podman rm -f -t0 $ctr
 }
 
+@test "podman healthcheck --health-on-failure=exec" {
+    run_podman 125 create --health-cmd true --health-on-failure=exec:hook $IMAGE
+    is "$output" "Error: invalid on-failure action \"exec:hook\" for health check: the hook must be an absolute path"
+
+    ctr="c-h-$(safename)"
+    hook=$PODMAN_TMPDIR/hook
+    hookout=$PODMAN_TMPDIR/hook.out
+    cat >$hook <<EOF
+#!/bin/sh
+echo "\$@" >>$hookout
+cat >>$hookout
+echo >>$hookout
+EOF
+    chmod +x $hook
+
+    run_podman run -d --name $ctr                 \
+           --health-cmd /home/podman/healthcheck  \
+           --health-retries=2                     \
+           --health-on-failure=exec:$hook         \
+           --health-interval=disable              \
+           $IMAGE /home/podman/pause
+    cid="$output"
+
+    run_podman inspect $ctr --format "{{.Config.HealthcheckOnFailureAction}}"
+    is "$output" "exec:$hook" "on-failure action is set to exec"
+
+    run_podman healthcheck run $ctr
+    run_podman exec $ctr touch /uh-oh
+
+    # The hook only runs once the container turns unhealthy
+    run_podman 1 healthcheck run $ctr
+    assert "$(cat $hookout 2>/dev/null)" == "" "hook does not run before the container turns unhealthy"
+    run_podman 1 healthcheck run $ctr
+    is "$output" "unhealthy" "output from 'podman healthcheck run'"
+    run_podman 1 healthcheck run $ctr
+
+    run cat $hookout
+    assert "${#lines[@]}" == 2 "hook ran once: $output"
+    is "${lines[0]}" "$cid $ctr" "arguments of the hook"
+    assert "$(jq -r .ExitCode <<<"${lines[1]}")" == "1" "exit code in the health log passed to the hook"
+
+    run_podman inspect $ctr --format "{{.State.Status}}"
+    is "$output" "running" "container keeps running"
+
+    run_podman rm -f -t0 $ctr
+}
+
+@test "podman healthcheck - HTTP and TCP probes run by podman" {
+    ctr="c-h-$(safename)"
+    ctr2="c2-h-$(safename)"
+
+    # Conflicting healthchecks
+    run_podman 125 create --health-cmd true --health-tcp 80 $IMAGE
+    is "$output" "Error: cannot specify both --health-cmd and --health-tcp" "--health-cmd with --health-tcp"
+    run_podman 125 create --health-tcp 80 --health-http-get :80 $IMAGE
+    is "$output" "Error: only one of --health-http-get, --health-tcp and --health-grpc can be specified"
+    run_podman 125 create --health-http-get 80/ready $IMAGE
+    assert "$output" =~ "invalid HTTP healthcheck target" "--health-http-get without port"
+
+    run_podman run -d --name $ctr                 \
+           --health-http-get :80/ready            \
+           --health-retries=1                     \
+           --health-interval=disable              \
+           $IMAGE sh -c "mkdir /www && echo READY >/www/ready && exec /bin/busybox-extras httpd -f -p 80 -h /www"
+
+    run_podman inspect --format "{{.Config.Healthcheck.Test}}" $ctr
+    is "$output" "[HTTP-GET http://localhost:80/ready]" ".Config.Healthcheck.Test"
+
+    # Wait for httpd to listen
+    for i in {1..10}; do
+        run_podman '?' healthcheck run $ctr
+        if [[ $status -eq 0 ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$status" -eq 0 "HTTP probe passes once httpd listens"
+
+    run_podman inspect --format "{{json .State.Healthcheck}}" $ctr
+    assert "$(jq -r .Status <<<"$output")" == "healthy" "status after HTTP probe passed"
+    assert "$(jq -r '.Log[-1].ExitCode' <<<"$output")" == "0" "exit code of HTTP probe"
+    assert "$(jq -r '.Log[-1].Output' <<<"$output")" =~ "200 OK.*READY" "output of HTTP probe"
+
+    # The image has no HTTP client, the probe does not need one
+    run_podman exec $ctr rm /www/ready
+    run_podman 1 healthcheck run $ctr
+    is "$output" "unhealthy" "output from 'podman healthcheck run' after HTTP probe failed"
+    run_podman inspect --format "{{json .State.Healthcheck}}" $ctr
+    assert "$(jq -r '.Log[-1].ExitCode' <<<"$output")" == "1" "exit code of failed HTTP probe"
+    assert "$(jq -r '.Log[-1].Output' <<<"$output")" =~ "404 Not Found" "output of failed HTTP probe"
+
+    # TCP probe in the network namespace of the first container
+    run_podman run -d --name $ctr2 --network container:$ctr \
+           --health-tcp 80                        \
+           --health-interval=disable              \
+           $IMAGE /home/podman/pause
+    run_podman healthcheck run $ctr2
+    is "$output" "" "output from 'podman healthcheck run' with TCP probe"
+    run_podman inspect --format "{{json .State.Healthcheck}}" $ctr2
+    assert "$(jq -r '.Log[-1].Output' <<<"$output")" == "TCP connection to localhost:80: connected" "output of TCP probe"
+
+    run_podman rm -f -t0 $ctr2 $ctr
+}
+
 # https://github.com/containers/podman/issues/25034
 @test "podman healthcheck - start errors" {
     skip_if_remote '$PATH overwrite not working via remote'
@@ -406,6 +406,53 @@ EOF
     assert $status -eq 4 "systemd unit should not exist after removal with --reload-systemd"
 }
 
+@test "quadlet verb - install and rm with --restart-changed option" {
+    local quadlet_file=$PODMAN_TMPDIR/restart-test.container
+    cat > $quadlet_file <<EOF
+[Container]
+Image=$IMAGE
+Exec=sh -c "echo STARTED CONTAINER FOR RESTART TEST; trap 'exit' SIGTERM; while :; do sleep 0.1; done"
+EOF
+    local service_name=$(quadlet_to_service_name "restart-test.container")
+
+    run_podman quadlet install --restart-changed $quadlet_file
+    assert "$output" =~ "$service_name: none" "new unit which is not running should not be started"
+
+    systemctl start "$service_name"
+    run systemctl show -P InvocationID "$service_name"
+    local invocation=$output
+
+    # Installing an unchanged file changes nothing
+    run_podman quadlet install --replace --restart-changed $quadlet_file
+    assert "$output" !~ "$service_name" "unchanged unit should not be reported"
+
+    # Installing an unrelated Quadlet does not restart the service
+    local other_file=$PODMAN_TMPDIR/restart-test-other.network
+    echo "[Network]" > $other_file
+    local other_service=$(quadlet_to_service_name "restart-test-other.network")
+    run_podman quadlet install --restart-changed $other_file
+    assert "$output" =~ "$other_service: none"
+    assert "$output" !~ "$service_name" "unrelated unit should not be reported"
+
+    sed -i -e 's/STARTED CONTAINER/RESTARTED CONTAINER/' $quadlet_file
+    run_podman quadlet install --replace --restart-changed $quadlet_file
+    assert "$output" =~ "$service_name: restarted" "changed running unit should be restarted"
+
+    run systemctl is-active "$service_name"
+    assert "$output" == "active" "restarted unit should be active"
+    run systemctl show -P InvocationID "$service_name"
+    assert "$output" != "$invocation" "unit should have been restarted"
+
+    run_podman 125 quadlet install --replace --restart-changed --reload-systemd=false $quadlet_file
+    assert "$output" =~ "restarting the changed units requires reloading systemd"
+
+    run_podman quadlet rm --force --restart-changed restart-test.container restart-test-other.network
+    assert "$output" =~ "$service_name: none" "removed unit was stopped before removal"
+
+    run systemctl is-active "$service_name"
+    assert "$output" != "active" "removed unit should not be active"
+}
+
 @test "quadlet verb - list with --format option" {
     # Create a test quadlet file
     local quadlet_file=$PODMAN_TMPDIR/format-test.container
@@ -625,3 +672,121 @@ EOF
     # Cleanup: Remove the installed quadlet
     run_podman quadlet rm long.container
 }
+
+@test "quadlet verb - validate" {
+    cat > $PODMAN_TMPDIR/valid.container <<EOF
+[Container]
+Image=$IMAGE
+Network=valid.network
+EOF
+    cat > $PODMAN_TMPDIR/valid.network <<EOF
+[Network]
+EOF
+    run_podman quadlet validate $PODMAN_TMPDIR/valid.container $PODMAN_TMPDIR/valid.network
+    assert "$output" == "" "valid quadlets should have no diagnostics"
+
+    local invalid=$PODMAN_TMPDIR/invalid.container
+    cat > $invalid <<EOF
+[Container]
+Image=$IMAGE
+
+Foo=bar
+Network=missing.network
+EOF
+    run_podman 125 quadlet validate $invalid
+    assert "${lines[0]}" == "$invalid:4: error: unsupported key 'Foo' in group 'Container' in $invalid" \
+           "unknown key should be reported with its line"
+    assert "${lines[1]}" == "$invalid:5: error: Network references the Quadlet unit missing.network which does not exist" \
+           "missing network should be reported with its line"
+    assert "$output" =~ "Error: 2 error\(s\) found in Quadlet files"
+
+    run_podman 125 quadlet validate --format json $invalid
+    assert "$output" =~ '"Key": "Foo"' "JSON output should contain the unknown key"
+    assert "$output" =~ '"Line": 5' "JSON output should contain the line of the missing network"
+
+    run_podman 125 quadlet validate --format '{{.Group}}.{{.Key}}' $invalid
+    assert "${lines[0]}" == "Container.Foo"
+    assert "${lines[1]}" == "Container.Network"
+
+    # Drop-in files are merged, and their problems reported with their lines
+    mkdir $PODMAN_TMPDIR/valid.container.d
+    local dropin=$PODMAN_TMPDIR/valid.container.d/10-unknown.conf
+    cat > $dropin <<EOF
+[Container]
+Foo=bar
+Baz=qux
+EOF
+    run_podman 125 quadlet validate $PODMAN_TMPDIR/valid.container $PODMAN_TMPDIR/valid.network
+    assert "${lines[0]}" == "$dropin:2: error: unsupported key 'Foo' in group 'Container' in $PODMAN_TMPDIR/valid.container" \
+           "unknown key in drop-in should be reported with its line"
+    assert "${lines[1]}" == "$dropin:3: error: unsupported key 'Baz' in group 'Container' in $PODMAN_TMPDIR/valid.container" \
+           "every unknown key should be reported"
+    assert "$output" =~ "Error: 2 error\(s\) found in Quadlet files"
+}
+
+@test "quadlet verb - install from OCI artifact" {
+    local install_dir=$(get_quadlet_install_dir)
+    local app=app-$(safename)
+    local artifact=quay.io/podman-test/$app
+
+    mkdir $PODMAN_TMPDIR/v1 $PODMAN_TMPDIR/v2
+    cat > $PODMAN_TMPDIR/v1/$app.container <<EOF
+[Container]
+Image=$IMAGE
+Network=$app.network
+EnvironmentFile=$install_dir/$app.env
+EOF
+    echo "[Network]" > $PODMAN_TMPDIR/v1/$app.network
+    echo "VERSION=1" > $PODMAN_TMPDIR/v1/$app.env
+    cat > $PODMAN_TMPDIR/v2/$app.container <<EOF
+[Container]
+Image=$IMAGE
+Volume=$app.volume:/data
+EOF
+    echo "[Volume]" > $PODMAN_TMPDIR/v2/$app.volume
+
+    run_podman artifact add $artifact:1 $PODMAN_TMPDIR/v1/*
+    run_podman artifact add $artifact:2 $PODMAN_TMPDIR/v2/*
+
+    run_podman quadlet install oci://$artifact:1
+    assert "$output" =~ "$install_dir/$app.container" "install output should contain the container"
+    assert "$output" =~ "$install_dir/$app.network" "install output should contain the network"
+    assert "$(<$install_dir/$app.env)" == "VERSION=1" "supporting file should be installed"
+
+    run_podman quadlet list --format '{{.Name}} {{.App}} {{.Source}}' --filter name=$app
+    assert "$output" =~ "$app.container .$app.app $artifact:1" "list should show the artifact"
+    assert "${#lines[@]}" -eq 2 "list should only show the Quadlets of the artifact"
+
+    # The files recording the application must not break the generator
+    local dashuser=
+    if is_rootless; then
+        dashuser=-user
+    fi
+    QUADLET_UNIT_DIRS="$install_dir" run $QUADLET $dashuser -dryrun
+    echo "$output"
+    assert $status -eq 0 "quadlet generator should succeed after installing an artifact"
+    assert "$output" =~ "$app.service" "generator should convert the container"
+    assert "$output" !~ "\.source" "generator should ignore the artifact source file"
+
+    # Upgrading requires --replace and leaves the installed version alone otherwise
+    run_podman 125 quadlet install oci://$artifact:2
+    assert "$output" =~ "application $app is already installed"
+    assert "$(<$install_dir/$app.container)" == "$(<$PODMAN_TMPDIR/v1/$app.container)" "failed upgrade should not change files"
+
+    run_podman quadlet install --replace oci://$artifact:2
+    assert "$(<$install_dir/$app.container)" == "$(<$PODMAN_TMPDIR/v2/$app.container)" "container should be upgraded"
+    test -f $install_dir/$app.volume || die "new file should be installed"
+    test ! -e $install_dir/$app.network || die "file of the previous version should be removed"
+    test ! -e $install_dir/$app.env || die "supporting file of the previous version should be removed"
+
+    run_podman quadlet list --format '{{.Name}} {{.Source}}' --filter name=$app
+    assert "$output" =~ "$app.container $artifact:2" "list should show the new version"
+
+    # Removing one Quadlet removes the whole application
+    run_podman quadlet rm $app.container
+    test ! -e $install_dir/$app.volume || die "application should be removed"
+    test ! -e $install_dir/.$app.app || die "application file should be removed"
+    test ! -e $install_dir/.$app.source || die "artifact source file should be removed"
+
+    run_podman artifact rm $artifact:1 $artifact:2
+}
@@ -290,6 +290,115 @@ function _confirm_update() {
     _confirm_update $cname $ori_image
 }
 
+@test "podman auto-update - maintenance window and staged rollout" {
+    run_podman 125 auto-update --max-units -1
+    is "$output" "Error: --max-units must not be negative: -1"
+
+    # A maintenance window which does not contain the current time
+    hour=$(date -d '+2 hours' +%H)
+    window="$hour:00-$hour:30"
+
+    generate_service localtest local "" "--label io.containers.autoupdate.window=$window"
+    ctr_window=$cname
+    _wait_service_ready container-$ctr_window.service
+    generate_service localtest local
+    ctr1=$cname
+    _wait_service_ready container-$ctr1.service
+    generate_service localtest local
+    ctr2=$cname
+    _wait_service_ready container-$ctr2.service
+
+    run_podman commit --change CMD=/bin/bash $ctr1 quay.io/libpod/localtest:latest
+
+    run_podman auto-update --dry-run --max-units 1 --format "{{.Unit}},{{.Updated}},{{.Reason}}"
+    assert "$output" =~ "container-$ctr_window.service,skipped,outside of maintenance window \"$window\"" \
+           "update outside of the maintenance window is skipped"
+    assert "$(grep -c ',pending,update available' <<<"$output")" == 2 "both units are updated"
+
+    # The units are restarted one after another within a single run
+    run_podman auto-update --max-units 1 --rollback=false --format "{{.Unit}},{{.Updated}}"
+    assert "$output" =~ "container-$ctr1.service,true" "first unit is updated"
+    assert "$output" =~ "container-$ctr2.service,true" "second unit is updated in the same run"
+}
+
+@test "podman auto-update - container not running in a systemd unit" {
+    cname=c_local_$(safename)
+    image=quay.io/libpod/localtest:latest
+    run_podman tag $IMAGE $image
+    run_podman run -d --name $cname --label io.containers.autoupdate=local \
+               --health-cmd "test ! -e /uh-oh" --health-retries=1 --health-interval=disable \
+               $image top -d 120
+    old_id="$output"
+    run_podman inspect --format "{{.Image}}" $cname
+    ori_image="$output"
+
+    # An image failing the healthcheck is rolled back
+    run_podman run --name helper_$cname $IMAGE touch /uh-oh
+    run_podman commit -q helper_$cname $image
+    run_podman rm helper_$cname
+
+    run_podman auto-update --format "{{.Unit}},{{.ContainerName}},{{.Updated}},{{.Reason}}"
+    is "$output" ",$cname,rolled back,recreating the container with the updated image failed: .* is unhealthy" \
+       "update to an unhealthy image is rolled back"
+    run_podman inspect --format "{{.ID}} {{.Image}} {{.State.Status}}" $cname
+    is "$output" "$old_id $ori_image running" "the old container runs the previous image again"
+
+    # A healthy image is updated to
+    run_podman run --name helper_$cname $IMAGE true
+    run_podman commit -q helper_$cname $image
+    new_image="$output"
+    run_podman rm helper_$cname
+
+    run_podman auto-update --format "{{.Unit}},{{.ContainerName}},{{.Updated}},{{.Reason}}"
+    is "$output" ",$cname,true,recreated as container .*" "container is recreated"
+    run_podman inspect --format "{{.Image}} {{.State.Status}}" $cname
+    is "$output" "$new_image running" "the new container runs the updated image"
+    run_podman inspect --format "{{.ID}}" $cname
+    assert "$output" != "$old_id" "the container has been recreated"
+    run_podman ps -a --format "{{.Names}}"
+    assert "$output" !~ "$cname-autoupdate-old" "the old container has been removed"
+
+    run_podman rm -f -t0 $cname
+}
+
+@test "podman healthcheck --health-on-failure=rollback - container not running in a systemd unit" {
+    cname=c_rollback_$(safename)
+    image=quay.io/libpod/localtest:latest
+    run_podman tag $IMAGE $image
+    run_podman run -d --name $cname --label io.containers.autoupdate=local \
+               --health-cmd "test ! -e /uh-oh" --health-retries=1 --health-interval=disable \
+               --health-on-failure=rollback $image top -d 120
+    run_podman inspect --format "{{.Image}}" $cname
+    ori_image="$output"
+
+    run_podman run --name helper_$cname $IMAGE true
+    run_podman commit -q helper_$cname $image
+    new_image="$output"
+    run_podman rm helper_$cname
+
+    run_podman auto-update --format "{{.Unit}},{{.ContainerName}},{{.Updated}}"
+    is "$output" ",$cname,true" "container is recreated"
+    run_podman inspect --format "{{.ID}} {{.Image}}" $cname
+    assert "$output" =~ " $new_image\$" "the container runs the updated image"
+    updated_id="${output%% *}"
+
+    # Turning unhealthy rolls back the update
+    run_podman exec $cname touch /uh-oh
+    run_podman 1 healthcheck run $cname
+    is "$output" "unhealthy" "output from 'podman healthcheck run'"
+    run_podman inspect --format "{{.ID}} {{.Image}} {{.State.Status}}" $cname
+    assert "$output" =~ " $ori_image running\$" "the container runs the previous image again"
+    assert "${output%% *}" != "$updated_id" "the container has been recreated"
+    run_podman ps -a --format "{{.Names}}"
+    assert "$output" !~ "$cname-autoupdate-old" "the unhealthy container has been removed"
+    run_podman image inspect --format "{{.ID}}" $image
+    is "$output" "$ori_image" "the image is tagged again"
+
+    run_podman healthcheck run $cname
+
+    run_podman rm -f -t0 $cname
+}
+
 # This test can fail in dev. environment because of SELinux.
 # quick fix: chcon -t container_runtime_exec_t ./bin/podman
 @test "podman auto-update - label io.containers.autoupdate=local with rollback" {
@@ -407,3 +407,30 @@ function nrand() {
 }
 
 # vim: filetype=sh
+
+# bats test_tags=ci:parallel
+@test "podman update - pressure monitoring" {
+    local ctrname="c-h-$(safename)"
+
+    run_podman run -d --name $ctrname --pressure-threshold cpu=50 $IMAGE top
+    run_podman inspect --format '{{.Config.PressureMonitor.Interval}} {{.Config.PressureMonitor.CPU}}' $ctrname
+    is "$output" "10s 50" "default interval and cpu threshold"
+
+    run_podman update --pressure-interval 30s --pressure-threshold cpu=0 --pressure-threshold io=12.5 $ctrname
+    run_podman inspect --format '{{.Config.PressureMonitor.Interval}} {{.Config.PressureMonitor.CPU}} {{.Config.PressureMonitor.IO}}' $ctrname
+    is "$output" "30s 0 12.5" "updated interval and thresholds"
+
+    if ! is_remote; then
+        # Normally run by the systemd timer
+        run_podman container check-pressure $ctrname
+    fi
+
+    run_podman 125 update --pressure-threshold gpu=10 $ctrname
+    assert "$output" =~ "resource must be one of cpu, memory, io" "unknown resource is rejected"
+
+    run_podman update --pressure-interval disable $ctrname
+    run_podman inspect --format '{{.Config.PressureMonitor}}' $ctrname
+    is "$output" "<nil>" "pressure monitoring is disabled"
+
+    run_podman rm -t 0 -f $ctrname
+}